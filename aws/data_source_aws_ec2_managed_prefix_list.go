@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+)
+
+func dataSourceAwsEc2ManagedPrefixList() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsEc2ManagedPrefixListRead,
+
+		Schema: map[string]*schema.Schema{
+			"address_family": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"filter": ec2CustomFiltersSchema(),
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"max_entries": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchemaComputed(),
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsEc2ManagedPrefixListRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	input := &ec2.DescribeManagedPrefixListsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"prefix-list-name": d.Get("name").(string),
+		}),
+	}
+
+	if v, ok := d.GetOk("id"); ok {
+		input.PrefixListIds = aws.StringSlice([]string{v.(string)})
+	}
+
+	input.Filters = append(input.Filters, buildEC2CustomFilterList(
+		d.Get("filter").(*schema.Set),
+	)...)
+
+	if len(input.Filters) == 0 {
+		// Don't send an empty filters list; the EC2 API won't accept it.
+		input.Filters = nil
+	}
+
+	var prefixLists []*ec2.ManagedPrefixList
+
+	log.Printf("[DEBUG] Reading EC2 Managed Prefix Lists: %s", input)
+	err := conn.DescribeManagedPrefixListsPages(input, func(page *ec2.DescribeManagedPrefixListsOutput, lastPage bool) bool {
+		prefixLists = append(prefixLists, page.PrefixLists...)
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Managed Prefix Lists: %s", err)
+	}
+
+	if len(prefixLists) == 0 {
+		return fmt.Errorf("no EC2 Managed Prefix List matched; change the search criteria and try again")
+	}
+
+	if len(prefixLists) > 1 {
+		return fmt.Errorf("multiple EC2 Managed Prefix Lists matched; use additional constraints to reduce matches to a single prefix list")
+	}
+
+	prefixList := prefixLists[0]
+	id := aws.StringValue(prefixList.PrefixListId)
+
+	entries, err := finder.ManagedPrefixListEntriesByID(conn, id)
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Managed Prefix List (%s) entries: %s", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("address_family", prefixList.AddressFamily)
+	d.Set("arn", prefixList.PrefixListArn)
+
+	if err := d.Set("entries", flattenEc2PrefixListEntries(entries)); err != nil {
+		return fmt.Errorf("error setting entries: %s", err)
+	}
+
+	d.Set("max_entries", prefixList.MaxEntries)
+	d.Set("name", prefixList.PrefixListName)
+	d.Set("owner_id", prefixList.OwnerId)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(prefixList.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("version", prefixList.Version)
+
+	return nil
+}
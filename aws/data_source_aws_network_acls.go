@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsNetworkAcls() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsNetworkAclsRead,
+		Schema: map[string]*schema.Schema{
+			"filter": ec2CustomFiltersSchema(),
+
+			"tags": tagsSchemaComputed(),
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceAwsNetworkAclsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.DescribeNetworkAclsInput{}
+
+	if v, ok := d.GetOk("vpc_id"); ok {
+		input.Filters = buildEC2AttributeFilterList(map[string]string{
+			"vpc-id": v.(string),
+		})
+	}
+
+	input.Filters = append(input.Filters, ec2TagFiltersFromMap(d.Get("tags").(map[string]interface{}))...)
+	input.Filters = append(input.Filters, buildEC2CustomFilterList(d.Get("filter").(*schema.Set))...)
+
+	if len(input.Filters) == 0 {
+		// Don't send an empty filters list; the EC2 API won't accept it.
+		input.Filters = nil
+	}
+
+	var networkAclIDs []string
+
+	log.Printf("[DEBUG] Reading EC2 Network ACLs: %s", input)
+	err := conn.DescribeNetworkAclsPages(input, func(page *ec2.DescribeNetworkAclsOutput, lastPage bool) bool {
+		for _, acl := range page.NetworkAcls {
+			networkAclIDs = append(networkAclIDs, aws.StringValue(acl.NetworkAclId))
+		}
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Network ACLs: %s", err)
+	}
+
+	if len(networkAclIDs) == 0 {
+		return errors.New("no matching network ACLs found")
+	}
+
+	d.SetId(resource.UniqueId())
+
+	if err := d.Set("ids", networkAclIDs); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+
+	return nil
+}
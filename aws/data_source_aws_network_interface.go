@@ -0,0 +1,176 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func dataSourceAwsNetworkInterface() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsNetworkInterfaceRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"filter": ec2CustomFiltersSchema(),
+			"attachment": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attachment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"instance": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipv6_addresses": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"mac_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_dns_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"requester_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"security_groups": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchemaComputed(),
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	input := &ec2.DescribeNetworkInterfacesInput{}
+	if v, ok := d.GetOk("id"); ok {
+		input.NetworkInterfaceIds = []*string{aws.String(v.(string))}
+	}
+
+	if v, ok := d.GetOk("filter"); ok {
+		input.Filters = buildEC2CustomFilterList(v.(*schema.Set))
+	}
+
+	log.Printf("[DEBUG] Reading Network Interface: %s", input)
+	resp, err := conn.DescribeNetworkInterfaces(input)
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Network Interfaces: %s", err)
+	}
+	if resp == nil || len(resp.NetworkInterfaces) == 0 {
+		return fmt.Errorf("no matching network interface found")
+	}
+	if len(resp.NetworkInterfaces) > 1 {
+		return fmt.Errorf("multiple network interfaces matched; use additional constraints to reduce matches to a single network interface")
+	}
+
+	eni := resp.NetworkInterfaces[0]
+
+	d.SetId(aws.StringValue(eni.NetworkInterfaceId))
+
+	attachment := []map[string]interface{}{}
+	if eni.Attachment != nil {
+		attachment = []map[string]interface{}{flattenAttachment(eni.Attachment)}
+	}
+
+	if err := d.Set("attachment", attachment); err != nil {
+		return fmt.Errorf("error setting attachment: %s", err)
+	}
+
+	d.Set("availability_zone", eni.AvailabilityZone)
+	d.Set("description", eni.Description)
+	d.Set("interface_type", eni.InterfaceType)
+
+	if err := d.Set("ipv6_addresses", flattenNetworkInterfaceIpv6Addresses(eni.Ipv6Addresses)); err != nil {
+		return fmt.Errorf("error setting ipv6_addresses: %s", err)
+	}
+
+	d.Set("mac_address", eni.MacAddress)
+	d.Set("owner_id", eni.OwnerId)
+	d.Set("private_dns_name", eni.PrivateDnsName)
+	d.Set("private_ip", eni.PrivateIpAddress)
+
+	if err := d.Set("private_ips", flattenNetworkInterfacesPrivateIPAddresses(eni.PrivateIpAddresses)); err != nil {
+		return fmt.Errorf("error setting private_ips: %s", err)
+	}
+
+	d.Set("requester_id", eni.RequesterId)
+
+	if err := d.Set("security_groups", flattenGroupIdentifiers(eni.Groups)); err != nil {
+		return fmt.Errorf("error setting security_groups: %s", err)
+	}
+
+	d.Set("subnet_id", eni.SubnetId)
+	d.Set("vpc_id", eni.VpcId)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(eni.TagSet).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
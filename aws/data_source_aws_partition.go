@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsPartition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsPartitionRead,
+
+		Schema: map[string]*schema.Schema{
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_suffix": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"reverse_dns_prefix": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsPartitionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+
+	log.Printf("[DEBUG] Reading Partition.")
+	d.SetId(client.partition)
+
+	log.Printf("[DEBUG] Setting AWS Partition to %s.", client.partition)
+	d.Set("partition", client.partition)
+
+	log.Printf("[DEBUG] Setting AWS URL Suffix to %s.", client.dnsSuffix)
+	d.Set("dns_suffix", client.dnsSuffix)
+
+	d.Set("reverse_dns_prefix", reverseDNS(client.dnsSuffix))
+
+	return nil
+}
+
+// reverseDNS returns the labels of a domain name in reverse order, e.g.
+// "com.amazonaws" for "amazonaws.com".
+func reverseDNS(hostname string) string {
+	parts := strings.Split(hostname, ".")
+
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsRegions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsRegionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": ec2CustomFiltersSchema(),
+
+			"names": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"all_regions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func dataSourceAwsRegionsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[DEBUG] Reading regions.")
+
+	request := &ec2.DescribeRegionsInput{}
+	if v, ok := d.GetOk("filter"); ok {
+		request.Filters = buildEC2CustomFilterList(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("all_regions"); ok {
+		request.AllRegions = aws.Bool(v.(bool))
+	}
+
+	log.Printf("[DEBUG] Reading regions for request: %s", request)
+	response, err := conn.DescribeRegions(request)
+	if err != nil {
+		return fmt.Errorf("error fetching Regions: %s", err)
+	}
+
+	names := []string{}
+	for _, v := range response.Regions {
+		names = append(names, aws.StringValue(v.RegionName))
+	}
+
+	d.SetId(meta.(*AWSClient).partition)
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting names: %s", err)
+	}
+
+	return nil
+}
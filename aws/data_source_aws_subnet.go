@@ -0,0 +1,198 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func dataSourceAwsSubnet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSubnetRead,
+
+		Schema: map[string]*schema.Schema{
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"availability_zone_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"cidr_block": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"ipv6_cidr_block": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"default_for_az": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"filter": ec2CustomFiltersSchema(),
+
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tags": tagsSchemaComputed(),
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"assign_ipv6_address_on_creation": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"available_ip_address_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"map_public_ip_on_launch": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"ipv6_cidr_block_association_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	req := &ec2.DescribeSubnetsInput{}
+
+	if id, ok := d.GetOk("id"); ok {
+		req.SubnetIds = []*string{aws.String(id.(string))}
+	}
+
+	// We specify default_for_az as boolean, but EC2 filters want
+	// it to be serialized as a string. Note that setting it to
+	// "false" here does not actually filter by it *not* being
+	// the default, because Terraform can't distinguish between
+	// "false" and "not set".
+	defaultForAzStr := ""
+	if d.Get("default_for_az").(bool) {
+		defaultForAzStr = "true"
+	}
+
+	filters := map[string]string{
+		"availability-zone":    d.Get("availability_zone").(string),
+		"availability-zone-id": d.Get("availability_zone_id").(string),
+		"default-for-az":       defaultForAzStr,
+		"state":                d.Get("state").(string),
+		"vpc-id":               d.Get("vpc_id").(string),
+	}
+
+	if v, ok := d.GetOk("cidr_block"); ok {
+		filters["cidr-block"] = v.(string)
+	}
+
+	if v, ok := d.GetOk("ipv6_cidr_block"); ok {
+		filters["ipv6-cidr-block-association.ipv6-cidr-block"] = v.(string)
+	}
+
+	req.Filters = buildEC2AttributeFilterList(filters)
+
+	if tags, tagsOk := d.GetOk("tags"); tagsOk {
+		req.Filters = append(req.Filters, ec2TagFiltersFromMap(tags.(map[string]interface{}))...)
+	}
+
+	req.Filters = append(req.Filters, buildEC2CustomFilterList(
+		d.Get("filter").(*schema.Set),
+	)...)
+	if len(req.Filters) == 0 {
+		// Don't send an empty filters list; the EC2 API won't accept it.
+		req.Filters = nil
+	}
+
+	log.Printf("[DEBUG] Reading Subnet: %s", req)
+	resp, err := conn.DescribeSubnets(req)
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Subnets: %s", err)
+	}
+	if resp == nil || len(resp.Subnets) == 0 {
+		return fmt.Errorf("no matching subnet found")
+	}
+	if len(resp.Subnets) > 1 {
+		return fmt.Errorf("multiple subnets matched; use additional constraints to reduce matches to a single subnet")
+	}
+
+	subnet := resp.Subnets[0]
+
+	d.SetId(aws.StringValue(subnet.SubnetId))
+	d.Set("vpc_id", subnet.VpcId)
+	d.Set("availability_zone", subnet.AvailabilityZone)
+	d.Set("availability_zone_id", subnet.AvailabilityZoneId)
+	d.Set("cidr_block", subnet.CidrBlock)
+	d.Set("default_for_az", subnet.DefaultForAz)
+	d.Set("state", subnet.State)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(subnet.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("assign_ipv6_address_on_creation", subnet.AssignIpv6AddressOnCreation)
+	d.Set("available_ip_address_count", subnet.AvailableIpAddressCount)
+	d.Set("map_public_ip_on_launch", subnet.MapPublicIpOnLaunch)
+
+	d.Set("ipv6_cidr_block_association_id", "")
+	d.Set("ipv6_cidr_block", "")
+
+	for _, a := range subnet.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(a.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated { //we can only ever have 1 IPv6 block associated at once
+			d.Set("ipv6_cidr_block_association_id", a.AssociationId)
+			d.Set("ipv6_cidr_block", a.Ipv6CidrBlock)
+			break
+		}
+	}
+
+	d.Set("arn", subnet.SubnetArn)
+	d.Set("owner_id", subnet.OwnerId)
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsSubnetIDs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSubnetIDsRead,
+		Schema: map[string]*schema.Schema{
+			"filter": ec2CustomFiltersSchema(),
+
+			"tags": tagsSchemaComputed(),
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSubnetIDsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	vpcID := d.Get("vpc_id").(string)
+	input := &ec2.DescribeSubnetsInput{}
+
+	input.Filters = buildEC2AttributeFilterList(map[string]string{
+		"vpc-id": vpcID,
+	})
+	input.Filters = append(input.Filters, ec2TagFiltersFromMap(d.Get("tags").(map[string]interface{}))...)
+	input.Filters = append(input.Filters, buildEC2CustomFilterList(d.Get("filter").(*schema.Set))...)
+
+	var subnetIDs []string
+
+	log.Printf("[DEBUG] Reading EC2 Subnets: %s", input)
+	err := conn.DescribeSubnetsPages(input, func(page *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		for _, subnet := range page.Subnets {
+			subnetIDs = append(subnetIDs, aws.StringValue(subnet.SubnetId))
+		}
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 VPC (%s) subnets: %s", vpcID, err)
+	}
+
+	if len(subnetIDs) == 0 {
+		return fmt.Errorf("no matching subnet found for vpc with id %s", vpcID)
+	}
+
+	d.SetId(vpcID)
+
+	if err := d.Set("ids", subnetIDs); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+
+	return nil
+}
@@ -93,6 +93,31 @@ func dataSourceAwsVpc() *schema.Resource {
 				Computed: true,
 			},
 
+			"ipv6_cidr_block_associations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"association_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipv6_cidr_block": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipv6_pool": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"main_route_table_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -116,6 +141,7 @@ func dataSourceAwsVpc() *schema.Resource {
 
 func dataSourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
 
 	req := &ec2.DescribeVpcsInput{}
 
@@ -182,7 +208,7 @@ func dataSourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("default", vpc.IsDefault)
 	d.Set("state", vpc.State)
 
-	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(vpc.Tags).IgnoreAws().Map()); err != nil {
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(vpc.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
 		return fmt.Errorf("error setting tags: %s", err)
 	}
 
@@ -210,9 +236,23 @@ func dataSourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error setting cidr_block_associations: %s", err)
 	}
 
-	if vpc.Ipv6CidrBlockAssociationSet != nil {
-		d.Set("ipv6_association_id", vpc.Ipv6CidrBlockAssociationSet[0].AssociationId)
-		d.Set("ipv6_cidr_block", vpc.Ipv6CidrBlockAssociationSet[0].Ipv6CidrBlock)
+	ipv6CidrAssociations := []interface{}{}
+	for _, associationSet := range vpc.Ipv6CidrBlockAssociationSet {
+		association := map[string]interface{}{
+			"association_id":  aws.StringValue(associationSet.AssociationId),
+			"ipv6_cidr_block": aws.StringValue(associationSet.Ipv6CidrBlock),
+			"ipv6_pool":       aws.StringValue(associationSet.Ipv6Pool),
+			"state":           aws.StringValue(associationSet.Ipv6CidrBlockState.State),
+		}
+		ipv6CidrAssociations = append(ipv6CidrAssociations, association)
+
+		if aws.StringValue(associationSet.Ipv6CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			d.Set("ipv6_association_id", associationSet.AssociationId)
+			d.Set("ipv6_cidr_block", associationSet.Ipv6CidrBlock)
+		}
+	}
+	if err := d.Set("ipv6_cidr_block_associations", ipv6CidrAssociations); err != nil {
+		return fmt.Errorf("error setting ipv6_cidr_block_associations: %s", err)
 	}
 
 	attResp, err := awsVpcDescribeVpcAttribute("enableDnsSupport", aws.StringValue(vpc.VpcId), conn)
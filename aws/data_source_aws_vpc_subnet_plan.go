@@ -0,0 +1,338 @@
+package aws
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// dataSourceAwsVpcSubnetPlan lays out non-overlapping subnet CIDR blocks for
+// a number of tiers across availability zones, within the CIDR blocks of a
+// VPC or a given CIDR block.
+//
+// Blocks are allocated in tier order, then availability zone order, each at
+// the lowest free address. An existing subnet that is exactly the candidate
+// block in the same availability zone is claimed for it rather than treated
+// as a conflict, so that the plan does not move once its subnets exist.
+func dataSourceAwsVpcSubnetPlan() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsVpcSubnetPlanRead,
+
+		Schema: map[string]*schema.Schema{
+			"availability_zone_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"availability_zones": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+				ExactlyOneOf: []string{"cidr_block", "vpc_id"},
+			},
+			"cidr_blocks": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"subnets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr_block": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tier": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z_-]+$`), "must contain only alphanumeric characters, underscores and hyphens"),
+						},
+						"prefix_length": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(16, 28),
+						},
+					},
+				},
+			},
+			"vpc_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"cidr_block", "vpc_id"},
+			},
+		},
+	}
+}
+
+type vpcSubnetPlanTier struct {
+	name         string
+	prefixLength int
+}
+
+type vpcSubnetPlanSubnet struct {
+	availabilityZone string
+	cidrBlock        string
+	subnetID         string
+	tier             string
+}
+
+func dataSourceAwsVpcSubnetPlanRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	var cidrBlocks []string
+	var existing []*ec2.Subnet
+
+	if v, ok := d.GetOk("vpc_id"); ok {
+		vpcID := v.(string)
+		vpc, err := vpcDescribe(conn, vpcID)
+
+		if err != nil {
+			return fmt.Errorf("error reading EC2 VPC (%s): %s", vpcID, err)
+		}
+
+		if vpc == nil {
+			return fmt.Errorf("EC2 VPC (%s) not found", vpcID)
+		}
+
+		for _, a := range vpc.CidrBlockAssociationSet {
+			if aws.StringValue(a.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+				cidrBlocks = append(cidrBlocks, aws.StringValue(a.CidrBlock))
+			}
+		}
+
+		output, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+			Filters: buildEC2AttributeFilterList(map[string]string{
+				"vpc-id": vpcID,
+			}),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error reading EC2 VPC (%s) subnets: %s", vpcID, err)
+		}
+
+		existing = output.Subnets
+
+		d.SetId(vpcID)
+	} else {
+		cidrBlocks = append(cidrBlocks, d.Get("cidr_block").(string))
+
+		d.SetId(d.Get("cidr_block").(string))
+	}
+
+	for _, cidrBlock := range cidrBlocks {
+		if _, errs := validateCIDRNetworkAddress(cidrBlock, "cidr_block"); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+
+	var tiers []vpcSubnetPlanTier
+	names := make(map[string]bool)
+
+	for _, v := range d.Get("tier").([]interface{}) {
+		m := v.(map[string]interface{})
+		tier := vpcSubnetPlanTier{
+			name:         m["name"].(string),
+			prefixLength: m["prefix_length"].(int),
+		}
+
+		if names[tier.name] {
+			return fmt.Errorf("duplicate tier name: %s", tier.name)
+		}
+
+		names[tier.name] = true
+		tiers = append(tiers, tier)
+	}
+
+	availabilityZones, err := vpcSubnetPlanAvailabilityZones(conn, d.Get("availability_zone_count").(int))
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Planning subnets in %v for %d tiers across %v", cidrBlocks, len(tiers), availabilityZones)
+	subnets, err := vpcSubnetPlan(cidrBlocks, existing, tiers, availabilityZones)
+
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("availability_zones", availabilityZones); err != nil {
+		return fmt.Errorf("error setting availability_zones: %s", err)
+	}
+
+	tfList := make([]interface{}, 0, len(subnets))
+	tfMap := make(map[string]interface{}, len(subnets))
+
+	for _, subnet := range subnets {
+		tfList = append(tfList, map[string]interface{}{
+			"availability_zone": subnet.availabilityZone,
+			"cidr_block":        subnet.cidrBlock,
+			"subnet_id":         subnet.subnetID,
+			"tier":              subnet.tier,
+		})
+		tfMap[fmt.Sprintf("%s/%s", subnet.tier, subnet.availabilityZone)] = subnet.cidrBlock
+	}
+
+	if err := d.Set("subnets", tfList); err != nil {
+		return fmt.Errorf("error setting subnets: %s", err)
+	}
+
+	if err := d.Set("cidr_blocks", tfMap); err != nil {
+		return fmt.Errorf("error setting cidr_blocks: %s", err)
+	}
+
+	return nil
+}
+
+// vpcSubnetPlanAvailabilityZones returns the first count available
+// availability zones of the region by name.
+func vpcSubnetPlanAvailabilityZones(conn *ec2.EC2, count int) ([]string, error) {
+	output, err := conn.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"state": ec2.AvailabilityZoneStateAvailable,
+		}),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading EC2 Availability Zones: %s", err)
+	}
+
+	var names []string
+
+	for _, az := range output.AvailabilityZones {
+		names = append(names, aws.StringValue(az.ZoneName))
+	}
+
+	sort.Strings(names)
+
+	if len(names) < count {
+		return nil, fmt.Errorf("requested %d availability zones, only %d available", count, len(names))
+	}
+
+	return names[:count], nil
+}
+
+// vpcSubnetPlan allocates a CIDR block for each tier in each availability
+// zone from the given CIDR blocks, avoiding the existing subnets.
+func vpcSubnetPlan(cidrBlocks []string, existing []*ec2.Subnet, tiers []vpcSubnetPlanTier, availabilityZones []string) ([]vpcSubnetPlanSubnet, error) {
+	var planned []vpcSubnetPlanSubnet
+	var allocated []*net.IPNet
+	claimed := make(map[string]bool)
+
+	// claim returns whether the candidate block can be used in the
+	// availability zone, and the existing subnet it corresponds to, if any.
+	claim := func(candidate *net.IPNet, availabilityZone string) (string, bool) {
+		var subnetID string
+
+		for _, subnet := range existing {
+			_, network, err := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+
+			if err != nil || !ipNetsOverlap(candidate, network) {
+				continue
+			}
+
+			id := aws.StringValue(subnet.SubnetId)
+
+			if network.String() != candidate.String() || aws.StringValue(subnet.AvailabilityZone) != availabilityZone || claimed[id] {
+				return "", false
+			}
+
+			subnetID = id
+		}
+
+		return subnetID, true
+	}
+
+	for _, tier := range tiers {
+	Zones:
+		for _, availabilityZone := range availabilityZones {
+			for _, cidrBlock := range cidrBlocks {
+				_, network, _ := net.ParseCIDR(cidrBlock)
+				ones, bits := network.Mask.Size()
+
+				if bits != 32 || tier.prefixLength < ones {
+					continue
+				}
+
+				size := uint32(1) << uint(32-tier.prefixLength)
+
+			Candidates:
+				for n := uint32(0); n < uint32(1)<<uint(tier.prefixLength-ones); n++ {
+					ip := make(net.IP, net.IPv4len)
+					binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(network.IP.To4())+n*size)
+					candidate := &net.IPNet{IP: ip, Mask: net.CIDRMask(tier.prefixLength, 32)}
+
+					for _, a := range allocated {
+						if ipNetsOverlap(candidate, a) {
+							continue Candidates
+						}
+					}
+
+					subnetID, ok := claim(candidate, availabilityZone)
+
+					if !ok {
+						continue
+					}
+
+					if subnetID != "" {
+						claimed[subnetID] = true
+					}
+
+					allocated = append(allocated, candidate)
+					planned = append(planned, vpcSubnetPlanSubnet{
+						availabilityZone: availabilityZone,
+						cidrBlock:        candidate.String(),
+						subnetID:         subnetID,
+						tier:             tier.name,
+					})
+
+					continue Zones
+				}
+			}
+
+			return nil, fmt.Errorf("no free /%d CIDR block in %v for tier %s in %s", tier.prefixLength, cidrBlocks, tier.name, availabilityZone)
+		}
+	}
+
+	return planned, nil
+}
+
+func ipNetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
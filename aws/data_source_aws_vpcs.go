@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsVpcs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsVpcsRead,
+		Schema: map[string]*schema.Schema{
+			"filter": ec2CustomFiltersSchema(),
+
+			"tags": tagsSchemaComputed(),
+
+			"ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceAwsVpcsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.DescribeVpcsInput{}
+
+	input.Filters = append(input.Filters, ec2TagFiltersFromMap(d.Get("tags").(map[string]interface{}))...)
+	input.Filters = append(input.Filters, buildEC2CustomFilterList(d.Get("filter").(*schema.Set))...)
+
+	if len(input.Filters) == 0 {
+		// Don't send an empty filters list; the EC2 API won't accept it.
+		input.Filters = nil
+	}
+
+	var vpcIDs []string
+
+	log.Printf("[DEBUG] Reading EC2 VPCs: %s", input)
+	err := conn.DescribeVpcsPages(input, func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+		for _, vpc := range page.Vpcs {
+			vpcIDs = append(vpcIDs, aws.StringValue(vpc.VpcId))
+		}
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 VPCs: %s", err)
+	}
+
+	if len(vpcIDs) == 0 {
+		return errors.New("no matching VPC found")
+	}
+
+	d.SetId(resource.UniqueId())
+
+	if err := d.Set("ids", vpcIDs); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,3987 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/quicksight"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/fakeaws"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+// The tests in this file run every registered resource and data source
+// against an in-memory fakeaws server, without network access or credentials.
+
+// testAccFakeAWSMemberAccountID is the account of the "member" provider
+// configuration, which is not in the organization until it accepts an invitation.
+const testAccFakeAWSMemberAccountID = "210000000001"
+
+// testAccFakeAWSStateOnlyResources are resources whose destroy only removes
+// them from state, or whose Read cannot detect that they no longer exist.
+var testAccFakeAWSStateOnlyResources = map[string]bool{
+	"aws_default_network_acl":                 true,
+	"aws_default_route_table":                 true,
+	"aws_default_security_group":              true,
+	"aws_default_subnet":                      true,
+	"aws_default_vpc":                         true,
+	"aws_internet_gateway_delete":             true,
+	"aws_internet_gateway_detach":             true,
+	"aws_organizations_invitation":            true,
+	"aws_organizations_invitation_acceptance": true,
+}
+
+// testAccFakeAWSTest runs a unit test case with fresh provider instances,
+// so that test cases against different servers can run in parallel.
+func testAccFakeAWSTest(t *testing.T, c resource.TestCase) {
+	var providers []*schema.Provider
+
+	c.IsUnitTest = true
+	c.ProviderFactories = testAccProviderFactories(&providers)
+
+	resource.ParallelTest(t, c)
+}
+
+// testAccFakeAWSProviderConfig returns the default provider configuration,
+// and a "member" provider configuration for testAccFakeAWSMemberAccountID,
+// with every endpoint served by the server pointed at it.
+func testAccFakeAWSProviderConfig(s *fakeaws.Server) string {
+	return testAccFakeAWSProviderBlock(s, "", "AKIAFAKEAWS") + testAccFakeAWSProviderBlock(s, `alias = "member"`, testAccFakeAWSMemberAccountID)
+}
+
+// testAccFakeAWSProviderBlock returns a provider block for the fake server
+// with the given additional arguments.
+func testAccFakeAWSProviderBlock(s *fakeaws.Server, arguments, accessKey string) string {
+	var endpoints strings.Builder
+
+	for _, name := range fakeaws.EndpointServiceNames {
+		fmt.Fprintf(&endpoints, "    %s = %q\n", name, s.URL)
+	}
+
+	return fmt.Sprintf(`
+provider "aws" {
+  %[1]s
+  access_key              = %[2]q
+  secret_key              = "fakeaws"
+  region                  = %[3]q
+  skip_metadata_api_check = true
+
+  endpoints {
+%[4]s  }
+}
+`, arguments, accessKey, fakeaws.Region, endpoints.String())
+}
+
+// testAccFakeAWSClient returns a client for the default provider configuration.
+func testAccFakeAWSClient(t *testing.T, s *fakeaws.Server) *AWSClient {
+	config := &Config{
+		AccessKey:           "AKIAFAKEAWS",
+		SecretKey:           "fakeaws",
+		Region:              fakeaws.Region,
+		Endpoints:           s.Endpoints(),
+		MaxRetries:          1,
+		SkipCredsValidation: true,
+
+		SkipMetadataApiCheck: true,
+	}
+
+	client, err := config.Client()
+
+	if err != nil {
+		t.Fatalf("error configuring client: %s", err)
+	}
+
+	return client.(*AWSClient)
+}
+
+// testAccCheckFakeAWSDestroy verifies that every destroyed resource no
+// longer exists, using the resource's own Read function.
+func testAccCheckFakeAWSDestroy(client *AWSClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		provider := Provider().(*schema.Provider)
+
+		for name, rs := range s.RootModule().Resources {
+			if strings.HasPrefix(name, "data.") || testAccFakeAWSStateOnlyResources[rs.Type] {
+				continue
+			}
+
+			r, ok := provider.ResourcesMap[rs.Type]
+
+			if !ok {
+				return fmt.Errorf("unknown resource type: %s", rs.Type)
+			}
+
+			d := r.Data(rs.Primary)
+
+			if err := r.Read(d, client); err != nil {
+				return fmt.Errorf("error reading %s (%s): %s", name, rs.Primary.ID, err)
+			}
+
+			if d.Id() != "" {
+				return fmt.Errorf("%s (%s) still exists", name, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckFakeAWSResourceDisappears deletes a resource outside Terraform.
+func testAccCheckFakeAWSResourceDisappears(client *AWSClient, r *schema.Resource, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return r.Delete(r.Data(rs.Primary), client)
+	}
+}
+
+func TestFakeAWS_callerIdentity(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+data "aws_caller_identity" "current" {}
+
+data "aws_caller_identity" "member" {
+  provider = aws.member
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_caller_identity.current", "account_id", fakeaws.AccountID),
+					resource.TestCheckResourceAttr("data.aws_caller_identity.member", "account_id", testAccFakeAWSMemberAccountID),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpc(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_vpc.test"
+	dataSourceName := "data.aws_vpc.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+data "aws_vpc" "test" {
+  id = aws_vpc.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cidr_block", "10.1.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "enable_dns_hostnames", "true"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "main_route_table_id", resourceName, "main_route_table_id"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("updated"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "tags.Name", "updated"),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcCidrBlockAssociation(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+	resourceName := "aws_vpc.test"
+	associationResourceName := "aws_vpc_ipv4_cidr_block_association.test"
+	dataSourceName := "data.aws_vpc.test"
+
+	if _, err := client.ec2conn.ProvisionByoipCidr(&ec2.ProvisionByoipCidrInput{Cidr: aws.String("2600:1f14:ff00::/48")}); err != nil {
+		t.Fatalf("error provisioning BYOIP CIDR: %s", err)
+	}
+
+	pools, err := client.ec2conn.DescribeIpv6Pools(&ec2.DescribeIpv6PoolsInput{})
+
+	if err != nil {
+		t.Fatalf("error describing IPv6 pools: %s", err)
+	}
+
+	poolID := aws.StringValue(pools.Ipv6Pools[0].PoolId)
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+resource "aws_vpc" "test" {
+  cidr_block      = "10.1.0.0/16"
+  ipv6_cidr_block = "2600:1f14:ff00:100::/56"
+}
+`,
+				ExpectError: regexp.MustCompile(`ipv6_cidr_block can only be set together with ipv6_ipam_pool`),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcCidrBlockAssociationConfig(poolID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "assign_generated_ipv6_cidr_block", "false"),
+					resource.TestCheckResourceAttr(resourceName, "ipv6_cidr_block", "2600:1f14:ff00:100::/56"),
+					resource.TestCheckResourceAttr(resourceName, "ipv6_ipam_pool", poolID),
+					resource.TestCheckResourceAttr(associationResourceName, "cidr_block", "100.64.0.0/16"),
+					resource.TestCheckResourceAttrPair(associationResourceName, "vpc_id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "cidr_block_associations.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "cidr_block_associations.1.association_id", associationResourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "cidr_block_associations.1.cidr_block", "100.64.0.0/16"),
+					resource.TestCheckResourceAttr(dataSourceName, "cidr_block_associations.1.state", ec2.VpcCidrBlockStateCodeAssociated),
+					resource.TestCheckResourceAttr(dataSourceName, "ipv6_cidr_block_associations.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipv6_cidr_block_associations.0.association_id", resourceName, "ipv6_association_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "ipv6_cidr_block_associations.0.ipv6_cidr_block", "2600:1f14:ff00:100::/56"),
+					resource.TestCheckResourceAttr(dataSourceName, "ipv6_cidr_block_associations.0.ipv6_pool", poolID),
+					resource.TestCheckResourceAttr(dataSourceName, "ipv6_cidr_block_associations.0.state", ec2.VpcCidrBlockStateCodeAssociated),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcCidrBlockAssociationConfig(poolID, false),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      associationResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFakeAWSVpcCidrBlockAssociationConfig(poolID string, dataSource bool) string {
+	config := fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block      = "10.1.0.0/16"
+  ipv6_ipam_pool  = %[1]q
+  ipv6_cidr_block = "2600:1f14:ff00:100::/56"
+}
+
+resource "aws_vpc_ipv4_cidr_block_association" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "100.64.0.0/16"
+}
+`, poolID)
+
+	// The data source is dropped before importing the VPC, which it would
+	// otherwise be verified against.
+	if dataSource {
+		config += `
+data "aws_vpc" "test" {
+  id = aws_vpc_ipv4_cidr_block_association.test.vpc_id
+}
+`
+	}
+
+	return config
+}
+
+func TestFakeAWS_subnet(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_subnet.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "test" {
+  vpc_id                  = aws_vpc.test.id
+  cidr_block              = "10.1.1.0/24"
+  availability_zone       = "us-west-2a"
+  map_public_ip_on_launch = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "availability_zone_id", fakeaws.AvailabilityZones["us-west-2a"]),
+					resource.TestCheckResourceAttr(resourceName, "map_public_ip_on_launch", "true"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcImportTopology(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_vpc.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_subnet" "test" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.1.0/24"
+  availability_zone = "us-west-2a"
+}
+
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    cidr_block = "0.0.0.0/0"
+    gateway_id = aws_internet_gateway.test.id
+  }
+}
+
+resource "aws_route_table_association" "test" {
+  subnet_id      = aws_subnet.test.id
+  route_table_id = aws_route_table.test.id
+}
+
+resource "aws_network_acl" "test" {
+  vpc_id     = aws_vpc.test.id
+  subnet_ids = [aws_subnet.test.id]
+}
+
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+
+  ingress {
+    protocol    = "tcp"
+    from_port   = 443
+    to_port     = 443
+    cidr_blocks = ["10.0.0.0/8"]
+  }
+
+  egress {
+    protocol    = "-1"
+    from_port   = 0
+    to_port     = 0
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id       = aws_subnet.test.id
+  security_groups = [aws_security_group.test.id]
+}
+`,
+			},
+			{
+				Config:        testAccFakeAWSProviderConfig(s),
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "vpc-00000000000000002/bogus",
+				ExpectError:   regexp.MustCompile(`unexpected format for ID`),
+			},
+			{
+				Config:       testAccFakeAWSProviderConfig(s),
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return state.RootModule().Resources[resourceName].Primary.ID + tfec2.VpcTopologyImportIDSuffix, nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					got := make(map[string]int)
+
+					for _, state := range states {
+						got[state.Ephemeral.Type]++
+
+						if state.Ephemeral.Type == "aws_vpc" && !strings.HasPrefix(state.ID, "vpc-") {
+							return fmt.Errorf("expected VPC ID, got: %s", state.ID)
+						}
+
+						if state.Ephemeral.Type == "aws_internet_gateway" && !strings.HasPrefix(state.Attributes["vpc_id"], "vpc-") {
+							return fmt.Errorf("expected internet gateway vpc_id, got: %s", state.Attributes["vpc_id"])
+						}
+					}
+
+					// The default security group's rules stay inline, the
+					// internet gateway attachment is the gateway's vpc_id and
+					// the network ACL association is the ACL's subnet_ids.
+					want := map[string]int{
+						"aws_default_network_acl":     1,
+						"aws_default_route_table":     1,
+						"aws_default_security_group":  1,
+						"aws_internet_gateway":        1,
+						"aws_network_acl":             1,
+						"aws_network_interface":       1,
+						"aws_route_table":             1,
+						"aws_route_table_association": 1,
+						"aws_security_group":          1,
+						"aws_security_group_rule":     2,
+						"aws_subnet":                  1,
+						"aws_vpc":                     1,
+					}
+
+					if !reflect.DeepEqual(got, want) {
+						return fmt.Errorf("expected imported resources %v, got: %v", want, got)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcImportTopologyInstance(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+
+	// Instances are not managed by the provider, so one is run in a default
+	// subnet of the default VPC, whose topology is then imported.
+	subnets, err := client.ec2conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"availability-zone": "us-west-2a",
+			"default-for-az":    "true",
+		}),
+	})
+
+	if err != nil {
+		t.Fatalf("error describing default subnet: %s", err)
+	}
+
+	_, err = client.ec2conn.RunInstances(&ec2.RunInstancesInput{
+		MaxCount: aws.Int64(1),
+		MinCount: aws.Int64(1),
+		SubnetId: subnets.Subnets[0].SubnetId,
+	})
+
+	if err != nil {
+		t.Fatalf("error running instance: %s", err)
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccFakeAWSProviderConfig(s),
+				ResourceName:  "aws_vpc.test",
+				ImportState:   true,
+				ImportStateId: aws.StringValue(subnets.Subnets[0].VpcId) + tfec2.VpcTopologyImportIDSuffix,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					// The instance's primary network interface is created and
+					// deleted with the instance.
+					for _, state := range states {
+						if state.Ephemeral.Type == "aws_network_interface" {
+							return fmt.Errorf("expected no network interfaces to be imported, got: %s", state.ID)
+						}
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcSubnetPlan(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	dataSourceName := "data.aws_vpc_subnet_plan.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+data "aws_vpc_subnet_plan" "test" {
+  cidr_block              = "10.2.0.0/24"
+  availability_zone_count = 2
+
+  tier {
+    name          = "public"
+    prefix_length = 24
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`no free /24 CIDR block`),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcSubnetPlanConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "availability_zones.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "availability_zones.0", "us-west-2a"),
+					resource.TestCheckResourceAttr(dataSourceName, "availability_zones.1", "us-west-2b"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.0.tier", "public"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.0.availability_zone", "us-west-2a"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.0.cidr_block", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.0.subnet_id", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "cidr_blocks.public/us-west-2b", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr(dataSourceName, "cidr_blocks.private/us-west-2a", "10.1.16.0/20"),
+					resource.TestCheckResourceAttr(dataSourceName, "cidr_blocks.private/us-west-2b", "10.1.32.0/20"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcSubnetPlanConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aws_subnet.planned.0", "cidr_block", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("aws_subnet.planned.3", "cidr_block", "10.1.32.0/20"),
+					resource.TestCheckResourceAttr("aws_subnet.planned.3", "availability_zone", "us-west-2b"),
+				),
+			},
+			{
+				// Once the planned subnets exist they are claimed by the plan
+				// rather than moving it.
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcSubnetPlanConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.0.cidr_block", "10.1.1.0/24"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "subnets.0.subnet_id", "aws_subnet.planned.0", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "subnets.3.cidr_block", "10.1.32.0/20"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "subnets.3.subnet_id", "aws_subnet.planned.3", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSVpcSubnetPlanConfig(subnets bool) string {
+	config := testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "existing" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.0.0/24"
+  availability_zone = "us-west-2c"
+}
+
+data "aws_vpc_subnet_plan" "test" {
+  vpc_id                  = aws_subnet.existing.vpc_id
+  availability_zone_count = 2
+
+  tier {
+    name          = "public"
+    prefix_length = 24
+  }
+
+  tier {
+    name          = "private"
+    prefix_length = 20
+  }
+}
+`
+
+	if subnets {
+		config += `
+resource "aws_subnet" "planned" {
+  count = length(data.aws_vpc_subnet_plan.test.subnets)
+
+  vpc_id            = data.aws_vpc_subnet_plan.test.vpc_id
+  cidr_block        = data.aws_vpc_subnet_plan.test.subnets[count.index].cidr_block
+  availability_zone = data.aws_vpc_subnet_plan.test.subnets[count.index].availability_zone
+}
+`
+	}
+
+	return config
+}
+
+func TestFakeAWS_regionDataSources(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+data "aws_partition" "test" {}
+
+data "aws_region" "current" {}
+
+data "aws_region" "test" {
+  endpoint = "ec2.eu-west-1.amazonaws.com"
+}
+
+data "aws_regions" "test" {}
+
+data "aws_regions" "opt_in" {
+  all_regions = true
+
+  filter {
+    name   = "opt-in-status"
+    values = ["not-opted-in"]
+  }
+}
+
+data "aws_availability_zones" "test" {
+  state = "available"
+}
+
+data "aws_availability_zones" "exclude" {
+  exclude_names    = ["us-west-2a"]
+  exclude_zone_ids = ["usw2-az3"]
+}
+
+data "aws_availability_zones" "filter" {
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+
+  filter {
+    name   = "zone-id"
+    values = ["usw2-az2", "usw2-az3"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_partition.test", "partition", "aws"),
+					resource.TestCheckResourceAttr("data.aws_partition.test", "dns_suffix", "amazonaws.com"),
+					resource.TestCheckResourceAttr("data.aws_partition.test", "reverse_dns_prefix", "com.amazonaws"),
+					resource.TestCheckResourceAttr("data.aws_region.current", "name", fakeaws.Region),
+					resource.TestCheckResourceAttr("data.aws_region.current", "endpoint", "ec2.us-west-2.amazonaws.com"),
+					resource.TestCheckResourceAttr("data.aws_region.current", "description", "US West (Oregon)"),
+					resource.TestCheckResourceAttr("data.aws_region.test", "name", "eu-west-1"),
+					resource.TestCheckResourceAttr("data.aws_regions.opt_in", "names.#", "4"),
+					testAccCheckFakeAWSRegionsNames("data.aws_regions.test", fakeaws.Region, "af-south-1"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.test", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.test", "names.0", "us-west-2a"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.test", "zone_ids.0", "usw2-az1"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.test", "group_names.#", "1"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.exclude", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.exclude", "names.0", "us-west-2b"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.filter", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.aws_availability_zones.filter", "zone_ids.1", "usw2-az3"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+data "aws_region" "test" {
+  name     = "us-east-1"
+  endpoint = "ec2.eu-west-1.amazonaws.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`multiple regions matched`),
+			},
+		},
+	})
+}
+
+// testAccCheckFakeAWSRegionsNames checks that an aws_regions data source
+// includes the included region and not the excluded one.
+func testAccCheckFakeAWSRegionsNames(name, included, excluded string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		names := make(map[string]bool)
+
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "names.") && k != "names.#" {
+				names[v] = true
+			}
+		}
+
+		if !names[included] {
+			return fmt.Errorf("%s names do not include %s", name, included)
+		}
+
+		if names[excluded] {
+			return fmt.Errorf("%s names include %s", name, excluded)
+		}
+
+		return nil
+	}
+}
+
+func TestFakeAWS_ec2NetworkingDataSources(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	// The singular data sources read through a provider configuration that
+	// ignores the "Ignored" tag.
+	providerConfig := testAccFakeAWSProviderConfig(s) + testAccFakeAWSProviderBlock(s, `
+  alias = "ignore"
+
+  ignore_tags {
+    keys = ["Ignored"]
+  }
+`, "AKIAFAKEAWS")
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccFakeAWSEc2NetworkingDataSourcesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_vpcs.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.aws_subnet_ids.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.aws_subnet_ids.public", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.aws_subnet.test", "id", "aws_subnet.public", "id"),
+					resource.TestCheckResourceAttr("data.aws_subnet.test", "cidr_block", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("data.aws_subnet.test", "availability_zone_id", "usw2-az1"),
+					resource.TestCheckResourceAttr("data.aws_subnet.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("data.aws_subnet.test", "tags.Tier", "public"),
+					resource.TestCheckResourceAttrPair("data.aws_subnet.test", "arn", "aws_subnet.public", "arn"),
+					resource.TestCheckResourceAttrPair("data.aws_security_group.test", "id", "aws_security_group.test", "id"),
+					resource.TestCheckResourceAttr("data.aws_security_group.test", "description", "test"),
+					resource.TestCheckResourceAttrPair("data.aws_security_group.test", "arn", "aws_security_group.test", "arn"),
+					resource.TestCheckResourceAttr("data.aws_security_groups.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.aws_security_groups.test", "vpc_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("data.aws_route_table.test", "id", "aws_route_table.test", "id"),
+					resource.TestCheckResourceAttr("data.aws_route_table.test", "routes.#", "1"),
+					resource.TestCheckResourceAttrPair("data.aws_route_table.test", "routes.0.gateway_id", "aws_internet_gateway.test", "id"),
+					resource.TestCheckResourceAttr("data.aws_route_table.test", "associations.#", "1"),
+					resource.TestCheckResourceAttrPair("data.aws_route_table.test", "associations.0.subnet_id", "aws_subnet.public", "id"),
+					resource.TestCheckResourceAttr("data.aws_network_acls.test", "ids.#", "2"),
+					resource.TestCheckResourceAttrPair("data.aws_network_interface.test", "id", "aws_network_interface.test", "id"),
+					resource.TestCheckResourceAttrPair("data.aws_network_interface.test", "subnet_id", "aws_subnet.public", "id"),
+					resource.TestCheckResourceAttrPair("data.aws_network_interface.test", "vpc_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttr("data.aws_network_interface.test", "private_ips.#", "1"),
+					resource.TestCheckResourceAttr("data.aws_network_interface.test", "security_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.aws_network_interfaces.test", "ids.#", "1"),
+				),
+			},
+			{
+				Config: providerConfig + testAccFakeAWSVpcConfig("test") + `
+data "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.99.0/24"
+}
+`,
+				ExpectError: regexp.MustCompile(`no matching subnet found`),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSEc2NetworkingDataSourcesConfig() string {
+	return testAccFakeAWSVpcConfig("test") + `
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_subnet" "public" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.1.0/24"
+  availability_zone = "us-west-2a"
+
+  tags = {
+    Tier    = "public"
+    Ignored = "true"
+  }
+}
+
+resource "aws_subnet" "private" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.2.0/24"
+  availability_zone = "us-west-2b"
+}
+
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    cidr_block = "0.0.0.0/0"
+    gateway_id = aws_internet_gateway.test.id
+  }
+}
+
+resource "aws_route_table_association" "test" {
+  subnet_id      = aws_subnet.public.id
+  route_table_id = aws_route_table.test.id
+}
+
+resource "aws_network_acl" "test" {
+  vpc_id     = aws_vpc.test.id
+  subnet_ids = [aws_subnet.private.id]
+}
+
+resource "aws_security_group" "test" {
+  name        = "test"
+  description = "test"
+  vpc_id      = aws_vpc.test.id
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id       = aws_subnet.public.id
+  security_groups = [aws_security_group.test.id]
+}
+
+data "aws_vpcs" "test" {
+  filter {
+    name   = "vpc-id"
+    values = [aws_vpc.test.id]
+  }
+}
+
+data "aws_subnet_ids" "test" {
+  vpc_id = aws_subnet.private.vpc_id
+}
+
+data "aws_subnet_ids" "public" {
+  vpc_id = aws_subnet.public.vpc_id
+
+  tags = {
+    Tier = "public"
+  }
+}
+
+data "aws_subnet" "test" {
+  provider = aws.ignore
+
+  vpc_id = aws_vpc.test.id
+
+  filter {
+    name   = "tag:Tier"
+    values = [aws_subnet.public.tags["Tier"]]
+  }
+}
+
+data "aws_security_group" "test" {
+  provider = aws.ignore
+
+  name   = aws_security_group.test.name
+  vpc_id = aws_vpc.test.id
+}
+
+data "aws_security_groups" "test" {
+  filter {
+    name   = "vpc-id"
+    values = [aws_security_group.test.vpc_id]
+  }
+}
+
+data "aws_route_table" "test" {
+  provider = aws.ignore
+
+  subnet_id = aws_route_table_association.test.subnet_id
+}
+
+data "aws_network_acls" "test" {
+  vpc_id = aws_network_acl.test.vpc_id
+}
+
+data "aws_network_interface" "test" {
+  provider = aws.ignore
+
+  id = aws_network_interface.test.id
+}
+
+data "aws_network_interfaces" "test" {
+  filter {
+    name   = "subnet-id"
+    values = [aws_network_interface.test.subnet_id]
+  }
+}
+`
+}
+
+func TestFakeAWS_securityGroup(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_security_group.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+
+  ingress {
+    protocol    = "tcp"
+    from_port   = 443
+    to_port     = 443
+    cidr_blocks = ["10.0.0.0/8"]
+  }
+
+  egress {
+    protocol    = "-1"
+    from_port   = 0
+    to_port     = 0
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+
+resource "aws_security_group" "source" {
+  name   = "source"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_security_group_rule" "test" {
+  type                     = "ingress"
+  protocol                 = "tcp"
+  from_port                = 22
+  to_port                  = 22
+  security_group_id        = aws_security_group.source.id
+  source_security_group_id = aws_security_group.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "1"),
+					resource.TestCheckResourceAttrPair("aws_security_group_rule.test", "source_security_group_id", resourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_securityGroupRule(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_security_group_rule.test"
+	selfResourceName := "aws_security_group_rule.self"
+
+	importStateIDFunc := func(resourceName string) resource.ImportStateIdFunc {
+		return func(state *terraform.State) (string, error) {
+			return state.RootModule().Resources[resourceName].Primary.Attributes["fingerprint"], nil
+		}
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSSecurityGroupRuleConfig("first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestMatchResourceAttr(resourceName, "fingerprint", regexp.MustCompile(`^sg-[0-9a-f]+_ingress_tcp_443_443_10\.0\.0\.0/8_10\.1\.0\.0/16$`)),
+					resource.TestMatchResourceAttr(selfResourceName, "fingerprint", regexp.MustCompile(`^sg-[0-9a-f]+_egress_all_0_0_self$`)),
+					resource.TestMatchResourceAttr("aws_security_group_rule.shared", "fingerprint", regexp.MustCompile(`^sg-[0-9a-f]+_egress_all_0_0_0\.0\.0\.0/0$`)),
+				),
+			},
+			{
+				// Changing only the description updates the rule in place.
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSSecurityGroupRuleConfig("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestMatchResourceAttr(selfResourceName, "fingerprint", regexp.MustCompile(`^sg-[0-9a-f]+_egress_all_0_0_self$`)),
+					testAccCheckFakeAWSCallsLessThan(s, "AuthorizeSecurityGroupIngress", 2),
+					testAccCheckFakeAWSCallsLessThan(s, "RevokeSecurityGroupIngress", 1),
+					testAccCheckFakeAWSCallsLessThan(s, "UpdateSecurityGroupRuleDescriptionsIngress", 2),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: importStateIDFunc(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      selfResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: importStateIDFunc(selfResourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config:        testAccFakeAWSProviderConfig(s),
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "sg-12345678_ingress_gopher_443_443_10.0.0.0/8",
+				ExpectError:   regexp.MustCompile(`unexpected protocol \("gopher"\)`),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSSecurityGroupRuleConfig(description string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_security_group_rule" "test" {
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 443
+  to_port           = 443
+  cidr_blocks       = ["10.0.0.0/8", "10.1.0.0/16"]
+  description       = %[1]q
+  security_group_id = aws_security_group.test.id
+}
+
+resource "aws_security_group_rule" "self" {
+  type              = "egress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  self              = true
+  security_group_id = aws_security_group.test.id
+}
+
+# AWS merges this rule and the self rule into one permission.
+resource "aws_security_group_rule" "shared" {
+  type              = "egress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["0.0.0.0/0"]
+  security_group_id = aws_security_group.test.id
+}
+`, description)
+}
+
+func TestFakeAWS_securityGroupRuleBatching(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	// A window well above the default keeps the rules of each step in one
+	// batch however long Terraform takes to start them.
+	providerConfig := testAccFakeAWSProviderBlock(s, `security_group_rule_batch_window = "2s"`, "AKIAFAKEAWS")
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccFakeAWSSecurityGroupRuleBatchingConfig(10, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aws_security_group_rule.test.9", "from_port", "1009"),
+					testAccCheckFakeAWSCalls(s, "AuthorizeSecurityGroupIngress", 1),
+					testAccCheckFakeAWSSecurityGroupRuleReadsShareDescribe(t, s, 10),
+				),
+			},
+			{
+				Config: providerConfig + testAccFakeAWSSecurityGroupRuleBatchingConfig(2, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFakeAWSCalls(s, "AuthorizeSecurityGroupIngress", 1),
+					testAccCheckFakeAWSCalls(s, "RevokeSecurityGroupIngress", 1),
+				),
+			},
+			{
+				// A batch failing on a duplicate rule is retried one rule at
+				// a time, so the error is reported against the duplicate.
+				Config: providerConfig + testAccFakeAWSSecurityGroupRuleBatchingConfig(2, `
+resource "aws_security_group_rule" "duplicate" {
+  count = 2
+
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 2000
+  to_port           = 2000
+  cidr_blocks       = ["10.0.0.0/8"]
+  security_group_id = aws_security_group.test.id
+}
+`),
+				ExpectError: regexp.MustCompile(`A duplicate Security Group rule was found`),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSSecurityGroupRuleBatchingConfig(count int, extra string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_security_group_rule" "test" {
+  count = %[1]d
+
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 1000 + count.index
+  to_port           = 1000 + count.index
+  cidr_blocks       = ["10.0.0.0/8"]
+  security_group_id = aws_security_group.test.id
+}
+%[2]s`, count, extra)
+}
+
+func TestFakeAWS_securityGroupRuleNoBatching(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	providerConfig := testAccFakeAWSProviderBlock(s, `security_group_rule_batch_window = "0s"`, "AKIAFAKEAWS")
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccFakeAWSSecurityGroupRuleBatchingConfig(3, ""),
+				Check:  testAccCheckFakeAWSCalls(s, "AuthorizeSecurityGroupIngress", 3),
+			},
+			{
+				Config: providerConfig + testAccFakeAWSSecurityGroupRuleBatchingConfig(1, ""),
+				Check:  testAccCheckFakeAWSCalls(s, "RevokeSecurityGroupIngress", 2),
+			},
+		},
+	})
+}
+
+// testAccCheckFakeAWSSecurityGroupRuleReadsShareDescribe reads the first
+// count aws_security_group_rule.test resources concurrently and checks that
+// they share one DescribeSecurityGroups call.
+func testAccCheckFakeAWSSecurityGroupRuleReadsShareDescribe(t *testing.T, s *fakeaws.Server, count int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		client := testAccFakeAWSClient(t, s)
+		client.securityGroupRuleBatcher = newSecurityGroupRuleBatcher(time.Minute)
+
+		r := resourceAwsSecurityGroupRule()
+		before := s.Calls("DescribeSecurityGroups")
+		errs := make([]error, count)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < count; i++ {
+			rs, ok := state.RootModule().Resources[fmt.Sprintf("aws_security_group_rule.test.%d", i)]
+
+			if !ok {
+				return fmt.Errorf("not found: aws_security_group_rule.test.%d", i)
+			}
+
+			wg.Add(1)
+
+			go func(i int, d *schema.ResourceData) {
+				defer wg.Done()
+
+				if err := r.Read(d, client); err != nil {
+					errs[i] = err
+				} else if d.Id() == "" {
+					errs[i] = fmt.Errorf("aws_security_group_rule.test.%d not found", i)
+				}
+			}(i, r.Data(rs.Primary))
+		}
+
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+
+		if n := s.Calls("DescribeSecurityGroups") - before; n != 1 {
+			return fmt.Errorf("expected %d concurrent reads to make 1 DescribeSecurityGroups call, got: %d", count, n)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckFakeAWSCalls checks that the server received exactly n requests
+// to an operation.
+func testAccCheckFakeAWSCalls(s *fakeaws.Server, operation string, n int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if got := s.Calls(operation); got != n {
+			return fmt.Errorf("expected %d %s calls, got: %d", n, operation, got)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckFakeAWSCallsLessThan checks that the server received fewer than
+// max requests to an operation.
+func testAccCheckFakeAWSCallsLessThan(s *fakeaws.Server, operation string, max int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if n := s.Calls(operation); n >= max {
+			return fmt.Errorf("expected fewer than %d %s calls, got: %d", max, operation, n)
+		}
+
+		return nil
+	}
+}
+
+func TestFakeAWS_networkAcl(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_network_acl.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_network_acl" "test" {
+  vpc_id     = aws_vpc.test.id
+  subnet_ids = [aws_subnet.test.id]
+
+  ingress {
+    protocol   = "tcp"
+    rule_no    = 100
+    action     = "allow"
+    cidr_block = "10.0.0.0/8"
+    from_port  = 443
+    to_port    = 443
+  }
+
+  egress {
+    protocol   = "-1"
+    rule_no    = 100
+    action     = "allow"
+    cidr_block = "0.0.0.0/0"
+    from_port  = 0
+    to_port    = 0
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "egress.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ingress.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "subnet_ids.#", "1"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_routeTable(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_route_table.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id = aws_subnet.test.id
+}
+
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    cidr_block           = "0.0.0.0/0"
+    network_interface_id = aws_network_interface.test.id
+  }
+}
+`,
+				Check: resource.TestCheckResourceAttr(resourceName, "route.#", "1"),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_route(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_route.ipv4"
+	associationResourceName := "aws_route_table_association.test"
+	mainAssociationResourceName := "aws_main_route_table_association.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSRouteConfig("network_interface_id = aws_network_interface.test.id", "aws_route_table.test.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "network_interface_id", "aws_network_interface.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "origin", "CreateRoute"),
+					resource.TestCheckResourceAttr(resourceName, "state", "active"),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^r-rtb-`)),
+					resource.TestCheckResourceAttrPair("aws_route.ipv6", "gateway_id", "aws_internet_gateway.test", "id"),
+					resource.TestCheckResourceAttr("aws_route.prefix_list", "destination_prefix_list_id", "pl-68a54001"),
+					resource.TestCheckResourceAttr("aws_route_table.test", "route.#", "1"),
+					resource.TestCheckResourceAttrPair(associationResourceName, "route_table_id", "aws_route_table.test", "id"),
+					resource.TestMatchResourceAttr(associationResourceName, "id", regexp.MustCompile(`^rtbassoc-`)),
+					resource.TestCheckResourceAttrPair(mainAssociationResourceName, "original_route_table_id", "aws_vpc.test", "main_route_table_id"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSRouteConfig("gateway_id = aws_internet_gateway.test.id", "aws_route_table.other.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "gateway_id", "aws_internet_gateway.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "network_interface_id", ""),
+					resource.TestCheckResourceAttrPair(associationResourceName, "route_table_id", "aws_route_table.other", "id"),
+					resource.TestCheckResourceAttrPair(mainAssociationResourceName, "route_table_id", "aws_route_table.other", "id"),
+					resource.TestCheckResourceAttr("aws_route_table.test", "route.#", "1"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSRouteImportStateIdFunc(resourceName, "destination_cidr_block"),
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_route.ipv6",
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSRouteImportStateIdFunc("aws_route.ipv6", "destination_ipv6_cidr_block"),
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      associationResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSRouteTableAssociationImportStateIdFunc(associationResourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_routeTableInlineRoutesRemoved(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_route_table.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSRouteTableInlineRoutesConfig(`
+  route {
+    cidr_block = "0.0.0.0/0"
+    gateway_id = aws_internet_gateway.test.id
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "route.#", "1"),
+					resource.TestCheckResourceAttr("aws_route.test", "state", "active"),
+				),
+			},
+			{
+				// The route of the aws_route resource is not read into the
+				// emptied inline routes.
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSRouteTableInlineRoutesConfig(`
+  route = []
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "route.#", "0"),
+					resource.TestCheckResourceAttr("aws_route.test", "state", "active"),
+				),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSRouteTableInlineRoutesConfig(routes string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+%[1]s}
+
+resource "aws_route" "test" {
+  route_table_id         = aws_route_table.test.id
+  destination_cidr_block = "192.168.0.0/16"
+  gateway_id             = aws_internet_gateway.test.id
+}
+`, routes)
+}
+
+func testAccFakeAWSRouteImportStateIdFunc(resourceName, destinationAttribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s_%s", rs.Primary.Attributes["route_table_id"], rs.Primary.Attributes[destinationAttribute]), nil
+	}
+}
+
+func testAccFakeAWSRouteTableAssociationImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["subnet_id"], rs.Primary.Attributes["route_table_id"]), nil
+	}
+}
+
+func testAccFakeAWSRouteConfig(target, routeTableID string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id = aws_subnet.test.id
+}
+
+# The inline route coexists with the aws_route resources in the same table.
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    cidr_block           = "0.0.0.0/0"
+    network_interface_id = aws_network_interface.test.id
+  }
+}
+
+resource "aws_route_table" "other" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_route" "ipv4" {
+  route_table_id         = aws_route_table.test.id
+  destination_cidr_block = "192.168.0.0/16"
+  %[1]s
+}
+
+resource "aws_route" "ipv6" {
+  route_table_id              = aws_route_table.test.id
+  destination_ipv6_cidr_block = "::/0"
+  gateway_id                  = aws_internet_gateway.test.id
+}
+
+resource "aws_route" "prefix_list" {
+  route_table_id             = aws_route_table.test.id
+  destination_prefix_list_id = "pl-68a54001"
+  gateway_id                 = aws_internet_gateway.test.id
+}
+
+resource "aws_route_table_association" "test" {
+  subnet_id      = aws_subnet.test.id
+  route_table_id = %[2]s
+}
+
+resource "aws_main_route_table_association" "test" {
+  vpc_id         = aws_vpc.test.id
+  route_table_id = %[2]s
+}
+`, target, routeTableID)
+}
+
+func TestFakeAWS_networkInterface(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_network_interface.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id         = aws_subnet.test.id
+  private_ips       = ["10.1.1.10", "10.1.1.11"]
+  security_groups   = [aws_security_group.test.id]
+  source_dest_check = false
+  description       = "test"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "private_ips.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "security_groups.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "source_dest_check", "false"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_networkInterfaceAttachment(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+	eniResourceName := "aws_network_interface.ipv6"
+	attachmentResourceName := "aws_network_interface_attachment.test"
+	sgAttachmentResourceName := "aws_network_interface_sg_attachment.test"
+
+	// Instances are not managed by the provider, so one is run in the default
+	// subnet the network interface is created in.
+	subnets, err := client.ec2conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"availability-zone": "us-west-2a",
+			"default-for-az":    "true",
+		}),
+	})
+
+	if err != nil {
+		t.Fatalf("error describing default subnet: %s", err)
+	}
+
+	reservation, err := client.ec2conn.RunInstances(&ec2.RunInstancesInput{
+		MaxCount: aws.Int64(1),
+		MinCount: aws.Int64(1),
+		SubnetId: subnets.Subnets[0].SubnetId,
+	})
+
+	if err != nil {
+		t.Fatalf("error running instance: %s", err)
+	}
+
+	instanceID := aws.StringValue(reservation.Instances[0].InstanceId)
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNetworkInterfaceAttachmentConfig(instanceID, "ipv6_address_count = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(attachmentResourceName, "attachment_id", regexp.MustCompile(`^eni-attach-`)),
+					resource.TestCheckResourceAttr(attachmentResourceName, "instance_id", instanceID),
+					resource.TestCheckResourceAttr(attachmentResourceName, "status", ec2.AttachmentStatusAttached),
+					resource.TestCheckResourceAttrPair(sgAttachmentResourceName, "security_group_id", "aws_security_group.test", "id"),
+					resource.TestCheckResourceAttr(eniResourceName, "interface_type", ec2.NetworkInterfaceTypeEfa),
+					resource.TestCheckResourceAttr(eniResourceName, "ipv6_address_count", "2"),
+					resource.TestCheckResourceAttr(eniResourceName, "ipv6_addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNetworkInterfaceAttachmentConfig(instanceID, "ipv6_addresses = [cidrhost(aws_subnet.ipv6.ipv6_cidr_block, 10)]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(eniResourceName, "ipv6_address_count", "1"),
+					resource.TestCheckResourceAttr(eniResourceName, "ipv6_addresses.#", "1"),
+					testAccCheckFakeAWSNetworkInterfaceGroups(client, "aws_network_interface.test", 2),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      attachmentResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      sgAttachmentResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      eniResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckFakeAWSNetworkInterfaceGroups checks the number of security
+// groups of a network interface, which its state only shows once refreshed.
+func testAccCheckFakeAWSNetworkInterfaceGroups(client *AWSClient, name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		eni, err := finder.NetworkInterfaceByID(client.ec2conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if eni == nil || len(eni.Groups) != count {
+			return fmt.Errorf("expected %s to have %d security groups, got: %v", name, count, eni)
+		}
+
+		return nil
+	}
+}
+
+func testAccFakeAWSNetworkInterfaceAttachmentConfig(instanceID, ipv6Addresses string) string {
+	return fmt.Sprintf(`
+data "aws_subnet" "default" {
+  availability_zone = "us-west-2a"
+  default_for_az    = true
+}
+
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = data.aws_subnet.default.vpc_id
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id = data.aws_subnet.default.id
+}
+
+resource "aws_network_interface_attachment" "test" {
+  instance_id          = %[1]q
+  network_interface_id = aws_network_interface.test.id
+  device_index         = 1
+}
+
+resource "aws_network_interface_sg_attachment" "test" {
+  security_group_id    = aws_security_group.test.id
+  network_interface_id = aws_network_interface.test.id
+}
+
+resource "aws_vpc" "ipv6" {
+  cidr_block                       = "10.2.0.0/16"
+  assign_generated_ipv6_cidr_block = true
+}
+
+resource "aws_subnet" "ipv6" {
+  vpc_id          = aws_vpc.ipv6.id
+  cidr_block      = "10.2.1.0/24"
+  ipv6_cidr_block = cidrsubnet(aws_vpc.ipv6.ipv6_cidr_block, 8, 1)
+}
+
+resource "aws_network_interface" "ipv6" {
+  subnet_id      = aws_subnet.ipv6.id
+  interface_type = "efa"
+  %[2]s
+}
+`, instanceID, ipv6Addresses)
+}
+
+func TestFakeAWS_vpcEndpoint(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	gatewayResourceName := "aws_vpc_endpoint.s3"
+	interfaceResourceName := "aws_vpc_endpoint.ssm"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcEndpointConfig(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(gatewayResourceName, "vpc_endpoint_type", "Gateway"),
+					resource.TestCheckResourceAttr(gatewayResourceName, "state", "available"),
+					resource.TestCheckResourceAttr(gatewayResourceName, "prefix_list_id", "pl-68a54001"),
+					resource.TestCheckResourceAttr(gatewayResourceName, "cidr_blocks.#", "3"),
+					resource.TestCheckResourceAttr(gatewayResourceName, "route_table_ids.#", "1"),
+					resource.TestCheckResourceAttr(gatewayResourceName, "tags.Name", "s3"),
+					resource.TestCheckResourceAttr(interfaceResourceName, "vpc_endpoint_type", "Interface"),
+					resource.TestCheckResourceAttr(interfaceResourceName, "network_interface_ids.#", "1"),
+					resource.TestCheckResourceAttr(interfaceResourceName, "security_group_ids.#", "1"),
+					resource.TestCheckResourceAttr(interfaceResourceName, "dns_entry.#", "3"),
+					resource.TestCheckResourceAttr(interfaceResourceName, "requester_managed", "false"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.logs", "network_interface_ids.#", "2"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcEndpointConfig(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:*","Resource":"*"}]}`, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(gatewayResourceName, "policy", regexp.MustCompile(`s3:\*`)),
+					resource.TestCheckResourceAttr(interfaceResourceName, "private_dns_enabled", "false"),
+					resource.TestCheckResourceAttr(interfaceResourceName, "dns_entry.#", "2"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.dynamodb", "route_table_ids.#", "1"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.sts", "subnet_ids.#", "1"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      gatewayResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      interfaceResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_vpc_endpoint_route_table_association.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSVpcEndpointAssociationImportStateIdFunc("aws_vpc_endpoint_route_table_association.test", "route_table_id"),
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_vpc_endpoint_subnet_association.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSVpcEndpointAssociationImportStateIdFunc("aws_vpc_endpoint_subnet_association.test", "subnet_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcEndpointAutoAccept(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	output, err := testAccFakeAWSClient(t, s).ec2conn.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
+		AcceptanceRequired:      aws.Bool(true),
+		NetworkLoadBalancerArns: aws.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/test/0123456789abcdef"}),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC Endpoint Service: %s", err)
+	}
+
+	serviceName := aws.StringValue(output.ServiceConfiguration.ServiceName)
+	resourceName := "aws_vpc_endpoint.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcEndpointAutoAcceptConfig(serviceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "pendingAcceptance"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcEndpointAutoAcceptConfig(serviceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "available"),
+					resource.TestCheckResourceAttr(resourceName, "prefix_list_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "cidr_blocks.#", "0"),
+				),
+			},
+			{
+				Config:                  testAccFakeAWSProviderConfig(s),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auto_accept"},
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcEndpointPendingAcceptanceDelete(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	output, err := testAccFakeAWSClient(t, s).ec2conn.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
+		AcceptanceRequired:      aws.Bool(true),
+		NetworkLoadBalancerArns: aws.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/test/0123456789abcdef"}),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC Endpoint Service: %s", err)
+	}
+
+	// The endpoint is destroyed while still pending acceptance.
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcEndpointAutoAcceptConfig(aws.StringValue(output.ServiceConfiguration.ServiceName), false),
+				Check:  resource.TestCheckResourceAttr("aws_vpc_endpoint.test", "state", "pendingAcceptance"),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSVpcEndpointAssociationImportStateIdFunc(resourceName, attribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["vpc_endpoint_id"], rs.Primary.Attributes[attribute]), nil
+	}
+}
+
+func testAccFakeAWSVpcEndpointConfig(policy string, privateDnsEnabled bool) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_subnet" "a" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.1.0/24"
+  availability_zone = "us-west-2a"
+}
+
+resource "aws_subnet" "b" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.2.0/24"
+  availability_zone = "us-west-2b"
+}
+
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_vpc_endpoint" "s3" {
+  vpc_id          = aws_vpc.test.id
+  service_name    = "com.amazonaws.us-west-2.s3"
+  route_table_ids = [aws_route_table.test.id]
+  policy          = %[1]q
+
+  tags = {
+    Name = "s3"
+  }
+}
+
+resource "aws_vpc_endpoint" "dynamodb" {
+  vpc_id       = aws_vpc.test.id
+  service_name = "com.amazonaws.us-west-2.dynamodb"
+}
+
+resource "aws_vpc_endpoint_route_table_association" "test" {
+  vpc_endpoint_id = aws_vpc_endpoint.dynamodb.id
+  route_table_id  = aws_route_table.test.id
+}
+
+resource "aws_vpc_endpoint" "ssm" {
+  vpc_id              = aws_vpc.test.id
+  service_name        = "com.amazonaws.us-west-2.ssm"
+  vpc_endpoint_type   = "Interface"
+  subnet_ids          = [aws_subnet.a.id]
+  security_group_ids  = [aws_security_group.test.id]
+  private_dns_enabled = %[2]t
+}
+
+resource "aws_vpc_endpoint" "sts" {
+  vpc_id            = aws_vpc.test.id
+  service_name      = "com.amazonaws.us-west-2.sts"
+  vpc_endpoint_type = "Interface"
+}
+
+resource "aws_vpc_endpoint_subnet_association" "test" {
+  vpc_endpoint_id = aws_vpc_endpoint.sts.id
+  subnet_id       = aws_subnet.b.id
+}
+
+resource "aws_vpc_endpoint" "logs" {
+  vpc_id            = aws_vpc.test.id
+  service_name      = "com.amazonaws.us-west-2.logs"
+  vpc_endpoint_type = "Interface"
+  subnet_ids        = [aws_subnet.a.id, aws_subnet.b.id]
+}
+`, policy, privateDnsEnabled)
+}
+
+func testAccFakeAWSVpcEndpointAutoAcceptConfig(serviceName string, autoAccept bool) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_vpc_endpoint" "test" {
+  vpc_id            = aws_vpc.test.id
+  service_name      = %[1]q
+  vpc_endpoint_type = "Interface"
+  subnet_ids        = [aws_subnet.test.id]
+  auto_accept       = %[2]t
+}
+`, serviceName, autoAccept)
+}
+
+func TestFakeAWS_defaultSecurityGroup(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_default_security_group" "test" {
+  vpc_id = aws_vpc.test.id
+}
+`,
+				ExpectError: regexp.MustCompile(`CannotDelete`),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_defaultNetworkAcl(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_default_network_acl" "test" {
+  default_network_acl_id = aws_vpc.test.default_network_acl_id
+}
+`,
+				ExpectError: regexp.MustCompile(`cannot delete default network ACL`),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_defaultNetworkAclGuardrails(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSNetworkGuardrailsProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_default_network_acl" "test" {
+  default_network_acl_id = aws_vpc.test.default_network_acl_id
+
+  ingress {
+    protocol   = "tcp"
+    rule_no    = 100
+    action     = "allow"
+    cidr_block = "0.0.0.0/0"
+    from_port  = 22
+    to_port    = 22
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Network ACL ingress entry 100 \(protocol tcp, ports 22-22, 0.0.0.0/0\) is forbidden`),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_networkAclRule(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+	resourceName := "aws_network_acl_rule.tcp"
+	associationResourceName := "aws_network_acl_association.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNetworkAclRuleConfig("aws_network_acl.test.id", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "protocol", "tcp"),
+					resource.TestCheckResourceAttr(resourceName, "rule_action", "allow"),
+					resource.TestCheckResourceAttr(resourceName, "egress", "false"),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^nacl-`)),
+					resource.TestCheckResourceAttr("aws_network_acl_rule.icmp", "icmp_type", "8"),
+					resource.TestCheckResourceAttr("aws_network_acl_rule.icmp", "icmp_code", "-1"),
+					resource.TestCheckResourceAttr("aws_network_acl_rule.ipv6", "ipv6_cidr_block", "::/0"),
+					resource.TestCheckResourceAttr("aws_network_acl.test", "ingress.#", "1"),
+					resource.TestMatchResourceAttr(associationResourceName, "id", regexp.MustCompile(`^aclassoc-`)),
+					testAccCheckFakeAWSSubnetNetworkAcl(client, "aws_subnet.test", "aws_network_acl.test", "id"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNetworkAclRuleConfig("aws_network_acl.other.id", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(associationResourceName, "network_acl_id", "aws_network_acl.other", "id"),
+					testAccCheckFakeAWSSubnetNetworkAcl(client, "aws_subnet.test", "aws_network_acl.other", "id"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSNetworkAclRuleImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      associationResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSNetworkAclAssociationImportStateIdFunc(associationResourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNetworkAclRuleConfig("", ""),
+				Check:  testAccCheckFakeAWSSubnetNetworkAcl(client, "aws_subnet.test", "aws_vpc.test", "default_network_acl_id"),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNetworkAclRuleConfig("", `
+resource "aws_network_acl_rule" "collision" {
+  network_acl_id = aws_network_acl.test.id
+  rule_number    = 100
+  protocol       = "udp"
+  rule_action    = "deny"
+  cidr_block     = "10.0.0.0/8"
+  from_port      = 53
+  to_port        = 53
+}
+`),
+				ExpectError: regexp.MustCompile(`already has an ingress entry with rule number 100`),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_networkAclRuleGuardrails(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	rule := `
+resource "aws_network_acl_rule" "test" {
+  network_acl_id = aws_network_acl.test.id
+  rule_number    = 100
+  protocol       = "tcp"
+  rule_action    = "allow"
+  cidr_block     = "0.0.0.0/0"
+  from_port      = 22
+  to_port        = 22
+}
+`
+	tags := `
+  tags = {
+    GuardrailException = "approved"
+  }
+`
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccFakeAWSNetworkGuardrailsProviderConfig(s) + testAccFakeAWSNetworkAclRuleGuardrailsConfig("") + rule,
+				ExpectError: regexp.MustCompile(`Network ACL Rule ingress entry 100 \(protocol tcp, ports 22-22, 0.0.0.0/0\) is forbidden`),
+			},
+			{
+				// The allow-list tags are read from the existing network ACL.
+				Config: testAccFakeAWSNetworkGuardrailsProviderConfig(s) + testAccFakeAWSNetworkAclRuleGuardrailsConfig(tags),
+			},
+			{
+				Config: testAccFakeAWSNetworkGuardrailsProviderConfig(s) + testAccFakeAWSNetworkAclRuleGuardrailsConfig(tags) + rule,
+				Check:  resource.TestCheckResourceAttr("aws_network_acl_rule.test", "from_port", "22"),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSNetworkGuardrailsProviderConfig(s *fakeaws.Server) string {
+	return testAccFakeAWSProviderBlock(s, `
+  network_guardrails {
+    allowed_tags = {
+      GuardrailException = "approved"
+    }
+
+    forbidden_rule {
+      cidr_blocks = ["0.0.0.0/0"]
+      from_port   = 22
+      to_port     = 22
+      protocol    = "tcp"
+    }
+  }
+`, "AKIAFAKEAWS")
+}
+
+func testAccFakeAWSNetworkAclRuleGuardrailsConfig(tags string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_network_acl" "test" {
+  vpc_id = aws_vpc.test.id
+%[1]s}
+`, tags)
+}
+
+// testAccCheckFakeAWSSubnetNetworkAcl checks that a subnet is associated with
+// the network ACL whose ID is in the given attribute of another resource.
+func testAccCheckFakeAWSSubnetNetworkAcl(client *AWSClient, subnetResourceName, resourceName, attribute string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		subnet, ok := s.RootModule().Resources[subnetResourceName]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", subnetResourceName)
+		}
+
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		association, err := findNetworkAclAssociation(subnet.Primary.ID, client.ec2conn)
+
+		if err != nil {
+			return err
+		}
+
+		if got, want := aws.StringValue(association.NetworkAclId), rs.Primary.Attributes[attribute]; got != want {
+			return fmt.Errorf("subnet %s is associated with network ACL %s, want %s", subnet.Primary.ID, got, want)
+		}
+
+		return nil
+	}
+}
+
+func testAccFakeAWSNetworkAclRuleImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s:%s:%s:%s", rs.Primary.Attributes["network_acl_id"], rs.Primary.Attributes["rule_number"], rs.Primary.Attributes["protocol"], rs.Primary.Attributes["egress"]), nil
+	}
+}
+
+func testAccFakeAWSNetworkAclAssociationImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return rs.Primary.Attributes["subnet_id"], nil
+	}
+}
+
+// testAccFakeAWSNetworkAclRuleConfig returns a configuration with network
+// ACL rules, and the subnet associated with the given network ACL, if any.
+func testAccFakeAWSNetworkAclRuleConfig(networkAclID, extra string) string {
+	if networkAclID != "" {
+		extra = fmt.Sprintf(`
+resource "aws_network_acl_association" "test" {
+  subnet_id      = aws_subnet.test.id
+  network_acl_id = %s
+}
+`, networkAclID) + extra
+	}
+
+	return testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+# The inline entry coexists with the aws_network_acl_rule resources in the same ACL.
+resource "aws_network_acl" "test" {
+  vpc_id = aws_vpc.test.id
+
+  ingress {
+    protocol   = "tcp"
+    rule_no    = 100
+    action     = "allow"
+    cidr_block = "10.0.0.0/8"
+    from_port  = 443
+    to_port    = 443
+  }
+}
+
+resource "aws_network_acl" "other" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_network_acl_rule" "tcp" {
+  network_acl_id = aws_network_acl.test.id
+  rule_number    = 200
+  protocol       = "tcp"
+  rule_action    = "allow"
+  cidr_block     = "10.0.0.0/8"
+  from_port      = 80
+  to_port        = 80
+}
+
+resource "aws_network_acl_rule" "icmp" {
+  network_acl_id = aws_network_acl.test.id
+  rule_number    = 100
+  egress         = true
+  protocol       = "icmp"
+  rule_action    = "allow"
+  cidr_block     = "0.0.0.0/0"
+  icmp_type      = 8
+  icmp_code      = -1
+}
+
+resource "aws_network_acl_rule" "ipv6" {
+  network_acl_id  = aws_network_acl.test.id
+  rule_number     = 300
+  protocol        = "-1"
+  rule_action     = "deny"
+  ipv6_cidr_block = "::/0"
+}
+` + extra
+}
+
+// The network association resource is covered by
+// TestFakeAWS_clientVpnNetworkAssociation, so the test associates the subnet
+// directly.
+func TestFakeAWS_clientVpn(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+	resourceName := "aws_ec2_client_vpn_endpoint.test"
+	routeResourceName := "aws_ec2_client_vpn_route.test"
+
+	var endpointID, subnetID, associationID string
+
+	associate := func() {
+		resp, err := client.ec2conn.AssociateClientVpnTargetNetwork(&ec2.AssociateClientVpnTargetNetworkInput{
+			ClientVpnEndpointId: aws.String(endpointID),
+			SubnetId:            aws.String(subnetID),
+		})
+
+		if err != nil {
+			t.Fatalf("error associating target network: %s", err)
+		}
+
+		associationID = aws.StringValue(resp.AssociationId)
+	}
+
+	disassociate := func() {
+		_, err := client.ec2conn.DisassociateClientVpnTargetNetwork(&ec2.DisassociateClientVpnTargetNetworkInput{
+			AssociationId:       aws.String(associationID),
+			ClientVpnEndpointId: aws.String(endpointID),
+		})
+
+		if err != nil {
+			t.Fatalf("error disassociating target network: %s", err)
+		}
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSClientVpnConfig("test", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", ec2.ClientVpnEndpointStatusCodePendingAssociate),
+					resource.TestCheckResourceAttr(resourceName, "transport_protocol", "udp"),
+					resource.TestCheckResourceAttr(resourceName, "authentication_options.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "connection_log_options.0.enabled", "false"),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`:client-vpn-endpoint/cvpn-endpoint-`)),
+					resource.TestMatchResourceAttr(resourceName, "dns_name", regexp.MustCompile(`^\*\.cvpn-endpoint-`)),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+					resource.TestCheckResourceAttr("aws_ec2_client_vpn_authorization_rule.all", "authorize_all_groups", "true"),
+					resource.TestCheckResourceAttr("aws_ec2_client_vpn_authorization_rule.group", "access_group_id", "engineering"),
+					func(s *terraform.State) error {
+						endpointID = s.RootModule().Resources[resourceName].Primary.ID
+						subnetID = s.RootModule().Resources["aws_subnet.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: associate,
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSClientVpnConfig("updated", `
+resource "aws_ec2_client_vpn_route" "test" {
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.test.id
+  destination_cidr_block = "0.0.0.0/0"
+  target_vpc_subnet_id   = aws_subnet.test.id
+  description            = "internet"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", ec2.ClientVpnEndpointStatusCodeAvailable),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated"),
+					resource.TestCheckResourceAttr(resourceName, "split_tunnel", "true"),
+					resource.TestCheckResourceAttr(resourceName, "dns_servers.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "updated"),
+					resource.TestCheckResourceAttr(routeResourceName, "origin", "add-route"),
+					resource.TestCheckResourceAttr(routeResourceName, "type", "Nat"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_ec2_client_vpn_authorization_rule.all",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_ec2_client_vpn_authorization_rule.group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      routeResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Disassociating the subnet deletes the route through it.
+				PreConfig: disassociate,
+				Config:    testAccFakeAWSProviderConfig(s) + testAccFakeAWSClientVpnConfig("updated", ""),
+				Check:     resource.TestCheckResourceAttr(resourceName, "status", ec2.ClientVpnEndpointStatusCodePendingAssociate),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSClientVpnConfig(name, extra string) string {
+	var endpointArguments string
+
+	if name == "updated" {
+		endpointArguments = `
+  description  = "updated"
+  split_tunnel = true
+  dns_servers  = ["10.1.0.2"]
+`
+	}
+
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_subnet" "test" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.1.0/24"
+  availability_zone = "us-west-2a"
+}
+
+resource "aws_ec2_client_vpn_endpoint" "test" {
+  client_cidr_block      = "10.0.0.0/16"
+  server_certificate_arn = "arn:aws:acm:us-west-2:123456789012:certificate/server"
+%[2]s
+  authentication_options {
+    type                       = "certificate-authentication"
+    root_certificate_chain_arn = "arn:aws:acm:us-west-2:123456789012:certificate/root"
+  }
+
+  connection_log_options {
+    enabled = false
+  }
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ec2_client_vpn_authorization_rule" "all" {
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.test.id
+  target_network_cidr    = aws_vpc.test.cidr_block
+  authorize_all_groups   = true
+}
+
+resource "aws_ec2_client_vpn_authorization_rule" "group" {
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.test.id
+  target_network_cidr    = "192.168.0.0/16"
+  access_group_id        = "engineering"
+  description            = "engineering"
+}
+`, name, endpointArguments) + extra
+}
+
+func TestFakeAWS_clientVpnNetworkAssociation(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	defer func(associated, disassociated, pollInterval time.Duration) {
+		waiter.ClientVpnNetworkAssociationAssociatedDelay = associated
+		waiter.ClientVpnNetworkAssociationDisassociatedDelay = disassociated
+		waiter.ClientVpnNetworkAssociationStatusPollInterval = pollInterval
+	}(waiter.ClientVpnNetworkAssociationAssociatedDelay, waiter.ClientVpnNetworkAssociationDisassociatedDelay, waiter.ClientVpnNetworkAssociationStatusPollInterval)
+
+	waiter.ClientVpnNetworkAssociationAssociatedDelay = 0
+	waiter.ClientVpnNetworkAssociationDisassociatedDelay = 0
+	waiter.ClientVpnNetworkAssociationStatusPollInterval = 10 * time.Millisecond
+
+	client := testAccFakeAWSClient(t, s)
+	resourceName := "aws_ec2_client_vpn_network_association.test"
+	config := testAccFakeAWSProviderConfig(s) + testAccFakeAWSClientVpnConfig("test", `
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_ec2_client_vpn_network_association" "test" {
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.test.id
+  subnet_id              = aws_subnet.test.id
+  security_groups        = [aws_security_group.test.id]
+}
+`)
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", ec2.AssociationStatusCodeAssociated),
+					resource.TestCheckResourceAttr(resourceName, "security_groups.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "subnet_id", "aws_subnet.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_id", "aws_vpc.test", "id"),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^cvpn-assoc-`)),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccFakeAWSClientVpnNetworkAssociationImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config:             config,
+				Check:              testAccCheckFakeAWSResourceDisappears(client, resourceAwsEc2ClientVpnNetworkAssociation(), resourceName),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccFakeAWSClientVpnNetworkAssociationImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		return tfec2.ClientVpnNetworkAssociationCreateID(rs.Primary.Attributes["client_vpn_endpoint_id"], rs.Primary.ID), nil
+	}
+}
+
+func TestFakeAWS_defaultRouteTable(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_default_route_table" "test" {
+  default_route_table_id = aws_vpc.test.default_route_table_id
+}
+`,
+				ExpectError: regexp.MustCompile(`DependencyViolation`),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_internetGateway(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_internet_gateway.test"
+	attachmentResourceName := "aws_internet_gateway_attachment.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSInternetGatewayConfig("aws_vpc.test.id", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+					resource.TestCheckResourceAttr(resourceName, "owner_id", fakeaws.AccountID),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`:internet-gateway/igw-`)),
+					resource.TestCheckResourceAttrPair(attachmentResourceName, "vpc_id", "aws_vpc.other", "id"),
+					resource.TestCheckResourceAttrPair("data.aws_internet_gateway.test", "internet_gateway_id", "aws_internet_gateway.attached", "id"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSInternetGatewayConfig("aws_vpc.third.id", "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_id", "aws_vpc.third", "id"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "updated"),
+					resource.TestCheckResourceAttrPair("aws_internet_gateway.attached", "vpc_id", "aws_vpc.other", "id"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      attachmentResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFakeAWSInternetGatewayConfig(vpcID, name string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_vpc" "other" {
+  cidr_block = "10.2.0.0/16"
+}
+
+resource "aws_vpc" "third" {
+  cidr_block = "10.3.0.0/16"
+}
+
+resource "aws_internet_gateway" "test" {
+  vpc_id = %[1]s
+
+  tags = {
+    Name = %[2]q
+  }
+}
+
+resource "aws_internet_gateway" "attached" {}
+
+resource "aws_internet_gateway_attachment" "test" {
+  internet_gateway_id = aws_internet_gateway.attached.id
+  vpc_id              = aws_vpc.other.id
+}
+
+data "aws_internet_gateway" "test" {
+  filter {
+    name   = "attachment.vpc-id"
+    values = [aws_internet_gateway_attachment.test.vpc_id]
+  }
+}
+`, vpcID, name)
+}
+
+func TestFakeAWS_internetGatewayDataSource(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	dataSourceName := "data.aws_internet_gateway.default"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + fmt.Sprintf(`
+data "aws_internet_gateway" "default" {
+  filter {
+    name   = "attachment.vpc-id"
+    values = [%[1]q]
+  }
+}
+`, s.EC2.DefaultVpcID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "attachments.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "attachments.0.state", "available"),
+					resource.TestCheckResourceAttr(dataSourceName, "owner_id", fakeaws.AccountID),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_eip(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_eip.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSEipConfig("aws_network_interface.a.id", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "domain", "vpc"),
+					resource.TestCheckResourceAttr(resourceName, "vpc", "true"),
+					resource.TestMatchResourceAttr(resourceName, "allocation_id", regexp.MustCompile(`^eipalloc-`)),
+					resource.TestMatchResourceAttr(resourceName, "association_id", regexp.MustCompile(`^eipassoc-`)),
+					resource.TestCheckResourceAttrPair(resourceName, "network_interface", "aws_network_interface.a", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "private_ip", "aws_network_interface.a", "private_ip"),
+					resource.TestMatchResourceAttr(resourceName, "public_dns", regexp.MustCompile(`^ec2-.*\.us-west-2\.compute\.amazonaws\.com$`)),
+					resource.TestCheckResourceAttr(resourceName, "public_ipv4_pool", "amazon"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSEipConfig("aws_network_interface.b.id", "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "network_interface", "aws_network_interface.b", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "private_ip", "aws_network_interface.b", "private_ip"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "updated"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFakeAWSEipConfig(networkInterfaceID, name string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_network_interface" "a" {
+  subnet_id = aws_subnet.test.id
+}
+
+resource "aws_network_interface" "b" {
+  subnet_id = aws_subnet.test.id
+}
+
+resource "aws_eip" "test" {
+  vpc               = true
+  network_interface = %[1]s
+
+  tags = {
+    Name = %[2]q
+  }
+
+  depends_on = [aws_internet_gateway.test]
+}
+`, networkInterfaceID, name)
+}
+
+func TestFakeAWS_natGateway(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_nat_gateway.test"
+	dataSourceName := "data.aws_nat_gateway.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNatGatewayConfig("test") + testAccFakeAWSNatGatewayDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "allocation_id", "aws_eip.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "public_ip", "aws_eip.test", "public_ip"),
+					resource.TestMatchResourceAttr(resourceName, "network_interface_id", regexp.MustCompile(`^eni-`)),
+					resource.TestMatchResourceAttr(resourceName, "private_ip", regexp.MustCompile(`^10\.1\.1\.`)),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+					resource.TestCheckResourceAttr("aws_route_table.private", "route.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "state", "available"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "public_ip", resourceName, "public_ip"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSNatGatewayConfig("updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "updated"),
+					resource.TestCheckResourceAttrPair("aws_eip.test", "network_interface", resourceName, "network_interface_id"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_natGatewayWithoutInternetGateway(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_eip" "test" {
+  vpc = true
+}
+
+resource "aws_nat_gateway" "test" {
+  allocation_id = aws_eip.test.id
+  subnet_id     = aws_subnet.test.id
+}
+`,
+				ExpectError: regexp.MustCompile(`Gateway.NotAttached`),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSNatGatewayConfig(name string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_subnet" "public" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_subnet" "private" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.2.0/24"
+}
+
+resource "aws_eip" "test" {
+  vpc = true
+}
+
+resource "aws_nat_gateway" "test" {
+  allocation_id = aws_eip.test.id
+  subnet_id     = aws_subnet.public.id
+
+  tags = {
+    Name = %[1]q
+  }
+
+  depends_on = [aws_internet_gateway.test]
+}
+
+resource "aws_route_table" "private" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    cidr_block     = "0.0.0.0/0"
+    nat_gateway_id = aws_nat_gateway.test.id
+  }
+}
+`, name)
+}
+
+// testAccFakeAWSNatGatewayDataSourceConfig is only used in the first step, as
+// the data source would otherwise be taken for the resource on import.
+const testAccFakeAWSNatGatewayDataSourceConfig = `
+data "aws_nat_gateway" "test" {
+  subnet_id = aws_nat_gateway.test.subnet_id
+
+  filter {
+    name   = "state"
+    values = ["available"]
+  }
+}
+`
+
+func TestFakeAWS_vpcPeeringConnection(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_vpc_peering_connection.test"
+	accepterResourceName := "aws_vpc_peering_connection_accepter.peer"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcPeeringConnectionConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "peer_vpc_id", "aws_vpc.peer", "id"),
+					resource.TestCheckResourceAttr(resourceName, "peer_owner_id", testAccFakeAWSMemberAccountID),
+					resource.TestCheckResourceAttr(resourceName, "peer_region", fakeaws.Region),
+					resource.TestCheckResourceAttr(resourceName, "tags.Side", "requester"),
+					resource.TestCheckResourceAttr(accepterResourceName, "accept_status", "active"),
+					resource.TestCheckResourceAttrPair(accepterResourceName, "vpc_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttr(accepterResourceName, "accepter.0.allow_remote_vpc_dns_resolution", "true"),
+					resource.TestCheckResourceAttr(accepterResourceName, "requester.0.allow_remote_vpc_dns_resolution", "false"),
+					resource.TestCheckResourceAttr(accepterResourceName, "tags.Side", "accepter"),
+					resource.TestCheckResourceAttrPair("aws_route.test", "vpc_peering_connection_id", resourceName, "id"),
+				),
+			},
+			{
+				// The requester learns about the acceptance on refresh and
+				// can then set the options of its side.
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcPeeringConnectionConfig(`
+  requester {
+    allow_remote_vpc_dns_resolution = true
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "accept_status", "active"),
+					resource.TestCheckResourceAttr(resourceName, "accepter.0.allow_remote_vpc_dns_resolution", "true"),
+					resource.TestCheckResourceAttr(resourceName, "requester.0.allow_remote_vpc_dns_resolution", "true"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The import uses the default provider configuration, which
+				// sees the requester account's tags.
+				Config:                  testAccFakeAWSProviderConfig(s),
+				ResourceName:            accepterResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auto_accept", "tags.%", "tags.Side"},
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcPeeringConnectionAutoAccept(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_vpc_peering_connection.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_vpc" "peer" {
+  cidr_block = "10.2.0.0/16"
+}
+
+resource "aws_vpc_peering_connection" "test" {
+  vpc_id      = aws_vpc.test.id
+  peer_vpc_id = aws_vpc.peer.id
+  peer_region = %[1]q
+  auto_accept = true
+}
+`, fakeaws.Region),
+				ExpectError: regexp.MustCompile(`peer_region cannot be set whilst auto_accept is true`),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_vpc" "peer" {
+  cidr_block = "10.2.0.0/16"
+}
+
+resource "aws_vpc_peering_connection" "test" {
+  vpc_id      = aws_vpc.test.id
+  peer_vpc_id = aws_vpc.peer.id
+  auto_accept = true
+
+  accepter {
+    allow_remote_vpc_dns_resolution = true
+  }
+
+  requester {
+    allow_remote_vpc_dns_resolution = true
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "accept_status", "active"),
+					resource.TestCheckResourceAttr(resourceName, "peer_owner_id", fakeaws.AccountID),
+					resource.TestCheckResourceAttr(resourceName, "accepter.0.allow_remote_vpc_dns_resolution", "true"),
+					resource.TestCheckResourceAttr(resourceName, "requester.0.allow_remote_vpc_dns_resolution", "true"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_vpc" "peer" {
+  cidr_block = "10.1.128.0/17"
+}
+
+resource "aws_vpc_peering_connection" "test" {
+  vpc_id      = aws_vpc.test.id
+  peer_vpc_id = aws_vpc.peer.id
+  auto_accept = true
+}
+`,
+				ExpectError: regexp.MustCompile(`overlapping CIDR range`),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSVpcPeeringConnectionConfig(requesterOptions string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_vpc" "peer" {
+  provider   = aws.member
+  cidr_block = "10.2.0.0/16"
+}
+
+data "aws_caller_identity" "peer" {
+  provider = aws.member
+}
+
+resource "aws_vpc_peering_connection" "test" {
+  vpc_id        = aws_vpc.test.id
+  peer_vpc_id   = aws_vpc.peer.id
+  peer_owner_id = data.aws_caller_identity.peer.account_id
+  peer_region   = %[1]q
+%[2]s
+  tags = {
+    Side = "requester"
+  }
+}
+
+resource "aws_vpc_peering_connection_accepter" "peer" {
+  provider                  = aws.member
+  vpc_peering_connection_id = aws_vpc_peering_connection.test.id
+  auto_accept               = true
+
+  accepter {
+    allow_remote_vpc_dns_resolution = true
+  }
+
+  tags = {
+    Side = "accepter"
+  }
+}
+
+resource "aws_route" "test" {
+  route_table_id            = aws_vpc.test.main_route_table_id
+  destination_cidr_block    = aws_vpc.peer.cidr_block
+  vpc_peering_connection_id = aws_vpc_peering_connection.test.id
+}
+`, fakeaws.Region, requesterOptions)
+}
+
+func TestFakeAWS_transitGateway(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_ec2_transit_gateway.test"
+	attachmentResourceName := "aws_ec2_transit_gateway_vpc_attachment.test"
+	routeTableResourceName := "aws_ec2_transit_gateway_route_table.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSTransitGatewayConfig("first", true, ec2.DnsSupportValueEnable),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "amazon_side_asn", "64512"),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "owner_id", fakeaws.AccountID),
+					resource.TestCheckResourceAttrSet(resourceName, "association_default_route_table_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "association_default_route_table_id", resourceName, "propagation_default_route_table_id"),
+					resource.TestCheckResourceAttr(attachmentResourceName, "subnet_ids.#", "1"),
+					resource.TestCheckResourceAttr(attachmentResourceName, "transit_gateway_default_route_table_association", "false"),
+					resource.TestCheckResourceAttr(attachmentResourceName, "transit_gateway_default_route_table_propagation", "true"),
+					resource.TestCheckResourceAttr(attachmentResourceName, "vpc_owner_id", fakeaws.AccountID),
+					resource.TestCheckResourceAttr(routeTableResourceName, "default_association_route_table", "false"),
+					resource.TestCheckResourceAttrPair("aws_ec2_transit_gateway_route_table_association.test", "resource_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttr("aws_ec2_transit_gateway_route_table_association.test", "resource_type", ec2.TransitGatewayAttachmentResourceTypeVpc),
+					resource.TestCheckResourceAttrPair("aws_ec2_transit_gateway_route_table_propagation.test", "resource_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttrPair("aws_route.test", "transit_gateway_id", resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSTransitGatewayConfig("second", false, ec2.DnsSupportValueDisable),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(attachmentResourceName, "dns_support", ec2.DnsSupportValueDisable),
+					resource.TestCheckResourceAttr(attachmentResourceName, "transit_gateway_default_route_table_propagation", "false"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      attachmentResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      routeTableResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_ec2_transit_gateway_route_table_association.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_ec2_transit_gateway_route_table_propagation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFakeAWSTransitGatewayConfig(description string, defaultPropagation bool, dnsSupport string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_ec2_transit_gateway" "test" {
+  description = %[1]q
+
+  tags = {
+    Name = "test"
+  }
+}
+
+resource "aws_subnet" "test" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.1.1.0/24"
+  availability_zone = "us-west-2a"
+}
+
+resource "aws_ec2_transit_gateway_vpc_attachment" "test" {
+  subnet_ids         = [aws_subnet.test.id]
+  transit_gateway_id = aws_ec2_transit_gateway.test.id
+  vpc_id             = aws_vpc.test.id
+  dns_support        = %[3]q
+
+  transit_gateway_default_route_table_association = false
+  transit_gateway_default_route_table_propagation = %[2]t
+}
+
+resource "aws_ec2_transit_gateway_route_table" "test" {
+  transit_gateway_id = aws_ec2_transit_gateway.test.id
+}
+
+resource "aws_ec2_transit_gateway_route_table_association" "test" {
+  transit_gateway_attachment_id  = aws_ec2_transit_gateway_vpc_attachment.test.id
+  transit_gateway_route_table_id = aws_ec2_transit_gateway_route_table.test.id
+}
+
+resource "aws_ec2_transit_gateway_route_table_propagation" "test" {
+  transit_gateway_attachment_id  = aws_ec2_transit_gateway_vpc_attachment.test.id
+  transit_gateway_route_table_id = aws_ec2_transit_gateway_route_table.test.id
+}
+
+resource "aws_route" "test" {
+  route_table_id         = aws_vpc.test.main_route_table_id
+  destination_cidr_block = "10.2.0.0/16"
+  transit_gateway_id     = aws_ec2_transit_gateway.test.id
+
+  depends_on = [aws_ec2_transit_gateway_vpc_attachment.test]
+}
+`, description, defaultPropagation, dnsSupport)
+}
+
+func TestFakeAWS_transitGatewayVpcAttachmentAccepter(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_ec2_transit_gateway_vpc_attachment.test"
+	accepterResourceName := "aws_ec2_transit_gateway_vpc_attachment_accepter.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+resource "aws_ec2_transit_gateway" "test" {}
+
+resource "aws_vpc" "member" {
+  provider   = aws.member
+  cidr_block = "10.2.0.0/16"
+}
+
+resource "aws_subnet" "member" {
+  provider          = aws.member
+  vpc_id            = aws_vpc.member.id
+  cidr_block        = "10.2.1.0/24"
+  availability_zone = "us-west-2b"
+}
+
+resource "aws_ec2_transit_gateway_vpc_attachment" "test" {
+  provider           = aws.member
+  subnet_ids         = [aws_subnet.member.id]
+  transit_gateway_id = aws_ec2_transit_gateway.test.id
+  vpc_id             = aws_vpc.member.id
+}
+
+resource "aws_ec2_transit_gateway_vpc_attachment_accepter" "test" {
+  transit_gateway_attachment_id = aws_ec2_transit_gateway_vpc_attachment.test.id
+
+  transit_gateway_default_route_table_propagation = false
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vpc_owner_id", testAccFakeAWSMemberAccountID),
+					resource.TestCheckResourceAttrPair(accepterResourceName, "transit_gateway_id", "aws_ec2_transit_gateway.test", "id"),
+					resource.TestCheckResourceAttrPair(accepterResourceName, "vpc_id", "aws_vpc.member", "id"),
+					resource.TestCheckResourceAttr(accepterResourceName, "vpc_owner_id", testAccFakeAWSMemberAccountID),
+					resource.TestCheckResourceAttr(accepterResourceName, "subnet_ids.#", "1"),
+					resource.TestCheckResourceAttr(accepterResourceName, "transit_gateway_default_route_table_association", "true"),
+					resource.TestCheckResourceAttr(accepterResourceName, "transit_gateway_default_route_table_propagation", "false"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      accepterResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAWS_vpcDhcpOptions(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+	resourceName := "aws_vpc_dhcp_options.test"
+	associationResourceName := "aws_vpc_dhcp_options_association.test"
+
+	testAccCheckVpcDhcpOptionsID := func(resourceName string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			want := "default"
+
+			if resourceName != "" {
+				want = state.RootModule().Resources[resourceName].Primary.ID
+			}
+
+			vpc, err := vpcDescribe(client.ec2conn, state.RootModule().Resources["aws_vpc.test"].Primary.ID)
+
+			if err != nil {
+				return err
+			}
+
+			if got := aws.StringValue(vpc.DhcpOptionsId); got != want {
+				return fmt.Errorf("expected VPC DHCP options %q, got: %q", want, got)
+			}
+
+			return nil
+		}
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcDhcpOptionsConfig("test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "domain_name", "corp.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "domain_name_servers.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "domain_name_servers.0", "10.0.0.2"),
+					resource.TestCheckResourceAttr(resourceName, "domain_name_servers.1", "AmazonProvidedDNS"),
+					resource.TestCheckResourceAttr(resourceName, "netbios_name_servers.0", "10.0.0.4"),
+					resource.TestCheckResourceAttr(resourceName, "netbios_node_type", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ntp_servers.0", "10.0.0.3"),
+					resource.TestCheckResourceAttr(resourceName, "owner_id", fakeaws.AccountID),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`:dhcp-options/dopt-`)),
+					resource.TestCheckResourceAttrPair(associationResourceName, "dhcp_options_id", resourceName, "id"),
+					testAccCheckVpcDhcpOptionsID(resourceName),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcDhcpOptionsConfig("other"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(associationResourceName, "dhcp_options_id", "aws_vpc_dhcp_options.other", "id"),
+					testAccCheckVpcDhcpOptionsID("aws_vpc_dhcp_options.other"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:       testAccFakeAWSProviderConfig(s),
+				ResourceName: associationResourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return state.RootModule().Resources["aws_vpc.test"].Primary.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				// Destroying the association reverts the VPC to the default
+				// DHCP options.
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_vpc_dhcp_options" "other" {
+  domain_name = "other.example.com"
+}
+`,
+				Check: testAccCheckVpcDhcpOptionsID(""),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSVpcDhcpOptionsConfig(associated string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_vpc_dhcp_options" "test" {
+  domain_name          = "corp.example.com"
+  domain_name_servers  = ["10.0.0.2", "AmazonProvidedDNS"]
+  ntp_servers          = ["10.0.0.3"]
+  netbios_name_servers = ["10.0.0.4"]
+  netbios_node_type    = "2"
+
+  tags = {
+    Name = "test"
+  }
+}
+
+resource "aws_vpc_dhcp_options" "other" {
+  domain_name = "other.example.com"
+}
+
+resource "aws_vpc_dhcp_options_association" "test" {
+  vpc_id          = aws_vpc.test.id
+  dhcp_options_id = aws_vpc_dhcp_options.%[1]s.id
+}
+`, associated)
+}
+
+func TestFakeAWS_ec2ManagedPrefixList(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_ec2_managed_prefix_list.test"
+	dataSourceName := "data.aws_ec2_managed_prefix_list.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSEc2ManagedPrefixListConfig(`
+  entry {
+    cidr        = "10.0.0.0/16"
+    description = "office"
+  }
+`) + testAccFakeAWSEc2ManagedPrefixListDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "address_family", "IPv4"),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "max_entries", "2"),
+					resource.TestCheckResourceAttr(resourceName, "owner_id", fakeaws.AccountID),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`:prefix-list/pl-`)),
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "entries.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "entries.0.cidr", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr(dataSourceName, "entries.0.description", "office"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.Name", "test"),
+					resource.TestCheckResourceAttr("data.aws_ec2_managed_prefix_list.s3", "id", "pl-68a54001"),
+					resource.TestCheckResourceAttr("data.aws_ec2_managed_prefix_list.s3", "owner_id", "AWS"),
+					resource.TestCheckResourceAttrPair("aws_security_group_rule.test", "prefix_list_ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttr("aws_route_table.test", "route.#", "1"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSEc2ManagedPrefixListConfig(`
+  entry {
+    cidr = "10.0.0.0/16"
+  }
+
+  entry {
+    cidr = "10.2.0.0/16"
+  }
+
+  entry {
+    cidr = "10.3.0.0/16"
+  }
+`) + testAccFakeAWSEc2ManagedPrefixListDataSourceConfig,
+				ExpectError: regexp.MustCompile(`the number of entries \(3\) exceeds max_entries \(2\)`),
+			},
+			{
+				// Changing the entries modifies the prefix list in place
+				// and bumps its version. The data sources are dropped from
+				// the configuration ahead of the import.
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSEc2ManagedPrefixListConfig(`
+  entry {
+    cidr        = "10.0.0.0/16"
+    description = "headquarters"
+  }
+
+  entry {
+    cidr = "10.2.0.0/16"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFakeAWSEc2ManagedPrefixListConfig(entries string) string {
+	return testAccFakeAWSVpcConfig("test") + fmt.Sprintf(`
+resource "aws_ec2_managed_prefix_list" "test" {
+  address_family = "IPv4"
+  max_entries    = 2
+  name           = "test"
+%s
+  tags = {
+    Name = "test"
+  }
+}
+
+resource "aws_security_group" "test" {
+  name   = "test"
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_security_group_rule" "test" {
+  type              = "egress"
+  from_port         = 443
+  to_port           = 443
+  protocol          = "tcp"
+  prefix_list_ids   = [aws_ec2_managed_prefix_list.test.id]
+  security_group_id = aws_security_group.test.id
+}
+
+resource "aws_internet_gateway" "test" {
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_route_table" "test" {
+  vpc_id = aws_vpc.test.id
+
+  route {
+    destination_prefix_list_id = aws_ec2_managed_prefix_list.test.id
+    gateway_id                 = aws_internet_gateway.test.id
+  }
+}
+`, entries)
+}
+
+// testAccFakeAWSEc2ManagedPrefixListDataSourceConfig is kept out of the
+// resource configuration as the data source shares the resource's type and ID,
+// which ImportStateVerify would otherwise compare the imported resource with.
+const testAccFakeAWSEc2ManagedPrefixListDataSourceConfig = `
+data "aws_ec2_managed_prefix_list" "test" {
+  id = aws_ec2_managed_prefix_list.test.id
+}
+
+data "aws_ec2_managed_prefix_list" "s3" {
+  name = "com.amazonaws.us-west-2.s3"
+}
+`
+
+func TestFakeAWS_defaultVpc(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+
+	igws, err := client.ec2conn.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"attachment.vpc-id": s.EC2.DefaultVpcID,
+		}),
+	})
+
+	if err != nil {
+		t.Fatalf("error describing default internet gateway: %s", err)
+	}
+
+	if len(igws.InternetGateways) != 1 {
+		t.Fatalf("expected 1 default internet gateway, got: %d", len(igws.InternetGateways))
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + fmt.Sprintf(`
+resource "aws_default_subnet" "a" {
+  availability_zone = "us-west-2a"
+}
+
+resource "aws_default_subnet" "b" {
+  availability_zone = "us-west-2b"
+}
+
+resource "aws_default_subnet" "c" {
+  availability_zone = "us-west-2c"
+}
+
+resource "aws_internet_gateway_detach" "default" {
+  vpc_id              = %[1]q
+  internet_gateway_id = %[2]q
+}
+
+resource "aws_internet_gateway_delete" "default" {
+  internet_gateway_id = aws_internet_gateway_detach.default.internet_gateway_id
+}
+
+resource "aws_default_vpc" "default" {
+  depends_on = [
+    aws_default_subnet.a,
+    aws_default_subnet.b,
+    aws_default_subnet.c,
+    aws_internet_gateway_delete.default,
+  ]
+}
+`, s.EC2.DefaultVpcID, aws.StringValue(igws.InternetGateways[0].InternetGatewayId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aws_default_vpc.default", "id", s.EC2.DefaultVpcID),
+					func(*terraform.State) error {
+						s.Lock()
+						defer s.Unlock()
+
+						if s.EC2.DefaultVpcID != "" {
+							return fmt.Errorf("default VPC was not deleted")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSVpcConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block           = "10.1.0.0/16"
+  enable_dns_hostnames = true
+
+  tags = {
+    Name = %[1]q
+  }
+}
+`, name)
+}
+
+func TestFakeAWS_flowLog(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	vpcResourceName := "aws_flow_log.vpc"
+	subnetResourceName := "aws_flow_log.subnet"
+	eniResourceName := "aws_flow_log.eni"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_flow_log" "vpc" {
+  vpc_id       = aws_vpc.test.id
+  traffic_type = "ALL"
+  iam_role_arn = "flow-logs-role"
+}
+`,
+				ExpectError: regexp.MustCompile(`"iam_role_arn" \(flow-logs-role\) is an invalid ARN`),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSVpcConfig("test") + `
+resource "aws_flow_log" "vpc" {
+  vpc_id       = aws_vpc.test.id
+  traffic_type = "ALL"
+}
+`,
+				ExpectError: regexp.MustCompile(`DeliverLogsPermissionArn can't be empty`),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSFlowLogConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(vpcResourceName, "iam_role_arn", "aws_iam_role.test", "arn"),
+					resource.TestCheckResourceAttr(vpcResourceName, "log_destination_type", ec2.LogDestinationTypeCloudWatchLogs),
+					resource.TestCheckResourceAttr(vpcResourceName, "log_destination", fmt.Sprintf("arn:aws:logs:%s:%s:log-group:vpc-flow-logs", fakeaws.Region, fakeaws.AccountID)),
+					resource.TestCheckResourceAttr(vpcResourceName, "max_aggregation_interval", "600"),
+					resource.TestCheckResourceAttr(vpcResourceName, "tags.Name", "test"),
+					resource.TestMatchResourceAttr(vpcResourceName, "arn", regexp.MustCompile(`:vpc-flow-log/fl-`)),
+					resource.TestCheckResourceAttrPair(subnetResourceName, "subnet_id", "aws_subnet.test", "id"),
+					resource.TestCheckResourceAttr(subnetResourceName, "log_destination_type", ec2.LogDestinationTypeS3),
+					resource.TestCheckResourceAttr(subnetResourceName, "log_format", "${srcaddr} ${dstaddr} ${action}"),
+					resource.TestCheckResourceAttr(subnetResourceName, "log_group_name", ""),
+					resource.TestCheckResourceAttr(subnetResourceName, "max_aggregation_interval", "60"),
+					resource.TestCheckResourceAttrPair(eniResourceName, "eni_id", "aws_network_interface.test", "id"),
+					resource.TestCheckResourceAttr(eniResourceName, "traffic_type", ec2.TrafficTypeReject),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      vpcResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      subnetResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      eniResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+var testAccFakeAWSFlowLogConfig = testAccFakeAWSVpcConfig("test") + `
+resource "aws_iam_role" "test" {
+  name = "flow-logs"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "vpc-flow-logs.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_subnet" "test" {
+  vpc_id     = aws_vpc.test.id
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_network_interface" "test" {
+  subnet_id = aws_subnet.test.id
+}
+
+resource "aws_flow_log" "vpc" {
+  vpc_id         = aws_vpc.test.id
+  traffic_type   = "ALL"
+  iam_role_arn   = aws_iam_role.test.arn
+  log_group_name = "vpc-flow-logs"
+
+  tags = {
+    Name = "test"
+  }
+}
+
+resource "aws_flow_log" "subnet" {
+  subnet_id                = aws_subnet.test.id
+  traffic_type             = "ACCEPT"
+  log_destination_type     = "s3"
+  log_destination          = "arn:aws:s3:::flow-logs"
+  log_format               = "$${srcaddr} $${dstaddr} $${action}"
+  max_aggregation_interval = 60
+}
+
+resource "aws_flow_log" "eni" {
+  eni_id               = aws_network_interface.test.id
+  traffic_type         = "REJECT"
+  log_destination_type = "s3"
+  log_destination      = "arn:aws:s3:::flow-logs"
+}
+`
+
+func TestFakeAWS_iamRole(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_iam_role.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSIamRoleConfig + `
+resource "aws_iam_role_policy" "test" {
+  name = "test"
+  role = aws_iam_role.test.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = "ec2:Describe*"
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "test" {
+  role       = aws_iam_role.test.name
+  policy_arn = "arn:aws:iam::aws:policy/ReadOnlyAccess"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "arn", fmt.Sprintf("arn:aws:iam::%s:role/test", fakeaws.AccountID)),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", "test"),
+				),
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_iam_role_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            testAccFakeAWSProviderConfig(s),
+				ResourceName:      "aws_iam_role_policy_attachment.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccAWSIAMRolePolicyAttachmentImportStateIdFunc("aws_iam_role_policy_attachment.test"),
+			},
+		},
+	})
+}
+
+const testAccFakeAWSIamRoleConfig = `
+resource "aws_iam_role" "test" {
+  name = "test"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "transfer.amazonaws.com" }
+    }]
+  })
+
+  tags = {
+    Name = "test"
+  }
+}
+`
+
+func TestFakeAWS_lex(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSLexConfig("Flowers"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aws_lex_slot_type.test", "version", "1"),
+					resource.TestCheckResourceAttr("aws_lex_intent.test", "version", "1"),
+					resource.TestCheckResourceAttr("aws_lex_bot.test", "version", "$LATEST"),
+					resource.TestCheckResourceAttrSet("aws_lex_bot.test", "checksum"),
+				),
+			},
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSLexConfig("Flowers and plants"),
+				Check:  resource.TestCheckResourceAttr("aws_lex_bot.test", "description", "Flowers and plants"),
+			},
+		},
+	})
+}
+
+func testAccFakeAWSLexConfig(description string) string {
+	return fmt.Sprintf(`
+resource "aws_lex_slot_type" "test" {
+  name    = "FlowerTypes"
+  publish = true
+
+  enumeration_values {
+    value    = "lilies"
+    synonyms = ["Lirium", "Lilium"]
+  }
+}
+
+resource "aws_lex_intent" "test" {
+  name    = "OrderFlowers"
+  publish = true
+
+  fulfillment_activity {
+    type = "ReturnIntent"
+  }
+
+  slots {
+    name              = "FlowerType"
+    slot_constraint   = "Required"
+    slot_type         = aws_lex_slot_type.test.name
+    slot_type_version = aws_lex_slot_type.test.version
+
+    value_elicitation_prompt {
+      max_attempts = 2
+
+      messages {
+        content      = "What type of flowers?"
+        content_type = "PlainText"
+      }
+    }
+  }
+}
+
+resource "aws_lex_bot" "test" {
+  name        = "OrderFlowers"
+  description = %[1]q
+
+  abort_statement {
+    messages {
+      content      = "Sorry, I am not able to assist at this time"
+      content_type = "PlainText"
+    }
+  }
+
+  intents {
+    intent_name    = aws_lex_intent.test.name
+    intent_version = aws_lex_intent.test.version
+  }
+}
+`, description)
+}
+
+func TestFakeAWS_organizationsGovCloudAccount(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_organizations_gov_cloud_account.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + `
+resource "aws_organizations_gov_cloud_account" "test" {
+  name  = "test"
+  email = "test@example.com"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "commercial_account_id"),
+					resource.TestCheckResourceAttr(resourceName, "status", organizations.AccountStatusActive),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_organizationsInvitation(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + fmt.Sprintf(`
+resource "aws_organizations_invitation" "test" {
+  account_id = %[1]q
+}
+`, testAccFakeAWSMemberAccountID),
+				Check: resource.TestCheckResourceAttrSet("aws_organizations_invitation.test", "arn"),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_organizationsInvitationAcceptance(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	// An accepted invitation can no longer be canceled, so it is made outside
+	// of the configuration.
+	output, err := testAccFakeAWSClient(t, s).organizationsconn.InviteAccountToOrganization(&organizations.InviteAccountToOrganizationInput{
+		Target: &organizations.HandshakeParty{
+			Id:   aws.String(testAccFakeAWSMemberAccountID),
+			Type: aws.String(organizations.HandshakePartyTypeAccount),
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("error inviting account: %s", err)
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + fmt.Sprintf(`
+resource "aws_organizations_invitation_acceptance" "test" {
+  provider      = aws.member
+  invitation_id = %[1]q
+}
+`, aws.StringValue(output.Handshake.Id)),
+				Check: resource.TestCheckResourceAttr("aws_organizations_invitation_acceptance.test", "id", aws.StringValue(output.Handshake.Id)),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_quickSight(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	client := testAccFakeAWSClient(t, s)
+
+	// Groups and users have no resources.
+	_, err := client.quicksightconn.CreateGroup(&quicksight.CreateGroupInput{
+		AwsAccountId: aws.String(fakeaws.AccountID),
+		GroupName:    aws.String("test"),
+		Namespace:    aws.String("default"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating QuickSight group: %s", err)
+	}
+
+	_, err = client.quicksightconn.RegisterUser(&quicksight.RegisterUserInput{
+		AwsAccountId: aws.String(fakeaws.AccountID),
+		Email:        aws.String("test@example.com"),
+		IdentityType: aws.String(quicksight.IdentityTypeQuicksight),
+		Namespace:    aws.String("default"),
+		UserName:     aws.String("test"),
+		UserRole:     aws.String(quicksight.UserRoleReader),
+	})
+
+	if err != nil {
+		t.Fatalf("error registering QuickSight user: %s", err)
+	}
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + fmt.Sprintf(`
+resource "aws_quicksight_namespace" "test" {
+  aws_account_id = %[1]q
+  namespace      = "test"
+  identity_store = "QUICKSIGHT"
+}
+
+resource "aws_quicksight_group_membership" "test" {
+  aws_account_id = %[1]q
+  group_name     = "test"
+  member_name    = "test"
+}
+
+resource "aws_quicksight_iam_policy_assignment" "test" {
+  aws_account_id    = %[1]q
+  assignment_name   = "test"
+  assignment_status = "ENABLED"
+  policy_arn        = "arn:aws:iam::aws:policy/ReadOnlyAccess"
+}
+
+resource "aws_quicksight_data_source" "test" {
+  aws_account_id   = %[1]q
+  data_source_id   = "test"
+  data_source_name = "test"
+  data_source_type = "ATHENA"
+
+  data_source_parameters {
+    athena_parameters {
+      workgroup = "primary"
+    }
+  }
+
+  permissions {
+    principal = "arn:aws:quicksight:%[2]s:%[1]s:user/default/test"
+    actions   = ["quicksight:DescribeDataSource"]
+  }
+}
+`, fakeaws.AccountID, fakeaws.Region),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aws_quicksight_namespace.test", "identity_store", quicksight.IdentityStoreQuicksight),
+					resource.TestCheckResourceAttr("aws_quicksight_iam_policy_assignment.test", "assignment_status", quicksight.AssignmentStatusEnabled),
+					resource.TestCheckResourceAttr("aws_quicksight_data_source.test", "data_source_type", quicksight.DataSourceTypeAthena),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeAWS_transferServer(t *testing.T) {
+	s := fakeaws.NewServer()
+	defer s.Close()
+
+	resourceName := "aws_transfer_server.test"
+
+	testAccFakeAWSTest(t, resource.TestCase{
+		CheckDestroy: testAccCheckFakeAWSDestroy(testAccFakeAWSClient(t, s)),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFakeAWSProviderConfig(s) + testAccFakeAWSIamRoleConfig + `
+resource "aws_transfer_server" "test" {
+  logging_role = aws_iam_role.test.arn
+
+  tags = {
+    Name = "test"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "endpoint_type", "PUBLIC"),
+					resource.TestCheckResourceAttr(resourceName, "identity_provider_type", "SERVICE_MANAGED"),
+					resource.TestCheckResourceAttrSet(resourceName, "endpoint"),
+				),
+			},
+			{
+				Config:                  testAccFakeAWSProviderConfig(s),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy"},
+			},
+		},
+	})
+}
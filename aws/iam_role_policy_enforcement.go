@@ -0,0 +1,193 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// IamRolePolicyConfig contains the permissions boundary, path and managed
+// policy requirements that IAM roles and their policy attachments are
+// checked against during plan.
+type IamRolePolicyConfig struct {
+	AllowedPaths                    []string
+	ForbiddenManagedPolicyArns      []string
+	RequiredPermissionsBoundaryArns []string
+}
+
+func iamRolePolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"allowed_paths": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateIamRolePolicyPath,
+					},
+					Set:         schema.HashString,
+					Description: "IAM role paths, including any nested paths beneath them, that roles must be created under.",
+				},
+				"forbidden_managed_policy_arns": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateArn,
+					},
+					Set:         schema.HashString,
+					Description: "Managed policy ARNs that must not be attached to IAM roles.",
+				},
+				"required_permissions_boundary_arns": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateArn,
+					},
+					Set:         schema.HashString,
+					Description: "Permissions boundary ARNs, one of which every IAM role must use.",
+				},
+			},
+		},
+	}
+}
+
+func expandProviderIamRolePolicy(l []interface{}) *IamRolePolicyConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	config := &IamRolePolicyConfig{}
+
+	if v, ok := m["allowed_paths"].(*schema.Set); ok {
+		for _, pathRaw := range v.List() {
+			config.AllowedPaths = append(config.AllowedPaths, pathRaw.(string))
+		}
+	}
+
+	if v, ok := m["forbidden_managed_policy_arns"].(*schema.Set); ok {
+		for _, arnRaw := range v.List() {
+			config.ForbiddenManagedPolicyArns = append(config.ForbiddenManagedPolicyArns, arnRaw.(string))
+		}
+	}
+
+	if v, ok := m["required_permissions_boundary_arns"].(*schema.Set); ok {
+		for _, arnRaw := range v.List() {
+			config.RequiredPermissionsBoundaryArns = append(config.RequiredPermissionsBoundaryArns, arnRaw.(string))
+		}
+	}
+
+	return config
+}
+
+func validateIamRolePolicyPath(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !strings.HasPrefix(value, "/") || !strings.HasSuffix(value, "/") {
+		errors = append(errors, fmt.Errorf("%q must begin and end with a forward slash (/): %q", k, value))
+	}
+
+	return
+}
+
+// checkPath returns an error if the role path is not under one of the allowed paths.
+func (c *IamRolePolicyConfig) checkPath(path string) error {
+	if c == nil || len(c.AllowedPaths) == 0 {
+		return nil
+	}
+
+	for _, allowedPath := range c.AllowedPaths {
+		if strings.HasPrefix(path, allowedPath) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("path (%s) is not allowed by the provider iam_role_policy configuration, expected one of: %s", path, strings.Join(c.AllowedPaths, ", "))
+}
+
+// checkPermissionsBoundary returns an error if the permissions boundary is not one of the required ARNs.
+func (c *IamRolePolicyConfig) checkPermissionsBoundary(permissionsBoundary string) error {
+	if c == nil || len(c.RequiredPermissionsBoundaryArns) == 0 {
+		return nil
+	}
+
+	for _, requiredArn := range c.RequiredPermissionsBoundaryArns {
+		if permissionsBoundary == requiredArn {
+			return nil
+		}
+	}
+
+	if permissionsBoundary == "" {
+		return fmt.Errorf("permissions_boundary is required by the provider iam_role_policy configuration, expected one of: %s", strings.Join(c.RequiredPermissionsBoundaryArns, ", "))
+	}
+
+	return fmt.Errorf("permissions_boundary (%s) is not allowed by the provider iam_role_policy configuration, expected one of: %s", permissionsBoundary, strings.Join(c.RequiredPermissionsBoundaryArns, ", "))
+}
+
+// checkManagedPolicyArn returns an error if the managed policy ARN is forbidden.
+func (c *IamRolePolicyConfig) checkManagedPolicyArn(policyArn string) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, forbiddenArn := range c.ForbiddenManagedPolicyArns {
+		if policyArn == forbiddenArn {
+			return fmt.Errorf("managed policy (%s) is forbidden by the provider iam_role_policy configuration", policyArn)
+		}
+	}
+
+	return nil
+}
+
+// iamRolePolicyRoleCustomizeDiff enforces the provider IAM role policy
+// against the path and permissions boundary of a role.
+func iamRolePolicyRoleCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*AWSClient)
+
+	if !ok || client.IamRolePolicyConfig == nil {
+		return nil
+	}
+
+	policy := client.IamRolePolicyConfig
+
+	if diff.NewValueKnown("path") {
+		if err := policy.checkPath(diff.Get("path").(string)); err != nil {
+			return fmt.Errorf("IAM Role (%s): %s", diff.Get("name").(string), err)
+		}
+	}
+
+	if diff.NewValueKnown("permissions_boundary") {
+		if err := policy.checkPermissionsBoundary(diff.Get("permissions_boundary").(string)); err != nil {
+			return fmt.Errorf("IAM Role (%s): %s", diff.Get("name").(string), err)
+		}
+	}
+
+	return nil
+}
+
+// iamRolePolicyRolePolicyAttachmentCustomizeDiff enforces the provider IAM
+// role policy against a managed policy attached to a role.
+func iamRolePolicyRolePolicyAttachmentCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*AWSClient)
+
+	if !ok || client.IamRolePolicyConfig == nil {
+		return nil
+	}
+
+	if !diff.NewValueKnown("policy_arn") {
+		return nil
+	}
+
+	if err := client.IamRolePolicyConfig.checkManagedPolicyArn(diff.Get("policy_arn").(string)); err != nil {
+		return fmt.Errorf("IAM Role Policy Attachment (%s): %s", diff.Get("role").(string), err)
+	}
+
+	return nil
+}
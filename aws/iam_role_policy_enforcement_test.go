@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestIamRolePolicyConfigChecks(t *testing.T) {
+	policy := &IamRolePolicyConfig{
+		AllowedPaths:                    []string{"/landing-zone/", "/service-role/"},
+		ForbiddenManagedPolicyArns:      []string{"arn:aws:iam::aws:policy/AdministratorAccess"},
+		RequiredPermissionsBoundaryArns: []string{"arn:aws:iam::123456789012:policy/boundary"},
+	}
+
+	if err := policy.checkPath("/landing-zone/team-a/"); err != nil {
+		t.Errorf("expected nested path to be allowed, got: %s", err)
+	}
+
+	if err := policy.checkPath("/"); err == nil {
+		t.Error("expected root path to be rejected")
+	}
+
+	if err := policy.checkPermissionsBoundary("arn:aws:iam::123456789012:policy/boundary"); err != nil {
+		t.Errorf("expected permissions boundary to be allowed, got: %s", err)
+	}
+
+	if err := policy.checkPermissionsBoundary(""); err == nil {
+		t.Error("expected missing permissions boundary to be rejected")
+	}
+
+	if err := policy.checkPermissionsBoundary("arn:aws:iam::123456789012:policy/other"); err == nil {
+		t.Error("expected other permissions boundary to be rejected")
+	}
+
+	if err := policy.checkManagedPolicyArn("arn:aws:iam::aws:policy/AdministratorAccess"); err == nil {
+		t.Error("expected forbidden managed policy to be rejected")
+	}
+
+	if err := policy.checkManagedPolicyArn("arn:aws:iam::aws:policy/ReadOnlyAccess"); err != nil {
+		t.Errorf("expected managed policy to be allowed, got: %s", err)
+	}
+
+	var nilPolicy *IamRolePolicyConfig
+
+	if err := nilPolicy.checkPermissionsBoundary(""); err != nil {
+		t.Errorf("expected no error without configuration, got: %s", err)
+	}
+}
+
+func TestValidateIamRolePolicyPath(t *testing.T) {
+	for _, v := range []string{"/", "/landing-zone/", "/a/b/"} {
+		if _, errors := validateIamRolePolicyPath(v, "allowed_paths"); len(errors) != 0 {
+			t.Errorf("%q should be a valid path: %q", v, errors)
+		}
+	}
+
+	for _, v := range []string{"", "landing-zone/", "/landing-zone"} {
+		if _, errors := validateIamRolePolicyPath(v, "allowed_paths"); len(errors) == 0 {
+			t.Errorf("%q should be an invalid path", v)
+		}
+	}
+}
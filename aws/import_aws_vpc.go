@@ -0,0 +1,229 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// VPC import optionally fans out to the resources making up the VPC's
+// network topology, so that an existing network can be brought under
+// management with a single import. Each resource is imported with its own
+// importer, e.g. security groups together with their rules, and the main
+// route table, default network ACL and default security group as their
+// aws_default_* resources.
+//
+// Resources that are already represented by an argument of another imported
+// resource are not imported separately, as they would otherwise be managed
+// twice:
+//   - the default security group's rules, which are its ingress and egress
+//     blocks
+//   - the subnet associations of network ACLs, which are their subnet_ids
+//     argument
+//   - the internet gateway attachment, which is the internet gateway's vpc_id
+//     argument
+func resourceAwsVpcImportTopology(
+	d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*AWSClient).ec2conn
+	vpcID := d.Id()
+	results := []*schema.ResourceData{d}
+
+	addResource := func(resourceType string, r *schema.Resource, id string) *schema.ResourceData {
+		d := r.Data(nil)
+		d.SetType(resourceType)
+		d.SetId(id)
+
+		results = append(results, d)
+		return d
+	}
+
+	importResource := func(resourceType string, r *schema.Resource, id string) ([]*schema.ResourceData, error) {
+		if r.Importer == nil || r.Importer.State == nil {
+			return []*schema.ResourceData{addResource(resourceType, r, id)}, nil
+		}
+
+		d := r.Data(nil)
+		d.SetType(resourceType)
+		d.SetId(id)
+
+		ds, err := r.Importer.State(d, meta)
+		if err != nil {
+			return nil, fmt.Errorf("error importing %s (%s): %s", resourceType, id, err)
+		}
+
+		results = append(results, ds...)
+		return ds, nil
+	}
+
+	vpcFilters := buildEC2AttributeFilterList(map[string]string{
+		"vpc-id": vpcID,
+	})
+
+	// Internet gateways
+	var internetGatewayIDs []string
+	err := conn.DescribeInternetGatewaysPages(&ec2.DescribeInternetGatewaysInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"attachment.vpc-id": vpcID,
+		}),
+	}, func(page *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
+		for _, igw := range page.InternetGateways {
+			internetGatewayIDs = append(internetGatewayIDs, aws.StringValue(igw.InternetGatewayId))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s) internet gateways: %s", vpcID, err)
+	}
+	for _, id := range internetGatewayIDs {
+		if _, err := importResource("aws_internet_gateway", resourceAwsInternetGateway(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	// Subnets
+	var subnetIDs []string
+	err = conn.DescribeSubnetsPages(&ec2.DescribeSubnetsInput{Filters: vpcFilters}, func(page *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		for _, subnet := range page.Subnets {
+			subnetIDs = append(subnetIDs, aws.StringValue(subnet.SubnetId))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s) subnets: %s", vpcID, err)
+	}
+	for _, id := range subnetIDs {
+		if _, err := importResource("aws_subnet", resourceAwsSubnet(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	// Route tables and their subnet and gateway associations
+	var routeTables []*ec2.RouteTable
+	err = conn.DescribeRouteTablesPages(&ec2.DescribeRouteTablesInput{Filters: vpcFilters}, func(page *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+		routeTables = append(routeTables, page.RouteTables...)
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s) route tables: %s", vpcID, err)
+	}
+	for _, rt := range routeTables {
+		id := aws.StringValue(rt.RouteTableId)
+		main := false
+
+		for _, assoc := range rt.Associations {
+			if aws.BoolValue(assoc.Main) {
+				main = true
+			}
+		}
+
+		if main {
+			ds, err := importResource("aws_default_route_table", resourceAwsDefaultRouteTable(), id)
+			if err != nil {
+				return nil, err
+			}
+			ds[0].Set("default_route_table_id", id)
+			ds[0].Set("vpc_id", vpcID)
+		} else {
+			if _, err := importResource("aws_route_table", resourceAwsRouteTable(), id); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, assoc := range rt.Associations {
+			if aws.BoolValue(assoc.Main) {
+				continue
+			}
+
+			targetID := aws.StringValue(assoc.SubnetId)
+			if targetID == "" {
+				targetID = aws.StringValue(assoc.GatewayId)
+			}
+			if targetID == "" {
+				continue
+			}
+
+			if _, err := importResource("aws_route_table_association", resourceAwsRouteTableAssociation(), fmt.Sprintf("%s/%s", targetID, id)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Network ACLs
+	var networkAcls []*ec2.NetworkAcl
+	err = conn.DescribeNetworkAclsPages(&ec2.DescribeNetworkAclsInput{Filters: vpcFilters}, func(page *ec2.DescribeNetworkAclsOutput, lastPage bool) bool {
+		networkAcls = append(networkAcls, page.NetworkAcls...)
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s) network ACLs: %s", vpcID, err)
+	}
+	for _, acl := range networkAcls {
+		id := aws.StringValue(acl.NetworkAclId)
+
+		if aws.BoolValue(acl.IsDefault) {
+			ds, err := importResource("aws_default_network_acl", resourceAwsDefaultNetworkAcl(), id)
+			if err != nil {
+				return nil, err
+			}
+			ds[0].Set("default_network_acl_id", id)
+			continue
+		}
+
+		if _, err := importResource("aws_network_acl", resourceAwsNetworkAcl(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	// Security groups, with their rules. The default security group's rules
+	// are kept inline.
+	var securityGroups []*ec2.SecurityGroup
+	err = conn.DescribeSecurityGroupsPages(&ec2.DescribeSecurityGroupsInput{Filters: vpcFilters}, func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		securityGroups = append(securityGroups, page.SecurityGroups...)
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s) security groups: %s", vpcID, err)
+	}
+	for _, sg := range securityGroups {
+		id := aws.StringValue(sg.GroupId)
+
+		if aws.StringValue(sg.GroupName) == "default" {
+			addResource("aws_default_security_group", resourceAwsDefaultSecurityGroup(), id)
+		} else {
+			if _, err := importResource("aws_security_group", resourceAwsSecurityGroup(), id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Network interfaces, except those managed by AWS on behalf of other
+	// resources such as NAT gateways and VPC endpoints, and the primary
+	// network interfaces of instances, which are created and deleted with the
+	// instance
+	var networkInterfaceIDs []string
+	err = conn.DescribeNetworkInterfacesPages(&ec2.DescribeNetworkInterfacesInput{Filters: vpcFilters}, func(page *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+		for _, eni := range page.NetworkInterfaces {
+			if aws.BoolValue(eni.RequesterManaged) || aws.StringValue(eni.InterfaceType) != ec2.NetworkInterfaceTypeInterface {
+				continue
+			}
+			if eni.Attachment != nil && aws.Int64Value(eni.Attachment.DeviceIndex) == 0 {
+				continue
+			}
+			networkInterfaceIDs = append(networkInterfaceIDs, aws.StringValue(eni.NetworkInterfaceId))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s) network interfaces: %s", vpcID, err)
+	}
+	for _, id := range networkInterfaceIDs {
+		if _, err := importResource("aws_network_interface", resourceAwsNetworkInterface(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
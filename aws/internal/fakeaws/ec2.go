@@ -0,0 +1,494 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// AvailabilityZones are the availability zones of Region, mapped to their IDs.
+var AvailabilityZones = map[string]string{
+	"us-west-2a": "usw2-az1",
+	"us-west-2b": "usw2-az2",
+	"us-west-2c": "usw2-az3",
+}
+
+// EC2 implements the Amazon EC2 API operations for VPC networking.
+//
+// A default VPC is created in Region, with a default subnet in each of
+// the AvailabilityZones and an attached internet gateway.
+type EC2 struct {
+	caller string
+	ids    map[string]int
+
+	addresses                    map[string]*ec2.Address
+	byoipCidrs                   map[string]*byoipCidr
+	clientVpnAuthorizationRules  map[string]*ec2.AuthorizationRule
+	clientVpnEndpoints           map[string]*ec2.ClientVpnEndpoint
+	clientVpnRoutes              map[string]*ec2.ClientVpnRoute
+	clientVpnTargetNetworks      map[string]*ec2.TargetNetwork
+	deletedVpcEndpoints          map[string]bool
+	dhcpOptionsSets              map[string]*ec2.DhcpOptions
+	flowLogs                     map[string]*ec2.FlowLog
+	instances                    map[string]*instance
+	internetGateways             map[string]*ec2.InternetGateway
+	ipv6Pools                    map[string]*ec2.Ipv6Pool
+	managedPrefixLists           map[string]*managedPrefixList
+	natGateways                  map[string]*ec2.NatGateway
+	networkAcls                  map[string]*ec2.NetworkAcl
+	networkInterfaces            map[string]*ec2.NetworkInterface
+	routeTables                  map[string]*ec2.RouteTable
+	securityGroupRules           map[string]*securityGroupRules
+	securityGroups               map[string]*ec2.SecurityGroup
+	subnets                      map[string]*ec2.Subnet
+	tags                         map[string]map[string]string
+	transitGatewayAssociations   map[string]*ec2.TransitGatewayAssociation
+	transitGatewayPropagations   map[string]*ec2.TransitGatewayPropagation
+	transitGatewayRouteTables    map[string]*ec2.TransitGatewayRouteTable
+	transitGatewayVpcAttachments map[string]*ec2.TransitGatewayVpcAttachment
+	transitGateways              map[string]*ec2.TransitGateway
+	vpcAttributes                map[string]*vpcAttributes
+	vpcEndpointServices          map[string]*ec2.ServiceConfiguration
+	vpcEndpoints                 map[string]*ec2.VpcEndpoint
+	vpcPeeringConnections        map[string]*ec2.VpcPeeringConnection
+	vpcs                         map[string]*ec2.Vpc
+
+	// DefaultVpcID is the ID of the default VPC, or empty once deleted.
+	DefaultVpcID string
+}
+
+func newEC2() *EC2 {
+	e := &EC2{
+		caller:                       AccountID,
+		ids:                          make(map[string]int),
+		addresses:                    make(map[string]*ec2.Address),
+		byoipCidrs:                   make(map[string]*byoipCidr),
+		clientVpnAuthorizationRules:  make(map[string]*ec2.AuthorizationRule),
+		clientVpnEndpoints:           make(map[string]*ec2.ClientVpnEndpoint),
+		clientVpnRoutes:              make(map[string]*ec2.ClientVpnRoute),
+		clientVpnTargetNetworks:      make(map[string]*ec2.TargetNetwork),
+		deletedVpcEndpoints:          make(map[string]bool),
+		dhcpOptionsSets:              make(map[string]*ec2.DhcpOptions),
+		flowLogs:                     make(map[string]*ec2.FlowLog),
+		instances:                    make(map[string]*instance),
+		internetGateways:             make(map[string]*ec2.InternetGateway),
+		ipv6Pools:                    make(map[string]*ec2.Ipv6Pool),
+		managedPrefixLists:           make(map[string]*managedPrefixList),
+		natGateways:                  make(map[string]*ec2.NatGateway),
+		networkAcls:                  make(map[string]*ec2.NetworkAcl),
+		networkInterfaces:            make(map[string]*ec2.NetworkInterface),
+		routeTables:                  make(map[string]*ec2.RouteTable),
+		securityGroupRules:           make(map[string]*securityGroupRules),
+		securityGroups:               make(map[string]*ec2.SecurityGroup),
+		subnets:                      make(map[string]*ec2.Subnet),
+		tags:                         make(map[string]map[string]string),
+		transitGatewayAssociations:   make(map[string]*ec2.TransitGatewayAssociation),
+		transitGatewayPropagations:   make(map[string]*ec2.TransitGatewayPropagation),
+		transitGatewayRouteTables:    make(map[string]*ec2.TransitGatewayRouteTable),
+		transitGatewayVpcAttachments: make(map[string]*ec2.TransitGatewayVpcAttachment),
+		transitGateways:              make(map[string]*ec2.TransitGateway),
+		vpcAttributes:                make(map[string]*vpcAttributes),
+		vpcEndpointServices:          make(map[string]*ec2.ServiceConfiguration),
+		vpcEndpoints:                 make(map[string]*ec2.VpcEndpoint),
+		vpcPeeringConnections:        make(map[string]*ec2.VpcPeeringConnection),
+		vpcs:                         make(map[string]*ec2.Vpc),
+	}
+
+	e.createDefaultVpc()
+
+	return e
+}
+
+// newID returns a new resource ID with the given prefix, e.g. "vpc".
+func (e *EC2) newID(prefix string) string {
+	e.ids[prefix]++
+
+	return fmt.Sprintf("%s-%017x", prefix, e.ids[prefix])
+}
+
+func ec2Error(code, format string, a ...interface{}) *Error {
+	return newError(http.StatusBadRequest, code, format, a...)
+}
+
+// sortedKeys returns the keys of a map with string keys in sorted order.
+// IDs returned by newID sort in creation order.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	keys := make([]string, 0, v.Len())
+
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// filterValuesFunc returns the values of the named filter attribute for a
+// resource, or false if the filter is not supported for the resource type.
+type filterValuesFunc func(id, name string) ([]string, bool)
+
+// selectIDs returns the IDs from all, in order, that are in ids (if any)
+// and match all filters. An error with notFoundCode is returned if any of
+// ids does not exist.
+func (e *EC2) selectIDs(all []string, ids []*string, filters []*ec2.Filter, notFoundCode, kind string, values filterValuesFunc) ([]string, error) {
+	exists := make(map[string]bool, len(all))
+
+	for _, id := range all {
+		exists[id] = true
+	}
+
+	wanted := make(map[string]bool, len(ids))
+
+	for _, id := range aws.StringValueSlice(ids) {
+		if !exists[id] {
+			return nil, ec2Error(notFoundCode, "The %s ID '%s' does not exist", kind, id)
+		}
+
+		wanted[id] = true
+	}
+
+	var selected []string
+
+	for _, id := range all {
+		if len(wanted) > 0 && !wanted[id] {
+			continue
+		}
+
+		ok, err := e.matchFilters(id, filters, values)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			selected = append(selected, id)
+		}
+	}
+
+	return selected, nil
+}
+
+func (e *EC2) matchFilters(id string, filters []*ec2.Filter, values filterValuesFunc) (bool, error) {
+	for _, filter := range filters {
+		name := aws.StringValue(filter.Name)
+		tags := e.tags[e.tagKey(id)]
+
+		var actual []string
+
+		switch {
+		case strings.HasPrefix(name, "tag:"):
+			if v, ok := tags[strings.TrimPrefix(name, "tag:")]; ok {
+				actual = []string{v}
+			}
+		case name == "tag-key":
+			actual = sortedKeys(tags)
+		case name == "tag-value":
+			for _, k := range sortedKeys(tags) {
+				actual = append(actual, tags[k])
+			}
+		default:
+			v, ok := values(id, name)
+
+			if !ok {
+				return false, ec2Error("InvalidParameterValue", "The filter '%s' is invalid", name)
+			}
+
+			actual = v
+		}
+
+		if !matchFilterValues(aws.StringValueSlice(filter.Values), actual) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchFilterValues returns whether any actual value matches any of the
+// filter values, which may contain * and ? wildcards.
+func matchFilterValues(patterns, actual []string) bool {
+	for _, pattern := range patterns {
+		var b strings.Builder
+
+		b.WriteString("^")
+
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				b.WriteString(".*")
+			case '?':
+				b.WriteString(".")
+			default:
+				b.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+
+		b.WriteString("$")
+
+		re := regexp.MustCompile(b.String())
+
+		for _, v := range actual {
+			if re.MatchString(v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func boolFilterValue(b *bool) []string {
+	return []string{fmt.Sprintf("%t", aws.BoolValue(b))}
+}
+
+func stringFilterValue(s *string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return []string{aws.StringValue(s)}
+}
+
+//
+// Tags
+//
+
+// ec2Tags returns the tags of a resource, sorted by key.
+func (e *EC2) ec2Tags(id string) []*ec2.Tag {
+	var tags []*ec2.Tag
+
+	key := e.tagKey(id)
+
+	for _, k := range sortedKeys(e.tags[key]) {
+		tags = append(tags, &ec2.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(e.tags[key][k]),
+		})
+	}
+
+	return tags
+}
+
+// tagKey returns the key of a resource's tags in e.tags. The tags of a VPC
+// peering connection are only visible to the account that added them.
+func (e *EC2) tagKey(id string) string {
+	if _, ok := e.vpcPeeringConnections[id]; ok {
+		return id + "/" + e.caller
+	}
+
+	return id
+}
+
+// resourceType returns the tag resource type of an existing resource.
+func (e *EC2) resourceType(id string) (string, bool) {
+	resources := []struct {
+		resourceType string
+		exists       bool
+	}{
+		{ec2.ResourceTypeClientVpnEndpoint, e.clientVpnEndpoints[id] != nil},
+		{ec2.ResourceTypeDhcpOptions, e.dhcpOptionsSets[id] != nil},
+		{ec2.ResourceTypeElasticIp, e.addresses[id] != nil},
+		{ec2.ResourceTypeVpcFlowLog, e.flowLogs[id] != nil},
+		{ec2.ResourceTypeInstance, e.instances[id] != nil},
+		{ec2.ResourceTypeInternetGateway, e.internetGateways[id] != nil},
+		{"ipv6pool-ec2", e.ipv6Pools[id] != nil},
+		{ec2.ResourceTypeNatgateway, e.natGateways[id] != nil},
+		{"prefix-list", e.managedPrefixLists[id] != nil},
+		{ec2.ResourceTypeNetworkAcl, e.networkAcls[id] != nil},
+		{ec2.ResourceTypeNetworkInterface, e.networkInterfaces[id] != nil},
+		{ec2.ResourceTypeRouteTable, e.routeTables[id] != nil},
+		{ec2.ResourceTypeSecurityGroup, e.securityGroups[id] != nil},
+		{ec2.ResourceTypeSubnet, e.subnets[id] != nil},
+		{ec2.ResourceTypeTransitGateway, e.transitGateways[id] != nil},
+		{ec2.ResourceTypeTransitGatewayAttachment, e.transitGatewayVpcAttachments[id] != nil},
+		{ec2.ResourceTypeTransitGatewayRouteTable, e.transitGatewayRouteTables[id] != nil},
+		{ec2.ResourceTypeVpc, e.vpcs[id] != nil},
+		{ec2.ResourceTypeVpcPeeringConnection, e.vpcPeeringConnections[id] != nil},
+		{"vpc-endpoint", e.vpcEndpoints[id] != nil},
+		{"vpc-endpoint-service", e.vpcEndpointServices[id] != nil},
+	}
+
+	for _, r := range resources {
+		if r.exists {
+			return r.resourceType, true
+		}
+	}
+
+	return "", false
+}
+
+func (e *EC2) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	for _, id := range aws.StringValueSlice(input.Resources) {
+		if _, ok := e.resourceType(id); !ok {
+			return nil, ec2Error("InvalidID", "The ID '%s' is not valid", id)
+		}
+	}
+
+	for _, tag := range input.Tags {
+		if strings.HasPrefix(aws.StringValue(tag.Key), "aws:") {
+			return nil, ec2Error("InvalidParameterValue", "Tag keys starting with 'aws:' are reserved for internal use")
+		}
+	}
+
+	for _, id := range aws.StringValueSlice(input.Resources) {
+		key := e.tagKey(id)
+
+		if e.tags[key] == nil {
+			e.tags[key] = make(map[string]string)
+		}
+
+		for _, tag := range input.Tags {
+			e.tags[key][aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func (e *EC2) DeleteTags(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	for _, id := range aws.StringValueSlice(input.Resources) {
+		if _, ok := e.resourceType(id); !ok {
+			return nil, ec2Error("InvalidID", "The ID '%s' is not valid", id)
+		}
+
+		tagKey := e.tagKey(id)
+
+		for _, tag := range input.Tags {
+			key := aws.StringValue(tag.Key)
+
+			if tag.Value != nil && e.tags[tagKey][key] != aws.StringValue(tag.Value) {
+				continue
+			}
+
+			delete(e.tags[tagKey], key)
+		}
+
+		if len(input.Tags) == 0 {
+			delete(e.tags, tagKey)
+		}
+	}
+
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+func (e *EC2) DescribeTags(input *ec2.DescribeTagsInput) (*ec2.DescribeTagsOutput, error) {
+	output := &ec2.DescribeTagsOutput{}
+
+	for _, tagKey := range sortedKeys(e.tags) {
+		id := strings.Split(tagKey, "/")[0]
+
+		if e.tagKey(id) != tagKey {
+			continue
+		}
+
+		resourceType, ok := e.resourceType(id)
+
+		if !ok {
+			continue
+		}
+
+		for _, k := range sortedKeys(e.tags[tagKey]) {
+			v := e.tags[tagKey][k]
+
+			values := func(_, name string) ([]string, bool) {
+				switch name {
+				case "key":
+					return []string{k}, true
+				case "resource-id":
+					return []string{id}, true
+				case "resource-type":
+					return []string{resourceType}, true
+				case "value":
+					return []string{v}, true
+				}
+
+				return nil, false
+			}
+
+			var match = true
+
+			for _, filter := range input.Filters {
+				actual, ok := values(id, aws.StringValue(filter.Name))
+
+				if !ok {
+					return nil, ec2Error("InvalidParameterValue", "The filter '%s' is invalid", aws.StringValue(filter.Name))
+				}
+
+				if !matchFilterValues(aws.StringValueSlice(filter.Values), actual) {
+					match = false
+				}
+			}
+
+			if !match {
+				continue
+			}
+
+			output.Tags = append(output.Tags, &ec2.TagDescription{
+				Key:          aws.String(k),
+				ResourceId:   aws.String(id),
+				ResourceType: aws.String(resourceType),
+				Value:        aws.String(v),
+			})
+		}
+	}
+
+	return output, nil
+}
+
+//
+// Account
+//
+
+func (e *EC2) DescribeAccountAttributes(input *ec2.DescribeAccountAttributesInput) (*ec2.DescribeAccountAttributesOutput, error) {
+	defaultVpcID := "none"
+
+	if e.DefaultVpcID != "" {
+		defaultVpcID = e.DefaultVpcID
+	}
+
+	attributes := map[string][]string{
+		"default-vpc":         {defaultVpcID},
+		"max-instances":       {"20"},
+		"supported-platforms": {"VPC"},
+	}
+
+	output := &ec2.DescribeAccountAttributesOutput{}
+
+	for _, name := range sortedKeys(attributes) {
+		if len(input.AttributeNames) > 0 && !contains(aws.StringValueSlice(input.AttributeNames), name) {
+			continue
+		}
+
+		attribute := &ec2.AccountAttribute{
+			AttributeName: aws.String(name),
+		}
+
+		for _, v := range attributes[name] {
+			attribute.AttributeValues = append(attribute.AttributeValues, &ec2.AccountAttributeValue{
+				AttributeValue: aws.String(v),
+			})
+		}
+
+		output.AccountAttributes = append(output.AccountAttributes, attribute)
+	}
+
+	return output, nil
+}
+
+func contains(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,344 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// publicIPv4Pool is the network public IP addresses are allocated from.
+const publicIPv4Pool = "198.18.0.0/15"
+
+func (e *EC2) address(allocationID string) (*ec2.Address, error) {
+	address, ok := e.addresses[allocationID]
+
+	if !ok {
+		return nil, ec2Error("InvalidAllocationID.NotFound", "The allocation ID '%s' does not exist", allocationID)
+	}
+
+	return address, nil
+}
+
+// addressByPublicIP returns the address with a public IP.
+func (e *EC2) addressByPublicIP(publicIP string) (*ec2.Address, error) {
+	for _, id := range sortedKeys(e.addresses) {
+		if aws.StringValue(e.addresses[id].PublicIp) == publicIP {
+			return e.addresses[id], nil
+		}
+	}
+
+	return nil, ec2Error("InvalidAddress.NotFound", "Address '%s' not found.", publicIP)
+}
+
+// resolveAddress returns the address with an allocation ID or public IP.
+func (e *EC2) resolveAddress(allocationID, publicIP *string) (*ec2.Address, error) {
+	if allocationID != nil {
+		return e.address(aws.StringValue(allocationID))
+	}
+
+	if publicIP != nil {
+		return e.addressByPublicIP(aws.StringValue(publicIP))
+	}
+
+	return nil, ec2Error("MissingParameter", "Either public IP or allocation id must be specified")
+}
+
+func publicDNSName(address string) string {
+	return fmt.Sprintf("ec2-%s.%s.compute.amazonaws.com", strings.Replace(address, ".", "-", -1), Region)
+}
+
+// associateAddress associates an address with a private IP address of a
+// network interface.
+func (e *EC2) associateAddress(address *ec2.Address, eni *ec2.NetworkInterface, privateIPAddress string) {
+	associationID := e.newID("eipassoc")
+
+	association := &ec2.NetworkInterfaceAssociation{
+		AllocationId:  address.AllocationId,
+		AssociationId: aws.String(associationID),
+		IpOwnerId:     aws.String(AccountID),
+		PublicDnsName: aws.String(publicDNSName(aws.StringValue(address.PublicIp))),
+		PublicIp:      address.PublicIp,
+	}
+
+	for _, a := range eni.PrivateIpAddresses {
+		if aws.StringValue(a.PrivateIpAddress) == privateIPAddress {
+			a.Association = awsutil.CopyOf(association).(*ec2.NetworkInterfaceAssociation)
+		}
+	}
+
+	if aws.StringValue(eni.PrivateIpAddress) == privateIPAddress {
+		eni.Association = association
+	}
+
+	address.AssociationId = aws.String(associationID)
+	address.NetworkInterfaceId = eni.NetworkInterfaceId
+	address.NetworkInterfaceOwnerId = eni.OwnerId
+	address.PrivateIpAddress = aws.String(privateIPAddress)
+}
+
+// disassociateAddress removes the association of an address from its
+// network interface.
+func (e *EC2) disassociateAddress(address *ec2.Address) {
+	if eni, ok := e.networkInterfaces[aws.StringValue(address.NetworkInterfaceId)]; ok {
+		if eni.Association != nil && aws.StringValue(eni.Association.AllocationId) == aws.StringValue(address.AllocationId) {
+			eni.Association = nil
+		}
+
+		for _, a := range eni.PrivateIpAddresses {
+			if a.Association != nil && aws.StringValue(a.Association.AllocationId) == aws.StringValue(address.AllocationId) {
+				a.Association = nil
+			}
+		}
+	}
+
+	address.AssociationId = nil
+	address.NetworkInterfaceId = nil
+	address.NetworkInterfaceOwnerId = nil
+	address.PrivateIpAddress = nil
+}
+
+// disassociateNetworkInterfaceAddresses removes the associations of all
+// addresses with a network interface.
+func (e *EC2) disassociateNetworkInterfaceAddresses(networkInterfaceID string) {
+	for _, id := range sortedKeys(e.addresses) {
+		address := e.addresses[id]
+
+		if aws.StringValue(address.NetworkInterfaceId) == networkInterfaceID {
+			e.disassociateAddress(address)
+		}
+	}
+}
+
+// internetGatewayAttachedToVpc returns whether any internet gateway is
+// attached to a VPC.
+func (e *EC2) internetGatewayAttachedToVpc(vpcID string) bool {
+	for _, id := range sortedKeys(e.internetGateways) {
+		if internetGatewayAttached(e.internetGateways[id], vpcID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EC2) AllocateAddress(input *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
+	if input.CustomerOwnedIpv4Pool != nil {
+		return nil, ec2Error("InvalidParameterValue", "Customer owned IPv4 pool '%s' does not exist", aws.StringValue(input.CustomerOwnedIpv4Pool))
+	}
+
+	if v := aws.StringValue(input.PublicIpv4Pool); v != "" && v != "amazon" {
+		return nil, ec2Error("InvalidPublicIpv4PoolID.NotFound", "The pool ID '%s' does not exist", v)
+	}
+
+	_, network, _ := net.ParseCIDR(publicIPv4Pool)
+	allocationID := e.newID("eipalloc")
+	publicIP := addToIP(network.IP, uint32(e.ids["eipalloc"])).String()
+
+	if input.Address != nil {
+		if _, err := e.addressByPublicIP(aws.StringValue(input.Address)); err == nil {
+			return nil, ec2Error("InvalidAddress.InUse", "Address %s is already allocated", aws.StringValue(input.Address))
+		}
+
+		publicIP = aws.StringValue(input.Address)
+	}
+
+	// Accounts without EC2-Classic allocate VPC addresses in either domain.
+	e.addresses[allocationID] = &ec2.Address{
+		AllocationId:       aws.String(allocationID),
+		Domain:             aws.String(ec2.DomainTypeVpc),
+		NetworkBorderGroup: aws.String(Region),
+		PublicIp:           aws.String(publicIP),
+		PublicIpv4Pool:     aws.String("amazon"),
+	}
+
+	return &ec2.AllocateAddressOutput{
+		AllocationId:       aws.String(allocationID),
+		Domain:             aws.String(ec2.DomainTypeVpc),
+		NetworkBorderGroup: aws.String(Region),
+		PublicIp:           aws.String(publicIP),
+		PublicIpv4Pool:     aws.String("amazon"),
+	}, nil
+}
+
+func (e *EC2) AssociateAddress(input *ec2.AssociateAddressInput) (*ec2.AssociateAddressOutput, error) {
+	address, err := e.resolveAddress(input.AllocationId, input.PublicIp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty parameters are treated as absent.
+	if aws.StringValue(input.InstanceId) != "" {
+		return nil, ec2Error("InvalidInstanceID.NotFound", "The instance ID '%s' does not exist", aws.StringValue(input.InstanceId))
+	}
+
+	if aws.StringValue(input.NetworkInterfaceId) == "" {
+		return nil, ec2Error("MissingParameter", "Either instance ID or network interface id must be specified")
+	}
+
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(eni.VpcId)
+
+	if !e.internetGatewayAttachedToVpc(vpcID) {
+		return nil, ec2Error("Gateway.NotAttached", "Network %s is not attached to any internet gateway", vpcID)
+	}
+
+	privateIPAddress := aws.StringValue(eni.PrivateIpAddress)
+
+	if input.PrivateIpAddress != nil {
+		privateIPAddress = aws.StringValue(input.PrivateIpAddress)
+		found := false
+
+		for _, a := range eni.PrivateIpAddresses {
+			if aws.StringValue(a.PrivateIpAddress) == privateIPAddress {
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, ec2Error("InvalidParameterValue", "The private IP address %s is not assigned to network interface %s", privateIPAddress, aws.StringValue(eni.NetworkInterfaceId))
+		}
+	}
+
+	if address.AssociationId != nil {
+		if !aws.BoolValue(input.AllowReassociation) {
+			return nil, ec2Error("Resource.AlreadyAssociated", "resource %s is already associated with associate-id %s", aws.StringValue(address.AllocationId), aws.StringValue(address.AssociationId))
+		}
+
+		e.disassociateAddress(address)
+	}
+
+	for _, id := range sortedKeys(e.addresses) {
+		other := e.addresses[id]
+
+		if aws.StringValue(other.NetworkInterfaceId) == aws.StringValue(eni.NetworkInterfaceId) && aws.StringValue(other.PrivateIpAddress) == privateIPAddress {
+			e.disassociateAddress(other)
+		}
+	}
+
+	e.associateAddress(address, eni, privateIPAddress)
+
+	return &ec2.AssociateAddressOutput{
+		AssociationId: address.AssociationId,
+	}, nil
+}
+
+func (e *EC2) DisassociateAddress(input *ec2.DisassociateAddressInput) (*ec2.DisassociateAddressOutput, error) {
+	var address *ec2.Address
+
+	switch {
+	case input.AssociationId != nil:
+		associationID := aws.StringValue(input.AssociationId)
+
+		for _, id := range sortedKeys(e.addresses) {
+			if aws.StringValue(e.addresses[id].AssociationId) == associationID {
+				address = e.addresses[id]
+			}
+		}
+
+		if address == nil {
+			return nil, ec2Error("InvalidAssociationID.NotFound", "The association ID '%s' does not exist", associationID)
+		}
+	case input.PublicIp != nil:
+		var err error
+
+		if address, err = e.addressByPublicIP(aws.StringValue(input.PublicIp)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ec2Error("MissingParameter", "Either public IP or association id must be specified")
+	}
+
+	if eni, ok := e.networkInterfaces[aws.StringValue(address.NetworkInterfaceId)]; ok && aws.BoolValue(eni.RequesterManaged) {
+		return nil, ec2Error("AuthFailure", "You do not have permission to access the specified resource.")
+	}
+
+	e.disassociateAddress(address)
+
+	return &ec2.DisassociateAddressOutput{}, nil
+}
+
+func (e *EC2) ReleaseAddress(input *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	address, err := e.resolveAddress(input.AllocationId, input.PublicIp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if address.AssociationId != nil {
+		return nil, ec2Error("InvalidIPAddress.InUse", "Address %s is in use.", aws.StringValue(address.PublicIp))
+	}
+
+	e.deleteResource(aws.StringValue(address.AllocationId))
+
+	return &ec2.ReleaseAddressOutput{}, nil
+}
+
+func (e *EC2) describeAddress(id string) *ec2.Address {
+	address := awsutil.CopyOf(e.addresses[id]).(*ec2.Address)
+	address.Tags = e.ec2Tags(id)
+
+	return address
+}
+
+func (e *EC2) addressFilterValues(id, name string) ([]string, bool) {
+	address := e.addresses[id]
+
+	switch name {
+	case "allocation-id":
+		return []string{id}, true
+	case "association-id":
+		return stringFilterValue(address.AssociationId), true
+	case "domain":
+		return stringFilterValue(address.Domain), true
+	case "network-border-group":
+		return stringFilterValue(address.NetworkBorderGroup), true
+	case "network-interface-id":
+		return stringFilterValue(address.NetworkInterfaceId), true
+	case "network-interface-owner-id":
+		return stringFilterValue(address.NetworkInterfaceOwnerId), true
+	case "private-ip-address":
+		return stringFilterValue(address.PrivateIpAddress), true
+	case "public-ip":
+		return stringFilterValue(address.PublicIp), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeAddresses(input *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	allocationIDs := input.AllocationIds
+
+	for _, publicIP := range aws.StringValueSlice(input.PublicIps) {
+		address, err := e.addressByPublicIP(publicIP)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allocationIDs = append(allocationIDs, address.AllocationId)
+	}
+
+	ids, err := e.selectIDs(sortedKeys(e.addresses), allocationIDs, input.Filters, "InvalidAllocationID.NotFound", "allocation", e.addressFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeAddressesOutput{}
+
+	for _, id := range ids {
+		output.Addresses = append(output.Addresses, e.describeAddress(id))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,83 @@
+package fakeaws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func describeAvailabilityZone(name string) *ec2.AvailabilityZone {
+	return &ec2.AvailabilityZone{
+		GroupName:          aws.String(Region),
+		NetworkBorderGroup: aws.String(Region),
+		OptInStatus:        aws.String(ec2.AvailabilityZoneOptInStatusOptInNotRequired),
+		RegionName:         aws.String(Region),
+		State:              aws.String(ec2.AvailabilityZoneStateAvailable),
+		ZoneId:             aws.String(AvailabilityZones[name]),
+		ZoneName:           aws.String(name),
+		ZoneType:           aws.String("availability-zone"),
+	}
+}
+
+func availabilityZoneFilterValues(name, filter string) ([]string, bool) {
+	az := describeAvailabilityZone(name)
+
+	switch filter {
+	case "group-name":
+		return stringFilterValue(az.GroupName), true
+	case "network-border-group":
+		return stringFilterValue(az.NetworkBorderGroup), true
+	case "opt-in-status":
+		return stringFilterValue(az.OptInStatus), true
+	case "region-name":
+		return stringFilterValue(az.RegionName), true
+	case "state":
+		return stringFilterValue(az.State), true
+	case "zone-id":
+		return stringFilterValue(az.ZoneId), true
+	case "zone-name":
+		return stringFilterValue(az.ZoneName), true
+	case "zone-type":
+		return stringFilterValue(az.ZoneType), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	names := input.ZoneNames
+
+	for _, id := range aws.StringValueSlice(input.ZoneIds) {
+		var found bool
+
+		for name, zoneID := range AvailabilityZones {
+			if zoneID == id {
+				names = append(names, aws.String(name))
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, ec2Error("InvalidParameterValue", "Invalid availability zone ID: [%s]", id)
+		}
+	}
+
+	for _, name := range aws.StringValueSlice(names) {
+		if _, ok := AvailabilityZones[name]; !ok {
+			return nil, ec2Error("InvalidParameterValue", "Invalid availability zone: [%s]", name)
+		}
+	}
+
+	ids, err := e.selectIDs(sortedKeys(AvailabilityZones), names, input.Filters, "InvalidParameterValue", "availability zone", availabilityZoneFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeAvailabilityZonesOutput{}
+
+	for _, name := range ids {
+		output.AvailabilityZones = append(output.AvailabilityZones, describeAvailabilityZone(name))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,245 @@
+package fakeaws
+
+import (
+	"encoding/binary"
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// byoipCidr is an address range brought to the caller's account. A
+// provisioned IPv6 range backs an IPv6 address pool from which VPC IPv6
+// CIDR blocks are allocated.
+type byoipCidr struct {
+	byoipCidr *ec2.ByoipCidr
+	ownerID   string
+	poolID    string
+}
+
+// vpcIpv6PoolCidrBlockSize is the prefix length of the VPC IPv6 CIDR blocks
+// allocated from an address pool.
+const vpcIpv6PoolCidrBlockSize = 56
+
+func (e *EC2) ProvisionByoipCidr(input *ec2.ProvisionByoipCidrInput) (*ec2.ProvisionByoipCidrOutput, error) {
+	cidr := aws.StringValue(input.Cidr)
+	_, network, err := net.ParseCIDR(cidr)
+
+	if err != nil || network.String() != cidr {
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter cidr is invalid. This is not a valid CIDR block.", cidr)
+	}
+
+	ones, bits := network.Mask.Size()
+
+	if (bits == 32 && ones > 24) || (bits == 128 && ones > 48) {
+		return nil, ec2Error("InvalidParameterValue", "The CIDR '%s' is more specific than the most specific allowed prefix.", cidr)
+	}
+
+	for _, existing := range sortedKeys(e.byoipCidrs) {
+		if cidrOverlaps(existing, cidr) {
+			return nil, ec2Error("InvalidByoipCidr.Conflict", "The CIDR '%s' conflicts with an existing BYOIP CIDR '%s'.", cidr, existing)
+		}
+	}
+
+	b := &byoipCidr{
+		byoipCidr: &ec2.ByoipCidr{
+			Cidr:        aws.String(cidr),
+			Description: input.Description,
+			State:       aws.String(ec2.ByoipCidrStateProvisioned),
+		},
+		ownerID: e.caller,
+	}
+
+	if bits == 128 {
+		b.poolID = e.newID("ipv6pool-ec2")
+		e.ipv6Pools[b.poolID] = &ec2.Ipv6Pool{
+			Description: input.Description,
+			PoolCidrBlocks: []*ec2.PoolCidrBlock{{
+				Cidr: aws.String(cidr),
+			}},
+			PoolId: aws.String(b.poolID),
+		}
+	}
+
+	e.byoipCidrs[cidr] = b
+
+	return &ec2.ProvisionByoipCidrOutput{
+		ByoipCidr: awsutil.CopyOf(b.byoipCidr).(*ec2.ByoipCidr),
+	}, nil
+}
+
+func (e *EC2) DeprovisionByoipCidr(input *ec2.DeprovisionByoipCidrInput) (*ec2.DeprovisionByoipCidrOutput, error) {
+	cidr := aws.StringValue(input.Cidr)
+	b, ok := e.byoipCidrs[cidr]
+
+	if !ok || b.ownerID != e.caller {
+		return nil, ec2Error("InvalidByoipCidr.NotFound", "The BYOIP CIDR '%s' does not exist", cidr)
+	}
+
+	if len(e.ipv6PoolCidrBlocks(b.poolID)) > 0 {
+		return nil, ec2Error("InvalidByoipCidr.InUse", "The BYOIP CIDR '%s' has CIDR blocks allocated from it", cidr)
+	}
+
+	delete(e.byoipCidrs, cidr)
+	delete(e.ipv6Pools, b.poolID)
+
+	b.byoipCidr.State = aws.String(ec2.ByoipCidrStateDeprovisioned)
+
+	return &ec2.DeprovisionByoipCidrOutput{
+		ByoipCidr: b.byoipCidr,
+	}, nil
+}
+
+func (e *EC2) DescribeByoipCidrs(input *ec2.DescribeByoipCidrsInput) (*ec2.DescribeByoipCidrsOutput, error) {
+	output := &ec2.DescribeByoipCidrsOutput{}
+
+	for _, cidr := range sortedKeys(e.byoipCidrs) {
+		if b := e.byoipCidrs[cidr]; b.ownerID == e.caller {
+			output.ByoipCidrs = append(output.ByoipCidrs, awsutil.CopyOf(b.byoipCidr).(*ec2.ByoipCidr))
+		}
+	}
+
+	return output, nil
+}
+
+// ipv6Pool returns an IPv6 address pool of the caller.
+func (e *EC2) ipv6Pool(id string) (*ec2.Ipv6Pool, error) {
+	pool, ok := e.ipv6Pools[id]
+
+	if !ok || e.ipv6PoolOwnerID(id) != e.caller {
+		return nil, ec2Error("InvalidIpv6PoolID.NotFound", "The ipv6 pool ID '%s' does not exist", id)
+	}
+
+	return pool, nil
+}
+
+func (e *EC2) ipv6PoolOwnerID(id string) string {
+	for _, cidr := range sortedKeys(e.byoipCidrs) {
+		if b := e.byoipCidrs[cidr]; b.poolID == id {
+			return b.ownerID
+		}
+	}
+
+	return ""
+}
+
+// ipv6PoolCidrBlocks returns the VPC IPv6 CIDR blocks allocated from an
+// address pool.
+func (e *EC2) ipv6PoolCidrBlocks(poolID string) []string {
+	var cidrBlocks []string
+
+	if poolID == "" {
+		return nil
+	}
+
+	for _, vpcID := range sortedKeys(e.vpcs) {
+		for _, a := range e.vpcs[vpcID].Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(a.Ipv6Pool) == poolID && aws.StringValue(a.Ipv6CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+				cidrBlocks = append(cidrBlocks, aws.StringValue(a.Ipv6CidrBlock))
+			}
+		}
+	}
+
+	return cidrBlocks
+}
+
+// allocateIpv6PoolCidrBlock returns a free VPC IPv6 CIDR block of the
+// address pool: the requested one, or else the first unallocated one.
+func (e *EC2) allocateIpv6PoolCidrBlock(poolID, cidrBlock string) (string, error) {
+	pool, err := e.ipv6Pool(poolID)
+
+	if err != nil {
+		return "", err
+	}
+
+	allocated := e.ipv6PoolCidrBlocks(poolID)
+	free := func(cidrBlock string) bool {
+		for _, existing := range allocated {
+			if cidrOverlaps(existing, cidrBlock) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if cidrBlock != "" {
+		_, network, err := net.ParseCIDR(cidrBlock)
+
+		if err != nil || network.IP.To4() != nil || network.String() != cidrBlock {
+			return "", ec2Error("InvalidParameterValue", "Value (%s) for parameter ipv6CidrBlock is invalid. This is not a valid CIDR block.", cidrBlock)
+		}
+
+		if ones, _ := network.Mask.Size(); ones != vpcIpv6PoolCidrBlockSize {
+			return "", ec2Error("InvalidParameterValue", "The IPv6 CIDR '%s' must have a /%d prefix length.", cidrBlock, vpcIpv6PoolCidrBlockSize)
+		}
+
+		if !cidrContains(aws.StringValue(pool.PoolCidrBlocks[0].Cidr), cidrBlock) {
+			return "", ec2Error("InvalidParameterValue", "The IPv6 CIDR '%s' is not within pool %s.", cidrBlock, poolID)
+		}
+
+		if !free(cidrBlock) {
+			return "", ec2Error("InvalidVpc.Range", "The IPv6 CIDR '%s' is already in use.", cidrBlock)
+		}
+
+		return cidrBlock, nil
+	}
+
+	_, network, _ := net.ParseCIDR(aws.StringValue(pool.PoolCidrBlocks[0].Cidr))
+	ones, _ := network.Mask.Size()
+
+	for n := 0; n < 1<<uint(vpcIpv6PoolCidrBlockSize-ones); n++ {
+		candidate := (&net.IPNet{
+			IP:   addToIPv6Prefix(network.IP, uint64(n)),
+			Mask: net.CIDRMask(vpcIpv6PoolCidrBlockSize, 128),
+		}).String()
+
+		if free(candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", ec2Error("InsufficientCidrBlocks", "The pool %s does not have enough free CIDR blocks", poolID)
+}
+
+func (e *EC2) DescribeIpv6Pools(input *ec2.DescribeIpv6PoolsInput) (*ec2.DescribeIpv6PoolsOutput, error) {
+	var ids []string
+
+	for _, id := range sortedKeys(e.ipv6Pools) {
+		if e.ipv6PoolOwnerID(id) == e.caller {
+			ids = append(ids, id)
+		}
+	}
+
+	ids, err := e.selectIDs(ids, input.PoolIds, input.Filters, "InvalidIpv6PoolID.NotFound", "ipv6 pool", func(id, name string) ([]string, bool) {
+		return nil, false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeIpv6PoolsOutput{}
+
+	for _, id := range ids {
+		pool := awsutil.CopyOf(e.ipv6Pools[id]).(*ec2.Ipv6Pool)
+		pool.Tags = e.ec2Tags(id)
+		output.Ipv6Pools = append(output.Ipv6Pools, pool)
+	}
+
+	return output, nil
+}
+
+// addToIPv6Prefix returns the IPv6 address of the n-th /56 prefix after ip.
+func addToIPv6Prefix(ip net.IP, n uint64) net.IP {
+	result := make(net.IP, net.IPv6len)
+	copy(result, ip.To16())
+
+	var prefix [8]byte
+	copy(prefix[1:], result[:7])
+	binary.BigEndian.PutUint64(prefix[:], binary.BigEndian.Uint64(prefix[:])+n)
+	copy(result[:7], prefix[1:])
+
+	return result
+}
@@ -0,0 +1,727 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Client VPN authorization rules and routes have no IDs. They are keyed by
+// their endpoint and the attributes that identify them within it.
+func clientVpnAuthorizationRuleKey(endpointID, targetNetworkCidr, accessGroupID string) string {
+	return strings.Join([]string{endpointID, targetNetworkCidr, accessGroupID}, ",")
+}
+
+func clientVpnRouteKey(endpointID, targetSubnetID, destinationCidr string) string {
+	return strings.Join([]string{endpointID, targetSubnetID, destinationCidr}, ",")
+}
+
+// validateClientVpnCidrBlock returns an error if a CIDR block is not valid
+// for the addresses of Client VPN clients.
+func validateClientVpnCidrBlock(cidrBlock string) error {
+	_, network, err := net.ParseCIDR(cidrBlock)
+
+	if err != nil || network.IP.To4() == nil || network.String() != cidrBlock {
+		return ec2Error("InvalidParameterValue", "Value (%s) for parameter clientCidrBlock is invalid. This is not a valid CIDR block.", cidrBlock)
+	}
+
+	if ones, _ := network.Mask.Size(); ones < 12 || ones > 22 {
+		return ec2Error("InvalidParameterValue", "The client CIDR block %s must have a size between /12 and /22", cidrBlock)
+	}
+
+	return nil
+}
+
+func (e *EC2) clientVpnEndpoint(id string) (*ec2.ClientVpnEndpoint, error) {
+	endpoint, ok := e.clientVpnEndpoints[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidClientVpnEndpointId.NotFound", "Endpoint %s does not exist", id)
+	}
+
+	return endpoint, nil
+}
+
+// clientVpnTargetNetworkIDs returns the association IDs of the target
+// networks of a Client VPN endpoint.
+func (e *EC2) clientVpnTargetNetworkIDs(endpointID string) []string {
+	var ids []string
+
+	for _, id := range sortedKeys(e.clientVpnTargetNetworks) {
+		if aws.StringValue(e.clientVpnTargetNetworks[id].ClientVpnEndpointId) == endpointID {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func (e *EC2) clientVpnSubnetAssociated(endpointID, subnetID string) bool {
+	for _, id := range e.clientVpnTargetNetworkIDs(endpointID) {
+		if aws.StringValue(e.clientVpnTargetNetworks[id].TargetNetworkId) == subnetID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateClientVpnEndpoint creates a Client VPN endpoint, which is pending
+// association until a target network is associated with it.
+func (e *EC2) CreateClientVpnEndpoint(input *ec2.CreateClientVpnEndpointInput) (*ec2.CreateClientVpnEndpointOutput, error) {
+	clientCidrBlock := aws.StringValue(input.ClientCidrBlock)
+
+	if err := validateClientVpnCidrBlock(clientCidrBlock); err != nil {
+		return nil, err
+	}
+
+	if len(input.AuthenticationOptions) == 0 || len(input.AuthenticationOptions) > 2 {
+		return nil, ec2Error("InvalidParameterValue", "Between one and two authentication options must be specified")
+	}
+
+	if input.ConnectionLogOptions == nil {
+		return nil, ec2Error("MissingParameter", "The request must contain the parameter ConnectionLogOptions")
+	}
+
+	endpointID := e.newID("cvpn-endpoint")
+
+	endpoint := &ec2.ClientVpnEndpoint{
+		ClientCidrBlock:      aws.String(clientCidrBlock),
+		ClientVpnEndpointId:  aws.String(endpointID),
+		ConnectionLogOptions: newClientVpnConnectionLogOptions(input.ConnectionLogOptions),
+		CreationTime:         aws.String(time.Now().UTC().Format("2006-01-02T15:04:05")),
+		Description:          input.Description,
+		DnsName:              aws.String(fmt.Sprintf("*.%s.prod.clientvpn.%s.amazonaws.com", endpointID, Region)),
+		DnsServers:           input.DnsServers,
+		SecurityGroupIds:     []*string{},
+		ServerCertificateArn: input.ServerCertificateArn,
+		SplitTunnel:          aws.Bool(aws.BoolValue(input.SplitTunnel)),
+		Status: &ec2.ClientVpnEndpointStatus{
+			Code:    aws.String(ec2.ClientVpnEndpointStatusCodePendingAssociate),
+			Message: aws.String("Pending Target Network Association"),
+		},
+		TransportProtocol: aws.String(ec2.TransportProtocolUdp),
+		VpnPort:           aws.Int64(443),
+		VpnProtocol:       aws.String(ec2.VpnProtocolOpenvpn),
+	}
+
+	if input.TransportProtocol != nil {
+		endpoint.TransportProtocol = input.TransportProtocol
+	}
+
+	if input.VpnPort != nil {
+		endpoint.VpnPort = input.VpnPort
+	}
+
+	for _, option := range input.AuthenticationOptions {
+		authentication := &ec2.ClientVpnAuthentication{
+			Type: option.Type,
+		}
+
+		switch aws.StringValue(option.Type) {
+		case ec2.ClientVpnAuthenticationTypeCertificateAuthentication:
+			if option.MutualAuthentication == nil {
+				return nil, ec2Error("InvalidParameterValue", "Mutual authentication requires a client root certificate chain")
+			}
+
+			authentication.MutualAuthentication = &ec2.CertificateAuthentication{
+				ClientRootCertificateChain: option.MutualAuthentication.ClientRootCertificateChainArn,
+			}
+		case ec2.ClientVpnAuthenticationTypeDirectoryServiceAuthentication:
+			if option.ActiveDirectory == nil {
+				return nil, ec2Error("InvalidParameterValue", "Active Directory authentication requires a directory ID")
+			}
+
+			authentication.ActiveDirectory = &ec2.DirectoryServiceAuthentication{
+				DirectoryId: option.ActiveDirectory.DirectoryId,
+			}
+		case ec2.ClientVpnAuthenticationTypeFederatedAuthentication:
+			if option.FederatedAuthentication == nil {
+				return nil, ec2Error("InvalidParameterValue", "Federated authentication requires a SAML provider ARN")
+			}
+
+			authentication.FederatedAuthentication = &ec2.FederatedAuthentication{
+				SamlProviderArn: option.FederatedAuthentication.SAMLProviderArn,
+			}
+		default:
+			return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter type is invalid.", aws.StringValue(option.Type))
+		}
+
+		endpoint.AuthenticationOptions = append(endpoint.AuthenticationOptions, authentication)
+	}
+
+	e.clientVpnEndpoints[endpointID] = endpoint
+	e.createTags(endpointID, ec2.ResourceTypeClientVpnEndpoint, input.TagSpecifications)
+
+	return &ec2.CreateClientVpnEndpointOutput{
+		ClientVpnEndpointId: aws.String(endpointID),
+		DnsName:             endpoint.DnsName,
+		Status:              endpoint.Status,
+	}, nil
+}
+
+func newClientVpnConnectionLogOptions(options *ec2.ConnectionLogOptions) *ec2.ConnectionLogResponseOptions {
+	response := &ec2.ConnectionLogResponseOptions{
+		Enabled: aws.Bool(aws.BoolValue(options.Enabled)),
+	}
+
+	if aws.BoolValue(options.Enabled) {
+		response.CloudwatchLogGroup = options.CloudwatchLogGroup
+		response.CloudwatchLogStream = options.CloudwatchLogStream
+
+		if response.CloudwatchLogStream == nil {
+			response.CloudwatchLogStream = aws.String(fmt.Sprintf("cvpn-endpoint-%s", time.Now().UTC().Format("20060102150405")))
+		}
+	}
+
+	return response
+}
+
+// DeleteClientVpnEndpoint deletes a Client VPN endpoint with its
+// authorization rules and routes. Target networks must be disassociated first.
+func (e *EC2) DeleteClientVpnEndpoint(input *ec2.DeleteClientVpnEndpointInput) (*ec2.DeleteClientVpnEndpointOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	if len(e.clientVpnTargetNetworkIDs(endpointID)) > 0 {
+		return nil, ec2Error("InvalidClientVpnEndpoint.InvalidState", "Endpoint %s has associated target networks. Disassociate them before deleting the endpoint.", endpointID)
+	}
+
+	for _, key := range sortedKeys(e.clientVpnAuthorizationRules) {
+		if aws.StringValue(e.clientVpnAuthorizationRules[key].ClientVpnEndpointId) == endpointID {
+			delete(e.clientVpnAuthorizationRules, key)
+		}
+	}
+
+	for _, key := range sortedKeys(e.clientVpnRoutes) {
+		if aws.StringValue(e.clientVpnRoutes[key].ClientVpnEndpointId) == endpointID {
+			delete(e.clientVpnRoutes, key)
+		}
+	}
+
+	e.deleteResource(endpointID)
+
+	return &ec2.DeleteClientVpnEndpointOutput{
+		Status: &ec2.ClientVpnEndpointStatus{
+			Code: aws.String(ec2.ClientVpnEndpointStatusCodeDeleting),
+		},
+	}, nil
+}
+
+func (e *EC2) describeClientVpnEndpoint(id string) *ec2.ClientVpnEndpoint {
+	endpoint := awsutil.CopyOf(e.clientVpnEndpoints[id]).(*ec2.ClientVpnEndpoint)
+	endpoint.Tags = e.ec2Tags(id)
+
+	return endpoint
+}
+
+func (e *EC2) clientVpnEndpointFilterValues(id, name string) ([]string, bool) {
+	endpoint := e.clientVpnEndpoints[id]
+
+	switch name {
+	case "endpoint-id":
+		return []string{id}, true
+	case "transport-protocol":
+		return stringFilterValue(endpoint.TransportProtocol), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeClientVpnEndpoints(input *ec2.DescribeClientVpnEndpointsInput) (*ec2.DescribeClientVpnEndpointsOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.clientVpnEndpoints), input.ClientVpnEndpointIds, input.Filters, "InvalidClientVpnEndpointId.NotFound", "Client VPN endpoint", e.clientVpnEndpointFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeClientVpnEndpointsOutput{}
+
+	for _, id := range ids {
+		output.ClientVpnEndpoints = append(output.ClientVpnEndpoints, e.describeClientVpnEndpoint(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) ModifyClientVpnEndpoint(input *ec2.ModifyClientVpnEndpointInput) (*ec2.ModifyClientVpnEndpointOutput, error) {
+	endpoint, err := e.clientVpnEndpoint(aws.StringValue(input.ClientVpnEndpointId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ConnectionLogOptions != nil {
+		endpoint.ConnectionLogOptions = newClientVpnConnectionLogOptions(input.ConnectionLogOptions)
+	}
+
+	if input.Description != nil {
+		endpoint.Description = input.Description
+	}
+
+	if input.DnsServers != nil {
+		endpoint.DnsServers = nil
+
+		if aws.BoolValue(input.DnsServers.Enabled) {
+			endpoint.DnsServers = input.DnsServers.CustomDnsServers
+		}
+	}
+
+	if input.ServerCertificateArn != nil {
+		endpoint.ServerCertificateArn = input.ServerCertificateArn
+	}
+
+	if input.SplitTunnel != nil {
+		endpoint.SplitTunnel = input.SplitTunnel
+	}
+
+	if input.VpnPort != nil {
+		endpoint.VpnPort = input.VpnPort
+	}
+
+	return &ec2.ModifyClientVpnEndpointOutput{
+		Return: aws.Bool(true),
+	}, nil
+}
+
+// AssociateClientVpnTargetNetwork associates a subnet with a Client VPN
+// endpoint, which becomes available, and adds a route to the VPC of the
+// subnet. All target networks of an endpoint must be in the same VPC.
+func (e *EC2) AssociateClientVpnTargetNetwork(input *ec2.AssociateClientVpnTargetNetworkInput) (*ec2.AssociateClientVpnTargetNetworkOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+	endpoint, err := e.clientVpnEndpoint(endpointID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	subnetID := aws.StringValue(subnet.SubnetId)
+	vpcID := aws.StringValue(subnet.VpcId)
+
+	if endpoint.VpcId != nil && aws.StringValue(endpoint.VpcId) != vpcID {
+		return nil, ec2Error("InvalidParameterValue", "Subnet %s is not in VPC %s of endpoint %s", subnetID, aws.StringValue(endpoint.VpcId), endpointID)
+	}
+
+	for _, id := range e.clientVpnTargetNetworkIDs(endpointID) {
+		existing := e.subnets[aws.StringValue(e.clientVpnTargetNetworks[id].TargetNetworkId)]
+
+		if aws.StringValue(existing.SubnetId) == subnetID {
+			return nil, ec2Error("InvalidClientVpnDuplicateAssociationException", "Subnet %s is already associated with endpoint %s", subnetID, endpointID)
+		}
+
+		if aws.StringValue(existing.AvailabilityZone) == aws.StringValue(subnet.AvailabilityZone) {
+			return nil, ec2Error("InvalidClientVpnSubnetId.DuplicateAz", "Endpoint %s already has a target network in %s", endpointID, aws.StringValue(subnet.AvailabilityZone))
+		}
+	}
+
+	if endpoint.VpcId == nil {
+		endpoint.VpcId = aws.String(vpcID)
+
+		if sg := e.defaultSecurityGroup(vpcID); sg != nil && len(endpoint.SecurityGroupIds) == 0 {
+			endpoint.SecurityGroupIds = []*string{sg.GroupId}
+		}
+	}
+
+	associationID := e.newID("cvpn-assoc")
+
+	e.clientVpnTargetNetworks[associationID] = &ec2.TargetNetwork{
+		AssociationId:       aws.String(associationID),
+		ClientVpnEndpointId: aws.String(endpointID),
+		SecurityGroups:      endpoint.SecurityGroupIds,
+		Status: &ec2.AssociationStatus{
+			Code: aws.String(ec2.AssociationStatusCodeAssociated),
+		},
+		TargetNetworkId: aws.String(subnetID),
+		VpcId:           aws.String(vpcID),
+	}
+
+	endpoint.Status = &ec2.ClientVpnEndpointStatus{
+		Code: aws.String(ec2.ClientVpnEndpointStatusCodeAvailable),
+	}
+
+	for _, cidrBlock := range vpcCidrBlocks(e.vpcs[vpcID]) {
+		e.clientVpnRoutes[clientVpnRouteKey(endpointID, subnetID, cidrBlock)] = &ec2.ClientVpnRoute{
+			ClientVpnEndpointId: aws.String(endpointID),
+			Description:         aws.String("Default Route"),
+			DestinationCidr:     aws.String(cidrBlock),
+			Origin:              aws.String("associate"),
+			Status: &ec2.ClientVpnRouteStatus{
+				Code: aws.String(ec2.ClientVpnRouteStatusCodeActive),
+			},
+			TargetSubnet: aws.String(subnetID),
+			Type:         aws.String("Nat"),
+		}
+	}
+
+	return &ec2.AssociateClientVpnTargetNetworkOutput{
+		AssociationId: aws.String(associationID),
+		Status: &ec2.AssociationStatus{
+			Code: aws.String(ec2.AssociationStatusCodeAssociating),
+		},
+	}, nil
+}
+
+// DisassociateClientVpnTargetNetwork disassociates a subnet from a Client
+// VPN endpoint and deletes the routes through it. An endpoint without
+// target networks is pending association again.
+func (e *EC2) DisassociateClientVpnTargetNetwork(input *ec2.DisassociateClientVpnTargetNetworkInput) (*ec2.DisassociateClientVpnTargetNetworkOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+	endpoint, err := e.clientVpnEndpoint(endpointID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	associationID := aws.StringValue(input.AssociationId)
+	network, ok := e.clientVpnTargetNetworks[associationID]
+
+	if !ok || aws.StringValue(network.ClientVpnEndpointId) != endpointID {
+		return nil, ec2Error("InvalidClientVpnAssociationId.NotFound", "Association %s does not exist", associationID)
+	}
+
+	for _, key := range sortedKeys(e.clientVpnRoutes) {
+		route := e.clientVpnRoutes[key]
+
+		if aws.StringValue(route.ClientVpnEndpointId) == endpointID && aws.StringValue(route.TargetSubnet) == aws.StringValue(network.TargetNetworkId) {
+			delete(e.clientVpnRoutes, key)
+		}
+	}
+
+	delete(e.clientVpnTargetNetworks, associationID)
+
+	if len(e.clientVpnTargetNetworkIDs(endpointID)) == 0 {
+		endpoint.Status = &ec2.ClientVpnEndpointStatus{
+			Code:    aws.String(ec2.ClientVpnEndpointStatusCodePendingAssociate),
+			Message: aws.String("Pending Target Network Association"),
+		}
+	}
+
+	return &ec2.DisassociateClientVpnTargetNetworkOutput{
+		AssociationId: aws.String(associationID),
+		Status: &ec2.AssociationStatus{
+			Code: aws.String(ec2.AssociationStatusCodeDisassociating),
+		},
+	}, nil
+}
+
+func (e *EC2) clientVpnTargetNetworkFilterValues(id, name string) ([]string, bool) {
+	network := e.clientVpnTargetNetworks[id]
+
+	switch name {
+	case "association-id":
+		return []string{id}, true
+	case "target-network-id":
+		return stringFilterValue(network.TargetNetworkId), true
+	case "vpc-id":
+		return stringFilterValue(network.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeClientVpnTargetNetworks(input *ec2.DescribeClientVpnTargetNetworksInput) (*ec2.DescribeClientVpnTargetNetworksOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	ids, err := e.selectIDs(e.clientVpnTargetNetworkIDs(endpointID), input.AssociationIds, input.Filters, "InvalidClientVpnAssociationId.NotFound", "Client VPN association", e.clientVpnTargetNetworkFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeClientVpnTargetNetworksOutput{}
+
+	for _, id := range ids {
+		output.ClientVpnTargetNetworks = append(output.ClientVpnTargetNetworks, awsutil.CopyOf(e.clientVpnTargetNetworks[id]).(*ec2.TargetNetwork))
+	}
+
+	return output, nil
+}
+
+// ApplySecurityGroupsToClientVpnTargetNetwork replaces the security groups
+// of the target networks of a Client VPN endpoint in a VPC.
+func (e *EC2) ApplySecurityGroupsToClientVpnTargetNetwork(input *ec2.ApplySecurityGroupsToClientVpnTargetNetworkInput) (*ec2.ApplySecurityGroupsToClientVpnTargetNetworkOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+	endpoint, err := e.clientVpnEndpoint(endpointID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(input.VpcId)
+
+	if aws.StringValue(endpoint.VpcId) != vpcID {
+		return nil, ec2Error("InvalidParameterValue", "Endpoint %s has no target networks in VPC %s", endpointID, vpcID)
+	}
+
+	for _, groupID := range aws.StringValueSlice(input.SecurityGroupIds) {
+		sg, err := e.securityGroup(groupID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if aws.StringValue(sg.VpcId) != vpcID {
+			return nil, ec2Error("InvalidParameterValue", "Security group %s is not in VPC %s", groupID, vpcID)
+		}
+	}
+
+	endpoint.SecurityGroupIds = aws.StringSlice(aws.StringValueSlice(input.SecurityGroupIds))
+
+	for _, id := range e.clientVpnTargetNetworkIDs(endpointID) {
+		e.clientVpnTargetNetworks[id].SecurityGroups = endpoint.SecurityGroupIds
+	}
+
+	return &ec2.ApplySecurityGroupsToClientVpnTargetNetworkOutput{
+		SecurityGroupIds: endpoint.SecurityGroupIds,
+	}, nil
+}
+
+func (e *EC2) AuthorizeClientVpnIngress(input *ec2.AuthorizeClientVpnIngressInput) (*ec2.AuthorizeClientVpnIngressOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	targetNetworkCidr := aws.StringValue(input.TargetNetworkCidr)
+
+	if err := validateCidrBlock(targetNetworkCidr, false); err != nil {
+		return nil, err
+	}
+
+	accessGroupID := aws.StringValue(input.AccessGroupId)
+
+	if (accessGroupID == "") == !aws.BoolValue(input.AuthorizeAllGroups) {
+		return nil, ec2Error("InvalidParameterCombination", "Exactly one of AccessGroupId or AuthorizeAllGroups must be specified")
+	}
+
+	key := clientVpnAuthorizationRuleKey(endpointID, targetNetworkCidr, accessGroupID)
+
+	if _, ok := e.clientVpnAuthorizationRules[key]; ok {
+		return nil, ec2Error("InvalidClientVpnDuplicateAuthorizationRule", "An authorization rule for %s already exists", targetNetworkCidr)
+	}
+
+	rule := &ec2.AuthorizationRule{
+		AccessAll:           aws.Bool(accessGroupID == ""),
+		ClientVpnEndpointId: aws.String(endpointID),
+		Description:         input.Description,
+		DestinationCidr:     aws.String(targetNetworkCidr),
+		GroupId:             aws.String(accessGroupID),
+		Status: &ec2.ClientVpnAuthorizationRuleStatus{
+			Code: aws.String(ec2.ClientVpnAuthorizationRuleStatusCodeActive),
+		},
+	}
+
+	e.clientVpnAuthorizationRules[key] = rule
+
+	return &ec2.AuthorizeClientVpnIngressOutput{
+		Status: &ec2.ClientVpnAuthorizationRuleStatus{
+			Code: aws.String(ec2.ClientVpnAuthorizationRuleStatusCodeAuthorizing),
+		},
+	}, nil
+}
+
+func (e *EC2) RevokeClientVpnIngress(input *ec2.RevokeClientVpnIngressInput) (*ec2.RevokeClientVpnIngressOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	targetNetworkCidr := aws.StringValue(input.TargetNetworkCidr)
+	key := clientVpnAuthorizationRuleKey(endpointID, targetNetworkCidr, aws.StringValue(input.AccessGroupId))
+
+	if _, ok := e.clientVpnAuthorizationRules[key]; !ok {
+		return nil, ec2Error("InvalidClientVpnEndpointAuthorizationRuleNotFound", "Cannot revoke access for the specified authorization rule. No matching authorization rule found for %s", targetNetworkCidr)
+	}
+
+	delete(e.clientVpnAuthorizationRules, key)
+
+	return &ec2.RevokeClientVpnIngressOutput{
+		Status: &ec2.ClientVpnAuthorizationRuleStatus{
+			Code: aws.String(ec2.ClientVpnAuthorizationRuleStatusCodeRevoking),
+		},
+	}, nil
+}
+
+func (e *EC2) clientVpnAuthorizationRuleFilterValues(key, name string) ([]string, bool) {
+	rule := e.clientVpnAuthorizationRules[key]
+
+	switch name {
+	case "description":
+		return stringFilterValue(rule.Description), true
+	case "destination-cidr":
+		return stringFilterValue(rule.DestinationCidr), true
+	case "group-id":
+		return stringFilterValue(rule.GroupId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeClientVpnAuthorizationRules(input *ec2.DescribeClientVpnAuthorizationRulesInput) (*ec2.DescribeClientVpnAuthorizationRulesOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for _, key := range sortedKeys(e.clientVpnAuthorizationRules) {
+		if aws.StringValue(e.clientVpnAuthorizationRules[key].ClientVpnEndpointId) == endpointID {
+			keys = append(keys, key)
+		}
+	}
+
+	keys, err := e.selectIDs(keys, nil, input.Filters, "", "", e.clientVpnAuthorizationRuleFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeClientVpnAuthorizationRulesOutput{}
+
+	for _, key := range keys {
+		output.AuthorizationRules = append(output.AuthorizationRules, awsutil.CopyOf(e.clientVpnAuthorizationRules[key]).(*ec2.AuthorizationRule))
+	}
+
+	return output, nil
+}
+
+// CreateClientVpnRoute adds a route to a Client VPN endpoint through one of
+// its associated target networks.
+func (e *EC2) CreateClientVpnRoute(input *ec2.CreateClientVpnRouteInput) (*ec2.CreateClientVpnRouteOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	destinationCidr := aws.StringValue(input.DestinationCidrBlock)
+
+	if err := validateCidrBlock(destinationCidr, false); err != nil {
+		return nil, err
+	}
+
+	subnetID := aws.StringValue(input.TargetVpcSubnetId)
+
+	if !e.clientVpnSubnetAssociated(endpointID, subnetID) {
+		return nil, ec2Error("InvalidClientVpnActiveAssociationNotFound", "Subnet %s is not associated with endpoint %s", subnetID, endpointID)
+	}
+
+	key := clientVpnRouteKey(endpointID, subnetID, destinationCidr)
+
+	if _, ok := e.clientVpnRoutes[key]; ok {
+		return nil, ec2Error("InvalidClientVpnDuplicateRoute", "A route for %s through %s already exists", destinationCidr, subnetID)
+	}
+
+	e.clientVpnRoutes[key] = &ec2.ClientVpnRoute{
+		ClientVpnEndpointId: aws.String(endpointID),
+		Description:         input.Description,
+		DestinationCidr:     aws.String(destinationCidr),
+		Origin:              aws.String("add-route"),
+		Status: &ec2.ClientVpnRouteStatus{
+			Code: aws.String(ec2.ClientVpnRouteStatusCodeActive),
+		},
+		TargetSubnet: aws.String(subnetID),
+		Type:         aws.String("Nat"),
+	}
+
+	return &ec2.CreateClientVpnRouteOutput{
+		Status: &ec2.ClientVpnRouteStatus{
+			Code: aws.String(ec2.ClientVpnRouteStatusCodeCreating),
+		},
+	}, nil
+}
+
+func (e *EC2) DeleteClientVpnRoute(input *ec2.DeleteClientVpnRouteInput) (*ec2.DeleteClientVpnRouteOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	destinationCidr := aws.StringValue(input.DestinationCidrBlock)
+	key := clientVpnRouteKey(endpointID, aws.StringValue(input.TargetVpcSubnetId), destinationCidr)
+	route, ok := e.clientVpnRoutes[key]
+
+	if !ok {
+		return nil, ec2Error("InvalidClientVpnRouteNotFound", "Route for %s does not exist", destinationCidr)
+	}
+
+	if aws.StringValue(route.Origin) == "associate" {
+		return nil, ec2Error("InvalidParameterValue", "Cannot delete the route for %s created by the target network association", destinationCidr)
+	}
+
+	delete(e.clientVpnRoutes, key)
+
+	return &ec2.DeleteClientVpnRouteOutput{
+		Status: &ec2.ClientVpnRouteStatus{
+			Code: aws.String(ec2.ClientVpnRouteStatusCodeDeleting),
+		},
+	}, nil
+}
+
+func (e *EC2) clientVpnRouteFilterValues(key, name string) ([]string, bool) {
+	route := e.clientVpnRoutes[key]
+
+	switch name {
+	case "destination-cidr":
+		return stringFilterValue(route.DestinationCidr), true
+	case "origin":
+		return stringFilterValue(route.Origin), true
+	case "target-subnet":
+		return stringFilterValue(route.TargetSubnet), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeClientVpnRoutes(input *ec2.DescribeClientVpnRoutesInput) (*ec2.DescribeClientVpnRoutesOutput, error) {
+	endpointID := aws.StringValue(input.ClientVpnEndpointId)
+
+	if _, err := e.clientVpnEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for _, key := range sortedKeys(e.clientVpnRoutes) {
+		if aws.StringValue(e.clientVpnRoutes[key].ClientVpnEndpointId) == endpointID {
+			keys = append(keys, key)
+		}
+	}
+
+	keys, err := e.selectIDs(keys, nil, input.Filters, "", "", e.clientVpnRouteFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeClientVpnRoutesOutput{}
+
+	for _, key := range keys {
+		output.Routes = append(output.Routes, awsutil.CopyOf(e.clientVpnRoutes[key]).(*ec2.ClientVpnRoute))
+	}
+
+	return output, nil
+}
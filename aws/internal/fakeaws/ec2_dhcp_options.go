@@ -0,0 +1,204 @@
+package fakeaws
+
+import (
+	"net"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// defaultDhcpOptionsID is the DHCP options ID of VPCs without a DHCP
+// options set.
+const defaultDhcpOptionsID = "default"
+
+func (e *EC2) dhcpOptions(id string) (*ec2.DhcpOptions, error) {
+	options, ok := e.dhcpOptionsSets[id]
+
+	if !ok || aws.StringValue(options.OwnerId) != e.caller {
+		return nil, ec2Error("InvalidDhcpOptionID.NotFound", "The dhcpOption ID '%s' does not exist", id)
+	}
+
+	return options, nil
+}
+
+// validateDhcpConfiguration validates the values of a DHCP option. Server
+// options take up to four IPv4 addresses; the DNS servers may instead be
+// the Amazon provided DNS server.
+func validateDhcpConfiguration(key string, values []string) error {
+	if len(values) == 0 {
+		return ec2Error("InvalidParameterValue", "Value for %s cannot be empty", key)
+	}
+
+	switch key {
+	case "domain-name":
+		if len(values) > 1 {
+			return ec2Error("InvalidParameterValue", "Only one value is allowed for domain-name")
+		}
+	case "domain-name-servers", "netbios-name-servers", "ntp-servers":
+		if len(values) > 4 {
+			return ec2Error("InvalidParameterValue", "Up to four values are allowed for %s", key)
+		}
+
+		for _, v := range values {
+			if key == "domain-name-servers" && v == "AmazonProvidedDNS" {
+				continue
+			}
+
+			if ip := net.ParseIP(v); ip == nil || ip.To4() == nil {
+				return ec2Error("InvalidParameterValue", "Value (%s) for parameter value is invalid. Invalid IPv4 address.", v)
+			}
+		}
+	case "netbios-node-type":
+		if len(values) > 1 || !strings.Contains(" 1 2 4 8 ", " "+values[0]+" ") {
+			return ec2Error("InvalidParameterValue", "Value (%s) for parameter netbios-node-type is invalid.", strings.Join(values, ","))
+		}
+	default:
+		return ec2Error("InvalidParameterValue", "Value (%s) for parameter key is invalid.", key)
+	}
+
+	return nil
+}
+
+func (e *EC2) CreateDhcpOptions(input *ec2.CreateDhcpOptionsInput) (*ec2.CreateDhcpOptionsOutput, error) {
+	if len(input.DhcpConfigurations) == 0 {
+		return nil, ec2Error("MissingParameter", "The request must contain the parameter dhcpConfiguration")
+	}
+
+	options := &ec2.DhcpOptions{
+		OwnerId: aws.String(e.caller),
+	}
+
+	for _, c := range input.DhcpConfigurations {
+		key := aws.StringValue(c.Key)
+		values := aws.StringValueSlice(c.Values)
+
+		if err := validateDhcpConfiguration(key, values); err != nil {
+			return nil, err
+		}
+
+		configuration := &ec2.DhcpConfiguration{
+			Key: aws.String(key),
+		}
+
+		for _, v := range values {
+			configuration.Values = append(configuration.Values, &ec2.AttributeValue{Value: aws.String(v)})
+		}
+
+		options.DhcpConfigurations = append(options.DhcpConfigurations, configuration)
+	}
+
+	dhcpOptionsID := e.newID("dopt")
+	options.DhcpOptionsId = aws.String(dhcpOptionsID)
+	e.dhcpOptionsSets[dhcpOptionsID] = options
+	e.createTags(dhcpOptionsID, ec2.ResourceTypeDhcpOptions, input.TagSpecifications)
+
+	return &ec2.CreateDhcpOptionsOutput{
+		DhcpOptions: e.describeDhcpOptions(dhcpOptionsID),
+	}, nil
+}
+
+// DeleteDhcpOptions deletes a DHCP options set that no VPC uses.
+func (e *EC2) DeleteDhcpOptions(input *ec2.DeleteDhcpOptionsInput) (*ec2.DeleteDhcpOptionsOutput, error) {
+	dhcpOptionsID := aws.StringValue(input.DhcpOptionsId)
+
+	if _, err := e.dhcpOptions(dhcpOptionsID); err != nil {
+		return nil, err
+	}
+
+	for _, id := range sortedKeys(e.vpcs) {
+		if aws.StringValue(e.vpcs[id].DhcpOptionsId) == dhcpOptionsID {
+			return nil, ec2Error("DependencyViolation", "The dhcpOptions '%s' has dependencies and cannot be deleted.", dhcpOptionsID)
+		}
+	}
+
+	e.deleteResource(dhcpOptionsID)
+
+	return &ec2.DeleteDhcpOptionsOutput{}, nil
+}
+
+// AssociateDhcpOptions associates a DHCP options set with a VPC, or with
+// "default" removes the VPC's DHCP options set.
+func (e *EC2) AssociateDhcpOptions(input *ec2.AssociateDhcpOptionsInput) (*ec2.AssociateDhcpOptionsOutput, error) {
+	dhcpOptionsID := aws.StringValue(input.DhcpOptionsId)
+
+	if dhcpOptionsID != defaultDhcpOptionsID {
+		if _, err := e.dhcpOptions(dhcpOptionsID); err != nil {
+			return nil, err
+		}
+	}
+
+	vpc, err := e.vpc(aws.StringValue(input.VpcId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpc.DhcpOptionsId = aws.String(dhcpOptionsID)
+
+	return &ec2.AssociateDhcpOptionsOutput{}, nil
+}
+
+func (e *EC2) describeDhcpOptions(id string) *ec2.DhcpOptions {
+	options := awsutil.CopyOf(e.dhcpOptionsSets[id]).(*ec2.DhcpOptions)
+	options.Tags = e.ec2Tags(id)
+
+	return options
+}
+
+func (e *EC2) dhcpOptionsFilterValues(id, name string) ([]string, bool) {
+	options := e.dhcpOptionsSets[id]
+
+	switch name {
+	case "dhcp-options-id":
+		return []string{id}, true
+	case "key":
+		var keys []string
+
+		for _, c := range options.DhcpConfigurations {
+			keys = append(keys, aws.StringValue(c.Key))
+		}
+
+		return keys, true
+	case "owner-id":
+		return stringFilterValue(options.OwnerId), true
+	case "value":
+		var values []string
+
+		for _, c := range options.DhcpConfigurations {
+			for _, v := range c.Values {
+				values = append(values, aws.StringValue(v.Value))
+			}
+		}
+
+		return values, true
+	}
+
+	return nil, false
+}
+
+// DescribeDhcpOptions returns the caller's DHCP options sets.
+func (e *EC2) DescribeDhcpOptions(input *ec2.DescribeDhcpOptionsInput) (*ec2.DescribeDhcpOptionsOutput, error) {
+	var all []string
+
+	for _, id := range sortedKeys(e.dhcpOptionsSets) {
+		if aws.StringValue(e.dhcpOptionsSets[id].OwnerId) == e.caller {
+			all = append(all, id)
+		}
+	}
+
+	ids, err := e.selectIDs(all, input.DhcpOptionsIds, input.Filters, "InvalidDhcpOptionID.NotFound", "dhcpOption", e.dhcpOptionsFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeDhcpOptionsOutput{}
+
+	for _, id := range ids {
+		output.DhcpOptions = append(output.DhcpOptions, e.describeDhcpOptions(id))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,294 @@
+package fakeaws
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// defaultFlowLogFormat is the log format of flow logs created without one.
+const defaultFlowLogFormat = "${version} ${account-id} ${interface-id} ${srcaddr} ${dstaddr} ${srcport} ${dstport} ${protocol} ${packets} ${bytes} ${start} ${end} ${action} ${log-status}"
+
+var flowLogFormatFieldRegexp = regexp.MustCompile(`^\$\{[a-z0-9-]+\}$`)
+
+func validateFlowLogFormat(format string) error {
+	fields := strings.Fields(format)
+
+	if len(fields) == 0 {
+		return ec2Error("InvalidParameter", "LogFormat must contain at least one field")
+	}
+
+	for _, field := range fields {
+		if !flowLogFormatFieldRegexp.MatchString(field) {
+			return ec2Error("InvalidParameter", "Unknown fields provided: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// flowLogResource returns an error unless a resource of the given flow log
+// resource type exists.
+func (e *EC2) flowLogResource(resourceType, id string) error {
+	var err error
+
+	switch resourceType {
+	case ec2.FlowLogsResourceTypeNetworkInterface:
+		_, err = e.networkInterface(id)
+	case ec2.FlowLogsResourceTypeSubnet:
+		_, err = e.subnet(id)
+	case ec2.FlowLogsResourceTypeVpc:
+		_, err = e.vpc(id)
+	default:
+		err = ec2Error("InvalidParameterValue", "Value (%s) for parameter resourceType is invalid.", resourceType)
+	}
+
+	return err
+}
+
+// flowLogDestination returns the log destination and log group name of a
+// flow log. Delivery to CloudWatch Logs needs a role to assume, delivery to
+// S3 a bucket ARN and no role.
+func (e *EC2) flowLogDestination(input *ec2.CreateFlowLogsInput, destinationType string) (string, string, error) {
+	switch destinationType {
+	case ec2.LogDestinationTypeCloudWatchLogs:
+		if input.DeliverLogsPermissionArn == nil {
+			return "", "", ec2Error("InvalidParameter", "DeliverLogsPermissionArn can't be empty if LogDestinationType is cloud-watch-logs.")
+		}
+
+		if input.LogDestination == nil {
+			if aws.StringValue(input.LogGroupName) == "" {
+				return "", "", ec2Error("MissingParameter", "Either LogGroupName or LogDestination must be specified.")
+			}
+
+			groupName := aws.StringValue(input.LogGroupName)
+
+			return "arn:aws:logs:" + Region + ":" + e.caller + ":log-group:" + groupName, groupName, nil
+		}
+
+		if input.LogGroupName != nil {
+			return "", "", ec2Error("InvalidParameter", "Please only provide LogGroupName or only provide LogDestination.")
+		}
+
+		destination := aws.StringValue(input.LogDestination)
+		parsed, err := arn.Parse(destination)
+
+		if err != nil || parsed.Service != "logs" || !strings.HasPrefix(parsed.Resource, "log-group:") {
+			return "", "", ec2Error("InvalidParameter", "LogDestination: %s is not a valid CloudWatch Logs log group ARN.", destination)
+		}
+
+		return destination, strings.TrimSuffix(strings.TrimPrefix(parsed.Resource, "log-group:"), ":*"), nil
+	case ec2.LogDestinationTypeS3:
+		if input.DeliverLogsPermissionArn != nil {
+			return "", "", ec2Error("InvalidParameter", "DeliverLogsPermissionArn is not applicable for s3 delivery.")
+		}
+
+		destination := aws.StringValue(input.LogDestination)
+		parsed, err := arn.Parse(destination)
+
+		if err != nil || parsed.Service != "s3" || parsed.Resource == "" {
+			return "", "", ec2Error("InvalidParameter", "LogDestination: %s is not a valid S3 bucket ARN.", destination)
+		}
+
+		return destination, "", nil
+	}
+
+	return "", "", ec2Error("InvalidParameterValue", "Value (%s) for parameter logDestinationType is invalid.", destinationType)
+}
+
+// CreateFlowLogs creates a flow log for each resource. Resources that do not
+// exist or already have a flow log with the same traffic type and
+// destination are reported as unsuccessful.
+func (e *EC2) CreateFlowLogs(input *ec2.CreateFlowLogsInput) (*ec2.CreateFlowLogsOutput, error) {
+	destinationType := ec2.LogDestinationTypeCloudWatchLogs
+
+	if input.LogDestinationType != nil {
+		destinationType = aws.StringValue(input.LogDestinationType)
+	}
+
+	destination, groupName, err := e.flowLogDestination(input, destinationType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch trafficType := aws.StringValue(input.TrafficType); trafficType {
+	case ec2.TrafficTypeAccept, ec2.TrafficTypeAll, ec2.TrafficTypeReject:
+	default:
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter trafficType is invalid.", trafficType)
+	}
+
+	format := defaultFlowLogFormat
+
+	if input.LogFormat != nil {
+		format = aws.StringValue(input.LogFormat)
+
+		if err := validateFlowLogFormat(format); err != nil {
+			return nil, err
+		}
+	}
+
+	interval := int64(600)
+
+	if input.MaxAggregationInterval != nil {
+		interval = aws.Int64Value(input.MaxAggregationInterval)
+
+		if interval != 60 && interval != 600 {
+			return nil, ec2Error("InvalidParameter", "Invalid Flow Log Max Aggregation Interval: %d", interval)
+		}
+	}
+
+	output := &ec2.CreateFlowLogsOutput{
+		Unsuccessful: []*ec2.UnsuccessfulItem{},
+	}
+
+	for _, resourceID := range aws.StringValueSlice(input.ResourceIds) {
+		err := e.flowLogResource(aws.StringValue(input.ResourceType), resourceID)
+
+		for _, id := range sortedKeys(e.flowLogs) {
+			fl := e.flowLogs[id]
+
+			if err == nil && aws.StringValue(fl.ResourceId) == resourceID && aws.StringValue(fl.TrafficType) == aws.StringValue(input.TrafficType) && aws.StringValue(fl.LogDestination) == destination {
+				err = ec2Error("FlowLogAlreadyExists", "There is an existing Flow Log with the same configuration and log destination.")
+			}
+		}
+
+		if err != nil {
+			output.Unsuccessful = append(output.Unsuccessful, &ec2.UnsuccessfulItem{
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String(err.(*Error).Code),
+					Message: aws.String(err.(*Error).Message),
+				},
+				ResourceId: aws.String(resourceID),
+			})
+
+			continue
+		}
+
+		flowLogID := e.newID("fl")
+
+		fl := &ec2.FlowLog{
+			CreationTime:             aws.Time(time.Now().UTC().Truncate(time.Second)),
+			DeliverLogsPermissionArn: input.DeliverLogsPermissionArn,
+			DeliverLogsStatus:        aws.String("SUCCESS"),
+			FlowLogId:                aws.String(flowLogID),
+			FlowLogStatus:            aws.String("ACTIVE"),
+			LogDestination:           aws.String(destination),
+			LogDestinationType:       aws.String(destinationType),
+			LogFormat:                aws.String(format),
+			MaxAggregationInterval:   aws.Int64(interval),
+			ResourceId:               aws.String(resourceID),
+			TrafficType:              input.TrafficType,
+		}
+
+		if groupName != "" {
+			fl.LogGroupName = aws.String(groupName)
+		}
+
+		e.flowLogs[flowLogID] = fl
+		e.createTags(flowLogID, ec2.ResourceTypeVpcFlowLog, input.TagSpecifications)
+
+		output.FlowLogIds = append(output.FlowLogIds, aws.String(flowLogID))
+	}
+
+	return output, nil
+}
+
+// DeleteFlowLogs deletes flow logs, reporting those that do not exist as
+// unsuccessful.
+func (e *EC2) DeleteFlowLogs(input *ec2.DeleteFlowLogsInput) (*ec2.DeleteFlowLogsOutput, error) {
+	output := &ec2.DeleteFlowLogsOutput{
+		Unsuccessful: []*ec2.UnsuccessfulItem{},
+	}
+
+	for _, id := range aws.StringValueSlice(input.FlowLogIds) {
+		if _, ok := e.flowLogs[id]; !ok {
+			output.Unsuccessful = append(output.Unsuccessful, &ec2.UnsuccessfulItem{
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String("InvalidFlowLogId.NotFound"),
+					Message: aws.String("These flow log ids in the input list are not found: [TotalCount: 1] " + id),
+				},
+				ResourceId: aws.String(id),
+			})
+
+			continue
+		}
+
+		e.deleteResource(id)
+	}
+
+	return output, nil
+}
+
+// deleteFlowLogs deletes the flow logs of a deleted VPC, subnet or network
+// interface.
+func (e *EC2) deleteFlowLogs(resourceID string) {
+	for _, id := range sortedKeys(e.flowLogs) {
+		if aws.StringValue(e.flowLogs[id].ResourceId) == resourceID {
+			delete(e.flowLogs, id)
+			delete(e.tags, id)
+		}
+	}
+}
+
+func (e *EC2) describeFlowLog(id string) *ec2.FlowLog {
+	fl := awsutil.CopyOf(e.flowLogs[id]).(*ec2.FlowLog)
+	fl.Tags = e.ec2Tags(id)
+
+	return fl
+}
+
+func (e *EC2) flowLogFilterValues(id, name string) ([]string, bool) {
+	fl := e.flowLogs[id]
+
+	switch name {
+	case "deliver-log-status":
+		return stringFilterValue(fl.DeliverLogsStatus), true
+	case "flow-log-id":
+		return []string{id}, true
+	case "log-destination-type":
+		return stringFilterValue(fl.LogDestinationType), true
+	case "log-group-name":
+		return stringFilterValue(fl.LogGroupName), true
+	case "resource-id":
+		return stringFilterValue(fl.ResourceId), true
+	case "traffic-type":
+		return stringFilterValue(fl.TrafficType), true
+	}
+
+	return nil, false
+}
+
+// DescribeFlowLogs ignores flow log IDs that do not exist, as EC2 does.
+func (e *EC2) DescribeFlowLogs(input *ec2.DescribeFlowLogsInput) (*ec2.DescribeFlowLogsOutput, error) {
+	var flowLogIDs []*string
+
+	for _, id := range aws.StringValueSlice(input.FlowLogIds) {
+		if _, ok := e.flowLogs[id]; ok {
+			flowLogIDs = append(flowLogIDs, aws.String(id))
+		}
+	}
+
+	output := &ec2.DescribeFlowLogsOutput{}
+
+	if len(input.FlowLogIds) > 0 && len(flowLogIDs) == 0 {
+		return output, nil
+	}
+
+	ids, err := e.selectIDs(sortedKeys(e.flowLogs), flowLogIDs, input.Filter, "InvalidFlowLogId.NotFound", "flowLog", e.flowLogFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		output.FlowLogs = append(output.FlowLogs, e.describeFlowLog(id))
+	}
+
+	return output, nil
+}
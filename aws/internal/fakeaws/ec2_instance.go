@@ -0,0 +1,244 @@
+package fakeaws
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// instance is an EC2 instance. Only what is needed to attach network
+// interfaces is implemented: instances are launched running, in a subnet,
+// with a primary network interface, and remain described once terminated.
+type instance struct {
+	instance      *ec2.Instance
+	reservationID string
+}
+
+func (e *EC2) instance(id string) (*ec2.Instance, error) {
+	i, ok := e.instances[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidInstanceID.NotFound", "The instance ID '%s' does not exist", id)
+	}
+
+	return i.instance, nil
+}
+
+// instanceNetworkInterfaces returns the network interfaces attached to an
+// instance, in order of creation.
+func (e *EC2) instanceNetworkInterfaces(instanceID string) []*ec2.NetworkInterface {
+	var enis []*ec2.NetworkInterface
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		eni := e.networkInterfaces[id]
+
+		if eni.Attachment != nil && aws.StringValue(eni.Attachment.InstanceId) == instanceID {
+			enis = append(enis, eni)
+		}
+	}
+
+	return enis
+}
+
+// RunInstances launches instances in a subnet, each with a primary network
+// interface at device index 0 that is deleted on termination.
+func (e *EC2) RunInstances(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	if input.SubnetId == nil {
+		return nil, ec2Error("MissingInput", "The request must contain the parameter subnetId")
+	}
+
+	subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	count := aws.Int64Value(input.MinCount)
+
+	if count < 1 || count > aws.Int64Value(input.MaxCount) {
+		return nil, ec2Error("InvalidParameterValue", "Invalid value '%d' for minCount.", count)
+	}
+
+	reservation := &ec2.Reservation{
+		OwnerId:       aws.String(AccountID),
+		ReservationId: aws.String(e.newID("r")),
+	}
+
+	for n := int64(0); n < count; n++ {
+		eni, err := e.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+			Description: aws.String("Primary network interface"),
+			Groups:      input.SecurityGroupIds,
+			SubnetId:    input.SubnetId,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		instanceID := e.newID("i")
+
+		i := &ec2.Instance{
+			ImageId:          input.ImageId,
+			InstanceId:       aws.String(instanceID),
+			InstanceType:     input.InstanceType,
+			LaunchTime:       aws.Time(time.Now().UTC()),
+			Placement:        &ec2.Placement{AvailabilityZone: subnet.AvailabilityZone},
+			PrivateIpAddress: eni.NetworkInterface.PrivateIpAddress,
+			SecurityGroups:   eni.NetworkInterface.Groups,
+			State: &ec2.InstanceState{
+				Code: aws.Int64(16),
+				Name: aws.String(ec2.InstanceStateNameRunning),
+			},
+			SubnetId: subnet.SubnetId,
+			VpcId:    subnet.VpcId,
+		}
+
+		e.instances[instanceID] = &instance{
+			instance:      i,
+			reservationID: aws.StringValue(reservation.ReservationId),
+		}
+		e.createTags(instanceID, ec2.ResourceTypeInstance, input.TagSpecifications)
+
+		if _, err := e.attachNetworkInterface(e.networkInterfaces[aws.StringValue(eni.NetworkInterface.NetworkInterfaceId)], i, 0, true); err != nil {
+			return nil, err
+		}
+
+		reservation.Instances = append(reservation.Instances, e.describeInstance(instanceID))
+	}
+
+	return reservation, nil
+}
+
+// TerminateInstances terminates instances, deleting the network interfaces
+// marked for deletion on termination and detaching the others.
+func (e *EC2) TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+	for _, id := range aws.StringValueSlice(input.InstanceIds) {
+		if _, err := e.instance(id); err != nil {
+			return nil, err
+		}
+	}
+
+	output := &ec2.TerminateInstancesOutput{}
+
+	for _, id := range aws.StringValueSlice(input.InstanceIds) {
+		i := e.instances[id].instance
+		previousState := awsutil.CopyOf(i.State).(*ec2.InstanceState)
+
+		for _, eni := range e.instanceNetworkInterfaces(id) {
+			deleteOnTermination := aws.BoolValue(eni.Attachment.DeleteOnTermination)
+
+			eni.Attachment = nil
+			eni.Status = aws.String(ec2.NetworkInterfaceStatusAvailable)
+
+			if deleteOnTermination {
+				if _, err := e.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: eni.NetworkInterfaceId}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		i.State = &ec2.InstanceState{
+			Code: aws.Int64(48),
+			Name: aws.String(ec2.InstanceStateNameTerminated),
+		}
+		i.PrivateIpAddress = nil
+
+		output.TerminatingInstances = append(output.TerminatingInstances, &ec2.InstanceStateChange{
+			CurrentState:  i.State,
+			InstanceId:    aws.String(id),
+			PreviousState: previousState,
+		})
+	}
+
+	return output, nil
+}
+
+func (e *EC2) describeInstance(id string) *ec2.Instance {
+	i := awsutil.CopyOf(e.instances[id].instance).(*ec2.Instance)
+	i.Tags = e.ec2Tags(id)
+
+	for _, eni := range e.instanceNetworkInterfaces(id) {
+		i.NetworkInterfaces = append(i.NetworkInterfaces, &ec2.InstanceNetworkInterface{
+			Attachment: &ec2.InstanceNetworkInterfaceAttachment{
+				AttachTime:          eni.Attachment.AttachTime,
+				AttachmentId:        eni.Attachment.AttachmentId,
+				DeleteOnTermination: eni.Attachment.DeleteOnTermination,
+				DeviceIndex:         eni.Attachment.DeviceIndex,
+				Status:              eni.Attachment.Status,
+			},
+			Description:        eni.Description,
+			Groups:             eni.Groups,
+			InterfaceType:      eni.InterfaceType,
+			MacAddress:         eni.MacAddress,
+			NetworkInterfaceId: eni.NetworkInterfaceId,
+			OwnerId:            eni.OwnerId,
+			PrivateIpAddress:   eni.PrivateIpAddress,
+			SourceDestCheck:    eni.SourceDestCheck,
+			Status:             eni.Status,
+			SubnetId:           eni.SubnetId,
+			VpcId:              eni.VpcId,
+		})
+	}
+
+	return i
+}
+
+func (e *EC2) instanceFilterValues(id, name string) ([]string, bool) {
+	i := e.instances[id].instance
+
+	switch name {
+	case "availability-zone":
+		return stringFilterValue(i.Placement.AvailabilityZone), true
+	case "instance-id":
+		return []string{id}, true
+	case "instance-state-name":
+		return stringFilterValue(i.State.Name), true
+	case "network-interface.network-interface-id":
+		var values []string
+
+		for _, eni := range e.instanceNetworkInterfaces(id) {
+			values = append(values, aws.StringValue(eni.NetworkInterfaceId))
+		}
+
+		return values, true
+	case "reservation-id":
+		return []string{e.instances[id].reservationID}, true
+	case "subnet-id":
+		return stringFilterValue(i.SubnetId), true
+	case "vpc-id":
+		return stringFilterValue(i.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.instances), input.InstanceIds, input.Filters, "InvalidInstanceID.NotFound", "instance", e.instanceFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeInstancesOutput{}
+	reservations := make(map[string]*ec2.Reservation)
+
+	for _, id := range ids {
+		reservationID := e.instances[id].reservationID
+		reservation, ok := reservations[reservationID]
+
+		if !ok {
+			reservation = &ec2.Reservation{
+				OwnerId:       aws.String(AccountID),
+				ReservationId: aws.String(reservationID),
+			}
+			reservations[reservationID] = reservation
+			output.Reservations = append(output.Reservations, reservation)
+		}
+
+		reservation.Instances = append(reservation.Instances, e.describeInstance(id))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,186 @@
+package fakeaws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// internetGatewayAttachmentStateAvailable is the state of an attached
+// internet gateway, which differs from the other attachment states.
+const internetGatewayAttachmentStateAvailable = "available"
+
+func (e *EC2) createInternetGateway() *ec2.InternetGateway {
+	internetGatewayID := e.newID("igw")
+
+	igw := &ec2.InternetGateway{
+		Attachments:       []*ec2.InternetGatewayAttachment{},
+		InternetGatewayId: aws.String(internetGatewayID),
+		OwnerId:           aws.String(AccountID),
+	}
+
+	e.internetGateways[internetGatewayID] = igw
+
+	return igw
+}
+
+func (e *EC2) internetGateway(id string) (*ec2.InternetGateway, error) {
+	igw, ok := e.internetGateways[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidInternetGatewayID.NotFound", "The internetGateway ID '%s' does not exist", id)
+	}
+
+	return igw, nil
+}
+
+// internetGatewayAttached returns whether an internet gateway is attached to a VPC.
+func internetGatewayAttached(igw *ec2.InternetGateway, vpcID string) bool {
+	for _, a := range igw.Attachments {
+		if aws.StringValue(a.VpcId) == vpcID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EC2) CreateInternetGateway(input *ec2.CreateInternetGatewayInput) (*ec2.CreateInternetGatewayOutput, error) {
+	igw := e.createInternetGateway()
+
+	return &ec2.CreateInternetGatewayOutput{
+		InternetGateway: e.describeInternetGateway(aws.StringValue(igw.InternetGatewayId)),
+	}, nil
+}
+
+func (e *EC2) AttachInternetGateway(input *ec2.AttachInternetGatewayInput) (*ec2.AttachInternetGatewayOutput, error) {
+	igw, err := e.internetGateway(aws.StringValue(input.InternetGatewayId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	if len(igw.Attachments) > 0 {
+		return nil, ec2Error("Resource.AlreadyAssociated", "resource %s is already attached to network %s", aws.StringValue(igw.InternetGatewayId), aws.StringValue(igw.Attachments[0].VpcId))
+	}
+
+	for _, id := range sortedKeys(e.internetGateways) {
+		if internetGatewayAttached(e.internetGateways[id], vpcID) {
+			return nil, ec2Error("InvalidParameterValue", "Network %s already has an internet gateway attached", vpcID)
+		}
+	}
+
+	igw.Attachments = []*ec2.InternetGatewayAttachment{{
+		State: aws.String(internetGatewayAttachmentStateAvailable),
+		VpcId: aws.String(vpcID),
+	}}
+
+	return &ec2.AttachInternetGatewayOutput{}, nil
+}
+
+func (e *EC2) DetachInternetGateway(input *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
+	internetGatewayID := aws.StringValue(input.InternetGatewayId)
+	igw, err := e.internetGateway(internetGatewayID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(input.VpcId)
+
+	if !internetGatewayAttached(igw, vpcID) {
+		return nil, ec2Error("Gateway.NotAttached", "resource %s is not attached to network %s", internetGatewayID, vpcID)
+	}
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		eni := e.networkInterfaces[id]
+
+		if aws.StringValue(eni.VpcId) == vpcID && eni.Association != nil && eni.Association.PublicIp != nil {
+			return nil, ec2Error("DependencyViolation", "Network %s has some mapped public address(es). Please unmap those public address(es) before detaching the gateway.", vpcID)
+		}
+	}
+
+	igw.Attachments = []*ec2.InternetGatewayAttachment{}
+
+	// Routes to a detached internet gateway become blackholes.
+	for _, id := range sortedKeys(e.routeTables) {
+		for _, route := range e.routeTables[id].Routes {
+			if aws.StringValue(route.GatewayId) == internetGatewayID {
+				route.State = aws.String(ec2.RouteStateBlackhole)
+			}
+		}
+	}
+
+	return &ec2.DetachInternetGatewayOutput{}, nil
+}
+
+func (e *EC2) DeleteInternetGateway(input *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
+	internetGatewayID := aws.StringValue(input.InternetGatewayId)
+	igw, err := e.internetGateway(internetGatewayID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(igw.Attachments) > 0 {
+		return nil, ec2Error("DependencyViolation", "The internetGateway '%s' has dependencies and cannot be deleted.", internetGatewayID)
+	}
+
+	e.deleteResource(internetGatewayID)
+
+	return &ec2.DeleteInternetGatewayOutput{}, nil
+}
+
+func (e *EC2) describeInternetGateway(id string) *ec2.InternetGateway {
+	igw := awsutil.CopyOf(e.internetGateways[id]).(*ec2.InternetGateway)
+	igw.Tags = e.ec2Tags(id)
+
+	return igw
+}
+
+func (e *EC2) internetGatewayFilterValues(id, name string) ([]string, bool) {
+	igw := e.internetGateways[id]
+
+	var values []string
+
+	switch name {
+	case "attachment.state":
+		for _, a := range igw.Attachments {
+			values = append(values, aws.StringValue(a.State))
+		}
+	case "attachment.vpc-id":
+		for _, a := range igw.Attachments {
+			values = append(values, aws.StringValue(a.VpcId))
+		}
+	case "internet-gateway-id":
+		values = []string{id}
+	case "owner-id":
+		values = stringFilterValue(igw.OwnerId)
+	default:
+		return nil, false
+	}
+
+	return values, true
+}
+
+func (e *EC2) DescribeInternetGateways(input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.internetGateways), input.InternetGatewayIds, input.Filters, "InvalidInternetGatewayID.NotFound", "internetGateway", e.internetGatewayFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeInternetGatewaysOutput{}
+
+	for _, id := range ids {
+		output.InternetGateways = append(output.InternetGateways, e.describeInternetGateway(id))
+	}
+
+	return output, nil
+}
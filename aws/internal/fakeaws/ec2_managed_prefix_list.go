@@ -0,0 +1,378 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// managedPrefixList is a customer-managed prefix list. Every modification
+// of its entries creates a new version of the list.
+type managedPrefixList struct {
+	prefixList *ec2.ManagedPrefixList
+
+	// versions are the entries of each version of the list, oldest first.
+	versions [][]*ec2.PrefixListEntry
+}
+
+// awsManagedPrefixListOwnerID is the owner ID of the AWS-managed prefix
+// lists of services with gateway endpoints.
+const awsManagedPrefixListOwnerID = "AWS"
+
+// maxManagedPrefixListEntries is the largest maximum number of entries of a
+// customer-managed prefix list.
+const maxManagedPrefixListEntries = 1000
+
+// awsManagedPrefixLists returns the AWS-managed prefix lists, keyed by ID.
+func awsManagedPrefixLists() map[string]*managedPrefixList {
+	prefixLists := make(map[string]*managedPrefixList)
+
+	for _, svc := range awsVpcEndpointServices {
+		if svc.prefixListID == "" {
+			continue
+		}
+
+		var entries []*ec2.PrefixListEntry
+
+		for _, cidr := range svc.cidrs {
+			entries = append(entries, &ec2.PrefixListEntry{Cidr: aws.String(cidr)})
+		}
+
+		prefixLists[svc.prefixListID] = &managedPrefixList{
+			prefixList: &ec2.ManagedPrefixList{
+				AddressFamily:  aws.String("IPv4"),
+				OwnerId:        aws.String(awsManagedPrefixListOwnerID),
+				PrefixListArn:  aws.String(fmt.Sprintf("arn:aws:ec2:%s:aws:prefix-list/%s", Region, svc.prefixListID)),
+				PrefixListId:   aws.String(svc.prefixListID),
+				PrefixListName: aws.String(awsVpcEndpointServiceName(svc.name)),
+				State:          aws.String(ec2.PrefixListStateCreateComplete),
+			},
+			versions: [][]*ec2.PrefixListEntry{entries},
+		}
+	}
+
+	return prefixLists
+}
+
+// managedPrefixList returns an AWS-managed prefix list or a prefix list of
+// the caller.
+func (e *EC2) managedPrefixList(id string) (*managedPrefixList, error) {
+	if pl, ok := awsManagedPrefixLists()[id]; ok {
+		return pl, nil
+	}
+
+	pl, ok := e.managedPrefixLists[id]
+
+	if !ok || aws.StringValue(pl.prefixList.OwnerId) != e.caller {
+		return nil, ec2Error("InvalidPrefixListID.NotFound", "The prefix list ID '%s' does not exist", id)
+	}
+
+	return pl, nil
+}
+
+// customerManagedPrefixList returns a prefix list of the caller.
+func (e *EC2) customerManagedPrefixList(id string) (*managedPrefixList, error) {
+	pl, err := e.managedPrefixList(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(pl.prefixList.OwnerId) == awsManagedPrefixListOwnerID {
+		return nil, ec2Error("InvalidPrefixListModification", "The prefix list '%s' is managed by AWS and cannot be modified", id)
+	}
+
+	return pl, nil
+}
+
+// managedPrefixListInUse returns whether a prefix list is referenced by
+// security group rules or routes.
+func (e *EC2) managedPrefixListInUse(id string) bool {
+	for _, groupID := range sortedKeys(e.securityGroupRules) {
+		rules := e.securityGroupRules[groupID]
+
+		for _, rule := range append(rules.ingress, rules.egress...) {
+			if rule.peerType == securityGroupRulePeerPrefixList && rule.peer == id {
+				return true
+			}
+		}
+	}
+
+	for _, routeTableID := range sortedKeys(e.routeTables) {
+		for _, route := range e.routeTables[routeTableID].Routes {
+			if aws.StringValue(route.DestinationPrefixListId) == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validatePrefixListEntry validates the CIDR block of an entry against the
+// address family of the list.
+func validatePrefixListEntry(addressFamily, cidr string) error {
+	_, network, err := net.ParseCIDR(cidr)
+
+	if err != nil || network.String() != cidr {
+		return ec2Error("InvalidParameterValue", "Value (%s) for parameter cidr is invalid. This is not a valid CIDR block.", cidr)
+	}
+
+	if (network.IP.To4() != nil) != (addressFamily == "IPv4") {
+		return ec2Error("InvalidParameterValue", "The CIDR '%s' does not match the address family %s of the prefix list.", cidr, addressFamily)
+	}
+
+	return nil
+}
+
+// latestPrefixListEntries returns a copy of the entries of the latest
+// version of a prefix list.
+func latestPrefixListEntries(pl *managedPrefixList) []*ec2.PrefixListEntry {
+	var entries []*ec2.PrefixListEntry
+
+	for _, entry := range pl.versions[len(pl.versions)-1] {
+		entries = append(entries, awsutil.CopyOf(entry).(*ec2.PrefixListEntry))
+	}
+
+	return entries
+}
+
+// addPrefixListEntries adds entries to a list of entries, replacing the
+// description of existing CIDR blocks.
+func addPrefixListEntries(addressFamily string, entries []*ec2.PrefixListEntry, add []*ec2.AddPrefixListEntry) ([]*ec2.PrefixListEntry, error) {
+Add:
+	for _, a := range add {
+		cidr := aws.StringValue(a.Cidr)
+
+		if err := validatePrefixListEntry(addressFamily, cidr); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if aws.StringValue(entry.Cidr) == cidr {
+				entry.Description = a.Description
+				continue Add
+			}
+		}
+
+		entries = append(entries, &ec2.PrefixListEntry{
+			Cidr:        aws.String(cidr),
+			Description: a.Description,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return aws.StringValue(entries[i].Cidr) < aws.StringValue(entries[j].Cidr)
+	})
+
+	return entries, nil
+}
+
+func (e *EC2) CreateManagedPrefixList(input *ec2.CreateManagedPrefixListInput) (*ec2.CreateManagedPrefixListOutput, error) {
+	addressFamily := aws.StringValue(input.AddressFamily)
+
+	if addressFamily != "IPv4" && addressFamily != "IPv6" {
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter addressFamily is invalid.", addressFamily)
+	}
+
+	if aws.StringValue(input.PrefixListName) == "" {
+		return nil, ec2Error("MissingParameter", "The request must contain the parameter prefixListName")
+	}
+
+	maxEntries := aws.Int64Value(input.MaxEntries)
+
+	if maxEntries < 1 || maxEntries > maxManagedPrefixListEntries {
+		return nil, ec2Error("InvalidParameterValue", "Value (%d) for parameter maxEntries is invalid.", maxEntries)
+	}
+
+	entries, err := addPrefixListEntries(addressFamily, nil, input.Entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(entries)) > maxEntries {
+		return nil, ec2Error("InvalidParameterValue", "The number of entries (%d) exceeds the maximum number of entries (%d).", len(entries), maxEntries)
+	}
+
+	prefixListID := e.newID("pl")
+
+	pl := &managedPrefixList{
+		prefixList: &ec2.ManagedPrefixList{
+			AddressFamily:  aws.String(addressFamily),
+			MaxEntries:     aws.Int64(maxEntries),
+			OwnerId:        aws.String(e.caller),
+			PrefixListArn:  aws.String(fmt.Sprintf("arn:aws:ec2:%s:%s:prefix-list/%s", Region, e.caller, prefixListID)),
+			PrefixListId:   aws.String(prefixListID),
+			PrefixListName: input.PrefixListName,
+			State:          aws.String(ec2.PrefixListStateCreateComplete),
+			Version:        aws.Int64(1),
+		},
+		versions: [][]*ec2.PrefixListEntry{entries},
+	}
+
+	e.managedPrefixLists[prefixListID] = pl
+	e.createTags(prefixListID, "prefix-list", input.TagSpecifications)
+
+	return &ec2.CreateManagedPrefixListOutput{
+		PrefixList: e.describeManagedPrefixList(pl),
+	}, nil
+}
+
+// ModifyManagedPrefixList renames a prefix list or modifies its entries,
+// creating a new version. Entries can only be modified in the current
+// version of the list.
+func (e *EC2) ModifyManagedPrefixList(input *ec2.ModifyManagedPrefixListInput) (*ec2.ModifyManagedPrefixListOutput, error) {
+	prefixListID := aws.StringValue(input.PrefixListId)
+	pl, err := e.customerManagedPrefixList(prefixListID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.AddEntries) > 0 || len(input.RemoveEntries) > 0 {
+		if input.CurrentVersion == nil {
+			return nil, ec2Error("MissingParameter", "The request must contain the parameter currentVersion")
+		}
+
+		if version := aws.Int64Value(pl.prefixList.Version); aws.Int64Value(input.CurrentVersion) != version {
+			return nil, ec2Error("PrefixListVersionMismatch", "The prefix list %s has the version %d, not %d.", prefixListID, version, aws.Int64Value(input.CurrentVersion))
+		}
+
+		entries := latestPrefixListEntries(pl)
+
+	Remove:
+		for _, r := range input.RemoveEntries {
+			for i, entry := range entries {
+				if aws.StringValue(entry.Cidr) == aws.StringValue(r.Cidr) {
+					entries = append(entries[:i], entries[i+1:]...)
+					continue Remove
+				}
+			}
+
+			return nil, ec2Error("InvalidPrefixListModification", "The CIDR '%s' does not exist in prefix list %s.", aws.StringValue(r.Cidr), prefixListID)
+		}
+
+		entries, err = addPrefixListEntries(aws.StringValue(pl.prefixList.AddressFamily), entries, input.AddEntries)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if maxEntries := aws.Int64Value(pl.prefixList.MaxEntries); int64(len(entries)) > maxEntries {
+			return nil, ec2Error("InvalidParameterValue", "The number of entries (%d) exceeds the maximum number of entries (%d).", len(entries), maxEntries)
+		}
+
+		pl.versions = append(pl.versions, entries)
+		pl.prefixList.Version = aws.Int64(int64(len(pl.versions)))
+	}
+
+	if input.PrefixListName != nil {
+		pl.prefixList.PrefixListName = input.PrefixListName
+	}
+
+	pl.prefixList.State = aws.String(ec2.PrefixListStateModifyComplete)
+
+	return &ec2.ModifyManagedPrefixListOutput{
+		PrefixList: e.describeManagedPrefixList(pl),
+	}, nil
+}
+
+// DeleteManagedPrefixList deletes a prefix list that no security group rule
+// or route references.
+func (e *EC2) DeleteManagedPrefixList(input *ec2.DeleteManagedPrefixListInput) (*ec2.DeleteManagedPrefixListOutput, error) {
+	prefixListID := aws.StringValue(input.PrefixListId)
+	pl, err := e.customerManagedPrefixList(prefixListID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if e.managedPrefixListInUse(prefixListID) {
+		return nil, ec2Error("DependencyViolation", "The prefix list '%s' is in use and cannot be deleted.", prefixListID)
+	}
+
+	e.deleteResource(prefixListID)
+	pl.prefixList.State = aws.String(ec2.PrefixListStateDeleteComplete)
+
+	return &ec2.DeleteManagedPrefixListOutput{
+		PrefixList: awsutil.CopyOf(pl.prefixList).(*ec2.ManagedPrefixList),
+	}, nil
+}
+
+func (e *EC2) GetManagedPrefixListEntries(input *ec2.GetManagedPrefixListEntriesInput) (*ec2.GetManagedPrefixListEntriesOutput, error) {
+	prefixListID := aws.StringValue(input.PrefixListId)
+	pl, err := e.managedPrefixList(prefixListID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	version := int64(len(pl.versions))
+
+	if input.TargetVersion != nil {
+		version = aws.Int64Value(input.TargetVersion)
+	}
+
+	if version < 1 || version > int64(len(pl.versions)) {
+		return nil, ec2Error("InvalidParameterValue", "The prefix list %s does not have the version %d.", prefixListID, version)
+	}
+
+	output := &ec2.GetManagedPrefixListEntriesOutput{}
+
+	for _, entry := range pl.versions[version-1] {
+		output.Entries = append(output.Entries, awsutil.CopyOf(entry).(*ec2.PrefixListEntry))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) describeManagedPrefixList(pl *managedPrefixList) *ec2.ManagedPrefixList {
+	prefixList := awsutil.CopyOf(pl.prefixList).(*ec2.ManagedPrefixList)
+	prefixList.Tags = e.ec2Tags(aws.StringValue(prefixList.PrefixListId))
+
+	return prefixList
+}
+
+func (e *EC2) DescribeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error) {
+	prefixLists := awsManagedPrefixLists()
+
+	for _, id := range sortedKeys(e.managedPrefixLists) {
+		if pl := e.managedPrefixLists[id]; aws.StringValue(pl.prefixList.OwnerId) == e.caller {
+			prefixLists[id] = pl
+		}
+	}
+
+	values := func(id, name string) ([]string, bool) {
+		prefixList := prefixLists[id].prefixList
+
+		switch name {
+		case "owner-id":
+			return stringFilterValue(prefixList.OwnerId), true
+		case "prefix-list-id":
+			return stringFilterValue(prefixList.PrefixListId), true
+		case "prefix-list-name":
+			return stringFilterValue(prefixList.PrefixListName), true
+		}
+
+		return nil, false
+	}
+
+	ids, err := e.selectIDs(sortedKeys(prefixLists), input.PrefixListIds, input.Filters, "InvalidPrefixListID.NotFound", "prefix list", values)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeManagedPrefixListsOutput{}
+
+	for _, id := range ids {
+		output.PrefixLists = append(output.PrefixLists, e.describeManagedPrefixList(prefixLists[id]))
+	}
+
+	return output, nil
+}
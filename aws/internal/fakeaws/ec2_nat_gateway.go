@@ -0,0 +1,196 @@
+package fakeaws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func (e *EC2) natGateway(id string) (*ec2.NatGateway, error) {
+	ngw, ok := e.natGateways[id]
+
+	if !ok || aws.StringValue(ngw.State) == ec2.NatGatewayStateDeleted {
+		return nil, ec2Error("NatGatewayNotFound", "The Nat Gateway %s was not found", id)
+	}
+
+	return ngw, nil
+}
+
+// createTags adds the tags of the tag specifications for a resource type to
+// a new resource.
+func (e *EC2) createTags(id, resourceType string, specifications []*ec2.TagSpecification) {
+	key := e.tagKey(id)
+
+	for _, specification := range specifications {
+		if aws.StringValue(specification.ResourceType) != resourceType {
+			continue
+		}
+
+		for _, tag := range specification.Tags {
+			if e.tags[key] == nil {
+				e.tags[key] = make(map[string]string)
+			}
+
+			e.tags[key][aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+}
+
+// CreateNatGateway creates a NAT gateway with a network interface in the
+// subnet, mapped to the allocated address. A NAT gateway in a VPC without an
+// internet gateway fails.
+func (e *EC2) CreateNatGateway(input *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
+	subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := e.address(aws.StringValue(input.AllocationId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if address.AssociationId != nil {
+		return nil, ec2Error("Resource.AlreadyAssociated", "Elastic IP address [%s] is already associated", aws.StringValue(address.AllocationId))
+	}
+
+	natGatewayID := e.newID("nat")
+	vpcID := aws.StringValue(subnet.VpcId)
+
+	ngw := &ec2.NatGateway{
+		CreateTime:          aws.Time(time.Now().UTC().Truncate(time.Second)),
+		NatGatewayAddresses: []*ec2.NatGatewayAddress{},
+		NatGatewayId:        aws.String(natGatewayID),
+		State:               aws.String(ec2.NatGatewayStateAvailable),
+		SubnetId:            subnet.SubnetId,
+		VpcId:               subnet.VpcId,
+	}
+
+	if e.internetGatewayAttachedToVpc(vpcID) {
+		output, err := e.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+			Description: aws.String(fmt.Sprintf("Interface for NAT Gateway %s", natGatewayID)),
+			Groups:      aws.StringSlice([]string{}),
+			SubnetId:    subnet.SubnetId,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		eni := e.networkInterfaces[aws.StringValue(output.NetworkInterface.NetworkInterfaceId)]
+		eni.Groups = []*ec2.GroupIdentifier{}
+		eni.InterfaceType = aws.String("nat_gateway")
+		eni.RequesterId = aws.String("AWS")
+		eni.RequesterManaged = aws.Bool(true)
+		eni.SourceDestCheck = aws.Bool(false)
+
+		e.associateAddress(address, eni, aws.StringValue(eni.PrivateIpAddress))
+
+		ngw.NatGatewayAddresses = append(ngw.NatGatewayAddresses, &ec2.NatGatewayAddress{
+			AllocationId:       address.AllocationId,
+			NetworkInterfaceId: eni.NetworkInterfaceId,
+			PrivateIp:          eni.PrivateIpAddress,
+			PublicIp:           address.PublicIp,
+		})
+	} else {
+		ngw.FailureCode = aws.String("Gateway.NotAttached")
+		ngw.FailureMessage = aws.String(fmt.Sprintf("Network %s has no Internet gateway attached", vpcID))
+		ngw.State = aws.String(ec2.NatGatewayStateFailed)
+	}
+
+	e.natGateways[natGatewayID] = ngw
+	e.createTags(natGatewayID, ec2.ResourceTypeNatgateway, input.TagSpecifications)
+
+	return &ec2.CreateNatGatewayOutput{
+		ClientToken: input.ClientToken,
+		NatGateway:  e.describeNatGateway(natGatewayID),
+	}, nil
+}
+
+// DeleteNatGateway deletes the network interface of a NAT gateway and
+// releases its address. Routes to the NAT gateway become blackholes and
+// deleted NAT gateways remain visible.
+func (e *EC2) DeleteNatGateway(input *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
+	natGatewayID := aws.StringValue(input.NatGatewayId)
+	ngw, err := e.natGateway(natGatewayID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range ngw.NatGatewayAddresses {
+		networkInterfaceID := aws.StringValue(a.NetworkInterfaceId)
+
+		if eni, ok := e.networkInterfaces[networkInterfaceID]; ok {
+			e.disassociateNetworkInterfaceAddresses(networkInterfaceID)
+			e.deleteResource(networkInterfaceID)
+			e.updateAvailableIPAddressCount(e.subnets[aws.StringValue(eni.SubnetId)])
+		}
+	}
+
+	for _, id := range sortedKeys(e.routeTables) {
+		for _, route := range e.routeTables[id].Routes {
+			if aws.StringValue(route.NatGatewayId) == natGatewayID {
+				route.State = aws.String(ec2.RouteStateBlackhole)
+			}
+		}
+	}
+
+	ngw.DeleteTime = aws.Time(time.Now().UTC().Truncate(time.Second))
+	ngw.State = aws.String(ec2.NatGatewayStateDeleted)
+
+	return &ec2.DeleteNatGatewayOutput{
+		NatGatewayId: aws.String(natGatewayID),
+	}, nil
+}
+
+func (e *EC2) describeNatGateway(id string) *ec2.NatGateway {
+	ngw := awsutil.CopyOf(e.natGateways[id]).(*ec2.NatGateway)
+	ngw.Tags = e.ec2Tags(id)
+
+	return ngw
+}
+
+func (e *EC2) natGatewayFilterValues(id, name string) ([]string, bool) {
+	ngw := e.natGateways[id]
+
+	switch name {
+	case "nat-gateway-id":
+		return []string{id}, true
+	case "state":
+		return stringFilterValue(ngw.State), true
+	case "subnet-id":
+		return stringFilterValue(ngw.SubnetId), true
+	case "vpc-id":
+		return stringFilterValue(ngw.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeNatGateways(input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	for _, id := range aws.StringValueSlice(input.NatGatewayIds) {
+		if _, ok := e.natGateways[id]; !ok {
+			return nil, ec2Error("NatGatewayNotFound", "NAT gateway %s was not found", id)
+		}
+	}
+
+	ids, err := e.selectIDs(sortedKeys(e.natGateways), input.NatGatewayIds, input.Filter, "NatGatewayNotFound", "NAT gateway", e.natGatewayFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeNatGatewaysOutput{}
+
+	for _, id := range ids {
+		output.NatGateways = append(output.NatGateways, e.describeNatGateway(id))
+	}
+
+	return output, nil
+}
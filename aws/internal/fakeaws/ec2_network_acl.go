@@ -0,0 +1,410 @@
+package fakeaws
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	networkAclDefaultRuleNumberIpv4 = 32767
+	networkAclDefaultRuleNumberIpv6 = 32768
+)
+
+// networkAclProtocols maps the protocol names accepted by the EC2 API to
+// the protocol numbers returned in network ACL entries.
+var networkAclProtocols = map[string]string{
+	"all":    "-1",
+	"icmp":   "1",
+	"icmpv6": "58",
+	"tcp":    "6",
+	"udp":    "17",
+}
+
+// createNetworkAcl creates a network ACL. A default network ACL allows all
+// traffic; other network ACLs deny all traffic.
+func (e *EC2) createNetworkAcl(vpcID string, isDefault bool) *ec2.NetworkAcl {
+	networkAclID := e.newID("acl")
+	vpc := e.vpcs[vpcID]
+
+	acl := &ec2.NetworkAcl{
+		Associations: []*ec2.NetworkAclAssociation{},
+		IsDefault:    aws.Bool(isDefault),
+		NetworkAclId: aws.String(networkAclID),
+		OwnerId:      aws.String(AccountID),
+		VpcId:        aws.String(vpcID),
+	}
+
+	for _, egress := range []bool{false, true} {
+		if isDefault {
+			acl.Entries = append(acl.Entries, &ec2.NetworkAclEntry{
+				CidrBlock:  aws.String("0.0.0.0/0"),
+				Egress:     aws.Bool(egress),
+				Protocol:   aws.String("-1"),
+				RuleAction: aws.String(ec2.RuleActionAllow),
+				RuleNumber: aws.Int64(100),
+			})
+		}
+
+		acl.Entries = append(acl.Entries, &ec2.NetworkAclEntry{
+			CidrBlock:  aws.String("0.0.0.0/0"),
+			Egress:     aws.Bool(egress),
+			Protocol:   aws.String("-1"),
+			RuleAction: aws.String(ec2.RuleActionDeny),
+			RuleNumber: aws.Int64(networkAclDefaultRuleNumberIpv4),
+		})
+
+		if len(vpcIpv6CidrBlocks(vpc)) == 0 {
+			continue
+		}
+
+		if isDefault {
+			acl.Entries = append(acl.Entries, &ec2.NetworkAclEntry{
+				Egress:        aws.Bool(egress),
+				Ipv6CidrBlock: aws.String("::/0"),
+				Protocol:      aws.String("-1"),
+				RuleAction:    aws.String(ec2.RuleActionAllow),
+				RuleNumber:    aws.Int64(101),
+			})
+		}
+
+		acl.Entries = append(acl.Entries, &ec2.NetworkAclEntry{
+			Egress:        aws.Bool(egress),
+			Ipv6CidrBlock: aws.String("::/0"),
+			Protocol:      aws.String("-1"),
+			RuleAction:    aws.String(ec2.RuleActionDeny),
+			RuleNumber:    aws.Int64(networkAclDefaultRuleNumberIpv6),
+		})
+	}
+
+	e.networkAcls[networkAclID] = acl
+
+	return acl
+}
+
+// defaultNetworkAcl returns the default network ACL of a VPC.
+func (e *EC2) defaultNetworkAcl(vpcID string) *ec2.NetworkAcl {
+	for _, id := range sortedKeys(e.networkAcls) {
+		acl := e.networkAcls[id]
+
+		if aws.StringValue(acl.VpcId) == vpcID && aws.BoolValue(acl.IsDefault) {
+			return acl
+		}
+	}
+
+	return nil
+}
+
+func (e *EC2) networkAcl(id string) (*ec2.NetworkAcl, error) {
+	acl, ok := e.networkAcls[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidNetworkAclID.NotFound", "The network ACL ID '%s' does not exist", id)
+	}
+
+	return acl, nil
+}
+
+// findNetworkAclEntry returns the index of an entry in a network ACL.
+func findNetworkAclEntry(acl *ec2.NetworkAcl, ruleNumber int64, egress bool) (int, bool) {
+	for i, entry := range acl.Entries {
+		if aws.Int64Value(entry.RuleNumber) == ruleNumber && aws.BoolValue(entry.Egress) == egress {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// newNetworkAclEntry returns a network ACL entry from the arguments of a
+// CreateNetworkAclEntry or ReplaceNetworkAclEntry request.
+func newNetworkAclEntry(input *ec2.CreateNetworkAclEntryInput) (*ec2.NetworkAclEntry, error) {
+	ruleNumber := aws.Int64Value(input.RuleNumber)
+
+	if ruleNumber < 1 || ruleNumber > 32766 {
+		return nil, ec2Error("InvalidParameterValue", "Value (%d) for parameter ruleNumber is invalid. Rule numbers must be between 1 and 32766.", ruleNumber)
+	}
+
+	protocol := strings.ToLower(aws.StringValue(input.Protocol))
+
+	if v, ok := networkAclProtocols[protocol]; ok {
+		protocol = v
+	}
+
+	if n, err := strconv.Atoi(protocol); err != nil || n < -1 || n > 255 {
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter protocol is invalid.", aws.StringValue(input.Protocol))
+	}
+
+	ruleAction := aws.StringValue(input.RuleAction)
+
+	if ruleAction != ec2.RuleActionAllow && ruleAction != ec2.RuleActionDeny {
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter ruleAction is invalid.", ruleAction)
+	}
+
+	if (input.CidrBlock == nil) == (input.Ipv6CidrBlock == nil) {
+		return nil, ec2Error("InvalidParameterCombination", "Exactly one of cidrBlock or ipv6CidrBlock must be specified")
+	}
+
+	if input.CidrBlock != nil {
+		if err := validateCidrBlock(aws.StringValue(input.CidrBlock), false); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Ipv6CidrBlock != nil {
+		if err := validateCidrBlock(aws.StringValue(input.Ipv6CidrBlock), true); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &ec2.NetworkAclEntry{
+		CidrBlock:     input.CidrBlock,
+		Egress:        aws.Bool(aws.BoolValue(input.Egress)),
+		Ipv6CidrBlock: input.Ipv6CidrBlock,
+		Protocol:      aws.String(protocol),
+		RuleAction:    aws.String(ruleAction),
+		RuleNumber:    aws.Int64(ruleNumber),
+	}
+
+	switch protocol {
+	case "6", "17":
+		if input.PortRange == nil || input.PortRange.From == nil || input.PortRange.To == nil {
+			return nil, ec2Error("InvalidParameterValue", "The request must contain the parameter portRange for protocol %s", protocol)
+		}
+
+		entry.PortRange = &ec2.PortRange{
+			From: input.PortRange.From,
+			To:   input.PortRange.To,
+		}
+	case "1", "58":
+		if input.IcmpTypeCode == nil {
+			return nil, ec2Error("InvalidParameterValue", "The request must contain the parameter icmpTypeCode for protocol %s", protocol)
+		}
+
+		entry.IcmpTypeCode = &ec2.IcmpTypeCode{
+			Code: input.IcmpTypeCode.Code,
+			Type: input.IcmpTypeCode.Type,
+		}
+	}
+
+	return entry, nil
+}
+
+// sortNetworkAclEntries sorts network ACL entries egress first, then by rule number.
+func sortNetworkAclEntries(entries []*ec2.NetworkAclEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if aws.BoolValue(entries[i].Egress) != aws.BoolValue(entries[j].Egress) {
+			return aws.BoolValue(entries[i].Egress)
+		}
+
+		return aws.Int64Value(entries[i].RuleNumber) < aws.Int64Value(entries[j].RuleNumber)
+	})
+}
+
+func (e *EC2) CreateNetworkAcl(input *ec2.CreateNetworkAclInput) (*ec2.CreateNetworkAclOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	acl := e.createNetworkAcl(vpcID, false)
+
+	return &ec2.CreateNetworkAclOutput{
+		NetworkAcl: e.describeNetworkAcl(aws.StringValue(acl.NetworkAclId)),
+	}, nil
+}
+
+func (e *EC2) DeleteNetworkAcl(input *ec2.DeleteNetworkAclInput) (*ec2.DeleteNetworkAclOutput, error) {
+	networkAclID := aws.StringValue(input.NetworkAclId)
+	acl, err := e.networkAcl(networkAclID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.BoolValue(acl.IsDefault) {
+		return nil, ec2Error("InvalidParameterValue", "cannot delete default network ACL %s", networkAclID)
+	}
+
+	if len(acl.Associations) > 0 {
+		return nil, ec2Error("DependencyViolation", "The networkAcl '%s' has dependencies and cannot be deleted.", networkAclID)
+	}
+
+	e.deleteResource(networkAclID)
+
+	return &ec2.DeleteNetworkAclOutput{}, nil
+}
+
+func (e *EC2) describeNetworkAcl(id string) *ec2.NetworkAcl {
+	acl := awsutil.CopyOf(e.networkAcls[id]).(*ec2.NetworkAcl)
+	acl.Tags = e.ec2Tags(id)
+	sortNetworkAclEntries(acl.Entries)
+
+	return acl
+}
+
+func (e *EC2) networkAclFilterValues(id, name string) ([]string, bool) {
+	acl := e.networkAcls[id]
+
+	var associationValue func(a *ec2.NetworkAclAssociation) *string
+
+	switch name {
+	case "association.association-id":
+		associationValue = func(a *ec2.NetworkAclAssociation) *string { return a.NetworkAclAssociationId }
+	case "association.network-acl-id":
+		associationValue = func(a *ec2.NetworkAclAssociation) *string { return a.NetworkAclId }
+	case "association.subnet-id":
+		associationValue = func(a *ec2.NetworkAclAssociation) *string { return a.SubnetId }
+	case "default":
+		return boolFilterValue(acl.IsDefault), true
+	case "network-acl-id":
+		return []string{id}, true
+	case "owner-id":
+		return stringFilterValue(acl.OwnerId), true
+	case "vpc-id":
+		return stringFilterValue(acl.VpcId), true
+	default:
+		return nil, false
+	}
+
+	var values []string
+
+	for _, a := range acl.Associations {
+		values = append(values, stringFilterValue(associationValue(a))...)
+	}
+
+	return values, true
+}
+
+func (e *EC2) DescribeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.networkAcls), input.NetworkAclIds, input.Filters, "InvalidNetworkAclID.NotFound", "network ACL", e.networkAclFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeNetworkAclsOutput{}
+
+	for _, id := range ids {
+		output.NetworkAcls = append(output.NetworkAcls, e.describeNetworkAcl(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) CreateNetworkAclEntry(input *ec2.CreateNetworkAclEntryInput) (*ec2.CreateNetworkAclEntryOutput, error) {
+	acl, err := e.networkAcl(aws.StringValue(input.NetworkAclId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newNetworkAclEntry(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := findNetworkAclEntry(acl, aws.Int64Value(entry.RuleNumber), aws.BoolValue(entry.Egress)); ok {
+		return nil, ec2Error("NetworkAclEntryAlreadyExists", "The network acl entry identified by %d already exists.", aws.Int64Value(entry.RuleNumber))
+	}
+
+	acl.Entries = append(acl.Entries, entry)
+
+	return &ec2.CreateNetworkAclEntryOutput{}, nil
+}
+
+func (e *EC2) ReplaceNetworkAclEntry(input *ec2.ReplaceNetworkAclEntryInput) (*ec2.ReplaceNetworkAclEntryOutput, error) {
+	acl, err := e.networkAcl(aws.StringValue(input.NetworkAclId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	createInput := &ec2.CreateNetworkAclEntryInput{}
+	awsutil.Copy(createInput, input)
+
+	entry, err := newNetworkAclEntry(createInput)
+
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := findNetworkAclEntry(acl, aws.Int64Value(entry.RuleNumber), aws.BoolValue(entry.Egress))
+
+	if !ok {
+		return nil, ec2Error("InvalidNetworkAclEntry.NotFound", "The network acl entry identified by %d does not exist.", aws.Int64Value(entry.RuleNumber))
+	}
+
+	acl.Entries[i] = entry
+
+	return &ec2.ReplaceNetworkAclEntryOutput{}, nil
+}
+
+func (e *EC2) DeleteNetworkAclEntry(input *ec2.DeleteNetworkAclEntryInput) (*ec2.DeleteNetworkAclEntryOutput, error) {
+	acl, err := e.networkAcl(aws.StringValue(input.NetworkAclId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	ruleNumber := aws.Int64Value(input.RuleNumber)
+
+	if ruleNumber == networkAclDefaultRuleNumberIpv4 || ruleNumber == networkAclDefaultRuleNumberIpv6 {
+		return nil, ec2Error("InvalidParameterValue", "Value (%d) for parameter ruleNumber is invalid. Default rules cannot be deleted.", ruleNumber)
+	}
+
+	i, ok := findNetworkAclEntry(acl, ruleNumber, aws.BoolValue(input.Egress))
+
+	if !ok {
+		return nil, ec2Error("InvalidNetworkAclEntry.NotFound", "The network acl entry identified by %d does not exist.", ruleNumber)
+	}
+
+	acl.Entries = append(acl.Entries[:i], acl.Entries[i+1:]...)
+
+	return &ec2.DeleteNetworkAclEntryOutput{}, nil
+}
+
+func (e *EC2) ReplaceNetworkAclAssociation(input *ec2.ReplaceNetworkAclAssociationInput) (*ec2.ReplaceNetworkAclAssociationOutput, error) {
+	acl, err := e.networkAcl(aws.StringValue(input.NetworkAclId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	associationID := aws.StringValue(input.AssociationId)
+
+	for _, id := range sortedKeys(e.networkAcls) {
+		existing := e.networkAcls[id]
+
+		for i, a := range existing.Associations {
+			if aws.StringValue(a.NetworkAclAssociationId) != associationID {
+				continue
+			}
+
+			if aws.StringValue(existing.VpcId) != aws.StringValue(acl.VpcId) {
+				return nil, ec2Error("InvalidParameterValue", "Network ACL %s and association %s belong to different networks", aws.StringValue(acl.NetworkAclId), associationID)
+			}
+
+			existing.Associations = append(existing.Associations[:i], existing.Associations[i+1:]...)
+
+			association := &ec2.NetworkAclAssociation{
+				NetworkAclAssociationId: aws.String(e.newID("aclassoc")),
+				NetworkAclId:            acl.NetworkAclId,
+				SubnetId:                a.SubnetId,
+			}
+			acl.Associations = append(acl.Associations, association)
+
+			return &ec2.ReplaceNetworkAclAssociationOutput{
+				NewAssociationId: association.NetworkAclAssociationId,
+			}, nil
+		}
+	}
+
+	return nil, ec2Error("InvalidAssociationID.NotFound", "The association ID '%s' does not exist", associationID)
+}
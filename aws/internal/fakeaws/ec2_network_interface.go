@@ -0,0 +1,775 @@
+package fakeaws
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func (e *EC2) networkInterface(id string) (*ec2.NetworkInterface, error) {
+	eni, ok := e.networkInterfaces[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '%s' does not exist", id)
+	}
+
+	return eni, nil
+}
+
+// privateIPAddressesInUse returns the private IP addresses of all network
+// interfaces in a subnet.
+func (e *EC2) privateIPAddressesInUse(subnetID string) map[string]bool {
+	inUse := make(map[string]bool)
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		eni := e.networkInterfaces[id]
+
+		if aws.StringValue(eni.SubnetId) != subnetID {
+			continue
+		}
+
+		for _, a := range eni.PrivateIpAddresses {
+			inUse[aws.StringValue(a.PrivateIpAddress)] = true
+		}
+	}
+
+	return inUse
+}
+
+// allocatePrivateIPAddress returns the first free private IP address in a
+// subnet. The first four addresses and the last address are reserved.
+func (e *EC2) allocatePrivateIPAddress(subnet *ec2.Subnet, inUse map[string]bool) (string, error) {
+	_, network, _ := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+	ones, bits := network.Mask.Size()
+	base := binary.BigEndian.Uint32(network.IP.To4())
+	size := uint32(1) << uint(bits-ones)
+
+	for offset := uint32(4); offset < size-1; offset++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, base+offset)
+
+		if !inUse[ip.String()] {
+			return ip.String(), nil
+		}
+	}
+
+	return "", ec2Error("InsufficientFreeAddressesInSubnet", "The specified subnet %s does not have enough free addresses to satisfy the request.", aws.StringValue(subnet.SubnetId))
+}
+
+// validatePrivateIPAddress validates an explicitly requested private IP address.
+func validatePrivateIPAddress(subnet *ec2.Subnet, address string, inUse map[string]bool) error {
+	ip := net.ParseIP(address)
+
+	if ip == nil || ip.To4() == nil {
+		return ec2Error("InvalidParameterValue", "Value (%s) for parameter privateIpAddress is invalid.", address)
+	}
+
+	if !cidrContains(aws.StringValue(subnet.CidrBlock), address+"/32") {
+		return ec2Error("InvalidParameterValue", "Address does not fall within the subnet's address range")
+	}
+
+	if inUse[address] {
+		return ec2Error("InvalidIPAddress.InUse", "The specified address is already in use.")
+	}
+
+	return nil
+}
+
+func privateDNSName(address string) string {
+	return fmt.Sprintf("ip-%s.%s.compute.internal", strings.Replace(address, ".", "-", -1), Region)
+}
+
+func (e *EC2) assignPrivateIPAddresses(eni *ec2.NetworkInterface, subnet *ec2.Subnet, addresses []string, count int64, primary bool) error {
+	inUse := e.privateIPAddressesInUse(aws.StringValue(subnet.SubnetId))
+
+	for _, address := range addresses {
+		if err := validatePrivateIPAddress(subnet, address, inUse); err != nil {
+			return err
+		}
+
+		inUse[address] = true
+	}
+
+	for i := int64(0); i < count; i++ {
+		address, err := e.allocatePrivateIPAddress(subnet, inUse)
+
+		if err != nil {
+			return err
+		}
+
+		addresses = append(addresses, address)
+		inUse[address] = true
+	}
+
+	for i, address := range addresses {
+		eni.PrivateIpAddresses = append(eni.PrivateIpAddresses, &ec2.NetworkInterfacePrivateIpAddress{
+			Primary:          aws.Bool(primary && i == 0),
+			PrivateDnsName:   aws.String(privateDNSName(address)),
+			PrivateIpAddress: aws.String(address),
+		})
+	}
+
+	e.updateAvailableIPAddressCount(subnet)
+
+	return nil
+}
+
+func (e *EC2) updateAvailableIPAddressCount(subnet *ec2.Subnet) {
+	_, network, _ := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+	ones, bits := network.Mask.Size()
+	inUse := e.privateIPAddressesInUse(aws.StringValue(subnet.SubnetId))
+
+	subnet.AvailableIpAddressCount = aws.Int64(int64(1)<<uint(bits-ones) - 5 - int64(len(inUse)))
+}
+
+// ipv6AddressesInUse returns the IPv6 addresses of all network interfaces
+// in a subnet.
+func (e *EC2) ipv6AddressesInUse(subnetID string) map[string]bool {
+	inUse := make(map[string]bool)
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		eni := e.networkInterfaces[id]
+
+		if aws.StringValue(eni.SubnetId) != subnetID {
+			continue
+		}
+
+		for _, a := range eni.Ipv6Addresses {
+			inUse[aws.StringValue(a.Ipv6Address)] = true
+		}
+	}
+
+	return inUse
+}
+
+// allocateIpv6Address returns the first free IPv6 address in a subnet's IPv6
+// CIDR block. As with IPv4, the first four addresses are reserved.
+func allocateIpv6Address(subnet *ec2.Subnet, inUse map[string]bool) (string, error) {
+	cidrBlocks := subnetIpv6CidrBlocks(subnet)
+
+	if len(cidrBlocks) == 0 {
+		return "", ec2Error("InvalidParameterValue", "The subnet %s does not have an IPv6 CIDR block.", aws.StringValue(subnet.SubnetId))
+	}
+
+	_, network, _ := net.ParseCIDR(cidrBlocks[0])
+	base := binary.BigEndian.Uint64(network.IP[8:])
+
+	for offset := uint64(4); offset < 1<<16; offset++ {
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, network.IP[:8])
+		binary.BigEndian.PutUint64(ip[8:], base+offset)
+
+		if !inUse[ip.String()] {
+			return ip.String(), nil
+		}
+	}
+
+	return "", ec2Error("InsufficientFreeAddressesInSubnet", "The specified subnet %s does not have enough free addresses to satisfy the request.", aws.StringValue(subnet.SubnetId))
+}
+
+// validateIpv6Address validates an explicitly requested IPv6 address and
+// returns it in canonical form.
+func validateIpv6Address(subnet *ec2.Subnet, address string, inUse map[string]bool) (string, error) {
+	ip := net.ParseIP(address)
+
+	if ip == nil || ip.To4() != nil {
+		return "", ec2Error("InvalidParameterValue", "Value (%s) for parameter ipv6Addresses is invalid.", address)
+	}
+
+	address = ip.String()
+	var inRange bool
+
+	for _, cidrBlock := range subnetIpv6CidrBlocks(subnet) {
+		if cidrContains(cidrBlock, address+"/128") {
+			inRange = true
+		}
+	}
+
+	if !inRange {
+		return "", ec2Error("InvalidParameterValue", "Address does not fall within the subnet's address range")
+	}
+
+	if inUse[address] {
+		return "", ec2Error("InvalidIPAddress.InUse", "The specified address is already in use.")
+	}
+
+	return address, nil
+}
+
+// assignIpv6Addresses assigns the given IPv6 addresses and count more from
+// the subnet to a network interface, returning the assigned addresses.
+func (e *EC2) assignIpv6Addresses(eni *ec2.NetworkInterface, subnet *ec2.Subnet, addresses []string, count int64) ([]string, error) {
+	inUse := e.ipv6AddressesInUse(aws.StringValue(subnet.SubnetId))
+	var assigned []string
+
+	for _, address := range addresses {
+		address, err := validateIpv6Address(subnet, address, inUse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		assigned = append(assigned, address)
+		inUse[address] = true
+	}
+
+	for i := int64(0); i < count; i++ {
+		address, err := allocateIpv6Address(subnet, inUse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		assigned = append(assigned, address)
+		inUse[address] = true
+	}
+
+	for _, address := range assigned {
+		eni.Ipv6Addresses = append(eni.Ipv6Addresses, &ec2.NetworkInterfaceIpv6Address{
+			Ipv6Address: aws.String(address),
+		})
+	}
+
+	return assigned, nil
+}
+
+func (e *EC2) groupIdentifiers(groupIDs []string, vpcID string) ([]*ec2.GroupIdentifier, error) {
+	var groups []*ec2.GroupIdentifier
+
+	for _, groupID := range groupIDs {
+		sg, err := e.securityGroup(groupID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if aws.StringValue(sg.VpcId) != vpcID {
+			return nil, ec2Error("InvalidParameter", "Security group %s and subnet belong to different networks.", groupID)
+		}
+
+		groups = append(groups, &ec2.GroupIdentifier{
+			GroupId:   sg.GroupId,
+			GroupName: sg.GroupName,
+		})
+	}
+
+	return groups, nil
+}
+
+func (e *EC2) CreateNetworkInterface(input *ec2.CreateNetworkInterfaceInput) (*ec2.CreateNetworkInterfaceOutput, error) {
+	subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(subnet.VpcId)
+	groupIDs := aws.StringValueSlice(input.Groups)
+
+	if len(groupIDs) == 0 {
+		if sg := e.defaultSecurityGroup(vpcID); sg != nil {
+			groupIDs = []string{aws.StringValue(sg.GroupId)}
+		}
+	}
+
+	groups, err := e.groupIdentifiers(groupIDs, vpcID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+
+	if input.PrivateIpAddress != nil {
+		addresses = append(addresses, aws.StringValue(input.PrivateIpAddress))
+	}
+
+	for _, a := range input.PrivateIpAddresses {
+		address := aws.StringValue(a.PrivateIpAddress)
+
+		if contains(addresses, address) {
+			continue
+		}
+
+		if aws.BoolValue(a.Primary) {
+			addresses = append([]string{address}, addresses...)
+		} else {
+			addresses = append(addresses, address)
+		}
+	}
+
+	count := aws.Int64Value(input.SecondaryPrivateIpAddressCount)
+
+	if len(addresses) == 0 {
+		count++
+	}
+
+	var ipv6Addresses []string
+
+	for _, a := range input.Ipv6Addresses {
+		ipv6Addresses = append(ipv6Addresses, aws.StringValue(a.Ipv6Address))
+	}
+
+	if len(ipv6Addresses) > 0 && input.Ipv6AddressCount != nil {
+		return nil, ec2Error("InvalidParameterCombination", "Only one of ipv6Addresses or ipv6AddressCount may be specified")
+	}
+
+	ipv6Count := aws.Int64Value(input.Ipv6AddressCount)
+
+	// A subnet assigning IPv6 addresses on creation assigns one to network
+	// interfaces not requesting any.
+	if len(ipv6Addresses) == 0 && input.Ipv6AddressCount == nil && aws.BoolValue(subnet.AssignIpv6AddressOnCreation) {
+		ipv6Count = 1
+	}
+
+	interfaceType := ec2.NetworkInterfaceTypeInterface
+
+	if input.InterfaceType != nil {
+		if v := aws.StringValue(input.InterfaceType); v != ec2.NetworkInterfaceCreationTypeEfa {
+			return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter interfaceType is invalid.", v)
+		}
+
+		interfaceType = ec2.NetworkInterfaceTypeEfa
+	}
+
+	networkInterfaceID := e.newID("eni")
+
+	eni := &ec2.NetworkInterface{
+		AvailabilityZone:   subnet.AvailabilityZone,
+		Description:        aws.String(aws.StringValue(input.Description)),
+		Groups:             groups,
+		InterfaceType:      aws.String(interfaceType),
+		MacAddress:         aws.String(fmt.Sprintf("02:00:00:%02x:%02x:%02x", byte(e.ids["eni"]>>16), byte(e.ids["eni"]>>8), byte(e.ids["eni"]))),
+		NetworkInterfaceId: aws.String(networkInterfaceID),
+		OwnerId:            aws.String(AccountID),
+		RequesterManaged:   aws.Bool(false),
+		SourceDestCheck:    aws.Bool(true),
+		Status:             aws.String(ec2.NetworkInterfaceStatusAvailable),
+		SubnetId:           subnet.SubnetId,
+		VpcId:              subnet.VpcId,
+	}
+
+	e.networkInterfaces[networkInterfaceID] = eni
+
+	if err := e.assignPrivateIPAddresses(eni, subnet, addresses, count, true); err != nil {
+		delete(e.networkInterfaces, networkInterfaceID)
+
+		return nil, err
+	}
+
+	if len(ipv6Addresses) > 0 || ipv6Count > 0 {
+		if _, err := e.assignIpv6Addresses(eni, subnet, ipv6Addresses, ipv6Count); err != nil {
+			delete(e.networkInterfaces, networkInterfaceID)
+			e.updateAvailableIPAddressCount(subnet)
+
+			return nil, err
+		}
+	}
+
+	eni.PrivateIpAddress = eni.PrivateIpAddresses[0].PrivateIpAddress
+	eni.PrivateDnsName = eni.PrivateIpAddresses[0].PrivateDnsName
+
+	return &ec2.CreateNetworkInterfaceOutput{
+		NetworkInterface: e.describeNetworkInterface(networkInterfaceID),
+	}, nil
+}
+
+func (e *EC2) DeleteNetworkInterface(input *ec2.DeleteNetworkInterfaceInput) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	networkInterfaceID := aws.StringValue(input.NetworkInterfaceId)
+	eni, err := e.networkInterface(networkInterfaceID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if eni.Attachment != nil {
+		return nil, ec2Error("InvalidNetworkInterface.InUse", "Interface: [%s] in use.", networkInterfaceID)
+	}
+
+	e.disassociateNetworkInterfaceAddresses(networkInterfaceID)
+	e.deleteResource(networkInterfaceID)
+	e.updateAvailableIPAddressCount(e.subnets[aws.StringValue(eni.SubnetId)])
+
+	return &ec2.DeleteNetworkInterfaceOutput{}, nil
+}
+
+func (e *EC2) describeNetworkInterface(id string) *ec2.NetworkInterface {
+	eni := awsutil.CopyOf(e.networkInterfaces[id]).(*ec2.NetworkInterface)
+	eni.TagSet = e.ec2Tags(id)
+
+	return eni
+}
+
+func (e *EC2) networkInterfaceFilterValues(id, name string) ([]string, bool) {
+	eni := e.networkInterfaces[id]
+
+	switch name {
+	case "addresses.private-ip-address", "private-ip-address":
+		var values []string
+
+		for _, a := range eni.PrivateIpAddresses {
+			values = append(values, aws.StringValue(a.PrivateIpAddress))
+		}
+
+		return values, true
+	case "attachment.attachment-id":
+		if eni.Attachment == nil {
+			return nil, true
+		}
+
+		return stringFilterValue(eni.Attachment.AttachmentId), true
+	case "attachment.instance-id":
+		if eni.Attachment == nil {
+			return nil, true
+		}
+
+		return stringFilterValue(eni.Attachment.InstanceId), true
+	case "availability-zone":
+		return stringFilterValue(eni.AvailabilityZone), true
+	case "description":
+		return stringFilterValue(eni.Description), true
+	case "group-id":
+		var values []string
+
+		for _, g := range eni.Groups {
+			values = append(values, aws.StringValue(g.GroupId))
+		}
+
+		return values, true
+	case "group-name":
+		var values []string
+
+		for _, g := range eni.Groups {
+			values = append(values, aws.StringValue(g.GroupName))
+		}
+
+		return values, true
+	case "interface-type":
+		return stringFilterValue(eni.InterfaceType), true
+	case "ipv6-addresses.ipv6-address":
+		var values []string
+
+		for _, a := range eni.Ipv6Addresses {
+			values = append(values, aws.StringValue(a.Ipv6Address))
+		}
+
+		return values, true
+	case "mac-address":
+		return stringFilterValue(eni.MacAddress), true
+	case "network-interface-id":
+		return []string{id}, true
+	case "owner-id":
+		return stringFilterValue(eni.OwnerId), true
+	case "requester-managed":
+		return boolFilterValue(eni.RequesterManaged), true
+	case "source-dest-check":
+		return boolFilterValue(eni.SourceDestCheck), true
+	case "status":
+		return stringFilterValue(eni.Status), true
+	case "subnet-id":
+		return stringFilterValue(eni.SubnetId), true
+	case "vpc-id":
+		return stringFilterValue(eni.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.networkInterfaces), input.NetworkInterfaceIds, input.Filters, "InvalidNetworkInterfaceID.NotFound", "networkInterface", e.networkInterfaceFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeNetworkInterfacesOutput{}
+
+	for _, id := range ids {
+		output.NetworkInterfaces = append(output.NetworkInterfaces, e.describeNetworkInterface(id))
+	}
+
+	return output, nil
+}
+
+// attachNetworkInterface attaches a network interface to an instance.
+func (e *EC2) attachNetworkInterface(eni *ec2.NetworkInterface, instance *ec2.Instance, deviceIndex int64, deleteOnTermination bool) (string, error) {
+	networkInterfaceID := aws.StringValue(eni.NetworkInterfaceId)
+	instanceID := aws.StringValue(instance.InstanceId)
+
+	if eni.Attachment != nil {
+		return "", ec2Error("InvalidNetworkInterface.InUse", "Interface: [%s] in use.", networkInterfaceID)
+	}
+
+	if state := aws.StringValue(instance.State.Name); state != ec2.InstanceStateNameRunning && state != ec2.InstanceStateNameStopped {
+		return "", ec2Error("IncorrectInstanceState", "The instance '%s' is not in a valid state for this operation.", instanceID)
+	}
+
+	if aws.StringValue(eni.AvailabilityZone) != aws.StringValue(instance.Placement.AvailabilityZone) {
+		return "", ec2Error("InvalidParameterCombination", "You may not attach a network interface to an instance if they are not in the same availability zone")
+	}
+
+	for _, attached := range e.instanceNetworkInterfaces(instanceID) {
+		if aws.Int64Value(attached.Attachment.DeviceIndex) == deviceIndex {
+			return "", ec2Error("InvalidParameterValue", "Instance '%s' already has an interface attached at device index '%d'.", instanceID, deviceIndex)
+		}
+	}
+
+	attachmentID := e.newID("eni-attach")
+
+	eni.Attachment = &ec2.NetworkInterfaceAttachment{
+		AttachTime:          aws.Time(time.Now().UTC()),
+		AttachmentId:        aws.String(attachmentID),
+		DeleteOnTermination: aws.Bool(deleteOnTermination),
+		DeviceIndex:         aws.Int64(deviceIndex),
+		InstanceId:          aws.String(instanceID),
+		InstanceOwnerId:     aws.String(AccountID),
+		Status:              aws.String(ec2.AttachmentStatusAttached),
+	}
+	eni.Status = aws.String(ec2.NetworkInterfaceStatusInUse)
+
+	return attachmentID, nil
+}
+
+func (e *EC2) AttachNetworkInterface(input *ec2.AttachNetworkInterfaceInput) (*ec2.AttachNetworkInterfaceOutput, error) {
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := e.instance(aws.StringValue(input.InstanceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID, err := e.attachNetworkInterface(eni, instance, aws.Int64Value(input.DeviceIndex), false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ec2.AttachNetworkInterfaceOutput{
+		AttachmentId: aws.String(attachmentID),
+	}, nil
+}
+
+func (e *EC2) DetachNetworkInterface(input *ec2.DetachNetworkInterfaceInput) (*ec2.DetachNetworkInterfaceOutput, error) {
+	attachmentID := aws.StringValue(input.AttachmentId)
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		eni := e.networkInterfaces[id]
+
+		if eni.Attachment != nil && aws.StringValue(eni.Attachment.AttachmentId) == attachmentID {
+			eni.Attachment = nil
+			eni.Status = aws.String(ec2.NetworkInterfaceStatusAvailable)
+
+			return &ec2.DetachNetworkInterfaceOutput{}, nil
+		}
+	}
+
+	return nil, ec2Error("InvalidAttachmentID.NotFound", "The attachment ID '%s' does not exist", attachmentID)
+}
+
+func (e *EC2) ModifyNetworkInterfaceAttribute(input *ec2.ModifyNetworkInterfaceAttributeInput) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var n int
+
+	if input.Attachment != nil {
+		n++
+	}
+
+	if input.Description != nil {
+		n++
+	}
+
+	if input.Groups != nil {
+		n++
+	}
+
+	if input.SourceDestCheck != nil {
+		n++
+	}
+
+	if n != 1 {
+		return nil, ec2Error("InvalidParameterCombination", "Fields for multiple attribute types specified")
+	}
+
+	switch {
+	case input.Attachment != nil:
+		if eni.Attachment == nil || aws.StringValue(eni.Attachment.AttachmentId) != aws.StringValue(input.Attachment.AttachmentId) {
+			return nil, ec2Error("InvalidAttachmentID.NotFound", "The attachment ID '%s' does not exist", aws.StringValue(input.Attachment.AttachmentId))
+		}
+
+		eni.Attachment.DeleteOnTermination = aws.Bool(aws.BoolValue(input.Attachment.DeleteOnTermination))
+	case input.Description != nil:
+		eni.Description = aws.String(aws.StringValue(input.Description.Value))
+	case input.Groups != nil:
+		groups, err := e.groupIdentifiers(aws.StringValueSlice(input.Groups), aws.StringValue(eni.VpcId))
+
+		if err != nil {
+			return nil, err
+		}
+
+		eni.Groups = groups
+	case input.SourceDestCheck != nil:
+		eni.SourceDestCheck = aws.Bool(aws.BoolValue(input.SourceDestCheck.Value))
+	}
+
+	return &ec2.ModifyNetworkInterfaceAttributeOutput{}, nil
+}
+
+func (e *EC2) AssignPrivateIpAddresses(input *ec2.AssignPrivateIpAddressesInput) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.PrivateIpAddresses) > 0 && input.SecondaryPrivateIpAddressCount != nil {
+		return nil, ec2Error("InvalidParameterCombination", "Only one of privateIpAddresses or secondaryPrivateIpAddressCount may be specified")
+	}
+
+	assigned := make(map[string]bool)
+
+	for _, a := range eni.PrivateIpAddresses {
+		assigned[aws.StringValue(a.PrivateIpAddress)] = true
+	}
+
+	// Addresses already assigned to the network interface are ignored.
+	var addresses []string
+
+	for _, address := range aws.StringValueSlice(input.PrivateIpAddresses) {
+		if !assigned[address] {
+			addresses = append(addresses, address)
+		}
+	}
+
+	n := len(eni.PrivateIpAddresses)
+
+	if err := e.assignPrivateIPAddresses(eni, e.subnets[aws.StringValue(eni.SubnetId)], addresses, aws.Int64Value(input.SecondaryPrivateIpAddressCount), false); err != nil {
+		return nil, err
+	}
+
+	output := &ec2.AssignPrivateIpAddressesOutput{
+		NetworkInterfaceId: eni.NetworkInterfaceId,
+	}
+
+	for _, a := range eni.PrivateIpAddresses[n:] {
+		output.AssignedPrivateIpAddresses = append(output.AssignedPrivateIpAddresses, &ec2.AssignedPrivateIpAddress{
+			PrivateIpAddress: a.PrivateIpAddress,
+		})
+	}
+
+	return output, nil
+}
+
+func (e *EC2) UnassignPrivateIpAddresses(input *ec2.UnassignPrivateIpAddressesInput) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range aws.StringValueSlice(input.PrivateIpAddresses) {
+		var found bool
+
+		for i, a := range eni.PrivateIpAddresses {
+			if aws.StringValue(a.PrivateIpAddress) != address {
+				continue
+			}
+
+			if aws.BoolValue(a.Primary) {
+				return nil, ec2Error("InvalidParameterValue", "The primary private IP address %s cannot be unassigned.", address)
+			}
+
+			eni.PrivateIpAddresses = append(eni.PrivateIpAddresses[:i], eni.PrivateIpAddresses[i+1:]...)
+			found = true
+
+			break
+		}
+
+		if !found {
+			return nil, ec2Error("InvalidParameterValue", "Some of the specified addresses are not assigned to interface %s", aws.StringValue(eni.NetworkInterfaceId))
+		}
+	}
+
+	e.updateAvailableIPAddressCount(e.subnets[aws.StringValue(eni.SubnetId)])
+
+	return &ec2.UnassignPrivateIpAddressesOutput{}, nil
+}
+
+func (e *EC2) AssignIpv6Addresses(input *ec2.AssignIpv6AddressesInput) (*ec2.AssignIpv6AddressesOutput, error) {
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.Ipv6Addresses) > 0 && input.Ipv6AddressCount != nil {
+		return nil, ec2Error("InvalidParameterCombination", "Only one of ipv6Addresses or ipv6AddressCount may be specified")
+	}
+
+	assigned, err := e.assignIpv6Addresses(eni, e.subnets[aws.StringValue(eni.SubnetId)], aws.StringValueSlice(input.Ipv6Addresses), aws.Int64Value(input.Ipv6AddressCount))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ec2.AssignIpv6AddressesOutput{
+		AssignedIpv6Addresses: aws.StringSlice(assigned),
+		NetworkInterfaceId:    eni.NetworkInterfaceId,
+	}, nil
+}
+
+func (e *EC2) UnassignIpv6Addresses(input *ec2.UnassignIpv6AddressesInput) (*ec2.UnassignIpv6AddressesOutput, error) {
+	eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := append([]*ec2.NetworkInterfaceIpv6Address{}, eni.Ipv6Addresses...)
+	var unassigned []string
+
+	for _, address := range aws.StringValueSlice(input.Ipv6Addresses) {
+		var found bool
+
+		if ip := net.ParseIP(address); ip != nil {
+			address = ip.String()
+		}
+
+		for i, a := range remaining {
+			if aws.StringValue(a.Ipv6Address) == address {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, ec2Error("InvalidParameterValue", "Some of the specified addresses are not assigned to interface %s", aws.StringValue(eni.NetworkInterfaceId))
+		}
+
+		unassigned = append(unassigned, address)
+	}
+
+	eni.Ipv6Addresses = remaining
+
+	return &ec2.UnassignIpv6AddressesOutput{
+		NetworkInterfaceId:      eni.NetworkInterfaceId,
+		UnassignedIpv6Addresses: aws.StringSlice(unassigned),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package fakeaws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// optInRegions are the regions of the aws partition that accounts must opt
+// in to. No account has opted in to any of them.
+var optInRegions = map[string]bool{
+	endpoints.AfSouth1RegionID: true,
+	endpoints.ApEast1RegionID:  true,
+	endpoints.EuSouth1RegionID: true,
+	endpoints.MeSouth1RegionID: true,
+}
+
+func describeRegion(name string) *ec2.Region {
+	optInStatus := "opt-in-not-required"
+
+	if optInRegions[name] {
+		optInStatus = "not-opted-in"
+	}
+
+	return &ec2.Region{
+		Endpoint:    aws.String(fmt.Sprintf("ec2.%s.amazonaws.com", name)),
+		OptInStatus: aws.String(optInStatus),
+		RegionName:  aws.String(name),
+	}
+}
+
+func regionFilterValues(name, filter string) ([]string, bool) {
+	region := describeRegion(name)
+
+	switch filter {
+	case "endpoint":
+		return stringFilterValue(region.Endpoint), true
+	case "opt-in-status":
+		return stringFilterValue(region.OptInStatus), true
+	case "region-name":
+		return stringFilterValue(region.RegionName), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeRegions(input *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
+	regions := endpoints.AwsPartition().Regions()
+
+	for _, name := range aws.StringValueSlice(input.RegionNames) {
+		if _, ok := regions[name]; !ok {
+			return nil, ec2Error("InvalidParameterValue", "Invalid region: [%s]", name)
+		}
+	}
+
+	ids, err := e.selectIDs(sortedKeys(regions), input.RegionNames, input.Filters, "InvalidParameterValue", "region", regionFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeRegionsOutput{}
+
+	for _, name := range ids {
+		if optInRegions[name] && !aws.BoolValue(input.AllRegions) {
+			continue
+		}
+
+		output.Regions = append(output.Regions, describeRegion(name))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,592 @@
+package fakeaws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// createRouteTable creates a route table with local routes for the CIDR
+// blocks of its VPC.
+func (e *EC2) createRouteTable(vpcID string, main bool) *ec2.RouteTable {
+	routeTableID := e.newID("rtb")
+	vpc := e.vpcs[vpcID]
+
+	rt := &ec2.RouteTable{
+		Associations:    []*ec2.RouteTableAssociation{},
+		OwnerId:         aws.String(AccountID),
+		PropagatingVgws: []*ec2.PropagatingVgw{},
+		RouteTableId:    aws.String(routeTableID),
+		VpcId:           aws.String(vpcID),
+	}
+
+	for _, cidrBlock := range append(vpcCidrBlocks(vpc), vpcIpv6CidrBlocks(vpc)...) {
+		rt.Routes = append(rt.Routes, localRoute(cidrBlock))
+	}
+
+	if main {
+		rt.Associations = append(rt.Associations, &ec2.RouteTableAssociation{
+			AssociationState: &ec2.RouteTableAssociationState{
+				State: aws.String(ec2.RouteTableAssociationStateCodeAssociated),
+			},
+			Main:                    aws.Bool(true),
+			RouteTableAssociationId: aws.String(e.newID("rtbassoc")),
+			RouteTableId:            aws.String(routeTableID),
+		})
+	}
+
+	e.routeTables[routeTableID] = rt
+
+	return rt
+}
+
+func localRoute(cidrBlock string) *ec2.Route {
+	route := &ec2.Route{
+		GatewayId: aws.String("local"),
+		Origin:    aws.String(ec2.RouteOriginCreateRouteTable),
+		State:     aws.String(ec2.RouteStateActive),
+	}
+
+	if strings.Contains(cidrBlock, ":") {
+		route.DestinationIpv6CidrBlock = aws.String(cidrBlock)
+	} else {
+		route.DestinationCidrBlock = aws.String(cidrBlock)
+	}
+
+	return route
+}
+
+func removeLocalRoute(routes []*ec2.Route, cidrBlock string) []*ec2.Route {
+	var remaining []*ec2.Route
+
+	for _, route := range routes {
+		if aws.StringValue(route.GatewayId) == "local" && routeDestination(route) == cidrBlock {
+			continue
+		}
+
+		remaining = append(remaining, route)
+	}
+
+	return remaining
+}
+
+// routeDestination returns the destination CIDR block or prefix list of a route.
+func routeDestination(route *ec2.Route) string {
+	switch {
+	case route.DestinationCidrBlock != nil:
+		return aws.StringValue(route.DestinationCidrBlock)
+	case route.DestinationIpv6CidrBlock != nil:
+		return aws.StringValue(route.DestinationIpv6CidrBlock)
+	}
+
+	return aws.StringValue(route.DestinationPrefixListId)
+}
+
+func isMainRouteTable(rt *ec2.RouteTable) bool {
+	for _, a := range rt.Associations {
+		if aws.BoolValue(a.Main) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mainRouteTable returns the main route table of a VPC.
+func (e *EC2) mainRouteTable(vpcID string) *ec2.RouteTable {
+	for _, id := range sortedKeys(e.routeTables) {
+		rt := e.routeTables[id]
+
+		if aws.StringValue(rt.VpcId) == vpcID && isMainRouteTable(rt) {
+			return rt
+		}
+	}
+
+	return nil
+}
+
+func (e *EC2) routeTable(id string) (*ec2.RouteTable, error) {
+	rt, ok := e.routeTables[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidRouteTableID.NotFound", "The routeTable ID '%s' does not exist", id)
+	}
+
+	return rt, nil
+}
+
+// routeTableAssociation returns the route table and association with the given ID.
+func (e *EC2) routeTableAssociation(associationID string) (*ec2.RouteTable, int, error) {
+	for _, id := range sortedKeys(e.routeTables) {
+		rt := e.routeTables[id]
+
+		for i, a := range rt.Associations {
+			if aws.StringValue(a.RouteTableAssociationId) == associationID {
+				return rt, i, nil
+			}
+		}
+	}
+
+	return nil, 0, ec2Error("InvalidAssociationID.NotFound", "The association ID '%s' does not exist", associationID)
+}
+
+// newRoute returns a route from the destination and target of a CreateRoute
+// or ReplaceRoute request.
+func (e *EC2) newRoute(rt *ec2.RouteTable, input *ec2.CreateRouteInput) (*ec2.Route, error) {
+	route := &ec2.Route{
+		DestinationCidrBlock:     input.DestinationCidrBlock,
+		DestinationIpv6CidrBlock: input.DestinationIpv6CidrBlock,
+		DestinationPrefixListId:  input.DestinationPrefixListId,
+		Origin:                   aws.String(ec2.RouteOriginCreateRoute),
+		State:                    aws.String(ec2.RouteStateActive),
+	}
+
+	var destinations int
+
+	for _, destination := range []*string{input.DestinationCidrBlock, input.DestinationIpv6CidrBlock, input.DestinationPrefixListId} {
+		if destination != nil {
+			destinations++
+		}
+	}
+
+	if destinations != 1 {
+		return nil, ec2Error("InvalidParameterCombination", "The parameter destinationCidrBlock cannot be used with the parameter destinationIpv6CidrBlock")
+	}
+
+	if input.DestinationCidrBlock != nil {
+		if err := validateCidrBlock(aws.StringValue(input.DestinationCidrBlock), false); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.DestinationIpv6CidrBlock != nil {
+		if err := validateCidrBlock(aws.StringValue(input.DestinationIpv6CidrBlock), true); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.DestinationPrefixListId != nil {
+		if _, err := e.managedPrefixList(aws.StringValue(input.DestinationPrefixListId)); err != nil {
+			return nil, err
+		}
+	}
+
+	vpcID := aws.StringValue(rt.VpcId)
+
+	switch {
+	case input.GatewayId != nil:
+		gatewayID := aws.StringValue(input.GatewayId)
+		igw, ok := e.internetGateways[gatewayID]
+
+		if !ok {
+			return nil, ec2Error("InvalidGatewayID.NotFound", "The gateway ID '%s' does not exist", gatewayID)
+		}
+
+		if !internetGatewayAttached(igw, vpcID) {
+			return nil, ec2Error("InvalidParameterValue", "route table %s and network gateway %s belong to different networks", aws.StringValue(rt.RouteTableId), gatewayID)
+		}
+
+		route.GatewayId = input.GatewayId
+	case input.NetworkInterfaceId != nil:
+		eni, err := e.networkInterface(aws.StringValue(input.NetworkInterfaceId))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if aws.StringValue(eni.VpcId) != vpcID {
+			return nil, ec2Error("InvalidParameterValue", "route table %s and interface %s belong to different networks", aws.StringValue(rt.RouteTableId), aws.StringValue(eni.NetworkInterfaceId))
+		}
+
+		route.NetworkInterfaceId = input.NetworkInterfaceId
+	case input.CarrierGatewayId != nil:
+		return nil, ec2Error("InvalidCarrierGatewayID.NotFound", "The carrier gateway ID '%s' does not exist", aws.StringValue(input.CarrierGatewayId))
+	case input.EgressOnlyInternetGatewayId != nil:
+		return nil, ec2Error("InvalidGatewayID.NotFound", "The gateway ID '%s' does not exist", aws.StringValue(input.EgressOnlyInternetGatewayId))
+	case input.InstanceId != nil:
+		return nil, ec2Error("InvalidInstanceID.NotFound", "The instance ID '%s' does not exist", aws.StringValue(input.InstanceId))
+	case input.LocalGatewayId != nil:
+		return nil, ec2Error("InvalidLocalGatewayID.NotFound", "The local gateway ID '%s' does not exist", aws.StringValue(input.LocalGatewayId))
+	case input.NatGatewayId != nil:
+		natGatewayID := aws.StringValue(input.NatGatewayId)
+		ngw, ok := e.natGateways[natGatewayID]
+
+		if !ok || aws.StringValue(ngw.State) != ec2.NatGatewayStateAvailable {
+			return nil, ec2Error("InvalidNatGatewayID.NotFound", "The nat gateway ID '%s' does not exist", natGatewayID)
+		}
+
+		if aws.StringValue(ngw.VpcId) != vpcID {
+			return nil, ec2Error("InvalidParameterValue", "route table %s and network gateway %s belong to different networks", aws.StringValue(rt.RouteTableId), natGatewayID)
+		}
+
+		route.NatGatewayId = input.NatGatewayId
+	case input.TransitGatewayId != nil:
+		transitGatewayID := aws.StringValue(input.TransitGatewayId)
+
+		if _, err := e.transitGateway(transitGatewayID); err != nil {
+			return nil, err
+		}
+
+		if !e.transitGatewayVpcAttached(transitGatewayID, vpcID) {
+			return nil, ec2Error("InvalidParameterValue", "route table %s and network gateway %s belong to different networks", aws.StringValue(rt.RouteTableId), transitGatewayID)
+		}
+
+		route.TransitGatewayId = input.TransitGatewayId
+	case input.VpcPeeringConnectionId != nil:
+		pcxID := aws.StringValue(input.VpcPeeringConnectionId)
+		pcx, err := e.vpcPeeringConnection(pcxID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch vpcPeeringConnectionStatusCode(pcx) {
+		case ec2.VpcPeeringConnectionStateReasonCodeActive, ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance:
+		default:
+			return nil, ec2Error("InvalidVpcPeeringConnectionID.NotFound", "The vpcPeeringConnection ID '%s' does not exist", pcxID)
+		}
+
+		if aws.StringValue(pcx.AccepterVpcInfo.VpcId) != vpcID && aws.StringValue(pcx.RequesterVpcInfo.VpcId) != vpcID {
+			return nil, ec2Error("InvalidParameterValue", "route table %s and peering connection %s belong to different networks", aws.StringValue(rt.RouteTableId), pcxID)
+		}
+
+		route.VpcPeeringConnectionId = input.VpcPeeringConnectionId
+	default:
+		return nil, ec2Error("MissingParameter", "The request must contain exactly one of gatewayId, natGatewayId, networkInterfaceId, vpcPeeringConnectionId, egressOnlyInternetGatewayId, transitGatewayId, localGatewayId, carrierGatewayId or instanceId")
+	}
+
+	return route, nil
+}
+
+// findRoute returns the index of the route with a destination in a route table.
+func findRoute(rt *ec2.RouteTable, destination string) (int, bool) {
+	for i, route := range rt.Routes {
+		if routeDestination(route) == destination {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (e *EC2) CreateRouteTable(input *ec2.CreateRouteTableInput) (*ec2.CreateRouteTableOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	rt := e.createRouteTable(vpcID, false)
+
+	return &ec2.CreateRouteTableOutput{
+		RouteTable: e.describeRouteTable(aws.StringValue(rt.RouteTableId)),
+	}, nil
+}
+
+func (e *EC2) DeleteRouteTable(input *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
+	routeTableID := aws.StringValue(input.RouteTableId)
+	rt, err := e.routeTable(routeTableID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rt.Associations) > 0 {
+		return nil, ec2Error("DependencyViolation", "The routeTable '%s' has dependencies and cannot be deleted.", routeTableID)
+	}
+
+	for _, id := range sortedKeys(e.vpcEndpoints) {
+		vpce := e.vpcEndpoints[id]
+
+		if contains(aws.StringValueSlice(vpce.RouteTableIds), routeTableID) {
+			_ = e.removeVpcEndpointRouteTables(vpce, []string{routeTableID})
+		}
+	}
+
+	e.deleteResource(routeTableID)
+
+	return &ec2.DeleteRouteTableOutput{}, nil
+}
+
+func (e *EC2) describeRouteTable(id string) *ec2.RouteTable {
+	rt := awsutil.CopyOf(e.routeTables[id]).(*ec2.RouteTable)
+	rt.Tags = e.ec2Tags(id)
+
+	return rt
+}
+
+func (e *EC2) routeTableFilterValues(id, name string) ([]string, bool) {
+	rt := e.routeTables[id]
+
+	var associationValue func(a *ec2.RouteTableAssociation) *string
+	var routeValue func(r *ec2.Route) *string
+
+	switch name {
+	case "association.gateway-id":
+		associationValue = func(a *ec2.RouteTableAssociation) *string { return a.GatewayId }
+	case "association.main":
+		associationValue = func(a *ec2.RouteTableAssociation) *string { return aws.String(boolFilterValue(a.Main)[0]) }
+	case "association.route-table-association-id":
+		associationValue = func(a *ec2.RouteTableAssociation) *string { return a.RouteTableAssociationId }
+	case "association.route-table-id":
+		associationValue = func(a *ec2.RouteTableAssociation) *string { return a.RouteTableId }
+	case "association.subnet-id":
+		associationValue = func(a *ec2.RouteTableAssociation) *string { return a.SubnetId }
+	case "owner-id":
+		return stringFilterValue(rt.OwnerId), true
+	case "route-table-id":
+		return []string{id}, true
+	case "route.destination-cidr-block":
+		routeValue = func(r *ec2.Route) *string { return r.DestinationCidrBlock }
+	case "route.destination-ipv6-cidr-block":
+		routeValue = func(r *ec2.Route) *string { return r.DestinationIpv6CidrBlock }
+	case "route.destination-prefix-list-id":
+		routeValue = func(r *ec2.Route) *string { return r.DestinationPrefixListId }
+	case "route.gateway-id":
+		routeValue = func(r *ec2.Route) *string { return r.GatewayId }
+	case "route.nat-gateway-id":
+		routeValue = func(r *ec2.Route) *string { return r.NatGatewayId }
+	case "route.origin":
+		routeValue = func(r *ec2.Route) *string { return r.Origin }
+	case "route.state":
+		routeValue = func(r *ec2.Route) *string { return r.State }
+	case "route.transit-gateway-id":
+		routeValue = func(r *ec2.Route) *string { return r.TransitGatewayId }
+	case "route.vpc-peering-connection-id":
+		routeValue = func(r *ec2.Route) *string { return r.VpcPeeringConnectionId }
+	case "vpc-id":
+		return stringFilterValue(rt.VpcId), true
+	default:
+		return nil, false
+	}
+
+	var values []string
+
+	if associationValue != nil {
+		for _, a := range rt.Associations {
+			values = append(values, stringFilterValue(associationValue(a))...)
+		}
+	}
+
+	if routeValue != nil {
+		for _, r := range rt.Routes {
+			values = append(values, stringFilterValue(routeValue(r))...)
+		}
+	}
+
+	return values, true
+}
+
+func (e *EC2) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.routeTables), input.RouteTableIds, input.Filters, "InvalidRouteTableID.NotFound", "routeTable", e.routeTableFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeRouteTablesOutput{}
+
+	for _, id := range ids {
+		output.RouteTables = append(output.RouteTables, e.describeRouteTable(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) CreateRoute(input *ec2.CreateRouteInput) (*ec2.CreateRouteOutput, error) {
+	rt, err := e.routeTable(aws.StringValue(input.RouteTableId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	route, err := e.newRoute(rt, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := findRoute(rt, routeDestination(route)); ok {
+		return nil, ec2Error("RouteAlreadyExists", "The route identified by %s already exists.", routeDestination(route))
+	}
+
+	rt.Routes = append(rt.Routes, route)
+
+	return &ec2.CreateRouteOutput{Return: aws.Bool(true)}, nil
+}
+
+func (e *EC2) ReplaceRoute(input *ec2.ReplaceRouteInput) (*ec2.ReplaceRouteOutput, error) {
+	rt, err := e.routeTable(aws.StringValue(input.RouteTableId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	createInput := &ec2.CreateRouteInput{}
+	awsutil.Copy(createInput, input)
+
+	route, err := e.newRoute(rt, createInput)
+
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := findRoute(rt, routeDestination(route))
+
+	if !ok || aws.StringValue(rt.Routes[i].GatewayId) == "local" {
+		return nil, ec2Error("InvalidParameterValue", "There is no route defined for '%s' in the route table. Use CreateRoute instead.", routeDestination(route))
+	}
+
+	rt.Routes[i] = route
+
+	return &ec2.ReplaceRouteOutput{}, nil
+}
+
+func (e *EC2) DeleteRoute(input *ec2.DeleteRouteInput) (*ec2.DeleteRouteOutput, error) {
+	routeTableID := aws.StringValue(input.RouteTableId)
+	rt, err := e.routeTable(routeTableID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	route := &ec2.Route{
+		DestinationCidrBlock:     input.DestinationCidrBlock,
+		DestinationIpv6CidrBlock: input.DestinationIpv6CidrBlock,
+		DestinationPrefixListId:  input.DestinationPrefixListId,
+	}
+	destination := routeDestination(route)
+	i, ok := findRoute(rt, destination)
+
+	if !ok {
+		return nil, ec2Error("InvalidRoute.NotFound", "no route with destination-cidr-block %s in route table %s", destination, routeTableID)
+	}
+
+	if aws.StringValue(rt.Routes[i].GatewayId) == "local" {
+		return nil, ec2Error("InvalidParameterValue", "cannot remove local route %s in route table %s", destination, routeTableID)
+	}
+
+	rt.Routes = append(rt.Routes[:i], rt.Routes[i+1:]...)
+
+	return &ec2.DeleteRouteOutput{}, nil
+}
+
+func (e *EC2) AssociateRouteTable(input *ec2.AssociateRouteTableInput) (*ec2.AssociateRouteTableOutput, error) {
+	routeTableID := aws.StringValue(input.RouteTableId)
+	rt, err := e.routeTable(routeTableID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	association := &ec2.RouteTableAssociation{
+		AssociationState: &ec2.RouteTableAssociationState{
+			State: aws.String(ec2.RouteTableAssociationStateCodeAssociated),
+		},
+		Main:         aws.Bool(false),
+		RouteTableId: rt.RouteTableId,
+	}
+
+	switch {
+	case input.SubnetId != nil:
+		subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if aws.StringValue(subnet.VpcId) != aws.StringValue(rt.VpcId) {
+			return nil, ec2Error("InvalidParameterValue", "routeTable %s and subnet %s belong to different networks", routeTableID, aws.StringValue(subnet.SubnetId))
+		}
+
+		association.SubnetId = subnet.SubnetId
+	case input.GatewayId != nil:
+		igw, ok := e.internetGateways[aws.StringValue(input.GatewayId)]
+
+		if !ok {
+			return nil, ec2Error("InvalidGatewayID.NotFound", "The gateway ID '%s' does not exist", aws.StringValue(input.GatewayId))
+		}
+
+		if !internetGatewayAttached(igw, aws.StringValue(rt.VpcId)) {
+			return nil, ec2Error("InvalidParameterValue", "routeTable %s and gateway %s belong to different networks", routeTableID, aws.StringValue(input.GatewayId))
+		}
+
+		association.GatewayId = input.GatewayId
+	default:
+		return nil, ec2Error("MissingParameter", "The request must contain the parameter subnetId or gatewayId")
+	}
+
+	for _, id := range sortedKeys(e.routeTables) {
+		for _, a := range e.routeTables[id].Associations {
+			if (association.SubnetId != nil && aws.StringValue(a.SubnetId) == aws.StringValue(association.SubnetId)) ||
+				(association.GatewayId != nil && aws.StringValue(a.GatewayId) == aws.StringValue(association.GatewayId)) {
+				return nil, ec2Error("Resource.AlreadyAssociated", "the specified association for route table %s conflicts with an existing association", routeTableID)
+			}
+		}
+	}
+
+	association.RouteTableAssociationId = aws.String(e.newID("rtbassoc"))
+	rt.Associations = append(rt.Associations, association)
+
+	return &ec2.AssociateRouteTableOutput{
+		AssociationId:    association.RouteTableAssociationId,
+		AssociationState: awsutil.CopyOf(association.AssociationState).(*ec2.RouteTableAssociationState),
+	}, nil
+}
+
+func (e *EC2) DisassociateRouteTable(input *ec2.DisassociateRouteTableInput) (*ec2.DisassociateRouteTableOutput, error) {
+	associationID := aws.StringValue(input.AssociationId)
+	rt, i, err := e.routeTableAssociation(associationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.BoolValue(rt.Associations[i].Main) {
+		return nil, ec2Error("InvalidParameterValue", "cannot disassociate the main route table association %s", associationID)
+	}
+
+	rt.Associations = append(rt.Associations[:i], rt.Associations[i+1:]...)
+
+	return &ec2.DisassociateRouteTableOutput{}, nil
+}
+
+func (e *EC2) ReplaceRouteTableAssociation(input *ec2.ReplaceRouteTableAssociationInput) (*ec2.ReplaceRouteTableAssociationOutput, error) {
+	rt, err := e.routeTable(aws.StringValue(input.RouteTableId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing, i, err := e.routeTableAssociation(aws.StringValue(input.AssociationId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(existing.VpcId) != aws.StringValue(rt.VpcId) {
+		return nil, ec2Error("InvalidParameterValue", "routeTable %s and association %s belong to different networks", aws.StringValue(rt.RouteTableId), aws.StringValue(input.AssociationId))
+	}
+
+	association := existing.Associations[i]
+	existing.Associations = append(existing.Associations[:i], existing.Associations[i+1:]...)
+
+	association.RouteTableAssociationId = aws.String(e.newID("rtbassoc"))
+	association.RouteTableId = rt.RouteTableId
+	rt.Associations = append(rt.Associations, association)
+
+	return &ec2.ReplaceRouteTableAssociationOutput{
+		AssociationState: awsutil.CopyOf(association.AssociationState).(*ec2.RouteTableAssociationState),
+		NewAssociationId: association.RouteTableAssociationId,
+	}, nil
+}
+
+func (e *EC2) DisableVgwRoutePropagation(input *ec2.DisableVgwRoutePropagationInput) (*ec2.DisableVgwRoutePropagationOutput, error) {
+	return nil, ec2Error("InvalidVpnGatewayID.NotFound", "The vpnGateway ID '%s' does not exist", aws.StringValue(input.GatewayId))
+}
+
+func (e *EC2) EnableVgwRoutePropagation(input *ec2.EnableVgwRoutePropagationInput) (*ec2.EnableVgwRoutePropagationOutput, error) {
+	return nil, ec2Error("InvalidVpnGatewayID.NotFound", "The vpnGateway ID '%s' does not exist", aws.StringValue(input.GatewayId))
+}
@@ -0,0 +1,617 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// securityGroupRule is a single security group rule. The EC2 API groups
+// rules with the same protocol and ports into one IP permission.
+type securityGroupRule struct {
+	description string
+	fromPort    *int64
+	peer        string
+	peerType    string
+	protocol    string
+	toPort      *int64
+	userID      string
+}
+
+const (
+	securityGroupRulePeerCidrIpv4   = "cidr-ipv4"
+	securityGroupRulePeerCidrIpv6   = "cidr-ipv6"
+	securityGroupRulePeerGroup      = "group"
+	securityGroupRulePeerPrefixList = "prefix-list"
+)
+
+func (r *securityGroupRule) equal(other *securityGroupRule) bool {
+	return r.protocol == other.protocol &&
+		aws.Int64Value(r.fromPort) == aws.Int64Value(other.fromPort) &&
+		aws.Int64Value(r.toPort) == aws.Int64Value(other.toPort) &&
+		r.peerType == other.peerType &&
+		r.peer == other.peer
+}
+
+func (r *securityGroupRule) String() string {
+	ports := "ALL"
+
+	if r.fromPort != nil {
+		ports = fmt.Sprintf("from port: %d, to port: %d", aws.Int64Value(r.fromPort), aws.Int64Value(r.toPort))
+	}
+
+	return fmt.Sprintf("peer: %s, %s, %s, ALLOW", r.peer, strings.ToUpper(r.protocol), ports)
+}
+
+type securityGroupRules struct {
+	egress  []*securityGroupRule
+	ingress []*securityGroupRule
+}
+
+// securityGroupProtocols maps the protocol names and numbers accepted by the
+// EC2 API to the protocol returned in IP permissions.
+var securityGroupProtocols = map[string]string{
+	"-1":     "-1",
+	"1":      "icmp",
+	"17":     "udp",
+	"58":     "58",
+	"6":      "tcp",
+	"all":    "-1",
+	"icmp":   "icmp",
+	"icmpv6": "58",
+	"tcp":    "tcp",
+	"udp":    "udp",
+}
+
+func (e *EC2) createSecurityGroup(vpcID, name, description string) *ec2.SecurityGroup {
+	groupID := e.newID("sg")
+
+	sg := &ec2.SecurityGroup{
+		Description: aws.String(description),
+		GroupId:     aws.String(groupID),
+		GroupName:   aws.String(name),
+		OwnerId:     aws.String(AccountID),
+		VpcId:       aws.String(vpcID),
+	}
+
+	rules := &securityGroupRules{
+		egress: []*securityGroupRule{{
+			peer:     "0.0.0.0/0",
+			peerType: securityGroupRulePeerCidrIpv4,
+			protocol: "-1",
+		}},
+	}
+
+	if name == "default" {
+		rules.ingress = append(rules.ingress, &securityGroupRule{
+			peer:     groupID,
+			peerType: securityGroupRulePeerGroup,
+			protocol: "-1",
+			userID:   AccountID,
+		})
+	}
+
+	e.securityGroups[groupID] = sg
+	e.securityGroupRules[groupID] = rules
+
+	return sg
+}
+
+func (e *EC2) securityGroup(id string) (*ec2.SecurityGroup, error) {
+	sg, ok := e.securityGroups[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidGroup.NotFound", "The security group '%s' does not exist", id)
+	}
+
+	return sg, nil
+}
+
+// securityGroupByName returns the security group with the given name in a VPC.
+func (e *EC2) securityGroupByName(vpcID, name string) *ec2.SecurityGroup {
+	for _, id := range sortedKeys(e.securityGroups) {
+		sg := e.securityGroups[id]
+
+		if aws.StringValue(sg.VpcId) == vpcID && aws.StringValue(sg.GroupName) == name {
+			return sg
+		}
+	}
+
+	return nil
+}
+
+// defaultSecurityGroup returns the default security group of a VPC.
+func (e *EC2) defaultSecurityGroup(vpcID string) *ec2.SecurityGroup {
+	return e.securityGroupByName(vpcID, "default")
+}
+
+// resolveSecurityGroup returns the security group identified by ID, or by
+// name in the default VPC.
+func (e *EC2) resolveSecurityGroup(groupID, groupName *string) (*ec2.SecurityGroup, error) {
+	if groupID != nil {
+		return e.securityGroup(aws.StringValue(groupID))
+	}
+
+	if sg := e.securityGroupByName(e.DefaultVpcID, aws.StringValue(groupName)); sg != nil {
+		return sg, nil
+	}
+
+	return nil, ec2Error("InvalidGroup.NotFound", "The security group '%s' does not exist in default VPC '%s'", aws.StringValue(groupName), e.DefaultVpcID)
+}
+
+// expandIPPermissions returns the individual rules of IP permissions.
+func (e *EC2) expandIPPermissions(sg *ec2.SecurityGroup, permissions []*ec2.IpPermission) ([]*securityGroupRule, error) {
+	var rules []*securityGroupRule
+
+	for _, p := range permissions {
+		protocol, ok := securityGroupProtocols[strings.ToLower(aws.StringValue(p.IpProtocol))]
+
+		if !ok {
+			protocol = aws.StringValue(p.IpProtocol)
+
+			if n, err := fmt.Sscanf(protocol, "%d", new(int)); err != nil || n != 1 {
+				return nil, ec2Error("InvalidParameterValue", "Invalid value '%s' for IP protocol. Unknown protocol.", protocol)
+			}
+		}
+
+		var fromPort, toPort *int64
+
+		switch protocol {
+		case "tcp", "udp":
+			if p.FromPort == nil || p.ToPort == nil {
+				return nil, ec2Error("InvalidParameterValue", "Invalid value 'null' for fromPort or toPort. Ports must be specified for protocol %s.", protocol)
+			}
+
+			if aws.Int64Value(p.FromPort) > aws.Int64Value(p.ToPort) || aws.Int64Value(p.FromPort) < 0 || aws.Int64Value(p.ToPort) > 65535 {
+				return nil, ec2Error("InvalidParameterValue", "Invalid TCP/UDP port range (%d, %d)", aws.Int64Value(p.FromPort), aws.Int64Value(p.ToPort))
+			}
+
+			fromPort, toPort = p.FromPort, p.ToPort
+		case "icmp", "58":
+			fromPort, toPort = p.FromPort, p.ToPort
+
+			if fromPort == nil {
+				fromPort = aws.Int64(-1)
+			}
+
+			if toPort == nil {
+				toPort = aws.Int64(-1)
+			}
+		}
+
+		newRule := func(peerType, peer string, description *string) *securityGroupRule {
+			return &securityGroupRule{
+				description: aws.StringValue(description),
+				fromPort:    fromPort,
+				peer:        peer,
+				peerType:    peerType,
+				protocol:    protocol,
+				toPort:      toPort,
+			}
+		}
+
+		var n int
+
+		for _, r := range p.IpRanges {
+			cidrBlock := aws.StringValue(r.CidrIp)
+
+			if err := validateCidrBlock(cidrBlock, false); err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, newRule(securityGroupRulePeerCidrIpv4, cidrBlock, r.Description))
+			n++
+		}
+
+		for _, r := range p.Ipv6Ranges {
+			cidrBlock := aws.StringValue(r.CidrIpv6)
+
+			if err := validateCidrBlock(cidrBlock, true); err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, newRule(securityGroupRulePeerCidrIpv6, cidrBlock, r.Description))
+			n++
+		}
+
+		for _, r := range p.PrefixListIds {
+			if _, err := e.managedPrefixList(aws.StringValue(r.PrefixListId)); err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, newRule(securityGroupRulePeerPrefixList, aws.StringValue(r.PrefixListId), r.Description))
+			n++
+		}
+
+		for _, pair := range p.UserIdGroupPairs {
+			peer, err := e.resolveSecurityGroup(pair.GroupId, pair.GroupName)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if aws.StringValue(peer.VpcId) != aws.StringValue(sg.VpcId) {
+				return nil, ec2Error("InvalidGroup.NotFound", "You have specified two resources that belong to different networks.")
+			}
+
+			rule := newRule(securityGroupRulePeerGroup, aws.StringValue(peer.GroupId), pair.Description)
+			rule.userID = AccountID
+			rules = append(rules, rule)
+			n++
+		}
+
+		if n == 0 {
+			return nil, ec2Error("InvalidParameterValue", "The request must contain the parameter ipPermissions")
+		}
+	}
+
+	return rules, nil
+}
+
+// validateCidrBlock returns an error if a CIDR block is not valid for the address family.
+func validateCidrBlock(cidrBlock string, ipv6 bool) error {
+	ip, _, err := net.ParseCIDR(cidrBlock)
+
+	if err != nil {
+		return ec2Error("InvalidParameterValue", "CIDR block %s is malformed", cidrBlock)
+	}
+
+	if (ip.To4() == nil) != ipv6 {
+		return ec2Error("InvalidParameterValue", "Invalid IPv%s CIDR block %s", map[bool]string{false: "4", true: "6"}[ipv6], cidrBlock)
+	}
+
+	return nil
+}
+
+// flattenSecurityGroupRules returns IP permissions grouping rules with the
+// same protocol and ports, in the order in which they were authorized.
+func flattenSecurityGroupRules(rules []*securityGroupRule) []*ec2.IpPermission {
+	var permissions []*ec2.IpPermission
+	var groups []*securityGroupRule
+
+	for _, rule := range rules {
+		var p *ec2.IpPermission
+
+		for i, group := range groups {
+			if group.protocol == rule.protocol && aws.Int64Value(group.fromPort) == aws.Int64Value(rule.fromPort) && aws.Int64Value(group.toPort) == aws.Int64Value(rule.toPort) {
+				p = permissions[i]
+				break
+			}
+		}
+
+		if p == nil {
+			p = &ec2.IpPermission{
+				FromPort:         rule.fromPort,
+				IpProtocol:       aws.String(rule.protocol),
+				IpRanges:         []*ec2.IpRange{},
+				Ipv6Ranges:       []*ec2.Ipv6Range{},
+				PrefixListIds:    []*ec2.PrefixListId{},
+				ToPort:           rule.toPort,
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			permissions = append(permissions, p)
+			groups = append(groups, rule)
+		}
+
+		var description *string
+
+		if rule.description != "" {
+			description = aws.String(rule.description)
+		}
+
+		switch rule.peerType {
+		case securityGroupRulePeerCidrIpv4:
+			p.IpRanges = append(p.IpRanges, &ec2.IpRange{CidrIp: aws.String(rule.peer), Description: description})
+		case securityGroupRulePeerCidrIpv6:
+			p.Ipv6Ranges = append(p.Ipv6Ranges, &ec2.Ipv6Range{CidrIpv6: aws.String(rule.peer), Description: description})
+		case securityGroupRulePeerPrefixList:
+			p.PrefixListIds = append(p.PrefixListIds, &ec2.PrefixListId{PrefixListId: aws.String(rule.peer), Description: description})
+		case securityGroupRulePeerGroup:
+			p.UserIdGroupPairs = append(p.UserIdGroupPairs, &ec2.UserIdGroupPair{GroupId: aws.String(rule.peer), UserId: aws.String(rule.userID), Description: description})
+		}
+	}
+
+	return permissions
+}
+
+// authorizeSecurityGroupRules adds rules to a security group's ingress or egress rules.
+func (e *EC2) authorizeSecurityGroupRules(sg *ec2.SecurityGroup, existing *[]*securityGroupRule, permissions []*ec2.IpPermission) error {
+	rules, err := e.expandIPPermissions(sg, permissions)
+
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range rules {
+		for _, other := range append(*existing, rules[:i]...) {
+			if rule.equal(other) {
+				return ec2Error("InvalidPermission.Duplicate", "the specified rule \"%s\" already exists", rule)
+			}
+		}
+	}
+
+	*existing = append(*existing, rules...)
+
+	return nil
+}
+
+// revokeSecurityGroupRules removes rules from a security group's ingress or egress rules.
+func (e *EC2) revokeSecurityGroupRules(sg *ec2.SecurityGroup, existing *[]*securityGroupRule, permissions []*ec2.IpPermission) error {
+	rules, err := e.expandIPPermissions(sg, permissions)
+
+	if err != nil {
+		return err
+	}
+
+	remaining := append([]*securityGroupRule{}, *existing...)
+
+	for _, rule := range rules {
+		found := false
+
+		for i, other := range remaining {
+			if rule.equal(other) {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return ec2Error("InvalidPermission.NotFound", "The specified rule does not exist in this security group.")
+		}
+	}
+
+	*existing = remaining
+
+	return nil
+}
+
+// updateSecurityGroupRuleDescriptions sets the descriptions of existing rules.
+func (e *EC2) updateSecurityGroupRuleDescriptions(sg *ec2.SecurityGroup, existing []*securityGroupRule, permissions []*ec2.IpPermission) error {
+	rules, err := e.expandIPPermissions(sg, permissions)
+
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		found := false
+
+		for _, other := range existing {
+			if rule.equal(other) {
+				other.description = rule.description
+				found = true
+			}
+		}
+
+		if !found {
+			return ec2Error("InvalidPermission.NotFound", "The specified rule does not exist in this security group.")
+		}
+	}
+
+	return nil
+}
+
+func (e *EC2) CreateSecurityGroup(input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if vpcID == "" {
+		if e.DefaultVpcID == "" {
+			return nil, ec2Error("VPCIdNotSpecified", "No default VPC for this user")
+		}
+
+		vpcID = e.DefaultVpcID
+	}
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(input.GroupName)
+
+	if name == "" || aws.StringValue(input.Description) == "" {
+		return nil, ec2Error("MissingParameter", "The request must contain the parameters groupName and groupDescription")
+	}
+
+	if strings.HasPrefix(name, "sg-") {
+		return nil, ec2Error("InvalidParameterValue", "Group names may not be in the format sg-*")
+	}
+
+	if name == "default" {
+		return nil, ec2Error("InvalidGroup.Reserved", "The security group 'default' is reserved")
+	}
+
+	if e.securityGroupByName(vpcID, name) != nil {
+		return nil, ec2Error("InvalidGroup.Duplicate", "The security group '%s' already exists for VPC '%s'", name, vpcID)
+	}
+
+	sg := e.createSecurityGroup(vpcID, name, aws.StringValue(input.Description))
+
+	return &ec2.CreateSecurityGroupOutput{
+		GroupId: sg.GroupId,
+	}, nil
+}
+
+func (e *EC2) DeleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	sg, err := e.resolveSecurityGroup(input.GroupId, input.GroupName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	groupID := aws.StringValue(sg.GroupId)
+
+	if aws.StringValue(sg.GroupName) == "default" {
+		return nil, ec2Error("CannotDelete", "the specified group: \"%s\" name: \"default\" cannot be deleted by a user", groupID)
+	}
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		for _, g := range e.networkInterfaces[id].Groups {
+			if aws.StringValue(g.GroupId) == groupID {
+				return nil, ec2Error("DependencyViolation", "resource %s has a dependent object", groupID)
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(e.securityGroupRules) {
+		if id == groupID {
+			continue
+		}
+
+		rules := e.securityGroupRules[id]
+
+		for _, rule := range append(append([]*securityGroupRule{}, rules.ingress...), rules.egress...) {
+			if rule.peerType == securityGroupRulePeerGroup && rule.peer == groupID {
+				return nil, ec2Error("DependencyViolation", "resource %s has a dependent object", groupID)
+			}
+		}
+	}
+
+	e.deleteResource(groupID)
+
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+func (e *EC2) describeSecurityGroup(id string) *ec2.SecurityGroup {
+	sg := awsutil.CopyOf(e.securityGroups[id]).(*ec2.SecurityGroup)
+	sg.IpPermissions = flattenSecurityGroupRules(e.securityGroupRules[id].ingress)
+	sg.IpPermissionsEgress = flattenSecurityGroupRules(e.securityGroupRules[id].egress)
+	sg.Tags = e.ec2Tags(id)
+
+	return sg
+}
+
+func (e *EC2) securityGroupFilterValues(id, name string) ([]string, bool) {
+	sg := e.securityGroups[id]
+
+	switch name {
+	case "description":
+		return stringFilterValue(sg.Description), true
+	case "group-id":
+		return []string{id}, true
+	case "group-name":
+		return stringFilterValue(sg.GroupName), true
+	case "owner-id":
+		return stringFilterValue(sg.OwnerId), true
+	case "vpc-id":
+		return stringFilterValue(sg.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	groupIDs := input.GroupIds
+
+	for _, name := range input.GroupNames {
+		sg, err := e.resolveSecurityGroup(nil, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		groupIDs = append(groupIDs, sg.GroupId)
+	}
+
+	ids, err := e.selectIDs(sortedKeys(e.securityGroups), groupIDs, input.Filters, "InvalidGroup.NotFound", "security group", e.securityGroupFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeSecurityGroupsOutput{}
+
+	for _, id := range ids {
+		output.SecurityGroups = append(output.SecurityGroups, e.describeSecurityGroup(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) AuthorizeSecurityGroupEgress(input *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	sg, err := e.securityGroup(aws.StringValue(input.GroupId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.authorizeSecurityGroupRules(sg, &e.securityGroupRules[aws.StringValue(sg.GroupId)].egress, input.IpPermissions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.AuthorizeSecurityGroupEgressOutput{}, nil
+}
+
+func (e *EC2) AuthorizeSecurityGroupIngress(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	sg, err := e.resolveSecurityGroup(input.GroupId, input.GroupName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.authorizeSecurityGroupRules(sg, &e.securityGroupRules[aws.StringValue(sg.GroupId)].ingress, input.IpPermissions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+}
+
+func (e *EC2) RevokeSecurityGroupEgress(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	sg, err := e.securityGroup(aws.StringValue(input.GroupId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.revokeSecurityGroupRules(sg, &e.securityGroupRules[aws.StringValue(sg.GroupId)].egress, input.IpPermissions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.RevokeSecurityGroupEgressOutput{Return: aws.Bool(true)}, nil
+}
+
+func (e *EC2) RevokeSecurityGroupIngress(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	sg, err := e.resolveSecurityGroup(input.GroupId, input.GroupName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.revokeSecurityGroupRules(sg, &e.securityGroupRules[aws.StringValue(sg.GroupId)].ingress, input.IpPermissions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.RevokeSecurityGroupIngressOutput{Return: aws.Bool(true)}, nil
+}
+
+func (e *EC2) UpdateSecurityGroupRuleDescriptionsEgress(input *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
+	sg, err := e.resolveSecurityGroup(input.GroupId, input.GroupName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.updateSecurityGroupRuleDescriptions(sg, e.securityGroupRules[aws.StringValue(sg.GroupId)].egress, input.IpPermissions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput{Return: aws.Bool(true)}, nil
+}
+
+func (e *EC2) UpdateSecurityGroupRuleDescriptionsIngress(input *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	sg, err := e.resolveSecurityGroup(input.GroupId, input.GroupName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.updateSecurityGroupRuleDescriptions(sg, e.securityGroupRules[aws.StringValue(sg.GroupId)].ingress, input.IpPermissions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput{Return: aws.Bool(true)}, nil
+}
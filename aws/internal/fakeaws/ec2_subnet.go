@@ -0,0 +1,360 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// createSubnet creates a subnet associated with the default network ACL of its VPC.
+func (e *EC2) createSubnet(vpc *ec2.Vpc, cidrBlock, availabilityZone string) *ec2.Subnet {
+	subnetID := e.newID("subnet")
+	_, network, _ := net.ParseCIDR(cidrBlock)
+	ones, bits := network.Mask.Size()
+
+	subnet := &ec2.Subnet{
+		AssignIpv6AddressOnCreation: aws.Bool(false),
+		AvailabilityZone:            aws.String(availabilityZone),
+		AvailabilityZoneId:          aws.String(AvailabilityZones[availabilityZone]),
+		AvailableIpAddressCount:     aws.Int64(int64(1)<<uint(bits-ones) - 5),
+		CidrBlock:                   aws.String(cidrBlock),
+		DefaultForAz:                aws.Bool(false),
+		MapPublicIpOnLaunch:         aws.Bool(false),
+		OwnerId:                     aws.String(AccountID),
+		State:                       aws.String(ec2.SubnetStateAvailable),
+		SubnetArn:                   aws.String(fmt.Sprintf("arn:aws:ec2:%s:%s:subnet/%s", Region, AccountID, subnetID)),
+		SubnetId:                    aws.String(subnetID),
+		VpcId:                       vpc.VpcId,
+	}
+
+	e.subnets[subnetID] = subnet
+
+	if acl := e.defaultNetworkAcl(aws.StringValue(vpc.VpcId)); acl != nil {
+		acl.Associations = append(acl.Associations, &ec2.NetworkAclAssociation{
+			NetworkAclAssociationId: aws.String(e.newID("aclassoc")),
+			NetworkAclId:            acl.NetworkAclId,
+			SubnetId:                aws.String(subnetID),
+		})
+	}
+
+	return subnet
+}
+
+func (e *EC2) subnet(id string) (*ec2.Subnet, error) {
+	subnet, ok := e.subnets[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidSubnetID.NotFound", "The subnet ID '%s' does not exist", id)
+	}
+
+	return subnet, nil
+}
+
+// subnetIpv6CidrBlocks returns the associated IPv6 CIDR blocks of a subnet.
+func subnetIpv6CidrBlocks(subnet *ec2.Subnet) []string {
+	var cidrBlocks []string
+
+	for _, a := range subnet.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(a.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+			cidrBlocks = append(cidrBlocks, aws.StringValue(a.Ipv6CidrBlock))
+		}
+	}
+
+	return cidrBlocks
+}
+
+func (e *EC2) validateSubnetIpv6CidrBlock(vpc *ec2.Vpc, subnetID, cidrBlock string) error {
+	_, network, err := net.ParseCIDR(cidrBlock)
+
+	if err != nil || network.IP.To4() != nil {
+		return ec2Error("InvalidParameterValue", "Value (%s) for parameter ipv6CidrBlock is invalid. This is not a valid IPv6 CIDR block.", cidrBlock)
+	}
+
+	if ones, _ := network.Mask.Size(); ones != 64 {
+		return ec2Error("InvalidSubnet.Range", "The IPv6 CIDR '%s' is invalid.", cidrBlock)
+	}
+
+	var inVpc bool
+
+	for _, vpcCidrBlock := range vpcIpv6CidrBlocks(vpc) {
+		if cidrContains(vpcCidrBlock, cidrBlock) {
+			inVpc = true
+		}
+	}
+
+	if !inVpc {
+		return ec2Error("InvalidSubnet.Range", "The IPv6 CIDR '%s' is invalid.", cidrBlock)
+	}
+
+	for _, id := range sortedKeys(e.subnets) {
+		if id == subnetID {
+			continue
+		}
+
+		for _, existing := range subnetIpv6CidrBlocks(e.subnets[id]) {
+			if cidrOverlaps(existing, cidrBlock) {
+				return ec2Error("InvalidSubnet.Conflict", "The IPv6 CIDR '%s' conflicts with another subnet", cidrBlock)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *EC2) newSubnetIpv6CidrBlockAssociation(cidrBlock string) *ec2.SubnetIpv6CidrBlockAssociation {
+	return &ec2.SubnetIpv6CidrBlockAssociation{
+		AssociationId: aws.String(e.newID("subnet-cidr-assoc")),
+		Ipv6CidrBlock: aws.String(cidrBlock),
+		Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+			State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+		},
+	}
+}
+
+func (e *EC2) CreateSubnet(input *ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error) {
+	vpc, err := e.vpc(aws.StringValue(input.VpcId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	availabilityZone := aws.StringValue(input.AvailabilityZone)
+
+	if input.AvailabilityZoneId != nil {
+		for name, id := range AvailabilityZones {
+			if id == aws.StringValue(input.AvailabilityZoneId) {
+				availabilityZone = name
+			}
+		}
+	}
+
+	if availabilityZone == "" {
+		availabilityZone = sortedKeys(AvailabilityZones)[0]
+	}
+
+	if _, ok := AvailabilityZones[availabilityZone]; !ok {
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter availabilityZone is invalid. Subnets can currently only be created in the following availability zones: %s.", availabilityZone, sortedKeys(AvailabilityZones))
+	}
+
+	cidrBlock := aws.StringValue(input.CidrBlock)
+	_, network, err := net.ParseCIDR(cidrBlock)
+
+	if err != nil || network.IP.To4() == nil || network.String() != cidrBlock {
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter cidrBlock is invalid. This is not a valid CIDR block.", cidrBlock)
+	}
+
+	var inVpc bool
+
+	for _, vpcCidrBlock := range vpcCidrBlocks(vpc) {
+		if cidrContains(vpcCidrBlock, cidrBlock) {
+			inVpc = true
+		}
+	}
+
+	if ones, _ := network.Mask.Size(); !inVpc || ones > 28 {
+		return nil, ec2Error("InvalidSubnet.Range", "The CIDR '%s' is invalid.", cidrBlock)
+	}
+
+	for _, id := range sortedKeys(e.subnets) {
+		subnet := e.subnets[id]
+
+		if aws.StringValue(subnet.VpcId) == aws.StringValue(vpc.VpcId) && cidrOverlaps(aws.StringValue(subnet.CidrBlock), cidrBlock) {
+			return nil, ec2Error("InvalidSubnet.Conflict", "The CIDR '%s' conflicts with another subnet", cidrBlock)
+		}
+	}
+
+	if input.Ipv6CidrBlock != nil {
+		if err := e.validateSubnetIpv6CidrBlock(vpc, "", aws.StringValue(input.Ipv6CidrBlock)); err != nil {
+			return nil, err
+		}
+	}
+
+	subnet := e.createSubnet(vpc, cidrBlock, availabilityZone)
+
+	if input.Ipv6CidrBlock != nil {
+		subnet.Ipv6CidrBlockAssociationSet = append(subnet.Ipv6CidrBlockAssociationSet, e.newSubnetIpv6CidrBlockAssociation(aws.StringValue(input.Ipv6CidrBlock)))
+	}
+
+	return &ec2.CreateSubnetOutput{
+		Subnet: e.describeSubnet(aws.StringValue(subnet.SubnetId)),
+	}, nil
+}
+
+func (e *EC2) DeleteSubnet(input *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error) {
+	subnetID := aws.StringValue(input.SubnetId)
+
+	if _, err := e.subnet(subnetID); err != nil {
+		return nil, err
+	}
+
+	for _, id := range sortedKeys(e.networkInterfaces) {
+		if aws.StringValue(e.networkInterfaces[id].SubnetId) == subnetID {
+			return nil, ec2Error("DependencyViolation", "The subnet '%s' has dependencies and cannot be deleted.", subnetID)
+		}
+	}
+
+	for _, id := range sortedKeys(e.clientVpnTargetNetworks) {
+		if aws.StringValue(e.clientVpnTargetNetworks[id].TargetNetworkId) == subnetID {
+			return nil, ec2Error("DependencyViolation", "The subnet '%s' has dependencies and cannot be deleted.", subnetID)
+		}
+	}
+
+	if e.transitGatewaySubnetAttached(subnetID) {
+		return nil, ec2Error("DependencyViolation", "The subnet '%s' has dependencies and cannot be deleted.", subnetID)
+	}
+
+	for _, id := range sortedKeys(e.networkAcls) {
+		acl := e.networkAcls[id]
+
+		for i, a := range acl.Associations {
+			if aws.StringValue(a.SubnetId) == subnetID {
+				acl.Associations = append(acl.Associations[:i], acl.Associations[i+1:]...)
+				break
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(e.routeTables) {
+		rt := e.routeTables[id]
+
+		for i, a := range rt.Associations {
+			if aws.StringValue(a.SubnetId) == subnetID {
+				rt.Associations = append(rt.Associations[:i], rt.Associations[i+1:]...)
+				break
+			}
+		}
+	}
+
+	e.deleteResource(subnetID)
+
+	return &ec2.DeleteSubnetOutput{}, nil
+}
+
+func (e *EC2) describeSubnet(id string) *ec2.Subnet {
+	subnet := awsutil.CopyOf(e.subnets[id]).(*ec2.Subnet)
+	subnet.Tags = e.ec2Tags(id)
+
+	return subnet
+}
+
+func (e *EC2) subnetFilterValues(id, name string) ([]string, bool) {
+	subnet := e.subnets[id]
+
+	switch name {
+	case "availability-zone", "availabilityZone":
+		return stringFilterValue(subnet.AvailabilityZone), true
+	case "availability-zone-id", "availabilityZoneId":
+		return stringFilterValue(subnet.AvailabilityZoneId), true
+	case "available-ip-address-count":
+		return []string{strconv.FormatInt(aws.Int64Value(subnet.AvailableIpAddressCount), 10)}, true
+	case "cidr", "cidr-block", "cidrBlock":
+		return stringFilterValue(subnet.CidrBlock), true
+	case "default-for-az", "defaultForAz":
+		return boolFilterValue(subnet.DefaultForAz), true
+	case "ipv6-cidr-block-association.ipv6-cidr-block":
+		return subnetIpv6CidrBlocks(subnet), true
+	case "owner-id":
+		return stringFilterValue(subnet.OwnerId), true
+	case "state":
+		return stringFilterValue(subnet.State), true
+	case "subnet-arn":
+		return stringFilterValue(subnet.SubnetArn), true
+	case "subnet-id":
+		return []string{id}, true
+	case "vpc-id":
+		return stringFilterValue(subnet.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.subnets), input.SubnetIds, input.Filters, "InvalidSubnetID.NotFound", "subnet", e.subnetFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeSubnetsOutput{}
+
+	for _, id := range ids {
+		output.Subnets = append(output.Subnets, e.describeSubnet(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) ModifySubnetAttribute(input *ec2.ModifySubnetAttributeInput) (*ec2.ModifySubnetAttributeOutput, error) {
+	subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if input.AssignIpv6AddressOnCreation != nil {
+		subnet.AssignIpv6AddressOnCreation = input.AssignIpv6AddressOnCreation.Value
+	}
+
+	if input.MapCustomerOwnedIpOnLaunch != nil {
+		subnet.MapCustomerOwnedIpOnLaunch = input.MapCustomerOwnedIpOnLaunch.Value
+	}
+
+	if input.MapPublicIpOnLaunch != nil {
+		subnet.MapPublicIpOnLaunch = input.MapPublicIpOnLaunch.Value
+	}
+
+	return &ec2.ModifySubnetAttributeOutput{}, nil
+}
+
+func (e *EC2) AssociateSubnetCidrBlock(input *ec2.AssociateSubnetCidrBlockInput) (*ec2.AssociateSubnetCidrBlockOutput, error) {
+	subnet, err := e.subnet(aws.StringValue(input.SubnetId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subnetIpv6CidrBlocks(subnet)) > 0 {
+		return nil, ec2Error("CidrLimitExceeded", "This network '%s' has met its maximum number of allowed CIDRs: 1", aws.StringValue(subnet.SubnetId))
+	}
+
+	cidrBlock := aws.StringValue(input.Ipv6CidrBlock)
+
+	if err := e.validateSubnetIpv6CidrBlock(e.vpcs[aws.StringValue(subnet.VpcId)], aws.StringValue(subnet.SubnetId), cidrBlock); err != nil {
+		return nil, err
+	}
+
+	association := e.newSubnetIpv6CidrBlockAssociation(cidrBlock)
+	subnet.Ipv6CidrBlockAssociationSet = append(subnet.Ipv6CidrBlockAssociationSet, association)
+
+	return &ec2.AssociateSubnetCidrBlockOutput{
+		Ipv6CidrBlockAssociation: awsutil.CopyOf(association).(*ec2.SubnetIpv6CidrBlockAssociation),
+		SubnetId:                 subnet.SubnetId,
+	}, nil
+}
+
+func (e *EC2) DisassociateSubnetCidrBlock(input *ec2.DisassociateSubnetCidrBlockInput) (*ec2.DisassociateSubnetCidrBlockOutput, error) {
+	associationID := aws.StringValue(input.AssociationId)
+
+	for _, id := range sortedKeys(e.subnets) {
+		subnet := e.subnets[id]
+
+		for _, a := range subnet.Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(a.AssociationId) != associationID || aws.StringValue(a.Ipv6CidrBlockState.State) != ec2.SubnetCidrBlockStateCodeAssociated {
+				continue
+			}
+
+			a.Ipv6CidrBlockState.State = aws.String(ec2.SubnetCidrBlockStateCodeDisassociated)
+
+			return &ec2.DisassociateSubnetCidrBlockOutput{
+				Ipv6CidrBlockAssociation: awsutil.CopyOf(a).(*ec2.SubnetIpv6CidrBlockAssociation),
+				SubnetId:                 subnet.SubnetId,
+			}, nil
+		}
+	}
+
+	return nil, ec2Error("InvalidSubnetCidrBlockAssociationID.NotFound", "The subnet CIDR block association ID '%s' does not exist", associationID)
+}
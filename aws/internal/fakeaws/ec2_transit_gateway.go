@@ -0,0 +1,1046 @@
+package fakeaws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Transit gateways are visible to every account, as if they were shared
+// with the organization through Resource Access Manager. Their route
+// tables, associations and propagations are only visible to the owner.
+
+// transitGatewayPropagationKey returns the key of a route table propagation,
+// which has no ID. An attachment has at most one association, so
+// associations are keyed by attachment ID.
+func transitGatewayPropagationKey(routeTableID, attachmentID string) string {
+	return strings.Join([]string{routeTableID, attachmentID}, ",")
+}
+
+func (e *EC2) transitGateway(id string) (*ec2.TransitGateway, error) {
+	tgw, ok := e.transitGateways[id]
+
+	if !ok || aws.StringValue(tgw.State) == ec2.TransitGatewayStateDeleted {
+		return nil, ec2Error("InvalidTransitGatewayID.NotFound", "Transit Gateway %s was deleted or does not exist.", id)
+	}
+
+	return tgw, nil
+}
+
+// ownedTransitGateway returns a transit gateway owned by the caller.
+func (e *EC2) ownedTransitGateway(id string) (*ec2.TransitGateway, error) {
+	tgw, err := e.transitGateway(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(tgw.OwnerId) != e.caller {
+		return nil, ec2Error("InvalidTransitGatewayID.NotFound", "Transit Gateway %s was deleted or does not exist.", id)
+	}
+
+	return tgw, nil
+}
+
+func (e *EC2) transitGatewayRouteTable(id string) (*ec2.TransitGatewayRouteTable, error) {
+	rt, ok := e.transitGatewayRouteTables[id]
+
+	if !ok || aws.StringValue(rt.State) == ec2.TransitGatewayRouteTableStateDeleted || !e.transitGatewayRouteTableVisible(rt) {
+		return nil, ec2Error("InvalidRouteTableID.NotFound", "Transit Gateway Route Table %s was deleted or does not exist.", id)
+	}
+
+	return rt, nil
+}
+
+func (e *EC2) transitGatewayRouteTableVisible(rt *ec2.TransitGatewayRouteTable) bool {
+	return aws.StringValue(e.transitGateways[aws.StringValue(rt.TransitGatewayId)].OwnerId) == e.caller
+}
+
+func (e *EC2) transitGatewayVpcAttachment(id string) (*ec2.TransitGatewayVpcAttachment, error) {
+	attachment, ok := e.transitGatewayVpcAttachments[id]
+
+	if !ok || aws.StringValue(attachment.State) == ec2.TransitGatewayAttachmentStateDeleted || !e.transitGatewayVpcAttachmentVisible(attachment) {
+		return nil, ec2Error("InvalidTransitGatewayAttachmentID.NotFound", "Transit Gateway Attachment %s was deleted or does not exist.", id)
+	}
+
+	return attachment, nil
+}
+
+// transitGatewayVpcAttachmentVisible returns whether the caller owns the VPC
+// or the transit gateway of an attachment.
+func (e *EC2) transitGatewayVpcAttachmentVisible(attachment *ec2.TransitGatewayVpcAttachment) bool {
+	tgw := e.transitGateways[aws.StringValue(attachment.TransitGatewayId)]
+
+	return aws.StringValue(attachment.VpcOwnerId) == e.caller || aws.StringValue(tgw.OwnerId) == e.caller
+}
+
+// transitGatewayDefaultRouteTable returns the default association or
+// propagation route table of a transit gateway, creating one if it has
+// neither.
+func (e *EC2) transitGatewayDefaultRouteTable(tgw *ec2.TransitGateway) *ec2.TransitGatewayRouteTable {
+	for _, id := range []*string{tgw.Options.AssociationDefaultRouteTableId, tgw.Options.PropagationDefaultRouteTableId} {
+		if rt, ok := e.transitGatewayRouteTables[aws.StringValue(id)]; ok {
+			return rt
+		}
+	}
+
+	return e.createTransitGatewayRouteTable(aws.StringValue(tgw.TransitGatewayId))
+}
+
+func (e *EC2) createTransitGatewayRouteTable(transitGatewayID string) *ec2.TransitGatewayRouteTable {
+	routeTableID := e.newID("tgw-rtb")
+
+	rt := &ec2.TransitGatewayRouteTable{
+		CreationTime:                 aws.Time(time.Now().UTC().Truncate(time.Second)),
+		DefaultAssociationRouteTable: aws.Bool(false),
+		DefaultPropagationRouteTable: aws.Bool(false),
+		State:                        aws.String(ec2.TransitGatewayRouteTableStateAvailable),
+		TransitGatewayId:             aws.String(transitGatewayID),
+		TransitGatewayRouteTableId:   aws.String(routeTableID),
+	}
+
+	e.transitGatewayRouteTables[routeTableID] = rt
+
+	return rt
+}
+
+// setTransitGatewayDefaultRouteTables updates the default route tables of a
+// transit gateway to match its default association and propagation options.
+func (e *EC2) setTransitGatewayDefaultRouteTables(tgw *ec2.TransitGateway) {
+	options := tgw.Options
+
+	if aws.StringValue(options.DefaultRouteTableAssociation) == ec2.DefaultRouteTableAssociationValueEnable && options.AssociationDefaultRouteTableId == nil {
+		options.AssociationDefaultRouteTableId = e.transitGatewayDefaultRouteTable(tgw).TransitGatewayRouteTableId
+	}
+
+	if aws.StringValue(options.DefaultRouteTableAssociation) == ec2.DefaultRouteTableAssociationValueDisable {
+		options.AssociationDefaultRouteTableId = nil
+	}
+
+	if aws.StringValue(options.DefaultRouteTablePropagation) == ec2.DefaultRouteTablePropagationValueEnable && options.PropagationDefaultRouteTableId == nil {
+		options.PropagationDefaultRouteTableId = e.transitGatewayDefaultRouteTable(tgw).TransitGatewayRouteTableId
+	}
+
+	if aws.StringValue(options.DefaultRouteTablePropagation) == ec2.DefaultRouteTablePropagationValueDisable {
+		options.PropagationDefaultRouteTableId = nil
+	}
+
+	for _, id := range sortedKeys(e.transitGatewayRouteTables) {
+		rt := e.transitGatewayRouteTables[id]
+
+		if aws.StringValue(rt.TransitGatewayId) != aws.StringValue(tgw.TransitGatewayId) {
+			continue
+		}
+
+		rt.DefaultAssociationRouteTable = aws.Bool(id == aws.StringValue(options.AssociationDefaultRouteTableId))
+		rt.DefaultPropagationRouteTable = aws.Bool(id == aws.StringValue(options.PropagationDefaultRouteTableId))
+	}
+}
+
+func validateTransitGatewayAmazonSideAsn(asn int64) error {
+	if (asn >= 64512 && asn <= 65534) || (asn >= 4200000000 && asn <= 4294967294) {
+		return nil
+	}
+
+	return ec2Error("InvalidParameterValue", "The Amazon side ASN %d is not in the private ASN ranges 64512-65534 or 4200000000-4294967294", asn)
+}
+
+// CreateTransitGateway creates a transit gateway with a default route table
+// if default route table association or propagation is enabled.
+func (e *EC2) CreateTransitGateway(input *ec2.CreateTransitGatewayInput) (*ec2.CreateTransitGatewayOutput, error) {
+	options := &ec2.TransitGatewayOptions{
+		AmazonSideAsn:                aws.Int64(64512),
+		AutoAcceptSharedAttachments:  aws.String(ec2.AutoAcceptSharedAttachmentsValueDisable),
+		DefaultRouteTableAssociation: aws.String(ec2.DefaultRouteTableAssociationValueEnable),
+		DefaultRouteTablePropagation: aws.String(ec2.DefaultRouteTablePropagationValueEnable),
+		DnsSupport:                   aws.String(ec2.DnsSupportValueEnable),
+		MulticastSupport:             aws.String(ec2.MulticastSupportValueDisable),
+		VpnEcmpSupport:               aws.String(ec2.VpnEcmpSupportValueEnable),
+	}
+
+	if o := input.Options; o != nil {
+		if o.AmazonSideAsn != nil {
+			if err := validateTransitGatewayAmazonSideAsn(aws.Int64Value(o.AmazonSideAsn)); err != nil {
+				return nil, err
+			}
+
+			options.AmazonSideAsn = o.AmazonSideAsn
+		}
+
+		if o.AutoAcceptSharedAttachments != nil {
+			options.AutoAcceptSharedAttachments = o.AutoAcceptSharedAttachments
+		}
+
+		if o.DefaultRouteTableAssociation != nil {
+			options.DefaultRouteTableAssociation = o.DefaultRouteTableAssociation
+		}
+
+		if o.DefaultRouteTablePropagation != nil {
+			options.DefaultRouteTablePropagation = o.DefaultRouteTablePropagation
+		}
+
+		if o.DnsSupport != nil {
+			options.DnsSupport = o.DnsSupport
+		}
+
+		if o.MulticastSupport != nil {
+			options.MulticastSupport = o.MulticastSupport
+		}
+
+		if o.VpnEcmpSupport != nil {
+			options.VpnEcmpSupport = o.VpnEcmpSupport
+		}
+	}
+
+	transitGatewayID := e.newID("tgw")
+
+	tgw := &ec2.TransitGateway{
+		CreationTime:      aws.Time(time.Now().UTC().Truncate(time.Second)),
+		Description:       input.Description,
+		Options:           options,
+		OwnerId:           aws.String(e.caller),
+		State:             aws.String(ec2.TransitGatewayStateAvailable),
+		TransitGatewayArn: aws.String(fmt.Sprintf("arn:aws:ec2:%s:%s:transit-gateway/%s", Region, e.caller, transitGatewayID)),
+		TransitGatewayId:  aws.String(transitGatewayID),
+	}
+
+	e.transitGateways[transitGatewayID] = tgw
+	e.setTransitGatewayDefaultRouteTables(tgw)
+	e.createTags(transitGatewayID, ec2.ResourceTypeTransitGateway, input.TagSpecifications)
+
+	return &ec2.CreateTransitGatewayOutput{
+		TransitGateway: e.describeTransitGateway(transitGatewayID),
+	}, nil
+}
+
+func (e *EC2) ModifyTransitGateway(input *ec2.ModifyTransitGatewayInput) (*ec2.ModifyTransitGatewayOutput, error) {
+	transitGatewayID := aws.StringValue(input.TransitGatewayId)
+	tgw, err := e.ownedTransitGateway(transitGatewayID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Description != nil {
+		tgw.Description = input.Description
+	}
+
+	if o := input.Options; o != nil {
+		for _, id := range []*string{o.AssociationDefaultRouteTableId, o.PropagationDefaultRouteTableId} {
+			if id == nil {
+				continue
+			}
+
+			rt, err := e.transitGatewayRouteTable(aws.StringValue(id))
+
+			if err != nil {
+				return nil, err
+			}
+
+			if aws.StringValue(rt.TransitGatewayId) != transitGatewayID {
+				return nil, ec2Error("InvalidParameterValue", "Transit Gateway Route Table %s does not belong to Transit Gateway %s", aws.StringValue(id), transitGatewayID)
+			}
+		}
+
+		options := tgw.Options
+
+		if o.AssociationDefaultRouteTableId != nil {
+			options.AssociationDefaultRouteTableId = o.AssociationDefaultRouteTableId
+			options.DefaultRouteTableAssociation = aws.String(ec2.DefaultRouteTableAssociationValueEnable)
+		}
+
+		if o.AutoAcceptSharedAttachments != nil {
+			options.AutoAcceptSharedAttachments = o.AutoAcceptSharedAttachments
+		}
+
+		if o.DefaultRouteTableAssociation != nil {
+			options.DefaultRouteTableAssociation = o.DefaultRouteTableAssociation
+		}
+
+		if o.DefaultRouteTablePropagation != nil {
+			options.DefaultRouteTablePropagation = o.DefaultRouteTablePropagation
+		}
+
+		if o.DnsSupport != nil {
+			options.DnsSupport = o.DnsSupport
+		}
+
+		if o.PropagationDefaultRouteTableId != nil {
+			options.PropagationDefaultRouteTableId = o.PropagationDefaultRouteTableId
+			options.DefaultRouteTablePropagation = aws.String(ec2.DefaultRouteTablePropagationValueEnable)
+		}
+
+		if o.VpnEcmpSupport != nil {
+			options.VpnEcmpSupport = o.VpnEcmpSupport
+		}
+
+		e.setTransitGatewayDefaultRouteTables(tgw)
+	}
+
+	return &ec2.ModifyTransitGatewayOutput{
+		TransitGateway: e.describeTransitGateway(transitGatewayID),
+	}, nil
+}
+
+// DeleteTransitGateway deletes a transit gateway and its default route
+// tables. It fails while the transit gateway has attachments or other route
+// tables. Deleted transit gateways remain visible.
+func (e *EC2) DeleteTransitGateway(input *ec2.DeleteTransitGatewayInput) (*ec2.DeleteTransitGatewayOutput, error) {
+	transitGatewayID := aws.StringValue(input.TransitGatewayId)
+	tgw, err := e.ownedTransitGateway(transitGatewayID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var attachmentIDs []string
+
+	for _, id := range sortedKeys(e.transitGatewayVpcAttachments) {
+		attachment := e.transitGatewayVpcAttachments[id]
+
+		if aws.StringValue(attachment.TransitGatewayId) == transitGatewayID && aws.StringValue(attachment.State) != ec2.TransitGatewayAttachmentStateDeleted {
+			attachmentIDs = append(attachmentIDs, id)
+		}
+	}
+
+	if len(attachmentIDs) > 0 {
+		return nil, ec2Error("IncorrectState", "%s has non-deleted Transit Gateway Attachments: %s", transitGatewayID, strings.Join(attachmentIDs, ", "))
+	}
+
+	var routeTableIDs []string
+
+	for _, id := range sortedKeys(e.transitGatewayRouteTables) {
+		rt := e.transitGatewayRouteTables[id]
+
+		if aws.StringValue(rt.TransitGatewayId) != transitGatewayID || aws.StringValue(rt.State) == ec2.TransitGatewayRouteTableStateDeleted {
+			continue
+		}
+
+		if aws.BoolValue(rt.DefaultAssociationRouteTable) || aws.BoolValue(rt.DefaultPropagationRouteTable) {
+			continue
+		}
+
+		routeTableIDs = append(routeTableIDs, id)
+	}
+
+	if len(routeTableIDs) > 0 {
+		return nil, ec2Error("IncorrectState", "%s has non-deleted Transit Gateway Route Tables: %s", transitGatewayID, strings.Join(routeTableIDs, ", "))
+	}
+
+	for _, id := range sortedKeys(e.transitGatewayRouteTables) {
+		rt := e.transitGatewayRouteTables[id]
+
+		if aws.StringValue(rt.TransitGatewayId) == transitGatewayID {
+			rt.State = aws.String(ec2.TransitGatewayRouteTableStateDeleted)
+		}
+	}
+
+	tgw.State = aws.String(ec2.TransitGatewayStateDeleted)
+
+	return &ec2.DeleteTransitGatewayOutput{
+		TransitGateway: e.describeTransitGateway(transitGatewayID),
+	}, nil
+}
+
+func (e *EC2) describeTransitGateway(id string) *ec2.TransitGateway {
+	tgw := awsutil.CopyOf(e.transitGateways[id]).(*ec2.TransitGateway)
+	tgw.Tags = e.ec2Tags(id)
+
+	return tgw
+}
+
+func (e *EC2) transitGatewayFilterValues(id, name string) ([]string, bool) {
+	tgw := e.transitGateways[id]
+
+	switch name {
+	case "options.amazon-side-asn":
+		return []string{fmt.Sprintf("%d", aws.Int64Value(tgw.Options.AmazonSideAsn))}, true
+	case "options.association-default-route-table-id":
+		return stringFilterValue(tgw.Options.AssociationDefaultRouteTableId), true
+	case "options.propagation-default-route-table-id":
+		return stringFilterValue(tgw.Options.PropagationDefaultRouteTableId), true
+	case "owner-id":
+		return stringFilterValue(tgw.OwnerId), true
+	case "state":
+		return stringFilterValue(tgw.State), true
+	case "transit-gateway-id":
+		return []string{id}, true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeTransitGateways(input *ec2.DescribeTransitGatewaysInput) (*ec2.DescribeTransitGatewaysOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.transitGateways), input.TransitGatewayIds, input.Filters, "InvalidTransitGatewayID.NotFound", "transitGateway", e.transitGatewayFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeTransitGatewaysOutput{}
+
+	for _, id := range ids {
+		output.TransitGateways = append(output.TransitGateways, e.describeTransitGateway(id))
+	}
+
+	return output, nil
+}
+
+//
+// VPC attachments
+//
+
+// validateTransitGatewayVpcAttachmentSubnets returns an error unless the
+// subnets are in the VPC and in different availability zones.
+func (e *EC2) validateTransitGatewayVpcAttachmentSubnets(vpcID string, subnetIDs []string) error {
+	if len(subnetIDs) == 0 {
+		return ec2Error("MissingParameter", "The request must contain the parameter SubnetIds")
+	}
+
+	zones := make(map[string]string)
+
+	for _, subnetID := range subnetIDs {
+		subnet, err := e.subnet(subnetID)
+
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(subnet.VpcId) != vpcID {
+			return ec2Error("InvalidParameterValue", "Subnet %s does not belong to VPC %s", subnetID, vpcID)
+		}
+
+		zone := aws.StringValue(subnet.AvailabilityZone)
+
+		if other, ok := zones[zone]; ok {
+			return ec2Error("DuplicateSubnetsInSameZone", "Subnets %s and %s are in the same availability zone %s", other, subnetID, zone)
+		}
+
+		zones[zone] = subnetID
+	}
+
+	return nil
+}
+
+// CreateTransitGatewayVpcAttachment attaches a VPC owned by the caller to a
+// transit gateway. An attachment to another account's transit gateway is
+// pending acceptance unless the transit gateway accepts shared attachments
+// automatically.
+func (e *EC2) CreateTransitGatewayVpcAttachment(input *ec2.CreateTransitGatewayVpcAttachmentInput) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
+	tgw, err := e.transitGateway(aws.StringValue(input.TransitGatewayId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(input.VpcId)
+	vpc, err := e.vpc(vpcID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(vpc.OwnerId) != e.caller {
+		return nil, ec2Error("InvalidVpcID.NotFound", "The vpc ID '%s' does not exist", vpcID)
+	}
+
+	subnetIDs := aws.StringValueSlice(input.SubnetIds)
+
+	if err := e.validateTransitGatewayVpcAttachmentSubnets(vpcID, subnetIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range sortedKeys(e.transitGatewayVpcAttachments) {
+		attachment := e.transitGatewayVpcAttachments[id]
+
+		if aws.StringValue(attachment.State) == ec2.TransitGatewayAttachmentStateDeleted {
+			continue
+		}
+
+		if aws.StringValue(attachment.TransitGatewayId) == aws.StringValue(tgw.TransitGatewayId) && aws.StringValue(attachment.VpcId) == vpcID {
+			return nil, ec2Error("DuplicateTransitGatewayAttachment", "%s has non-deleted Transit Gateway Attachments with same VPC ID.", aws.StringValue(tgw.TransitGatewayId))
+		}
+	}
+
+	options := &ec2.TransitGatewayVpcAttachmentOptions{
+		DnsSupport: aws.String(ec2.DnsSupportValueEnable),
+	}
+
+	if input.Options != nil && input.Options.DnsSupport != nil {
+		options.DnsSupport = input.Options.DnsSupport
+	}
+
+	attachmentID := e.newID("tgw-attach")
+
+	attachment := &ec2.TransitGatewayVpcAttachment{
+		CreationTime:               aws.Time(time.Now().UTC().Truncate(time.Second)),
+		Options:                    options,
+		State:                      aws.String(ec2.TransitGatewayAttachmentStatePendingAcceptance),
+		SubnetIds:                  aws.StringSlice(subnetIDs),
+		TransitGatewayAttachmentId: aws.String(attachmentID),
+		TransitGatewayId:           tgw.TransitGatewayId,
+		VpcId:                      vpc.VpcId,
+		VpcOwnerId:                 vpc.OwnerId,
+	}
+
+	e.transitGatewayVpcAttachments[attachmentID] = attachment
+	e.createTags(attachmentID, ec2.ResourceTypeTransitGatewayAttachment, input.TagSpecifications)
+
+	if aws.StringValue(tgw.OwnerId) == e.caller || aws.StringValue(tgw.Options.AutoAcceptSharedAttachments) == ec2.AutoAcceptSharedAttachmentsValueEnable {
+		e.acceptTransitGatewayVpcAttachment(attachment)
+	}
+
+	return &ec2.CreateTransitGatewayVpcAttachmentOutput{
+		TransitGatewayVpcAttachment: e.describeTransitGatewayVpcAttachment(attachmentID),
+	}, nil
+}
+
+// acceptTransitGatewayVpcAttachment makes an attachment available and
+// associates it with and propagates it to the transit gateway's default
+// route tables.
+func (e *EC2) acceptTransitGatewayVpcAttachment(attachment *ec2.TransitGatewayVpcAttachment) {
+	attachment.State = aws.String(ec2.TransitGatewayAttachmentStateAvailable)
+
+	tgw := e.transitGateways[aws.StringValue(attachment.TransitGatewayId)]
+
+	if id := tgw.Options.AssociationDefaultRouteTableId; id != nil {
+		e.associateTransitGatewayRouteTable(aws.StringValue(id), attachment)
+	}
+
+	if id := tgw.Options.PropagationDefaultRouteTableId; id != nil {
+		e.enableTransitGatewayRouteTablePropagation(aws.StringValue(id), attachment)
+	}
+}
+
+// AcceptTransitGatewayVpcAttachment accepts an attachment pending acceptance
+// on behalf of the transit gateway owner.
+func (e *EC2) AcceptTransitGatewayVpcAttachment(input *ec2.AcceptTransitGatewayVpcAttachmentInput) (*ec2.AcceptTransitGatewayVpcAttachmentOutput, error) {
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+	attachment, err := e.transitGatewayVpcAttachment(attachmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := e.ownedTransitGateway(aws.StringValue(attachment.TransitGatewayId)); err != nil {
+		return nil, ec2Error("InvalidTransitGatewayAttachmentID.NotFound", "Transit Gateway Attachment %s was deleted or does not exist.", attachmentID)
+	}
+
+	if state := aws.StringValue(attachment.State); state != ec2.TransitGatewayAttachmentStatePendingAcceptance {
+		return nil, ec2Error("IncorrectState", "tgw-attachment %s is in invalid state: %s", attachmentID, state)
+	}
+
+	e.acceptTransitGatewayVpcAttachment(attachment)
+
+	return &ec2.AcceptTransitGatewayVpcAttachmentOutput{
+		TransitGatewayVpcAttachment: e.describeTransitGatewayVpcAttachment(attachmentID),
+	}, nil
+}
+
+func (e *EC2) ModifyTransitGatewayVpcAttachment(input *ec2.ModifyTransitGatewayVpcAttachmentInput) (*ec2.ModifyTransitGatewayVpcAttachmentOutput, error) {
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+	attachment, err := e.transitGatewayVpcAttachment(attachmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(attachment.VpcOwnerId) != e.caller {
+		return nil, ec2Error("InvalidTransitGatewayAttachmentID.NotFound", "Transit Gateway Attachment %s was deleted or does not exist.", attachmentID)
+	}
+
+	if state := aws.StringValue(attachment.State); state != ec2.TransitGatewayAttachmentStateAvailable {
+		return nil, ec2Error("IncorrectState", "tgw-attachment %s is in invalid state: %s", attachmentID, state)
+	}
+
+	remove := make(map[string]bool)
+
+	for _, id := range aws.StringValueSlice(input.RemoveSubnetIds) {
+		remove[id] = true
+	}
+
+	var subnetIDs []string
+
+	for _, id := range aws.StringValueSlice(attachment.SubnetIds) {
+		if !remove[id] {
+			subnetIDs = append(subnetIDs, id)
+		}
+	}
+
+	subnetIDs = append(subnetIDs, aws.StringValueSlice(input.AddSubnetIds)...)
+
+	if err := e.validateTransitGatewayVpcAttachmentSubnets(aws.StringValue(attachment.VpcId), subnetIDs); err != nil {
+		return nil, err
+	}
+
+	attachment.SubnetIds = aws.StringSlice(subnetIDs)
+
+	if input.Options != nil && input.Options.DnsSupport != nil {
+		attachment.Options.DnsSupport = input.Options.DnsSupport
+	}
+
+	return &ec2.ModifyTransitGatewayVpcAttachmentOutput{
+		TransitGatewayVpcAttachment: e.describeTransitGatewayVpcAttachment(attachmentID),
+	}, nil
+}
+
+// DeleteTransitGatewayVpcAttachment deletes an attachment on behalf of
+// either account, removing its association and propagations. Deleted
+// attachments remain visible.
+func (e *EC2) DeleteTransitGatewayVpcAttachment(input *ec2.DeleteTransitGatewayVpcAttachmentInput) (*ec2.DeleteTransitGatewayVpcAttachmentOutput, error) {
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+	attachment, err := e.transitGatewayVpcAttachment(attachmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	delete(e.transitGatewayAssociations, attachmentID)
+
+	for _, key := range sortedKeys(e.transitGatewayPropagations) {
+		if aws.StringValue(e.transitGatewayPropagations[key].TransitGatewayAttachmentId) == attachmentID {
+			delete(e.transitGatewayPropagations, key)
+		}
+	}
+
+	attachment.State = aws.String(ec2.TransitGatewayAttachmentStateDeleted)
+
+	return &ec2.DeleteTransitGatewayVpcAttachmentOutput{
+		TransitGatewayVpcAttachment: e.describeTransitGatewayVpcAttachment(attachmentID),
+	}, nil
+}
+
+// transitGatewaySubnetAttached returns whether a subnet is used by an
+// attachment that is not deleted.
+func (e *EC2) transitGatewaySubnetAttached(subnetID string) bool {
+	for _, id := range sortedKeys(e.transitGatewayVpcAttachments) {
+		attachment := e.transitGatewayVpcAttachments[id]
+
+		if aws.StringValue(attachment.State) == ec2.TransitGatewayAttachmentStateDeleted {
+			continue
+		}
+
+		for _, s := range aws.StringValueSlice(attachment.SubnetIds) {
+			if s == subnetID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// transitGatewayVpcAttached returns whether a VPC has an available
+// attachment to a transit gateway.
+func (e *EC2) transitGatewayVpcAttached(transitGatewayID, vpcID string) bool {
+	for _, id := range sortedKeys(e.transitGatewayVpcAttachments) {
+		attachment := e.transitGatewayVpcAttachments[id]
+
+		if aws.StringValue(attachment.State) != ec2.TransitGatewayAttachmentStateAvailable {
+			continue
+		}
+
+		if aws.StringValue(attachment.TransitGatewayId) == transitGatewayID && aws.StringValue(attachment.VpcId) == vpcID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EC2) describeTransitGatewayVpcAttachment(id string) *ec2.TransitGatewayVpcAttachment {
+	attachment := awsutil.CopyOf(e.transitGatewayVpcAttachments[id]).(*ec2.TransitGatewayVpcAttachment)
+	attachment.Tags = e.ec2Tags(id)
+
+	return attachment
+}
+
+func (e *EC2) transitGatewayVpcAttachmentFilterValues(id, name string) ([]string, bool) {
+	attachment := e.transitGatewayVpcAttachments[id]
+
+	switch name {
+	case "state":
+		return stringFilterValue(attachment.State), true
+	case "transit-gateway-attachment-id":
+		return []string{id}, true
+	case "transit-gateway-id":
+		return stringFilterValue(attachment.TransitGatewayId), true
+	case "vpc-id":
+		return stringFilterValue(attachment.VpcId), true
+	}
+
+	return nil, false
+}
+
+// DescribeTransitGatewayVpcAttachments describes the attachments where the
+// caller owns the VPC or the transit gateway.
+func (e *EC2) DescribeTransitGatewayVpcAttachments(input *ec2.DescribeTransitGatewayVpcAttachmentsInput) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error) {
+	var visible []string
+
+	for _, id := range sortedKeys(e.transitGatewayVpcAttachments) {
+		if e.transitGatewayVpcAttachmentVisible(e.transitGatewayVpcAttachments[id]) {
+			visible = append(visible, id)
+		}
+	}
+
+	ids, err := e.selectIDs(visible, input.TransitGatewayAttachmentIds, input.Filters, "InvalidTransitGatewayAttachmentID.NotFound", "transitGatewayAttachment", e.transitGatewayVpcAttachmentFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeTransitGatewayVpcAttachmentsOutput{}
+
+	for _, id := range ids {
+		output.TransitGatewayVpcAttachments = append(output.TransitGatewayVpcAttachments, e.describeTransitGatewayVpcAttachment(id))
+	}
+
+	return output, nil
+}
+
+//
+// Route tables
+//
+
+func (e *EC2) CreateTransitGatewayRouteTable(input *ec2.CreateTransitGatewayRouteTableInput) (*ec2.CreateTransitGatewayRouteTableOutput, error) {
+	tgw, err := e.ownedTransitGateway(aws.StringValue(input.TransitGatewayId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rt := e.createTransitGatewayRouteTable(aws.StringValue(tgw.TransitGatewayId))
+	routeTableID := aws.StringValue(rt.TransitGatewayRouteTableId)
+
+	e.createTags(routeTableID, ec2.ResourceTypeTransitGatewayRouteTable, input.TagSpecifications)
+
+	return &ec2.CreateTransitGatewayRouteTableOutput{
+		TransitGatewayRouteTable: e.describeTransitGatewayRouteTable(routeTableID),
+	}, nil
+}
+
+// DeleteTransitGatewayRouteTable deletes a route table that is not a default
+// route table and has no associations. Its propagations are removed and
+// deleted route tables remain visible.
+func (e *EC2) DeleteTransitGatewayRouteTable(input *ec2.DeleteTransitGatewayRouteTableInput) (*ec2.DeleteTransitGatewayRouteTableOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+	rt, err := e.transitGatewayRouteTable(routeTableID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.BoolValue(rt.DefaultAssociationRouteTable) || aws.BoolValue(rt.DefaultPropagationRouteTable) {
+		return nil, ec2Error("IncorrectState", "Transit Gateway Route Table %s is a default route table of %s", routeTableID, aws.StringValue(rt.TransitGatewayId))
+	}
+
+	for _, id := range sortedKeys(e.transitGatewayAssociations) {
+		if aws.StringValue(e.transitGatewayAssociations[id].TransitGatewayRouteTableId) == routeTableID {
+			return nil, ec2Error("IncorrectState", "Transit Gateway Route Table %s has associations", routeTableID)
+		}
+	}
+
+	for _, key := range sortedKeys(e.transitGatewayPropagations) {
+		if aws.StringValue(e.transitGatewayPropagations[key].TransitGatewayRouteTableId) == routeTableID {
+			delete(e.transitGatewayPropagations, key)
+		}
+	}
+
+	rt.State = aws.String(ec2.TransitGatewayRouteTableStateDeleted)
+
+	return &ec2.DeleteTransitGatewayRouteTableOutput{
+		TransitGatewayRouteTable: e.describeTransitGatewayRouteTable(routeTableID),
+	}, nil
+}
+
+func (e *EC2) describeTransitGatewayRouteTable(id string) *ec2.TransitGatewayRouteTable {
+	rt := awsutil.CopyOf(e.transitGatewayRouteTables[id]).(*ec2.TransitGatewayRouteTable)
+	rt.Tags = e.ec2Tags(id)
+
+	return rt
+}
+
+func (e *EC2) transitGatewayRouteTableFilterValues(id, name string) ([]string, bool) {
+	rt := e.transitGatewayRouteTables[id]
+
+	switch name {
+	case "default-association-route-table":
+		return boolFilterValue(rt.DefaultAssociationRouteTable), true
+	case "default-propagation-route-table":
+		return boolFilterValue(rt.DefaultPropagationRouteTable), true
+	case "state":
+		return stringFilterValue(rt.State), true
+	case "transit-gateway-id":
+		return stringFilterValue(rt.TransitGatewayId), true
+	case "transit-gateway-route-table-id":
+		return []string{id}, true
+	}
+
+	return nil, false
+}
+
+// DescribeTransitGatewayRouteTables describes the route tables of transit
+// gateways owned by the caller.
+func (e *EC2) DescribeTransitGatewayRouteTables(input *ec2.DescribeTransitGatewayRouteTablesInput) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
+	var visible []string
+
+	for _, id := range sortedKeys(e.transitGatewayRouteTables) {
+		if e.transitGatewayRouteTableVisible(e.transitGatewayRouteTables[id]) {
+			visible = append(visible, id)
+		}
+	}
+
+	ids, err := e.selectIDs(visible, input.TransitGatewayRouteTableIds, input.Filters, "InvalidRouteTableID.NotFound", "transitGatewayRouteTable", e.transitGatewayRouteTableFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeTransitGatewayRouteTablesOutput{}
+
+	for _, id := range ids {
+		output.TransitGatewayRouteTables = append(output.TransitGatewayRouteTables, e.describeTransitGatewayRouteTable(id))
+	}
+
+	return output, nil
+}
+
+//
+// Associations and propagations
+//
+
+// routeTableAttachment returns a route table owned by the caller and an
+// available attachment to its transit gateway.
+func (e *EC2) routeTableAttachment(routeTableID, attachmentID string) (*ec2.TransitGatewayRouteTable, *ec2.TransitGatewayVpcAttachment, error) {
+	rt, err := e.transitGatewayRouteTable(routeTableID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := e.transitGatewayVpcAttachment(attachmentID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if aws.StringValue(attachment.TransitGatewayId) != aws.StringValue(rt.TransitGatewayId) {
+		return nil, nil, ec2Error("InvalidParameterValue", "Transit Gateway Attachment %s and Transit Gateway Route Table %s belong to different transit gateways", attachmentID, routeTableID)
+	}
+
+	if state := aws.StringValue(attachment.State); state != ec2.TransitGatewayAttachmentStateAvailable {
+		return nil, nil, ec2Error("IncorrectState", "tgw-attachment %s is in invalid state: %s", attachmentID, state)
+	}
+
+	return rt, attachment, nil
+}
+
+func (e *EC2) associateTransitGatewayRouteTable(routeTableID string, attachment *ec2.TransitGatewayVpcAttachment) *ec2.TransitGatewayAssociation {
+	association := &ec2.TransitGatewayAssociation{
+		ResourceId:                 attachment.VpcId,
+		ResourceType:               aws.String(ec2.TransitGatewayAttachmentResourceTypeVpc),
+		State:                      aws.String(ec2.TransitGatewayAssociationStateAssociated),
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+	}
+
+	e.transitGatewayAssociations[aws.StringValue(attachment.TransitGatewayAttachmentId)] = association
+
+	return association
+}
+
+func (e *EC2) AssociateTransitGatewayRouteTable(input *ec2.AssociateTransitGatewayRouteTableInput) (*ec2.AssociateTransitGatewayRouteTableOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+	_, attachment, err := e.routeTableAttachment(routeTableID, attachmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := e.transitGatewayAssociations[attachmentID]; ok {
+		return nil, ec2Error("Resource.AlreadyAssociated", "Transit Gateway Attachment %s is already associated to a route table.", attachmentID)
+	}
+
+	association := e.associateTransitGatewayRouteTable(routeTableID, attachment)
+
+	return &ec2.AssociateTransitGatewayRouteTableOutput{
+		Association: awsutil.CopyOf(association).(*ec2.TransitGatewayAssociation),
+	}, nil
+}
+
+func (e *EC2) DisassociateTransitGatewayRouteTable(input *ec2.DisassociateTransitGatewayRouteTableInput) (*ec2.DisassociateTransitGatewayRouteTableOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+
+	if _, err := e.transitGatewayRouteTable(routeTableID); err != nil {
+		return nil, err
+	}
+
+	association, ok := e.transitGatewayAssociations[attachmentID]
+
+	if !ok || aws.StringValue(association.TransitGatewayRouteTableId) != routeTableID {
+		return nil, ec2Error("InvalidAssociation.NotFound", "Association %s - %s not found", routeTableID, attachmentID)
+	}
+
+	delete(e.transitGatewayAssociations, attachmentID)
+
+	association = awsutil.CopyOf(association).(*ec2.TransitGatewayAssociation)
+	association.State = aws.String(ec2.TransitGatewayAssociationStateDisassociating)
+
+	return &ec2.DisassociateTransitGatewayRouteTableOutput{
+		Association: association,
+	}, nil
+}
+
+func transitGatewayAttachmentFilterValues(resourceID, resourceType, attachmentID *string) filterValuesFunc {
+	return func(_, name string) ([]string, bool) {
+		switch name {
+		case "resource-id":
+			return stringFilterValue(resourceID), true
+		case "resource-type":
+			return stringFilterValue(resourceType), true
+		case "transit-gateway-attachment-id":
+			return stringFilterValue(attachmentID), true
+		}
+
+		return nil, false
+	}
+}
+
+func (e *EC2) GetTransitGatewayRouteTableAssociations(input *ec2.GetTransitGatewayRouteTableAssociationsInput) (*ec2.GetTransitGatewayRouteTableAssociationsOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+
+	if _, err := e.transitGatewayRouteTable(routeTableID); err != nil {
+		return nil, err
+	}
+
+	output := &ec2.GetTransitGatewayRouteTableAssociationsOutput{}
+
+	for _, id := range sortedKeys(e.transitGatewayAssociations) {
+		association := e.transitGatewayAssociations[id]
+
+		if aws.StringValue(association.TransitGatewayRouteTableId) != routeTableID {
+			continue
+		}
+
+		ok, err := e.matchFilters(id, input.Filters, transitGatewayAttachmentFilterValues(association.ResourceId, association.ResourceType, association.TransitGatewayAttachmentId))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		output.Associations = append(output.Associations, &ec2.TransitGatewayRouteTableAssociation{
+			ResourceId:                 association.ResourceId,
+			ResourceType:               association.ResourceType,
+			State:                      association.State,
+			TransitGatewayAttachmentId: association.TransitGatewayAttachmentId,
+		})
+	}
+
+	return output, nil
+}
+
+func (e *EC2) enableTransitGatewayRouteTablePropagation(routeTableID string, attachment *ec2.TransitGatewayVpcAttachment) *ec2.TransitGatewayPropagation {
+	propagation := &ec2.TransitGatewayPropagation{
+		ResourceId:                 attachment.VpcId,
+		ResourceType:               aws.String(ec2.TransitGatewayAttachmentResourceTypeVpc),
+		State:                      aws.String(ec2.TransitGatewayPropagationStateEnabled),
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+	}
+
+	e.transitGatewayPropagations[transitGatewayPropagationKey(routeTableID, aws.StringValue(attachment.TransitGatewayAttachmentId))] = propagation
+
+	return propagation
+}
+
+func (e *EC2) EnableTransitGatewayRouteTablePropagation(input *ec2.EnableTransitGatewayRouteTablePropagationInput) (*ec2.EnableTransitGatewayRouteTablePropagationOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+	_, attachment, err := e.routeTableAttachment(routeTableID, attachmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := e.transitGatewayPropagations[transitGatewayPropagationKey(routeTableID, attachmentID)]; ok {
+		return nil, ec2Error("TransitGatewayRouteTablePropagation.Duplicate", "Propagation of %s to %s already exists", attachmentID, routeTableID)
+	}
+
+	propagation := e.enableTransitGatewayRouteTablePropagation(routeTableID, attachment)
+
+	return &ec2.EnableTransitGatewayRouteTablePropagationOutput{
+		Propagation: awsutil.CopyOf(propagation).(*ec2.TransitGatewayPropagation),
+	}, nil
+}
+
+func (e *EC2) DisableTransitGatewayRouteTablePropagation(input *ec2.DisableTransitGatewayRouteTablePropagationInput) (*ec2.DisableTransitGatewayRouteTablePropagationOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+	attachmentID := aws.StringValue(input.TransitGatewayAttachmentId)
+
+	if _, err := e.transitGatewayRouteTable(routeTableID); err != nil {
+		return nil, err
+	}
+
+	key := transitGatewayPropagationKey(routeTableID, attachmentID)
+	propagation, ok := e.transitGatewayPropagations[key]
+
+	if !ok {
+		return nil, ec2Error("TransitGatewayRouteTablePropagation.NotFound", "Propagation of %s to %s does not exist", attachmentID, routeTableID)
+	}
+
+	delete(e.transitGatewayPropagations, key)
+
+	propagation = awsutil.CopyOf(propagation).(*ec2.TransitGatewayPropagation)
+	propagation.State = aws.String(ec2.TransitGatewayPropagationStateDisabling)
+
+	return &ec2.DisableTransitGatewayRouteTablePropagationOutput{
+		Propagation: propagation,
+	}, nil
+}
+
+func (e *EC2) GetTransitGatewayRouteTablePropagations(input *ec2.GetTransitGatewayRouteTablePropagationsInput) (*ec2.GetTransitGatewayRouteTablePropagationsOutput, error) {
+	routeTableID := aws.StringValue(input.TransitGatewayRouteTableId)
+
+	if _, err := e.transitGatewayRouteTable(routeTableID); err != nil {
+		return nil, err
+	}
+
+	output := &ec2.GetTransitGatewayRouteTablePropagationsOutput{}
+
+	for _, key := range sortedKeys(e.transitGatewayPropagations) {
+		propagation := e.transitGatewayPropagations[key]
+
+		if aws.StringValue(propagation.TransitGatewayRouteTableId) != routeTableID {
+			continue
+		}
+
+		ok, err := e.matchFilters(key, input.Filters, transitGatewayAttachmentFilterValues(propagation.ResourceId, propagation.ResourceType, propagation.TransitGatewayAttachmentId))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		output.TransitGatewayRouteTablePropagations = append(output.TransitGatewayRouteTablePropagations, &ec2.TransitGatewayRouteTablePropagation{
+			ResourceId:                 propagation.ResourceId,
+			ResourceType:               propagation.ResourceType,
+			State:                      propagation.State,
+			TransitGatewayAttachmentId: propagation.TransitGatewayAttachmentId,
+		})
+	}
+
+	return output, nil
+}
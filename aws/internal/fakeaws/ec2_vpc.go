@@ -0,0 +1,707 @@
+package fakeaws
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const defaultVpcCidrBlock = "172.31.0.0/16"
+
+type vpcAttributes struct {
+	enableDnsHostnames bool
+	enableDnsSupport   bool
+}
+
+func (e *EC2) createDefaultVpc() {
+	vpc := e.createVpc(defaultVpcCidrBlock, ec2.TenancyDefault, nil)
+	vpcID := aws.StringValue(vpc.VpcId)
+
+	vpc.IsDefault = aws.Bool(true)
+	e.vpcAttributes[vpcID].enableDnsHostnames = true
+	e.DefaultVpcID = vpcID
+
+	_, network, _ := net.ParseCIDR(defaultVpcCidrBlock)
+
+	for i, availabilityZone := range sortedKeys(AvailabilityZones) {
+		cidrBlock := &net.IPNet{
+			IP:   addToIP(network.IP, uint32(i)<<12),
+			Mask: net.CIDRMask(20, 32),
+		}
+
+		subnet := e.createSubnet(vpc, cidrBlock.String(), availabilityZone)
+		subnet.DefaultForAz = aws.Bool(true)
+		subnet.MapPublicIpOnLaunch = aws.Bool(true)
+	}
+
+	igw := e.createInternetGateway()
+	igw.Attachments = []*ec2.InternetGatewayAttachment{{
+		State: aws.String(internetGatewayAttachmentStateAvailable),
+		VpcId: vpc.VpcId,
+	}}
+
+	rt := e.mainRouteTable(vpcID)
+	rt.Routes = append(rt.Routes, &ec2.Route{
+		DestinationCidrBlock: aws.String("0.0.0.0/0"),
+		GatewayId:            igw.InternetGatewayId,
+		Origin:               aws.String(ec2.RouteOriginCreateRoute),
+		State:                aws.String(ec2.RouteStateActive),
+	})
+}
+
+// createVpc creates a VPC with its default security group, default network
+// ACL and main route table, and with an optional IPv6 CIDR block.
+func (e *EC2) createVpc(cidrBlock, instanceTenancy string, ipv6 *ec2.VpcIpv6CidrBlockAssociation) *ec2.Vpc {
+	vpcID := e.newID("vpc")
+
+	vpc := &ec2.Vpc{
+		CidrBlock: aws.String(cidrBlock),
+		CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{{
+			AssociationId: aws.String(e.newID("vpc-cidr-assoc")),
+			CidrBlock:     aws.String(cidrBlock),
+			CidrBlockState: &ec2.VpcCidrBlockState{
+				State: aws.String(ec2.VpcCidrBlockStateCodeAssociated),
+			},
+		}},
+		DhcpOptionsId:   aws.String(defaultDhcpOptionsID),
+		InstanceTenancy: aws.String(instanceTenancy),
+		IsDefault:       aws.Bool(false),
+		OwnerId:         aws.String(e.caller),
+		State:           aws.String(ec2.VpcStateAvailable),
+		VpcId:           aws.String(vpcID),
+	}
+
+	if ipv6 != nil {
+		vpc.Ipv6CidrBlockAssociationSet = append(vpc.Ipv6CidrBlockAssociationSet, ipv6)
+	}
+
+	e.vpcs[vpcID] = vpc
+	e.vpcAttributes[vpcID] = &vpcAttributes{
+		enableDnsSupport: true,
+	}
+
+	e.createSecurityGroup(vpcID, "default", "default VPC security group")
+	e.createNetworkAcl(vpcID, true)
+	e.createRouteTable(vpcID, true)
+
+	return vpc
+}
+
+func (e *EC2) newVpcIpv6CidrBlockAssociation() *ec2.VpcIpv6CidrBlockAssociation {
+	e.ids["ipv6-pool"]++
+	n := e.ids["ipv6-pool"]
+
+	return &ec2.VpcIpv6CidrBlockAssociation{
+		AssociationId: aws.String(e.newID("vpc-cidr-assoc")),
+		Ipv6CidrBlock: aws.String(fmt.Sprintf("2600:1f14:%x:%x00::/56", 0xa00+n>>8, n&0xff)),
+		Ipv6CidrBlockState: &ec2.VpcCidrBlockState{
+			State: aws.String(ec2.VpcCidrBlockStateCodeAssociated),
+		},
+		Ipv6Pool:           aws.String("Amazon"),
+		NetworkBorderGroup: aws.String(Region),
+	}
+}
+
+// requestedVpcIpv6CidrBlockAssociation returns a new IPv6 CIDR block
+// association for a VPC, either Amazon-provided or allocated from an IPv6
+// address pool of the caller, or nil if no IPv6 CIDR block is requested.
+func (e *EC2) requestedVpcIpv6CidrBlockAssociation(amazonProvided bool, poolID, cidrBlock string) (*ec2.VpcIpv6CidrBlockAssociation, error) {
+	switch {
+	case amazonProvided && poolID != "":
+		return nil, ec2Error("InvalidParameterCombination", "The parameters amazonProvidedIpv6CidrBlock and ipv6Pool cannot be used together")
+	case poolID != "":
+		cidrBlock, err := e.allocateIpv6PoolCidrBlock(poolID, cidrBlock)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &ec2.VpcIpv6CidrBlockAssociation{
+			AssociationId: aws.String(e.newID("vpc-cidr-assoc")),
+			Ipv6CidrBlock: aws.String(cidrBlock),
+			Ipv6CidrBlockState: &ec2.VpcCidrBlockState{
+				State: aws.String(ec2.VpcCidrBlockStateCodeAssociated),
+			},
+			Ipv6Pool:           aws.String(poolID),
+			NetworkBorderGroup: aws.String(Region),
+		}, nil
+	case cidrBlock != "":
+		return nil, ec2Error("MissingParameter", "The request must contain the parameter ipv6Pool")
+	case amazonProvided:
+		return e.newVpcIpv6CidrBlockAssociation(), nil
+	}
+
+	return nil, nil
+}
+
+func (e *EC2) vpc(id string) (*ec2.Vpc, error) {
+	vpc, ok := e.vpcs[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidVpcID.NotFound", "The vpc ID '%s' does not exist", id)
+	}
+
+	return vpc, nil
+}
+
+// vpcCidrBlocks returns the associated IPv4 CIDR blocks of a VPC.
+func vpcCidrBlocks(vpc *ec2.Vpc) []string {
+	var cidrBlocks []string
+
+	for _, a := range vpc.CidrBlockAssociationSet {
+		if aws.StringValue(a.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			cidrBlocks = append(cidrBlocks, aws.StringValue(a.CidrBlock))
+		}
+	}
+
+	return cidrBlocks
+}
+
+// vpcIpv6CidrBlocks returns the associated IPv6 CIDR blocks of a VPC.
+func vpcIpv6CidrBlocks(vpc *ec2.Vpc) []string {
+	var cidrBlocks []string
+
+	for _, a := range vpc.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(a.Ipv6CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			cidrBlocks = append(cidrBlocks, aws.StringValue(a.Ipv6CidrBlock))
+		}
+	}
+
+	return cidrBlocks
+}
+
+func validateVpcCidrBlock(cidrBlock string) error {
+	_, network, err := net.ParseCIDR(cidrBlock)
+
+	if err != nil || network.IP.To4() == nil || network.String() != cidrBlock {
+		return ec2Error("InvalidParameterValue", "Value (%s) for parameter cidrBlock is invalid. This is not a valid CIDR block.", cidrBlock)
+	}
+
+	if ones, _ := network.Mask.Size(); ones < 16 || ones > 28 {
+		return ec2Error("InvalidVpc.Range", "The CIDR '%s' is invalid.", cidrBlock)
+	}
+
+	return nil
+}
+
+// maxVpcCidrBlocks is the maximum number of IPv4 CIDR blocks of a VPC.
+const maxVpcCidrBlocks = 5
+
+// rfc1918CidrBlocks are the private IPv4 address ranges.
+var rfc1918CidrBlocks = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// restrictedVpcCidrBlock returns whether a secondary CIDR block cannot be
+// added to a VPC with the given primary CIDR block: a VPC whose primary CIDR
+// block is in one private address range cannot add blocks from the others.
+func restrictedVpcCidrBlock(primary, cidrBlock string) bool {
+	for _, private := range rfc1918CidrBlocks {
+		if cidrContains(private, primary) {
+			for _, other := range rfc1918CidrBlocks {
+				if other != private && cidrContains(other, cidrBlock) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (e *EC2) CreateVpc(input *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
+	cidrBlock := aws.StringValue(input.CidrBlock)
+
+	if err := validateVpcCidrBlock(cidrBlock); err != nil {
+		return nil, err
+	}
+
+	instanceTenancy := aws.StringValue(input.InstanceTenancy)
+
+	if instanceTenancy == "" {
+		instanceTenancy = ec2.TenancyDefault
+	}
+
+	ipv6, err := e.requestedVpcIpv6CidrBlockAssociation(aws.BoolValue(input.AmazonProvidedIpv6CidrBlock), aws.StringValue(input.Ipv6Pool), aws.StringValue(input.Ipv6CidrBlock))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpc := e.createVpc(cidrBlock, instanceTenancy, ipv6)
+
+	return &ec2.CreateVpcOutput{
+		Vpc: e.describeVpc(aws.StringValue(vpc.VpcId)),
+	}, nil
+}
+
+func (e *EC2) DeleteVpc(input *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	if dependency, ok := e.vpcDependency(vpcID); ok {
+		return nil, ec2Error("DependencyViolation", "The vpc '%s' has dependencies and cannot be deleted: %s", vpcID, dependency)
+	}
+
+	for _, id := range sortedKeys(e.securityGroups) {
+		if aws.StringValue(e.securityGroups[id].VpcId) == vpcID {
+			e.deleteResource(id)
+		}
+	}
+
+	for _, id := range sortedKeys(e.networkAcls) {
+		if aws.StringValue(e.networkAcls[id].VpcId) == vpcID {
+			e.deleteResource(id)
+		}
+	}
+
+	for _, id := range sortedKeys(e.routeTables) {
+		if aws.StringValue(e.routeTables[id].VpcId) == vpcID {
+			e.deleteResource(id)
+		}
+	}
+
+	e.deleteVpcPeeringConnections(vpcID)
+	e.deleteResource(vpcID)
+
+	if e.DefaultVpcID == vpcID {
+		e.DefaultVpcID = ""
+	}
+
+	return &ec2.DeleteVpcOutput{}, nil
+}
+
+// vpcDependency returns a resource preventing deletion of a VPC.
+func (e *EC2) vpcDependency(vpcID string) (string, bool) {
+	for _, id := range sortedKeys(e.subnets) {
+		if aws.StringValue(e.subnets[id].VpcId) == vpcID {
+			return id, true
+		}
+	}
+
+	for _, id := range sortedKeys(e.internetGateways) {
+		for _, a := range e.internetGateways[id].Attachments {
+			if aws.StringValue(a.VpcId) == vpcID {
+				return id, true
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(e.securityGroups) {
+		sg := e.securityGroups[id]
+
+		if aws.StringValue(sg.VpcId) == vpcID && aws.StringValue(sg.GroupName) != "default" {
+			return id, true
+		}
+	}
+
+	for _, id := range sortedKeys(e.networkAcls) {
+		acl := e.networkAcls[id]
+
+		if aws.StringValue(acl.VpcId) == vpcID && !aws.BoolValue(acl.IsDefault) {
+			return id, true
+		}
+	}
+
+	for _, id := range sortedKeys(e.routeTables) {
+		rt := e.routeTables[id]
+
+		if aws.StringValue(rt.VpcId) == vpcID && !isMainRouteTable(rt) {
+			return id, true
+		}
+	}
+
+	for _, id := range sortedKeys(e.vpcEndpoints) {
+		if aws.StringValue(e.vpcEndpoints[id].VpcId) == vpcID {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// deleteResource removes a resource, its tags and its flow logs.
+func (e *EC2) deleteResource(id string) {
+	e.deleteFlowLogs(id)
+
+	delete(e.addresses, id)
+	delete(e.clientVpnEndpoints, id)
+	delete(e.dhcpOptionsSets, id)
+	delete(e.flowLogs, id)
+	delete(e.internetGateways, id)
+	delete(e.managedPrefixLists, id)
+	delete(e.natGateways, id)
+	delete(e.networkAcls, id)
+	delete(e.networkInterfaces, id)
+	delete(e.routeTables, id)
+	delete(e.securityGroupRules, id)
+	delete(e.securityGroups, id)
+	delete(e.subnets, id)
+	delete(e.tags, id)
+	delete(e.vpcAttributes, id)
+	delete(e.vpcEndpointServices, id)
+	delete(e.vpcEndpoints, id)
+	delete(e.vpcs, id)
+}
+
+func (e *EC2) describeVpc(id string) *ec2.Vpc {
+	vpc := awsutil.CopyOf(e.vpcs[id]).(*ec2.Vpc)
+	vpc.Tags = e.ec2Tags(id)
+
+	return vpc
+}
+
+func (e *EC2) vpcFilterValues(id, name string) ([]string, bool) {
+	vpc := e.vpcs[id]
+
+	switch name {
+	case "cidr", "cidr-block", "cidrBlock":
+		return stringFilterValue(vpc.CidrBlock), true
+	case "cidr-block-association.association-id":
+		var ids []string
+
+		for _, a := range vpc.CidrBlockAssociationSet {
+			ids = append(ids, aws.StringValue(a.AssociationId))
+		}
+
+		return ids, true
+	case "cidr-block-association.cidr-block":
+		return vpcCidrBlocks(vpc), true
+	case "cidr-block-association.state":
+		var states []string
+
+		for _, a := range vpc.CidrBlockAssociationSet {
+			states = append(states, aws.StringValue(a.CidrBlockState.State))
+		}
+
+		return states, true
+	case "dhcp-options-id":
+		return stringFilterValue(vpc.DhcpOptionsId), true
+	case "ipv6-cidr-block-association.association-id":
+		var ids []string
+
+		for _, a := range vpc.Ipv6CidrBlockAssociationSet {
+			ids = append(ids, aws.StringValue(a.AssociationId))
+		}
+
+		return ids, true
+	case "ipv6-cidr-block-association.ipv6-cidr-block":
+		return vpcIpv6CidrBlocks(vpc), true
+	case "ipv6-cidr-block-association.ipv6-pool":
+		var pools []string
+
+		for _, a := range vpc.Ipv6CidrBlockAssociationSet {
+			pools = append(pools, aws.StringValue(a.Ipv6Pool))
+		}
+
+		return pools, true
+	case "ipv6-cidr-block-association.state":
+		var states []string
+
+		for _, a := range vpc.Ipv6CidrBlockAssociationSet {
+			states = append(states, aws.StringValue(a.Ipv6CidrBlockState.State))
+		}
+
+		return states, true
+	case "isDefault", "is-default":
+		return boolFilterValue(vpc.IsDefault), true
+	case "owner-id":
+		return stringFilterValue(vpc.OwnerId), true
+	case "state":
+		return stringFilterValue(vpc.State), true
+	case "vpc-id":
+		return []string{id}, true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.vpcs), input.VpcIds, input.Filters, "InvalidVpcID.NotFound", "vpc", e.vpcFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeVpcsOutput{}
+
+	for _, id := range ids {
+		output.Vpcs = append(output.Vpcs, e.describeVpc(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) DescribeVpcAttribute(input *ec2.DescribeVpcAttributeInput) (*ec2.DescribeVpcAttributeOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	attributes := e.vpcAttributes[vpcID]
+	output := &ec2.DescribeVpcAttributeOutput{
+		VpcId: aws.String(vpcID),
+	}
+
+	switch aws.StringValue(input.Attribute) {
+	case ec2.VpcAttributeNameEnableDnsHostnames:
+		output.EnableDnsHostnames = &ec2.AttributeBooleanValue{Value: aws.Bool(attributes.enableDnsHostnames)}
+	case ec2.VpcAttributeNameEnableDnsSupport:
+		output.EnableDnsSupport = &ec2.AttributeBooleanValue{Value: aws.Bool(attributes.enableDnsSupport)}
+	default:
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter attribute is invalid.", aws.StringValue(input.Attribute))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) ModifyVpcAttribute(input *ec2.ModifyVpcAttributeInput) (*ec2.ModifyVpcAttributeOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	if (input.EnableDnsHostnames == nil) == (input.EnableDnsSupport == nil) {
+		return nil, ec2Error("InvalidParameterCombination", "Exactly one attribute must be specified.")
+	}
+
+	attributes := e.vpcAttributes[vpcID]
+
+	if input.EnableDnsHostnames != nil {
+		attributes.enableDnsHostnames = aws.BoolValue(input.EnableDnsHostnames.Value)
+	}
+
+	if input.EnableDnsSupport != nil {
+		attributes.enableDnsSupport = aws.BoolValue(input.EnableDnsSupport.Value)
+	}
+
+	return &ec2.ModifyVpcAttributeOutput{}, nil
+}
+
+func (e *EC2) ModifyVpcTenancy(input *ec2.ModifyVpcTenancyInput) (*ec2.ModifyVpcTenancyOutput, error) {
+	vpc, err := e.vpc(aws.StringValue(input.VpcId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(input.InstanceTenancy) != ec2.TenancyDefault {
+		return nil, ec2Error("InvalidParameterValue", "The tenancy value %s is not supported.", aws.StringValue(input.InstanceTenancy))
+	}
+
+	vpc.InstanceTenancy = input.InstanceTenancy
+
+	return &ec2.ModifyVpcTenancyOutput{ReturnValue: aws.Bool(true)}, nil
+}
+
+func (e *EC2) AssociateVpcCidrBlock(input *ec2.AssociateVpcCidrBlockInput) (*ec2.AssociateVpcCidrBlockOutput, error) {
+	vpc, err := e.vpc(aws.StringValue(input.VpcId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.AssociateVpcCidrBlockOutput{
+		VpcId: vpc.VpcId,
+	}
+
+	if aws.BoolValue(input.AmazonProvidedIpv6CidrBlock) || input.Ipv6Pool != nil || input.Ipv6CidrBlock != nil {
+		if len(vpcIpv6CidrBlocks(vpc)) > 0 {
+			return nil, ec2Error("CidrLimitExceeded", "This network '%s' has met its maximum number of allowed CIDRs: 1", aws.StringValue(vpc.VpcId))
+		}
+
+		association, err := e.requestedVpcIpv6CidrBlockAssociation(aws.BoolValue(input.AmazonProvidedIpv6CidrBlock), aws.StringValue(input.Ipv6Pool), aws.StringValue(input.Ipv6CidrBlock))
+
+		if err != nil {
+			return nil, err
+		}
+
+		vpc.Ipv6CidrBlockAssociationSet = append(vpc.Ipv6CidrBlockAssociationSet, association)
+		output.Ipv6CidrBlockAssociation = awsutil.CopyOf(association).(*ec2.VpcIpv6CidrBlockAssociation)
+
+		return output, nil
+	}
+
+	cidrBlock := aws.StringValue(input.CidrBlock)
+
+	if err := validateVpcCidrBlock(cidrBlock); err != nil {
+		return nil, err
+	}
+
+	cidrBlocks := vpcCidrBlocks(vpc)
+
+	if len(cidrBlocks) >= maxVpcCidrBlocks {
+		return nil, ec2Error("CidrLimitExceeded", "This network '%s' has met its maximum number of allowed CIDRs: %d", aws.StringValue(vpc.VpcId), maxVpcCidrBlocks)
+	}
+
+	for _, existing := range cidrBlocks {
+		if cidrOverlaps(existing, cidrBlock) {
+			return nil, ec2Error("InvalidVpc.Range", "The CIDR '%s' conflicts with another subnet", cidrBlock)
+		}
+	}
+
+	if restrictedVpcCidrBlock(aws.StringValue(vpc.CidrBlock), cidrBlock) {
+		return nil, ec2Error("InvalidVpc.Range", "The CIDR '%s' is restricted. Use a CIDR from the same private address range as the current CIDR range in your VPC.", cidrBlock)
+	}
+
+	association := &ec2.VpcCidrBlockAssociation{
+		AssociationId: aws.String(e.newID("vpc-cidr-assoc")),
+		CidrBlock:     aws.String(cidrBlock),
+		CidrBlockState: &ec2.VpcCidrBlockState{
+			State: aws.String(ec2.VpcCidrBlockStateCodeAssociated),
+		},
+	}
+	vpc.CidrBlockAssociationSet = append(vpc.CidrBlockAssociationSet, association)
+	output.CidrBlockAssociation = awsutil.CopyOf(association).(*ec2.VpcCidrBlockAssociation)
+
+	for _, id := range sortedKeys(e.routeTables) {
+		rt := e.routeTables[id]
+
+		if aws.StringValue(rt.VpcId) == aws.StringValue(vpc.VpcId) {
+			rt.Routes = append(rt.Routes, localRoute(cidrBlock))
+		}
+	}
+
+	return output, nil
+}
+
+func (e *EC2) DisassociateVpcCidrBlock(input *ec2.DisassociateVpcCidrBlockInput) (*ec2.DisassociateVpcCidrBlockOutput, error) {
+	associationID := aws.StringValue(input.AssociationId)
+
+	for _, vpcID := range sortedKeys(e.vpcs) {
+		vpc := e.vpcs[vpcID]
+
+		for i, a := range vpc.CidrBlockAssociationSet {
+			if aws.StringValue(a.AssociationId) != associationID || aws.StringValue(a.CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+				continue
+			}
+
+			if i == 0 {
+				return nil, ec2Error("OperationNotPermitted", "The vpc %s primary CIDR block cannot be disassociated", vpcID)
+			}
+
+			for _, subnetID := range sortedKeys(e.subnets) {
+				subnet := e.subnets[subnetID]
+
+				if aws.StringValue(subnet.VpcId) == vpcID && cidrContains(aws.StringValue(a.CidrBlock), aws.StringValue(subnet.CidrBlock)) {
+					return nil, ec2Error("InvalidCidrBlock.InUse", "The vpc %s currently has a subnet within CIDR block %s", vpcID, aws.StringValue(a.CidrBlock))
+				}
+			}
+
+			a.CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeDisassociated)
+
+			for _, id := range sortedKeys(e.routeTables) {
+				e.routeTables[id].Routes = removeLocalRoute(e.routeTables[id].Routes, aws.StringValue(a.CidrBlock))
+			}
+
+			return &ec2.DisassociateVpcCidrBlockOutput{
+				CidrBlockAssociation: awsutil.CopyOf(a).(*ec2.VpcCidrBlockAssociation),
+				VpcId:                vpc.VpcId,
+			}, nil
+		}
+
+		for _, a := range vpc.Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(a.AssociationId) != associationID || aws.StringValue(a.Ipv6CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+				continue
+			}
+
+			for _, subnetID := range sortedKeys(e.subnets) {
+				subnet := e.subnets[subnetID]
+
+				if aws.StringValue(subnet.VpcId) == vpcID && len(subnetIpv6CidrBlocks(subnet)) > 0 {
+					return nil, ec2Error("InvalidCidrBlock.InUse", "The vpc %s currently has a subnet within CIDR block %s", vpcID, aws.StringValue(a.Ipv6CidrBlock))
+				}
+			}
+
+			a.Ipv6CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeDisassociated)
+
+			for _, id := range sortedKeys(e.routeTables) {
+				e.routeTables[id].Routes = removeLocalRoute(e.routeTables[id].Routes, aws.StringValue(a.Ipv6CidrBlock))
+			}
+
+			return &ec2.DisassociateVpcCidrBlockOutput{
+				Ipv6CidrBlockAssociation: awsutil.CopyOf(a).(*ec2.VpcIpv6CidrBlockAssociation),
+				VpcId:                    vpc.VpcId,
+			}, nil
+		}
+	}
+
+	return nil, ec2Error("InvalidVpcCidrBlockAssociationID.NotFound", "The vpc CIDR block association ID '%s' does not exist", associationID)
+}
+
+//
+// ClassicLink is not available in Region.
+//
+
+func errClassicLinkUnsupported() error {
+	return ec2Error("UnsupportedOperation", "The functionality you requested is not available in this region.")
+}
+
+func (e *EC2) DescribeVpcClassicLink(input *ec2.DescribeVpcClassicLinkInput) (*ec2.DescribeVpcClassicLinkOutput, error) {
+	return nil, errClassicLinkUnsupported()
+}
+
+func (e *EC2) DescribeVpcClassicLinkDnsSupport(input *ec2.DescribeVpcClassicLinkDnsSupportInput) (*ec2.DescribeVpcClassicLinkDnsSupportOutput, error) {
+	return nil, errClassicLinkUnsupported()
+}
+
+func (e *EC2) DisableVpcClassicLink(input *ec2.DisableVpcClassicLinkInput) (*ec2.DisableVpcClassicLinkOutput, error) {
+	return nil, errClassicLinkUnsupported()
+}
+
+func (e *EC2) DisableVpcClassicLinkDnsSupport(input *ec2.DisableVpcClassicLinkDnsSupportInput) (*ec2.DisableVpcClassicLinkDnsSupportOutput, error) {
+	return nil, errClassicLinkUnsupported()
+}
+
+func (e *EC2) EnableVpcClassicLink(input *ec2.EnableVpcClassicLinkInput) (*ec2.EnableVpcClassicLinkOutput, error) {
+	return nil, errClassicLinkUnsupported()
+}
+
+func (e *EC2) EnableVpcClassicLinkDnsSupport(input *ec2.EnableVpcClassicLinkDnsSupportInput) (*ec2.EnableVpcClassicLinkDnsSupportOutput, error) {
+	return nil, errClassicLinkUnsupported()
+}
+
+//
+// CIDR helpers
+//
+
+// cidrContains returns whether the outer CIDR block contains the inner CIDR block.
+func cidrContains(outer, inner string) bool {
+	_, outerNetwork, err := net.ParseCIDR(outer)
+
+	if err != nil {
+		return false
+	}
+
+	_, innerNetwork, err := net.ParseCIDR(inner)
+
+	if err != nil {
+		return false
+	}
+
+	outerOnes, outerBits := outerNetwork.Mask.Size()
+	innerOnes, innerBits := innerNetwork.Mask.Size()
+
+	return outerBits == innerBits && outerOnes <= innerOnes && outerNetwork.Contains(innerNetwork.IP)
+}
+
+// cidrOverlaps returns whether two CIDR blocks have any addresses in common.
+func cidrOverlaps(a, b string) bool {
+	return cidrContains(a, b) || cidrContains(b, a)
+}
+
+// addToIP returns the IPv4 address n addresses after ip.
+func addToIP(ip net.IP, n uint32) net.IP {
+	ip4 := ip.To4()
+	result := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(result, binary.BigEndian.Uint32(ip4)+n)
+
+	return result
+}
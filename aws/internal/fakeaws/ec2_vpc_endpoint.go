@@ -0,0 +1,855 @@
+package fakeaws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	vpcEndpointStateAvailable         = "available"
+	vpcEndpointStatePendingAcceptance = "pendingAcceptance"
+	vpcEndpointStateRejected          = "rejected"
+)
+
+// vpcEndpointDefaultPolicy is the policy of an endpoint created without one.
+const vpcEndpointDefaultPolicy = `{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`
+
+// awsVpcEndpointService is an AWS service that VPC endpoints can be created for.
+type awsVpcEndpointService struct {
+	name  string
+	types []string
+
+	// prefixListID and cidrs are the prefix list of a service with
+	// gateway endpoints.
+	prefixListID string
+	cidrs        []string
+}
+
+// awsVpcEndpointServices are the AWS services in Region that VPC endpoints
+// can be created for.
+var awsVpcEndpointServices = []*awsVpcEndpointService{
+	{
+		name:         "dynamodb",
+		types:        []string{ec2.VpcEndpointTypeGateway},
+		prefixListID: "pl-00a54069",
+		cidrs:        []string{"52.94.10.0/24", "52.119.232.0/21"},
+	},
+	{name: "ec2", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "ec2messages", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "ecr.api", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "ecr.dkr", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "kms", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "logs", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "monitoring", types: []string{ec2.VpcEndpointTypeInterface}},
+	{
+		name:         "s3",
+		types:        []string{ec2.VpcEndpointTypeGateway, ec2.VpcEndpointTypeInterface},
+		prefixListID: "pl-68a54001",
+		cidrs:        []string{"52.218.128.0/17", "52.92.16.0/20", "54.231.160.0/19"},
+	},
+	{name: "secretsmanager", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "ssm", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "ssmmessages", types: []string{ec2.VpcEndpointTypeInterface}},
+	{name: "sts", types: []string{ec2.VpcEndpointTypeInterface}},
+}
+
+func awsVpcEndpointServiceName(name string) string {
+	return fmt.Sprintf("com.amazonaws.%s.%s", Region, name)
+}
+
+func (e *EC2) vpcEndpoint(id string) (*ec2.VpcEndpoint, error) {
+	vpce, ok := e.vpcEndpoints[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidVpcEndpointId.NotFound", "The Vpc Endpoint Id '%s' does not exist", id)
+	}
+
+	return vpce, nil
+}
+
+// vpcEndpointServiceTypes returns the endpoint types supported by a service
+// and whether connections to it require acceptance.
+func (e *EC2) vpcEndpointServiceTypes(serviceName string) ([]string, bool, bool) {
+	for _, svc := range awsVpcEndpointServices {
+		if awsVpcEndpointServiceName(svc.name) == serviceName {
+			return svc.types, false, true
+		}
+	}
+
+	for _, id := range sortedKeys(e.vpcEndpointServices) {
+		config := e.vpcEndpointServices[id]
+
+		if aws.StringValue(config.ServiceName) == serviceName {
+			return []string{ec2.VpcEndpointTypeInterface}, aws.BoolValue(config.AcceptanceRequired), true
+		}
+	}
+
+	return nil, false, false
+}
+
+// addVpcEndpointRouteTables adds the routes to the prefix list of a gateway
+// endpoint's service to route tables.
+func (e *EC2) addVpcEndpointRouteTables(vpce *ec2.VpcEndpoint, routeTableIDs []string) error {
+	var prefixListID string
+
+	for _, svc := range awsVpcEndpointServices {
+		if awsVpcEndpointServiceName(svc.name) == aws.StringValue(vpce.ServiceName) {
+			prefixListID = svc.prefixListID
+		}
+	}
+
+	for _, routeTableID := range routeTableIDs {
+		rt, err := e.routeTable(routeTableID)
+
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(rt.VpcId) != aws.StringValue(vpce.VpcId) {
+			return ec2Error("InvalidParameter", "Route table %s does not belong to vpc %s", routeTableID, aws.StringValue(vpce.VpcId))
+		}
+	}
+
+	for _, routeTableID := range routeTableIDs {
+		if contains(aws.StringValueSlice(vpce.RouteTableIds), routeTableID) {
+			continue
+		}
+
+		rt := e.routeTables[routeTableID]
+		rt.Routes = append(rt.Routes, &ec2.Route{
+			DestinationPrefixListId: aws.String(prefixListID),
+			GatewayId:               vpce.VpcEndpointId,
+			Origin:                  aws.String(ec2.RouteOriginCreateRoute),
+			State:                   aws.String(ec2.RouteStateActive),
+		})
+		vpce.RouteTableIds = append(vpce.RouteTableIds, aws.String(routeTableID))
+	}
+
+	return nil
+}
+
+// removeVpcEndpointRouteTables removes the routes of a gateway endpoint from
+// route tables.
+func (e *EC2) removeVpcEndpointRouteTables(vpce *ec2.VpcEndpoint, routeTableIDs []string) error {
+	for _, routeTableID := range routeTableIDs {
+		if !contains(aws.StringValueSlice(vpce.RouteTableIds), routeTableID) {
+			return ec2Error("InvalidParameter", "Route table %s is not associated with %s", routeTableID, aws.StringValue(vpce.VpcEndpointId))
+		}
+	}
+
+	for _, routeTableID := range routeTableIDs {
+		e.removeVpcEndpointRoutes(aws.StringValue(vpce.VpcEndpointId), routeTableID)
+
+		var remaining []*string
+
+		for _, id := range vpce.RouteTableIds {
+			if aws.StringValue(id) != routeTableID {
+				remaining = append(remaining, id)
+			}
+		}
+
+		vpce.RouteTableIds = remaining
+	}
+
+	return nil
+}
+
+func (e *EC2) removeVpcEndpointRoutes(vpcEndpointID, routeTableID string) {
+	rt, ok := e.routeTables[routeTableID]
+
+	if !ok {
+		return
+	}
+
+	var routes []*ec2.Route
+
+	for _, route := range rt.Routes {
+		if aws.StringValue(route.GatewayId) != vpcEndpointID {
+			routes = append(routes, route)
+		}
+	}
+
+	rt.Routes = routes
+}
+
+// addVpcEndpointSubnets creates a requester-managed network interface for an
+// interface endpoint in each subnet.
+func (e *EC2) addVpcEndpointSubnets(vpce *ec2.VpcEndpoint, subnetIDs []string) error {
+	zones := make(map[string]bool)
+
+	for _, id := range aws.StringValueSlice(vpce.SubnetIds) {
+		zones[aws.StringValue(e.subnets[id].AvailabilityZone)] = true
+	}
+
+	for _, subnetID := range subnetIDs {
+		subnet, err := e.subnet(subnetID)
+
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(subnet.VpcId) != aws.StringValue(vpce.VpcId) {
+			return ec2Error("InvalidParameter", "Subnet %s does not belong to vpc %s", subnetID, aws.StringValue(vpce.VpcId))
+		}
+
+		availabilityZone := aws.StringValue(subnet.AvailabilityZone)
+
+		if zones[availabilityZone] {
+			return ec2Error("DuplicateSubnetsInSameZone", "Found another VPC endpoint subnet in the availability zone of %s. VPC endpoint subnets should be in different availability zones supported by the VPC endpoint service.", subnetID)
+		}
+
+		zones[availabilityZone] = true
+	}
+
+	var groupIDs []string
+
+	for _, g := range vpce.Groups {
+		groupIDs = append(groupIDs, aws.StringValue(g.GroupId))
+	}
+
+	for _, subnetID := range subnetIDs {
+		output, err := e.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+			Description: aws.String(fmt.Sprintf("VPC Endpoint Interface %s", aws.StringValue(vpce.VpcEndpointId))),
+			Groups:      aws.StringSlice(groupIDs),
+			SubnetId:    aws.String(subnetID),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		eni := e.networkInterfaces[aws.StringValue(output.NetworkInterface.NetworkInterfaceId)]
+		eni.InterfaceType = aws.String("vpc_endpoint")
+		eni.RequesterId = aws.String("727180483921")
+		eni.RequesterManaged = aws.Bool(true)
+
+		vpce.NetworkInterfaceIds = append(vpce.NetworkInterfaceIds, eni.NetworkInterfaceId)
+		vpce.SubnetIds = append(vpce.SubnetIds, aws.String(subnetID))
+	}
+
+	e.setVpcEndpointDNSEntries(vpce)
+
+	return nil
+}
+
+// removeVpcEndpointSubnets deletes the network interfaces of an interface
+// endpoint in each subnet.
+func (e *EC2) removeVpcEndpointSubnets(vpce *ec2.VpcEndpoint, subnetIDs []string) error {
+	for _, subnetID := range subnetIDs {
+		if !contains(aws.StringValueSlice(vpce.SubnetIds), subnetID) {
+			return ec2Error("InvalidParameter", "Subnet %s is not associated with %s", subnetID, aws.StringValue(vpce.VpcEndpointId))
+		}
+	}
+
+	var networkInterfaceIDs, remaining []*string
+
+	for _, id := range vpce.NetworkInterfaceIds {
+		eni := e.networkInterfaces[aws.StringValue(id)]
+
+		if eni == nil {
+			continue
+		}
+
+		subnetID := aws.StringValue(eni.SubnetId)
+
+		if !contains(subnetIDs, subnetID) {
+			networkInterfaceIDs = append(networkInterfaceIDs, id)
+			remaining = append(remaining, eni.SubnetId)
+			continue
+		}
+
+		e.deleteResource(aws.StringValue(id))
+		e.updateAvailableIPAddressCount(e.subnets[subnetID])
+	}
+
+	vpce.NetworkInterfaceIds = networkInterfaceIDs
+	vpce.SubnetIds = remaining
+
+	e.setVpcEndpointDNSEntries(vpce)
+
+	return nil
+}
+
+// setVpcEndpointDNSEntries sets the regional and zonal DNS names of an
+// interface endpoint, plus the service's DNS name if private DNS is enabled.
+func (e *EC2) setVpcEndpointDNSEntries(vpce *ec2.VpcEndpoint) {
+	if aws.StringValue(vpce.VpcEndpointType) != ec2.VpcEndpointTypeInterface {
+		return
+	}
+
+	vpcEndpointID := aws.StringValue(vpce.VpcEndpointId)
+	service := strings.TrimPrefix(aws.StringValue(vpce.ServiceName), fmt.Sprintf("com.amazonaws.%s.", Region))
+	hostedZoneID := "Z1YSA3EXCYUU9Z"
+
+	vpce.DnsEntries = []*ec2.DnsEntry{{
+		DnsName:      aws.String(fmt.Sprintf("%s-%s.%s.%s.vpce.amazonaws.com", vpcEndpointID, vpcEndpointID[len(vpcEndpointID)-8:], service, Region)),
+		HostedZoneId: aws.String(hostedZoneID),
+	}}
+
+	for _, id := range vpce.SubnetIds {
+		vpce.DnsEntries = append(vpce.DnsEntries, &ec2.DnsEntry{
+			DnsName:      aws.String(fmt.Sprintf("%s-%s-%s.%s.%s.vpce.amazonaws.com", vpcEndpointID, vpcEndpointID[len(vpcEndpointID)-8:], aws.StringValue(e.subnets[aws.StringValue(id)].AvailabilityZone), service, Region)),
+			HostedZoneId: aws.String(hostedZoneID),
+		})
+	}
+
+	if aws.BoolValue(vpce.PrivateDnsEnabled) {
+		vpce.DnsEntries = append(vpce.DnsEntries, &ec2.DnsEntry{
+			DnsName: aws.String(fmt.Sprintf("%s.%s.amazonaws.com", service, Region)),
+		})
+	}
+}
+
+// validateVpcEndpointPrivateDNS returns an error if private DNS cannot be
+// enabled for an endpoint in a VPC.
+func (e *EC2) validateVpcEndpointPrivateDNS(vpcID string) error {
+	attributes := e.vpcAttributes[vpcID]
+
+	if !attributes.enableDnsSupport || !attributes.enableDnsHostnames {
+		return ec2Error("InvalidParameter", "Enabling private DNS requires both enableDnsSupport and enableDnsHostnames VPC attributes set to true for %s", vpcID)
+	}
+
+	return nil
+}
+
+func (e *EC2) vpcEndpointGroups(groupIDs []string, vpcID string) ([]*ec2.SecurityGroupIdentifier, error) {
+	if len(groupIDs) == 0 {
+		if sg := e.defaultSecurityGroup(vpcID); sg != nil {
+			groupIDs = []string{aws.StringValue(sg.GroupId)}
+		}
+	}
+
+	identifiers, err := e.groupIdentifiers(groupIDs, vpcID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []*ec2.SecurityGroupIdentifier
+
+	for _, g := range identifiers {
+		groups = append(groups, &ec2.SecurityGroupIdentifier{
+			GroupId:   g.GroupId,
+			GroupName: g.GroupName,
+		})
+	}
+
+	return groups, nil
+}
+
+func (e *EC2) CreateVpcEndpoint(input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+	vpcID := aws.StringValue(input.VpcId)
+
+	if _, err := e.vpc(vpcID); err != nil {
+		return nil, err
+	}
+
+	serviceName := aws.StringValue(input.ServiceName)
+	vpcEndpointType := aws.StringValue(input.VpcEndpointType)
+
+	if vpcEndpointType == "" {
+		vpcEndpointType = ec2.VpcEndpointTypeGateway
+	}
+
+	types, acceptanceRequired, ok := e.vpcEndpointServiceTypes(serviceName)
+
+	if !ok {
+		return nil, ec2Error("InvalidServiceName", "The Vpc Endpoint Service '%s' does not exist", serviceName)
+	}
+
+	if !contains(types, vpcEndpointType) {
+		return nil, ec2Error("InvalidParameter", "The Vpc Endpoint Service '%s' does not support endpoint type %s", serviceName, vpcEndpointType)
+	}
+
+	policy := aws.StringValue(input.PolicyDocument)
+
+	if policy == "" {
+		policy = vpcEndpointDefaultPolicy
+	}
+
+	vpcEndpointID := e.newID("vpce")
+
+	vpce := &ec2.VpcEndpoint{
+		CreationTimestamp:   aws.Time(time.Now().UTC().Truncate(time.Second)),
+		DnsEntries:          []*ec2.DnsEntry{},
+		Groups:              []*ec2.SecurityGroupIdentifier{},
+		NetworkInterfaceIds: []*string{},
+		OwnerId:             aws.String(AccountID),
+		PolicyDocument:      aws.String(policy),
+		PrivateDnsEnabled:   aws.Bool(false),
+		RequesterManaged:    aws.Bool(false),
+		RouteTableIds:       []*string{},
+		ServiceName:         aws.String(serviceName),
+		State:               aws.String(vpcEndpointStateAvailable),
+		SubnetIds:           []*string{},
+		VpcEndpointId:       aws.String(vpcEndpointID),
+		VpcEndpointType:     aws.String(vpcEndpointType),
+		VpcId:               aws.String(vpcID),
+	}
+
+	if acceptanceRequired {
+		vpce.State = aws.String(vpcEndpointStatePendingAcceptance)
+	}
+
+	switch vpcEndpointType {
+	case ec2.VpcEndpointTypeGateway:
+		if len(input.SubnetIds) > 0 || len(input.SecurityGroupIds) > 0 || aws.BoolValue(input.PrivateDnsEnabled) {
+			return nil, ec2Error("InvalidParameter", "Subnets, security groups and private DNS are not supported for Gateway endpoints")
+		}
+
+		if err := e.addVpcEndpointRouteTables(vpce, aws.StringValueSlice(input.RouteTableIds)); err != nil {
+			return nil, err
+		}
+	case ec2.VpcEndpointTypeInterface:
+		if len(input.RouteTableIds) > 0 {
+			return nil, ec2Error("InvalidParameter", "Route tables are not supported for Interface endpoints")
+		}
+
+		if aws.BoolValue(input.PrivateDnsEnabled) {
+			if err := e.validateVpcEndpointPrivateDNS(vpcID); err != nil {
+				return nil, err
+			}
+
+			vpce.PrivateDnsEnabled = aws.Bool(true)
+		}
+
+		groups, err := e.vpcEndpointGroups(aws.StringValueSlice(input.SecurityGroupIds), vpcID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		vpce.Groups = groups
+
+		if err := e.addVpcEndpointSubnets(vpce, aws.StringValueSlice(input.SubnetIds)); err != nil {
+			_ = e.removeVpcEndpointSubnets(vpce, aws.StringValueSlice(vpce.SubnetIds))
+
+			return nil, err
+		}
+	default:
+		return nil, ec2Error("InvalidParameterValue", "Value (%s) for parameter vpcEndpointType is invalid.", vpcEndpointType)
+	}
+
+	e.vpcEndpoints[vpcEndpointID] = vpce
+
+	return &ec2.CreateVpcEndpointOutput{
+		VpcEndpoint: e.describeVpcEndpoint(vpcEndpointID),
+	}, nil
+}
+
+func (e *EC2) ModifyVpcEndpoint(input *ec2.ModifyVpcEndpointInput) (*ec2.ModifyVpcEndpointOutput, error) {
+	vpce, err := e.vpcEndpoint(aws.StringValue(input.VpcEndpointId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(vpce.VpcId)
+	gateway := aws.StringValue(vpce.VpcEndpointType) == ec2.VpcEndpointTypeGateway
+
+	if gateway && (len(input.AddSubnetIds) > 0 || len(input.AddSecurityGroupIds) > 0 || aws.BoolValue(input.PrivateDnsEnabled)) {
+		return nil, ec2Error("InvalidParameter", "Subnets, security groups and private DNS are not supported for Gateway endpoints")
+	}
+
+	if !gateway && len(input.AddRouteTableIds) > 0 {
+		return nil, ec2Error("InvalidParameter", "Route tables are not supported for Interface endpoints")
+	}
+
+	if aws.BoolValue(input.PrivateDnsEnabled) {
+		if err := e.validateVpcEndpointPrivateDNS(vpcID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(input.AddSecurityGroupIds) > 0 {
+		if _, err := e.groupIdentifiers(aws.StringValueSlice(input.AddSecurityGroupIds), vpcID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.removeVpcEndpointRouteTables(vpce, aws.StringValueSlice(input.RemoveRouteTableIds)); err != nil {
+		return nil, err
+	}
+
+	if err := e.addVpcEndpointRouteTables(vpce, aws.StringValueSlice(input.AddRouteTableIds)); err != nil {
+		return nil, err
+	}
+
+	if len(input.AddSecurityGroupIds) > 0 || len(input.RemoveSecurityGroupIds) > 0 {
+		var groupIDs []string
+
+		for _, g := range vpce.Groups {
+			if groupID := aws.StringValue(g.GroupId); !contains(aws.StringValueSlice(input.RemoveSecurityGroupIds), groupID) {
+				groupIDs = append(groupIDs, groupID)
+			}
+		}
+
+		for _, groupID := range aws.StringValueSlice(input.AddSecurityGroupIds) {
+			if !contains(groupIDs, groupID) {
+				groupIDs = append(groupIDs, groupID)
+			}
+		}
+
+		groups, err := e.vpcEndpointGroups(groupIDs, vpcID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		vpce.Groups = groups
+
+		for _, id := range aws.StringValueSlice(vpce.NetworkInterfaceIds) {
+			e.networkInterfaces[id].Groups, _ = e.groupIdentifiers(groupIDs, vpcID)
+		}
+	}
+
+	if err := e.removeVpcEndpointSubnets(vpce, aws.StringValueSlice(input.RemoveSubnetIds)); err != nil {
+		return nil, err
+	}
+
+	if err := e.addVpcEndpointSubnets(vpce, aws.StringValueSlice(input.AddSubnetIds)); err != nil {
+		return nil, err
+	}
+
+	if aws.BoolValue(input.ResetPolicy) {
+		vpce.PolicyDocument = aws.String(vpcEndpointDefaultPolicy)
+	} else if input.PolicyDocument != nil {
+		vpce.PolicyDocument = input.PolicyDocument
+	}
+
+	if input.PrivateDnsEnabled != nil && !gateway {
+		vpce.PrivateDnsEnabled = input.PrivateDnsEnabled
+		e.setVpcEndpointDNSEntries(vpce)
+	}
+
+	return &ec2.ModifyVpcEndpointOutput{
+		Return: aws.Bool(true),
+	}, nil
+}
+
+// deleteVpcEndpoint deletes an endpoint. An endpoint pending acceptance or
+// rejected is still described in that state once before it is gone, so that
+// waiting for its deletion sees those states.
+func (e *EC2) deleteVpcEndpoint(vpce *ec2.VpcEndpoint) {
+	vpcEndpointID := aws.StringValue(vpce.VpcEndpointId)
+
+	for _, id := range aws.StringValueSlice(vpce.RouteTableIds) {
+		e.removeVpcEndpointRoutes(vpcEndpointID, id)
+	}
+
+	_ = e.removeVpcEndpointSubnets(vpce, aws.StringValueSlice(vpce.SubnetIds))
+
+	switch aws.StringValue(vpce.State) {
+	case vpcEndpointStatePendingAcceptance, vpcEndpointStateRejected:
+		e.deletedVpcEndpoints[vpcEndpointID] = true
+	default:
+		e.deleteResource(vpcEndpointID)
+	}
+}
+
+func (e *EC2) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+	output := &ec2.DeleteVpcEndpointsOutput{
+		Unsuccessful: []*ec2.UnsuccessfulItem{},
+	}
+
+	for _, id := range aws.StringValueSlice(input.VpcEndpointIds) {
+		vpce, err := e.vpcEndpoint(id)
+
+		if err != nil {
+			output.Unsuccessful = append(output.Unsuccessful, &ec2.UnsuccessfulItem{
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String(err.(*Error).Code),
+					Message: aws.String(err.(*Error).Message),
+				},
+				ResourceId: aws.String(id),
+			})
+
+			continue
+		}
+
+		e.deleteVpcEndpoint(vpce)
+	}
+
+	return output, nil
+}
+
+func (e *EC2) describeVpcEndpoint(id string) *ec2.VpcEndpoint {
+	vpce := awsutil.CopyOf(e.vpcEndpoints[id]).(*ec2.VpcEndpoint)
+	vpce.Tags = e.ec2Tags(id)
+
+	return vpce
+}
+
+func (e *EC2) vpcEndpointFilterValues(id, name string) ([]string, bool) {
+	vpce := e.vpcEndpoints[id]
+
+	switch name {
+	case "service-name":
+		return stringFilterValue(vpce.ServiceName), true
+	case "vpc-endpoint-id":
+		return []string{id}, true
+	case "vpc-endpoint-state":
+		return stringFilterValue(vpce.State), true
+	case "vpc-endpoint-type":
+		return stringFilterValue(vpce.VpcEndpointType), true
+	case "vpc-id":
+		return stringFilterValue(vpce.VpcId), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.vpcEndpoints), input.VpcEndpointIds, input.Filters, "InvalidVpcEndpointId.NotFound", "Vpc Endpoint", e.vpcEndpointFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeVpcEndpointsOutput{}
+
+	for _, id := range ids {
+		output.VpcEndpoints = append(output.VpcEndpoints, e.describeVpcEndpoint(id))
+
+		if e.deletedVpcEndpoints[id] {
+			delete(e.deletedVpcEndpoints, id)
+			e.deleteResource(id)
+		}
+	}
+
+	return output, nil
+}
+
+//
+// Prefix lists
+//
+
+func (e *EC2) DescribePrefixLists(input *ec2.DescribePrefixListsInput) (*ec2.DescribePrefixListsOutput, error) {
+	prefixLists := make(map[string]*ec2.PrefixList)
+
+	for _, svc := range awsVpcEndpointServices {
+		if svc.prefixListID == "" {
+			continue
+		}
+
+		prefixLists[svc.prefixListID] = &ec2.PrefixList{
+			Cidrs:          aws.StringSlice(svc.cidrs),
+			PrefixListId:   aws.String(svc.prefixListID),
+			PrefixListName: aws.String(awsVpcEndpointServiceName(svc.name)),
+		}
+	}
+
+	values := func(id, name string) ([]string, bool) {
+		switch name {
+		case "prefix-list-id":
+			return []string{id}, true
+		case "prefix-list-name":
+			return stringFilterValue(prefixLists[id].PrefixListName), true
+		}
+
+		return nil, false
+	}
+
+	ids, err := e.selectIDs(sortedKeys(prefixLists), input.PrefixListIds, input.Filters, "InvalidPrefixListID.NotFound", "prefix list", values)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribePrefixListsOutput{}
+
+	for _, id := range ids {
+		output.PrefixLists = append(output.PrefixLists, prefixLists[id])
+	}
+
+	return output, nil
+}
+
+//
+// Endpoint services
+//
+
+func (e *EC2) CreateVpcEndpointServiceConfiguration(input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+	if len(input.NetworkLoadBalancerArns) == 0 {
+		return nil, ec2Error("InvalidParameter", "At least one load balancer is required")
+	}
+
+	serviceID := e.newID("vpce-svc")
+
+	config := &ec2.ServiceConfiguration{
+		AcceptanceRequired:      aws.Bool(aws.BoolValue(input.AcceptanceRequired)),
+		AvailabilityZones:       aws.StringSlice(sortedKeys(AvailabilityZones)),
+		BaseEndpointDnsNames:    aws.StringSlice([]string{fmt.Sprintf("%s.%s.vpce.amazonaws.com", serviceID, Region)}),
+		ManagesVpcEndpoints:     aws.Bool(false),
+		NetworkLoadBalancerArns: input.NetworkLoadBalancerArns,
+		ServiceId:               aws.String(serviceID),
+		ServiceName:             aws.String(fmt.Sprintf("com.amazonaws.vpce.%s.%s", Region, serviceID)),
+		ServiceState:            aws.String(ec2.ServiceStateAvailable),
+		ServiceType: []*ec2.ServiceTypeDetail{{
+			ServiceType: aws.String(ec2.ServiceTypeInterface),
+		}},
+	}
+
+	e.vpcEndpointServices[serviceID] = config
+
+	return &ec2.CreateVpcEndpointServiceConfigurationOutput{
+		ServiceConfiguration: e.describeVpcEndpointService(serviceID),
+	}, nil
+}
+
+func (e *EC2) vpcEndpointService(id string) (*ec2.ServiceConfiguration, error) {
+	config, ok := e.vpcEndpointServices[id]
+
+	if !ok {
+		return nil, ec2Error("InvalidVpcEndpointServiceId.NotFound", "The Vpc Endpoint Service Id '%s' does not exist", id)
+	}
+
+	return config, nil
+}
+
+func (e *EC2) describeVpcEndpointService(id string) *ec2.ServiceConfiguration {
+	config := awsutil.CopyOf(e.vpcEndpointServices[id]).(*ec2.ServiceConfiguration)
+	config.Tags = e.ec2Tags(id)
+
+	return config
+}
+
+func (e *EC2) vpcEndpointServiceFilterValues(id, name string) ([]string, bool) {
+	config := e.vpcEndpointServices[id]
+
+	switch name {
+	case "service-id":
+		return []string{id}, true
+	case "service-name":
+		return stringFilterValue(config.ServiceName), true
+	case "service-state":
+		return stringFilterValue(config.ServiceState), true
+	}
+
+	return nil, false
+}
+
+func (e *EC2) DescribeVpcEndpointServiceConfigurations(input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+	ids, err := e.selectIDs(sortedKeys(e.vpcEndpointServices), input.ServiceIds, input.Filters, "InvalidVpcEndpointServiceId.NotFound", "Vpc Endpoint Service", e.vpcEndpointServiceFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeVpcEndpointServiceConfigurationsOutput{}
+
+	for _, id := range ids {
+		output.ServiceConfigurations = append(output.ServiceConfigurations, e.describeVpcEndpointService(id))
+	}
+
+	return output, nil
+}
+
+func (e *EC2) DeleteVpcEndpointServiceConfigurations(input *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+	output := &ec2.DeleteVpcEndpointServiceConfigurationsOutput{
+		Unsuccessful: []*ec2.UnsuccessfulItem{},
+	}
+
+	for _, id := range aws.StringValueSlice(input.ServiceIds) {
+		config, err := e.vpcEndpointService(id)
+
+		if err == nil {
+			for _, vpcEndpointID := range sortedKeys(e.vpcEndpoints) {
+				vpce := e.vpcEndpoints[vpcEndpointID]
+
+				if aws.StringValue(vpce.ServiceName) == aws.StringValue(config.ServiceName) && aws.StringValue(vpce.State) != vpcEndpointStateRejected {
+					err = ec2Error("ExistingVpcEndpointConnections", "Service %s has existing VPC Endpoint connections", id)
+				}
+			}
+		}
+
+		if err != nil {
+			output.Unsuccessful = append(output.Unsuccessful, &ec2.UnsuccessfulItem{
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String(err.(*Error).Code),
+					Message: aws.String(err.(*Error).Message),
+				},
+				ResourceId: aws.String(id),
+			})
+
+			continue
+		}
+
+		e.deleteResource(id)
+	}
+
+	return output, nil
+}
+
+// updateVpcEndpointConnections sets the state of pending endpoint connections
+// to a service, returning unsuccessful items for endpoints that are not.
+func (e *EC2) updateVpcEndpointConnections(serviceID string, vpcEndpointIDs []string, state string) ([]*ec2.UnsuccessfulItem, error) {
+	config, err := e.vpcEndpointService(serviceID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unsuccessful := []*ec2.UnsuccessfulItem{}
+
+	for _, id := range vpcEndpointIDs {
+		vpce, ok := e.vpcEndpoints[id]
+
+		if !ok || aws.StringValue(vpce.ServiceName) != aws.StringValue(config.ServiceName) {
+			unsuccessful = append(unsuccessful, &ec2.UnsuccessfulItem{
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String("InvalidVpcEndpoint.NotFound"),
+					Message: aws.String(fmt.Sprintf("The Vpc Endpoint '%s' does not exist for service %s", id, serviceID)),
+				},
+				ResourceId: aws.String(id),
+			})
+
+			continue
+		}
+
+		if aws.StringValue(vpce.State) != vpcEndpointStatePendingAcceptance {
+			unsuccessful = append(unsuccessful, &ec2.UnsuccessfulItem{
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String("InvalidState"),
+					Message: aws.String(fmt.Sprintf("Vpc Endpoint %s is in state %s", id, aws.StringValue(vpce.State))),
+				},
+				ResourceId: aws.String(id),
+			})
+
+			continue
+		}
+
+		vpce.State = aws.String(state)
+	}
+
+	return unsuccessful, nil
+}
+
+func (e *EC2) AcceptVpcEndpointConnections(input *ec2.AcceptVpcEndpointConnectionsInput) (*ec2.AcceptVpcEndpointConnectionsOutput, error) {
+	unsuccessful, err := e.updateVpcEndpointConnections(aws.StringValue(input.ServiceId), aws.StringValueSlice(input.VpcEndpointIds), vpcEndpointStateAvailable)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ec2.AcceptVpcEndpointConnectionsOutput{
+		Unsuccessful: unsuccessful,
+	}, nil
+}
+
+func (e *EC2) RejectVpcEndpointConnections(input *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error) {
+	unsuccessful, err := e.updateVpcEndpointConnections(aws.StringValue(input.ServiceId), aws.StringValueSlice(input.VpcEndpointIds), vpcEndpointStateRejected)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ec2.RejectVpcEndpointConnectionsOutput{
+		Unsuccessful: unsuccessful,
+	}, nil
+}
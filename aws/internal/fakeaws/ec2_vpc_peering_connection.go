@@ -0,0 +1,330 @@
+package fakeaws
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// vpcPeeringConnection returns a VPC peering connection visible to the
+// caller, i.e. one where the caller owns the requester or accepter VPC.
+func (e *EC2) vpcPeeringConnection(id string) (*ec2.VpcPeeringConnection, error) {
+	pcx, ok := e.vpcPeeringConnections[id]
+
+	if !ok || !e.vpcPeeringConnectionVisible(pcx) {
+		return nil, ec2Error("InvalidVpcPeeringConnectionID.NotFound", "The vpcPeeringConnection ID '%s' does not exist", id)
+	}
+
+	return pcx, nil
+}
+
+func (e *EC2) vpcPeeringConnectionVisible(pcx *ec2.VpcPeeringConnection) bool {
+	return aws.StringValue(pcx.RequesterVpcInfo.OwnerId) == e.caller || aws.StringValue(pcx.AccepterVpcInfo.OwnerId) == e.caller
+}
+
+func vpcPeeringConnectionStatusCode(pcx *ec2.VpcPeeringConnection) string {
+	return aws.StringValue(pcx.Status.Code)
+}
+
+func setVpcPeeringConnectionStatus(pcx *ec2.VpcPeeringConnection, code, message string) {
+	pcx.Status = &ec2.VpcPeeringConnectionStateReason{
+		Code:    aws.String(code),
+		Message: aws.String(message),
+	}
+}
+
+func newVpcPeeringConnectionOptionsDescription() *ec2.VpcPeeringConnectionOptionsDescription {
+	return &ec2.VpcPeeringConnectionOptionsDescription{
+		AllowDnsResolutionFromRemoteVpc:            aws.Bool(false),
+		AllowEgressFromLocalClassicLinkToRemoteVpc: aws.Bool(false),
+		AllowEgressFromLocalVpcToRemoteClassicLink: aws.Bool(false),
+	}
+}
+
+// CreateVpcPeeringConnection requests a peering connection from a VPC owned
+// by the caller. The peer VPC is looked up regardless of region, and the
+// request fails if it is not owned by the peer account or its CIDR block
+// overlaps the requester's.
+func (e *EC2) CreateVpcPeeringConnection(input *ec2.CreateVpcPeeringConnectionInput) (*ec2.CreateVpcPeeringConnectionOutput, error) {
+	vpc, err := e.vpc(aws.StringValue(input.VpcId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(vpc.OwnerId) != e.caller {
+		return nil, ec2Error("InvalidVpcID.NotFound", "The vpc ID '%s' does not exist", aws.StringValue(vpc.VpcId))
+	}
+
+	peerVpcID := aws.StringValue(input.PeerVpcId)
+
+	if peerVpcID == "" {
+		return nil, ec2Error("MissingParameter", "The request must contain the parameter peerVpcId")
+	}
+
+	peerOwnerID := e.caller
+
+	if input.PeerOwnerId != nil {
+		peerOwnerID = aws.StringValue(input.PeerOwnerId)
+	}
+
+	peerRegion := Region
+
+	if input.PeerRegion != nil {
+		peerRegion = aws.StringValue(input.PeerRegion)
+	}
+
+	pcxID := e.newID("pcx")
+
+	pcx := &ec2.VpcPeeringConnection{
+		AccepterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{
+			OwnerId: aws.String(peerOwnerID),
+			Region:  aws.String(peerRegion),
+			VpcId:   aws.String(peerVpcID),
+		},
+		ExpirationTime: aws.Time(time.Now().UTC().Truncate(time.Second).Add(7 * 24 * time.Hour)),
+		RequesterVpcInfo: &ec2.VpcPeeringConnectionVpcInfo{
+			CidrBlock: vpc.CidrBlock,
+			CidrBlockSet: []*ec2.CidrBlock{{
+				CidrBlock: vpc.CidrBlock,
+			}},
+			OwnerId: vpc.OwnerId,
+			Region:  aws.String(Region),
+			VpcId:   vpc.VpcId,
+		},
+		VpcPeeringConnectionId: aws.String(pcxID),
+	}
+
+	peerVpc, ok := e.vpcs[peerVpcID]
+
+	switch {
+	case !ok || aws.StringValue(peerVpc.OwnerId) != peerOwnerID:
+		setVpcPeeringConnectionStatus(pcx, ec2.VpcPeeringConnectionStateReasonCodeFailed, "Failed due to incorrect VPC-ID, Account ID, or overlapping CIDR range")
+	case cidrOverlaps(aws.StringValue(vpc.CidrBlock), aws.StringValue(peerVpc.CidrBlock)):
+		setVpcPeeringConnectionStatus(pcx, ec2.VpcPeeringConnectionStateReasonCodeFailed, "Failed due to incorrect VPC-ID, Account ID, or overlapping CIDR range")
+	default:
+		setVpcPeeringConnectionStatus(pcx, ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance, "Pending Acceptance by "+peerOwnerID)
+	}
+
+	e.vpcPeeringConnections[pcxID] = pcx
+	e.createTags(pcxID, ec2.ResourceTypeVpcPeeringConnection, input.TagSpecifications)
+
+	return &ec2.CreateVpcPeeringConnectionOutput{
+		VpcPeeringConnection: e.describeVpcPeeringConnection(pcxID),
+	}, nil
+}
+
+// AcceptVpcPeeringConnection activates a pending peering connection. Only
+// the accepter account can accept it.
+func (e *EC2) AcceptVpcPeeringConnection(input *ec2.AcceptVpcPeeringConnectionInput) (*ec2.AcceptVpcPeeringConnectionOutput, error) {
+	pcxID := aws.StringValue(input.VpcPeeringConnectionId)
+	pcx, err := e.vpcPeeringConnection(pcxID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(pcx.AccepterVpcInfo.OwnerId) != e.caller {
+		return nil, ec2Error("OperationNotPermitted", "User %s cannot accept peering %s", e.caller, pcxID)
+	}
+
+	if code := vpcPeeringConnectionStatusCode(pcx); code != ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance {
+		return nil, ec2Error("InvalidStateTransition", "Invalid state transition for %s, attempted to transition from %s to active", pcxID, code)
+	}
+
+	peerVpc := e.vpcs[aws.StringValue(pcx.AccepterVpcInfo.VpcId)]
+
+	pcx.AccepterVpcInfo.CidrBlock = peerVpc.CidrBlock
+	pcx.AccepterVpcInfo.CidrBlockSet = []*ec2.CidrBlock{{
+		CidrBlock: peerVpc.CidrBlock,
+	}}
+	pcx.AccepterVpcInfo.PeeringOptions = newVpcPeeringConnectionOptionsDescription()
+	pcx.ExpirationTime = nil
+	pcx.RequesterVpcInfo.PeeringOptions = newVpcPeeringConnectionOptionsDescription()
+	setVpcPeeringConnectionStatus(pcx, ec2.VpcPeeringConnectionStateReasonCodeActive, "Active")
+
+	return &ec2.AcceptVpcPeeringConnectionOutput{
+		VpcPeeringConnection: e.describeVpcPeeringConnection(pcxID),
+	}, nil
+}
+
+// DeleteVpcPeeringConnection deletes a pending or active peering connection
+// on behalf of either account. Routes to it become blackholes and deleted
+// peering connections remain visible.
+func (e *EC2) DeleteVpcPeeringConnection(input *ec2.DeleteVpcPeeringConnectionInput) (*ec2.DeleteVpcPeeringConnectionOutput, error) {
+	pcxID := aws.StringValue(input.VpcPeeringConnectionId)
+	pcx, err := e.vpcPeeringConnection(pcxID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch code := vpcPeeringConnectionStatusCode(pcx); code {
+	case ec2.VpcPeeringConnectionStateReasonCodeActive, ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance:
+	default:
+		return nil, ec2Error("InvalidStateTransition", "Invalid state transition for %s, attempted to transition from %s to deleted", pcxID, code)
+	}
+
+	e.deleteVpcPeeringConnection(pcx)
+
+	return &ec2.DeleteVpcPeeringConnectionOutput{
+		Return: aws.Bool(true),
+	}, nil
+}
+
+func (e *EC2) deleteVpcPeeringConnection(pcx *ec2.VpcPeeringConnection) {
+	pcxID := aws.StringValue(pcx.VpcPeeringConnectionId)
+
+	for _, id := range sortedKeys(e.routeTables) {
+		for _, route := range e.routeTables[id].Routes {
+			if aws.StringValue(route.VpcPeeringConnectionId) == pcxID {
+				route.State = aws.String(ec2.RouteStateBlackhole)
+			}
+		}
+	}
+
+	setVpcPeeringConnectionStatus(pcx, ec2.VpcPeeringConnectionStateReasonCodeDeleted, "Deleted by "+e.caller)
+}
+
+// deleteVpcPeeringConnections deletes the pending and active peering
+// connections of a deleted VPC.
+func (e *EC2) deleteVpcPeeringConnections(vpcID string) {
+	for _, id := range sortedKeys(e.vpcPeeringConnections) {
+		pcx := e.vpcPeeringConnections[id]
+
+		switch vpcPeeringConnectionStatusCode(pcx) {
+		case ec2.VpcPeeringConnectionStateReasonCodeActive, ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance:
+		default:
+			continue
+		}
+
+		if aws.StringValue(pcx.AccepterVpcInfo.VpcId) == vpcID || aws.StringValue(pcx.RequesterVpcInfo.VpcId) == vpcID {
+			e.deleteVpcPeeringConnection(pcx)
+		}
+	}
+}
+
+// ModifyVpcPeeringConnectionOptions modifies the options of an active
+// peering connection. Each account can only modify the options of its side.
+func (e *EC2) ModifyVpcPeeringConnectionOptions(input *ec2.ModifyVpcPeeringConnectionOptionsInput) (*ec2.ModifyVpcPeeringConnectionOptionsOutput, error) {
+	pcxID := aws.StringValue(input.VpcPeeringConnectionId)
+	pcx, err := e.vpcPeeringConnection(pcxID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if code := vpcPeeringConnectionStatusCode(pcx); code != ec2.VpcPeeringConnectionStateReasonCodeActive {
+		return nil, ec2Error("OperationNotPermitted", "Peering connection %s is not active: %s", pcxID, code)
+	}
+
+	sides := []struct {
+		info    *ec2.VpcPeeringConnectionVpcInfo
+		request *ec2.PeeringConnectionOptionsRequest
+	}{
+		{pcx.AccepterVpcInfo, input.AccepterPeeringConnectionOptions},
+		{pcx.RequesterVpcInfo, input.RequesterPeeringConnectionOptions},
+	}
+
+	for _, side := range sides {
+		if side.request != nil && aws.StringValue(side.info.OwnerId) != e.caller {
+			return nil, ec2Error("OperationNotPermitted", "Peering connection options for VPC %s can only be modified by its owner", aws.StringValue(side.info.VpcId))
+		}
+	}
+
+	output := &ec2.ModifyVpcPeeringConnectionOptionsOutput{}
+
+	for i, side := range sides {
+		if side.request == nil {
+			continue
+		}
+
+		options := side.info.PeeringOptions
+
+		if side.request.AllowDnsResolutionFromRemoteVpc != nil {
+			options.AllowDnsResolutionFromRemoteVpc = side.request.AllowDnsResolutionFromRemoteVpc
+		}
+
+		if side.request.AllowEgressFromLocalClassicLinkToRemoteVpc != nil {
+			options.AllowEgressFromLocalClassicLinkToRemoteVpc = side.request.AllowEgressFromLocalClassicLinkToRemoteVpc
+		}
+
+		if side.request.AllowEgressFromLocalVpcToRemoteClassicLink != nil {
+			options.AllowEgressFromLocalVpcToRemoteClassicLink = side.request.AllowEgressFromLocalVpcToRemoteClassicLink
+		}
+
+		description := &ec2.PeeringConnectionOptions{
+			AllowDnsResolutionFromRemoteVpc:            options.AllowDnsResolutionFromRemoteVpc,
+			AllowEgressFromLocalClassicLinkToRemoteVpc: options.AllowEgressFromLocalClassicLinkToRemoteVpc,
+			AllowEgressFromLocalVpcToRemoteClassicLink: options.AllowEgressFromLocalVpcToRemoteClassicLink,
+		}
+
+		if i == 0 {
+			output.AccepterPeeringConnectionOptions = description
+		} else {
+			output.RequesterPeeringConnectionOptions = description
+		}
+	}
+
+	return output, nil
+}
+
+func (e *EC2) describeVpcPeeringConnection(id string) *ec2.VpcPeeringConnection {
+	pcx := awsutil.CopyOf(e.vpcPeeringConnections[id]).(*ec2.VpcPeeringConnection)
+	pcx.Tags = e.ec2Tags(id)
+
+	return pcx
+}
+
+func (e *EC2) vpcPeeringConnectionFilterValues(id, name string) ([]string, bool) {
+	pcx := e.vpcPeeringConnections[id]
+
+	switch name {
+	case "accepter-vpc-info.cidr-block":
+		return stringFilterValue(pcx.AccepterVpcInfo.CidrBlock), true
+	case "accepter-vpc-info.owner-id":
+		return stringFilterValue(pcx.AccepterVpcInfo.OwnerId), true
+	case "accepter-vpc-info.vpc-id":
+		return stringFilterValue(pcx.AccepterVpcInfo.VpcId), true
+	case "requester-vpc-info.cidr-block":
+		return stringFilterValue(pcx.RequesterVpcInfo.CidrBlock), true
+	case "requester-vpc-info.owner-id":
+		return stringFilterValue(pcx.RequesterVpcInfo.OwnerId), true
+	case "requester-vpc-info.vpc-id":
+		return stringFilterValue(pcx.RequesterVpcInfo.VpcId), true
+	case "status-code":
+		return stringFilterValue(pcx.Status.Code), true
+	case "vpc-peering-connection-id":
+		return []string{id}, true
+	}
+
+	return nil, false
+}
+
+// DescribeVpcPeeringConnections describes the peering connections where the
+// caller owns the requester or accepter VPC.
+func (e *EC2) DescribeVpcPeeringConnections(input *ec2.DescribeVpcPeeringConnectionsInput) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	var visible []string
+
+	for _, id := range sortedKeys(e.vpcPeeringConnections) {
+		if e.vpcPeeringConnectionVisible(e.vpcPeeringConnections[id]) {
+			visible = append(visible, id)
+		}
+	}
+
+	ids, err := e.selectIDs(visible, input.VpcPeeringConnectionIds, input.Filters, "InvalidVpcPeeringConnectionID.NotFound", "vpcPeeringConnection", e.vpcPeeringConnectionFilterValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ec2.DescribeVpcPeeringConnectionsOutput{}
+
+	for _, id := range ids {
+		output.VpcPeeringConnections = append(output.VpcPeeringConnections, e.describeVpcPeeringConnection(id))
+	}
+
+	return output, nil
+}
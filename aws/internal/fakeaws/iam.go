@@ -0,0 +1,494 @@
+package fakeaws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+// IAM implements the AWS Identity and Access Management API operations for roles.
+type IAM struct {
+	ids int
+
+	roles map[string]*iamRole
+}
+
+type iamRole struct {
+	attachedPolicies []string
+	inlinePolicies   map[string]string
+	role             *iam.Role
+	tags             map[string]string
+}
+
+func newIAM() *IAM {
+	return &IAM{
+		roles: make(map[string]*iamRole),
+	}
+}
+
+func iamError(code, format string, a ...interface{}) *Error {
+	statusCode := http.StatusBadRequest
+
+	switch code {
+	case iam.ErrCodeNoSuchEntityException:
+		statusCode = http.StatusNotFound
+	case iam.ErrCodeDeleteConflictException, iam.ErrCodeEntityAlreadyExistsException:
+		statusCode = http.StatusConflict
+	}
+
+	return newError(statusCode, code, format, a...)
+}
+
+var iamRoleNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
+
+func (i *IAM) role(name string) (*iamRole, error) {
+	r, ok := i.roles[name]
+
+	if !ok {
+		return nil, iamError(iam.ErrCodeNoSuchEntityException, "The role with name %s cannot be found.", name)
+	}
+
+	return r, nil
+}
+
+// validatePolicyDocument returns an error unless document is a JSON object.
+func validatePolicyDocument(document string) error {
+	var v map[string]interface{}
+
+	if err := json.Unmarshal([]byte(document), &v); err != nil {
+		return iamError(iam.ErrCodeMalformedPolicyDocumentException, "Syntax errors in policy.")
+	}
+
+	return nil
+}
+
+func validatePolicyArn(policyArn string) error {
+	if a, err := arn.Parse(policyArn); err != nil || a.Service != "iam" || !strings.HasPrefix(a.Resource, "policy/") {
+		return iamError(iam.ErrCodeInvalidInputException, "ARN %s is not valid.", policyArn)
+	}
+
+	return nil
+}
+
+func (i *IAM) describeRole(r *iamRole) *iam.Role {
+	role := awsutil.CopyOf(r.role).(*iam.Role)
+
+	for _, k := range sortedKeys(r.tags) {
+		role.Tags = append(role.Tags, &iam.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(r.tags[k]),
+		})
+	}
+
+	return role
+}
+
+func (i *IAM) CreateRole(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+	name := aws.StringValue(input.RoleName)
+
+	if !iamRoleNameRegexp.MatchString(name) {
+		return nil, iamError("ValidationError", "1 validation error detected: Value '%s' at 'roleName' failed to satisfy constraint", name)
+	}
+
+	if _, ok := i.roles[name]; ok {
+		return nil, iamError(iam.ErrCodeEntityAlreadyExistsException, "Role with name %s already exists.", name)
+	}
+
+	path := aws.StringValue(input.Path)
+
+	if path == "" {
+		path = "/"
+	}
+
+	if !strings.HasPrefix(path, "/") || !strings.HasSuffix(path, "/") {
+		return nil, iamError("ValidationError", "The specified value for path is invalid. It must begin and end with / and contain only alphanumeric characters and/or / characters.")
+	}
+
+	document := aws.StringValue(input.AssumeRolePolicyDocument)
+
+	if err := validatePolicyDocument(document); err != nil {
+		return nil, err
+	}
+
+	maxSessionDuration := aws.Int64Value(input.MaxSessionDuration)
+
+	if maxSessionDuration == 0 {
+		maxSessionDuration = 3600
+	}
+
+	if maxSessionDuration < 3600 || maxSessionDuration > 43200 {
+		return nil, iamError("ValidationError", "The requested MaxSessionDuration %d exceeds the allowed range of 3600 to 43200 seconds.", maxSessionDuration)
+	}
+
+	i.ids++
+
+	r := &iamRole{
+		inlinePolicies: make(map[string]string),
+		role: &iam.Role{
+			Arn:                      aws.String(fmt.Sprintf("arn:aws:iam::%s:role%s%s", AccountID, path, name)),
+			AssumeRolePolicyDocument: aws.String(url.QueryEscape(document)),
+			CreateDate:               aws.Time(time.Now().UTC().Truncate(time.Second)),
+			Description:              input.Description,
+			MaxSessionDuration:       aws.Int64(maxSessionDuration),
+			Path:                     aws.String(path),
+			RoleId:                   aws.String(fmt.Sprintf("AROA%017X", i.ids)),
+			RoleName:                 aws.String(name),
+		},
+		tags: make(map[string]string),
+	}
+
+	if input.PermissionsBoundary != nil {
+		if err := validatePolicyArn(aws.StringValue(input.PermissionsBoundary)); err != nil {
+			return nil, err
+		}
+
+		r.role.PermissionsBoundary = &iam.AttachedPermissionsBoundary{
+			PermissionsBoundaryArn:  input.PermissionsBoundary,
+			PermissionsBoundaryType: aws.String(iam.PermissionsBoundaryAttachmentTypePermissionsBoundaryPolicy),
+		}
+	}
+
+	for _, tag := range input.Tags {
+		r.tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	i.roles[name] = r
+
+	return &iam.CreateRoleOutput{
+		Role: i.describeRole(r),
+	}, nil
+}
+
+func (i *IAM) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.GetRoleOutput{
+		Role: i.describeRole(r),
+	}, nil
+}
+
+func (i *IAM) DeleteRole(input *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error) {
+	name := aws.StringValue(input.RoleName)
+	r, err := i.role(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.attachedPolicies) > 0 || len(r.inlinePolicies) > 0 {
+		return nil, iamError(iam.ErrCodeDeleteConflictException, "Cannot delete entity, must detach all policies first.")
+	}
+
+	delete(i.roles, name)
+
+	return &iam.DeleteRoleOutput{}, nil
+}
+
+func (i *IAM) UpdateAssumeRolePolicy(input *iam.UpdateAssumeRolePolicyInput) (*iam.UpdateAssumeRolePolicyOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	document := aws.StringValue(input.PolicyDocument)
+
+	if err := validatePolicyDocument(document); err != nil {
+		return nil, err
+	}
+
+	r.role.AssumeRolePolicyDocument = aws.String(url.QueryEscape(document))
+
+	return &iam.UpdateAssumeRolePolicyOutput{}, nil
+}
+
+func (i *IAM) UpdateRole(input *iam.UpdateRoleInput) (*iam.UpdateRoleOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MaxSessionDuration != nil {
+		maxSessionDuration := aws.Int64Value(input.MaxSessionDuration)
+
+		if maxSessionDuration < 3600 || maxSessionDuration > 43200 {
+			return nil, iamError("ValidationError", "The requested MaxSessionDuration %d exceeds the allowed range of 3600 to 43200 seconds.", maxSessionDuration)
+		}
+
+		r.role.MaxSessionDuration = aws.Int64(maxSessionDuration)
+	}
+
+	if input.Description != nil {
+		r.role.Description = input.Description
+	}
+
+	return &iam.UpdateRoleOutput{}, nil
+}
+
+func (i *IAM) UpdateRoleDescription(input *iam.UpdateRoleDescriptionInput) (*iam.UpdateRoleDescriptionOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.role.Description = aws.String(aws.StringValue(input.Description))
+
+	return &iam.UpdateRoleDescriptionOutput{
+		Role: i.describeRole(r),
+	}, nil
+}
+
+func (i *IAM) PutRolePermissionsBoundary(input *iam.PutRolePermissionsBoundaryInput) (*iam.PutRolePermissionsBoundaryOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePolicyArn(aws.StringValue(input.PermissionsBoundary)); err != nil {
+		return nil, err
+	}
+
+	r.role.PermissionsBoundary = &iam.AttachedPermissionsBoundary{
+		PermissionsBoundaryArn:  input.PermissionsBoundary,
+		PermissionsBoundaryType: aws.String(iam.PermissionsBoundaryAttachmentTypePermissionsBoundaryPolicy),
+	}
+
+	return &iam.PutRolePermissionsBoundaryOutput{}, nil
+}
+
+func (i *IAM) DeleteRolePermissionsBoundary(input *iam.DeleteRolePermissionsBoundaryInput) (*iam.DeleteRolePermissionsBoundaryOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.role.PermissionsBoundary == nil {
+		return nil, iamError(iam.ErrCodeNoSuchEntityException, "The role %s has no permissions boundary.", aws.StringValue(input.RoleName))
+	}
+
+	r.role.PermissionsBoundary = nil
+
+	return &iam.DeleteRolePermissionsBoundaryOutput{}, nil
+}
+
+func (i *IAM) TagRole(input *iam.TagRoleInput) (*iam.TagRoleOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range input.Tags {
+		r.tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return &iam.TagRoleOutput{}, nil
+}
+
+func (i *IAM) UntagRole(input *iam.UntagRoleInput) (*iam.UntagRoleOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range aws.StringValueSlice(input.TagKeys) {
+		delete(r.tags, k)
+	}
+
+	return &iam.UntagRoleOutput{}, nil
+}
+
+func (i *IAM) ListRoleTags(input *iam.ListRoleTagsInput) (*iam.ListRoleTagsOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListRoleTagsOutput{
+		IsTruncated: aws.Bool(false),
+		Tags:        i.describeRole(r).Tags,
+	}, nil
+}
+
+// ListInstanceProfilesForRole always returns no instance profiles, as
+// instance profiles are not implemented.
+func (i *IAM) ListInstanceProfilesForRole(input *iam.ListInstanceProfilesForRoleInput) (*iam.ListInstanceProfilesForRoleOutput, error) {
+	if _, err := i.role(aws.StringValue(input.RoleName)); err != nil {
+		return nil, err
+	}
+
+	return &iam.ListInstanceProfilesForRoleOutput{
+		InstanceProfiles: []*iam.InstanceProfile{},
+		IsTruncated:      aws.Bool(false),
+	}, nil
+}
+
+func (i *IAM) RemoveRoleFromInstanceProfile(input *iam.RemoveRoleFromInstanceProfileInput) (*iam.RemoveRoleFromInstanceProfileOutput, error) {
+	return nil, iamError(iam.ErrCodeNoSuchEntityException, "Instance Profile %s cannot be found.", aws.StringValue(input.InstanceProfileName))
+}
+
+//
+// Inline policies
+//
+
+func (i *IAM) PutRolePolicy(input *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(input.PolicyName)
+
+	if !iamRoleNameRegexp.MatchString(name) {
+		return nil, iamError("ValidationError", "1 validation error detected: Value '%s' at 'policyName' failed to satisfy constraint", name)
+	}
+
+	document := aws.StringValue(input.PolicyDocument)
+
+	if err := validatePolicyDocument(document); err != nil {
+		return nil, err
+	}
+
+	r.inlinePolicies[name] = document
+
+	return &iam.PutRolePolicyOutput{}, nil
+}
+
+func (i *IAM) GetRolePolicy(input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	roleName := aws.StringValue(input.RoleName)
+	r, err := i.role(roleName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(input.PolicyName)
+	document, ok := r.inlinePolicies[name]
+
+	if !ok {
+		return nil, iamError(iam.ErrCodeNoSuchEntityException, "The role policy with name %s cannot be found.", name)
+	}
+
+	return &iam.GetRolePolicyOutput{
+		PolicyDocument: aws.String(url.QueryEscape(document)),
+		PolicyName:     aws.String(name),
+		RoleName:       aws.String(roleName),
+	}, nil
+}
+
+func (i *IAM) DeleteRolePolicy(input *iam.DeleteRolePolicyInput) (*iam.DeleteRolePolicyOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(input.PolicyName)
+
+	if _, ok := r.inlinePolicies[name]; !ok {
+		return nil, iamError(iam.ErrCodeNoSuchEntityException, "The role policy with name %s cannot be found.", name)
+	}
+
+	delete(r.inlinePolicies, name)
+
+	return &iam.DeleteRolePolicyOutput{}, nil
+}
+
+func (i *IAM) ListRolePolicies(input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListRolePoliciesOutput{
+		IsTruncated: aws.Bool(false),
+		PolicyNames: aws.StringSlice(sortedKeys(r.inlinePolicies)),
+	}, nil
+}
+
+//
+// Attached policies
+//
+
+func (i *IAM) AttachRolePolicy(input *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	policyArn := aws.StringValue(input.PolicyArn)
+
+	if err := validatePolicyArn(policyArn); err != nil {
+		return nil, err
+	}
+
+	if !contains(r.attachedPolicies, policyArn) {
+		r.attachedPolicies = append(r.attachedPolicies, policyArn)
+	}
+
+	return &iam.AttachRolePolicyOutput{}, nil
+}
+
+func (i *IAM) DetachRolePolicy(input *iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	policyArn := aws.StringValue(input.PolicyArn)
+
+	for j, v := range r.attachedPolicies {
+		if v == policyArn {
+			r.attachedPolicies = append(r.attachedPolicies[:j], r.attachedPolicies[j+1:]...)
+
+			return &iam.DetachRolePolicyOutput{}, nil
+		}
+	}
+
+	return nil, iamError(iam.ErrCodeNoSuchEntityException, "Policy %s was not found.", policyArn)
+}
+
+func (i *IAM) ListAttachedRolePolicies(input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
+	r, err := i.role(aws.StringValue(input.RoleName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &iam.ListAttachedRolePoliciesOutput{
+		AttachedPolicies: []*iam.AttachedPolicy{},
+		IsTruncated:      aws.Bool(false),
+	}
+
+	for _, policyArn := range r.attachedPolicies {
+		output.AttachedPolicies = append(output.AttachedPolicies, &iam.AttachedPolicy{
+			PolicyArn:  aws.String(policyArn),
+			PolicyName: aws.String(policyArn[strings.LastIndex(policyArn, "/")+1:]),
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,389 @@
+package fakeaws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/lexmodelbuildingservice"
+)
+
+const lexLatestVersion = "$LATEST"
+
+// LexModels implements the Amazon Lex Model Building Service API operations
+// for bots, intents and slot types.
+//
+// Every resource is stored as its versions keyed by version: the $LATEST
+// version is replaced by each put and numbered versions are created when a
+// put sets CreateVersion. Bots are READY as soon as they are put.
+type LexModels struct {
+	bots      map[string]map[string]*lexmodelbuildingservice.GetBotOutput
+	intents   map[string]map[string]*lexmodelbuildingservice.GetIntentOutput
+	slotTypes map[string]map[string]*lexmodelbuildingservice.GetSlotTypeOutput
+}
+
+func newLexModels() *LexModels {
+	return &LexModels{
+		bots:      make(map[string]map[string]*lexmodelbuildingservice.GetBotOutput),
+		intents:   make(map[string]map[string]*lexmodelbuildingservice.GetIntentOutput),
+		slotTypes: make(map[string]map[string]*lexmodelbuildingservice.GetSlotTypeOutput),
+	}
+}
+
+// lexModelsOperations returns the client used to route REST requests.
+func lexModelsOperations() *lexmodelbuildingservice.LexModelBuildingService {
+	return lexmodelbuildingservice.New(operationsSession())
+}
+
+func lexModelsError(code, format string, a ...interface{}) *Error {
+	statusCode := http.StatusBadRequest
+
+	switch code {
+	case lexmodelbuildingservice.ErrCodeConflictException:
+		statusCode = http.StatusConflict
+	case lexmodelbuildingservice.ErrCodeNotFoundException:
+		statusCode = http.StatusNotFound
+	case lexmodelbuildingservice.ErrCodePreconditionFailedException:
+		statusCode = http.StatusPreconditionFailed
+	}
+
+	return newError(statusCode, code, format, a...)
+}
+
+// lexChecksum returns the checksum of the content of a put request.
+func lexChecksum(input interface{}) string {
+	b, _ := json.Marshal(input)
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// checkLexChecksum returns an error unless checksum identifies the $LATEST
+// version of an existing resource, or is omitted for a new resource.
+func checkLexChecksum(kind, name string, exists bool, latest, checksum *string) error {
+	if !exists {
+		if checksum != nil {
+			return lexModelsError(lexmodelbuildingservice.ErrCodePreconditionFailedException, "Checksum provided for new %s %s.", kind, name)
+		}
+
+		return nil
+	}
+
+	if aws.StringValue(checksum) != aws.StringValue(latest) {
+		return lexModelsError(lexmodelbuildingservice.ErrCodePreconditionFailedException, "The checksum value doesn't match for '%s'.", name)
+	}
+
+	return nil
+}
+
+// lexNextVersion returns the version to publish for a resource with the given
+// numbered versions, and whether it is an existing version that already
+// matches the $LATEST checksum.
+func lexNextVersion(checksums []string, latest string) (string, bool) {
+	if n := len(checksums); n > 0 && checksums[n-1] == latest {
+		return strconv.Itoa(n), true
+	}
+
+	return strconv.Itoa(len(checksums) + 1), false
+}
+
+func (l *LexModels) PutBot(input *lexmodelbuildingservice.PutBotInput) (*lexmodelbuildingservice.PutBotOutput, error) {
+	name := aws.StringValue(input.Name)
+	versions, exists := l.bots[name]
+	now := time.Now()
+
+	latest := &lexmodelbuildingservice.GetBotOutput{}
+
+	if exists {
+		latest = versions[lexLatestVersion]
+	}
+
+	if err := checkLexChecksum("bot", name, exists, latest.Checksum, input.Checksum); err != nil {
+		return nil, err
+	}
+
+	for _, intent := range input.Intents {
+		if _, ok := l.intents[aws.StringValue(intent.IntentName)][aws.StringValue(intent.IntentVersion)]; !ok {
+			return nil, lexModelsError(lexmodelbuildingservice.ErrCodeBadRequestException, "The intent %s with version %s does not exist.", aws.StringValue(intent.IntentName), aws.StringValue(intent.IntentVersion))
+		}
+	}
+
+	content := awsutil.CopyOf(input).(*lexmodelbuildingservice.PutBotInput)
+	content.Checksum = nil
+	content.CreateVersion = nil
+
+	bot := &lexmodelbuildingservice.GetBotOutput{}
+	awsutil.Copy(bot, content)
+	bot.Checksum = aws.String(lexChecksum(content))
+	bot.CreatedDate = aws.Time(now)
+	bot.LastUpdatedDate = aws.Time(now)
+	bot.Status = aws.String(lexmodelbuildingservice.StatusReady)
+	bot.Version = aws.String(lexLatestVersion)
+
+	if exists {
+		bot.CreatedDate = latest.CreatedDate
+	} else {
+		versions = make(map[string]*lexmodelbuildingservice.GetBotOutput)
+		l.bots[name] = versions
+	}
+
+	versions[lexLatestVersion] = bot
+	result := bot
+
+	if aws.BoolValue(input.CreateVersion) {
+		var checksums []string
+
+		for i := 1; versions[strconv.Itoa(i)] != nil; i++ {
+			checksums = append(checksums, aws.StringValue(versions[strconv.Itoa(i)].Checksum))
+		}
+
+		version, published := lexNextVersion(checksums, aws.StringValue(bot.Checksum))
+
+		if !published {
+			v := awsutil.CopyOf(bot).(*lexmodelbuildingservice.GetBotOutput)
+			v.Version = aws.String(version)
+			versions[version] = v
+		}
+
+		result = versions[version]
+	}
+
+	output := &lexmodelbuildingservice.PutBotOutput{}
+	awsutil.Copy(output, result)
+	output.CreateVersion = input.CreateVersion
+
+	return output, nil
+}
+
+func (l *LexModels) GetBot(input *lexmodelbuildingservice.GetBotInput) (*lexmodelbuildingservice.GetBotOutput, error) {
+	name := aws.StringValue(input.Name)
+	version := aws.StringValue(input.VersionOrAlias)
+	bot, ok := l.bots[name][version]
+
+	if !ok {
+		return nil, lexModelsError(lexmodelbuildingservice.ErrCodeNotFoundException, "The bot %s with version %s does not exist.", name, version)
+	}
+
+	return awsutil.CopyOf(bot).(*lexmodelbuildingservice.GetBotOutput), nil
+}
+
+func (l *LexModels) DeleteBot(input *lexmodelbuildingservice.DeleteBotInput) (*lexmodelbuildingservice.DeleteBotOutput, error) {
+	name := aws.StringValue(input.Name)
+
+	if _, ok := l.bots[name]; !ok {
+		return nil, lexModelsError(lexmodelbuildingservice.ErrCodeNotFoundException, "The bot %s does not exist.", name)
+	}
+
+	delete(l.bots, name)
+
+	return &lexmodelbuildingservice.DeleteBotOutput{}, nil
+}
+
+func (l *LexModels) PutIntent(input *lexmodelbuildingservice.PutIntentInput) (*lexmodelbuildingservice.PutIntentOutput, error) {
+	name := aws.StringValue(input.Name)
+	versions, exists := l.intents[name]
+	now := time.Now()
+
+	latest := &lexmodelbuildingservice.GetIntentOutput{}
+
+	if exists {
+		latest = versions[lexLatestVersion]
+	}
+
+	if err := checkLexChecksum("intent", name, exists, latest.Checksum, input.Checksum); err != nil {
+		return nil, err
+	}
+
+	for _, slot := range input.Slots {
+		slotType := aws.StringValue(slot.SlotType)
+
+		// Built-in slot types are not versioned.
+		if strings.HasPrefix(slotType, "AMAZON.") {
+			continue
+		}
+
+		if _, ok := l.slotTypes[slotType][aws.StringValue(slot.SlotTypeVersion)]; !ok {
+			return nil, lexModelsError(lexmodelbuildingservice.ErrCodeBadRequestException, "The slot type %s with version %s does not exist.", slotType, aws.StringValue(slot.SlotTypeVersion))
+		}
+	}
+
+	content := awsutil.CopyOf(input).(*lexmodelbuildingservice.PutIntentInput)
+	content.Checksum = nil
+	content.CreateVersion = nil
+
+	intent := &lexmodelbuildingservice.GetIntentOutput{}
+	awsutil.Copy(intent, content)
+	intent.Checksum = aws.String(lexChecksum(content))
+	intent.CreatedDate = aws.Time(now)
+	intent.LastUpdatedDate = aws.Time(now)
+	intent.Version = aws.String(lexLatestVersion)
+
+	if exists {
+		intent.CreatedDate = latest.CreatedDate
+	} else {
+		versions = make(map[string]*lexmodelbuildingservice.GetIntentOutput)
+		l.intents[name] = versions
+	}
+
+	versions[lexLatestVersion] = intent
+	result := intent
+
+	if aws.BoolValue(input.CreateVersion) {
+		var checksums []string
+
+		for i := 1; versions[strconv.Itoa(i)] != nil; i++ {
+			checksums = append(checksums, aws.StringValue(versions[strconv.Itoa(i)].Checksum))
+		}
+
+		version, published := lexNextVersion(checksums, aws.StringValue(intent.Checksum))
+
+		if !published {
+			v := awsutil.CopyOf(intent).(*lexmodelbuildingservice.GetIntentOutput)
+			v.Version = aws.String(version)
+			versions[version] = v
+		}
+
+		result = versions[version]
+	}
+
+	output := &lexmodelbuildingservice.PutIntentOutput{}
+	awsutil.Copy(output, result)
+	output.CreateVersion = input.CreateVersion
+
+	return output, nil
+}
+
+func (l *LexModels) GetIntent(input *lexmodelbuildingservice.GetIntentInput) (*lexmodelbuildingservice.GetIntentOutput, error) {
+	name := aws.StringValue(input.Name)
+	version := aws.StringValue(input.Version)
+	intent, ok := l.intents[name][version]
+
+	if !ok {
+		return nil, lexModelsError(lexmodelbuildingservice.ErrCodeNotFoundException, "The intent %s with version %s does not exist.", name, version)
+	}
+
+	return awsutil.CopyOf(intent).(*lexmodelbuildingservice.GetIntentOutput), nil
+}
+
+func (l *LexModels) DeleteIntent(input *lexmodelbuildingservice.DeleteIntentInput) (*lexmodelbuildingservice.DeleteIntentOutput, error) {
+	name := aws.StringValue(input.Name)
+
+	if _, ok := l.intents[name]; !ok {
+		return nil, lexModelsError(lexmodelbuildingservice.ErrCodeNotFoundException, "The intent %s does not exist.", name)
+	}
+
+	for _, botName := range sortedKeys(l.bots) {
+		for _, bot := range l.bots[botName] {
+			for _, intent := range bot.Intents {
+				if aws.StringValue(intent.IntentName) == name {
+					return nil, lexModelsError(lexmodelbuildingservice.ErrCodeResourceInUseException, "The intent %s is referenced by bot %s.", name, botName)
+				}
+			}
+		}
+	}
+
+	delete(l.intents, name)
+
+	return &lexmodelbuildingservice.DeleteIntentOutput{}, nil
+}
+
+func (l *LexModels) PutSlotType(input *lexmodelbuildingservice.PutSlotTypeInput) (*lexmodelbuildingservice.PutSlotTypeOutput, error) {
+	name := aws.StringValue(input.Name)
+	versions, exists := l.slotTypes[name]
+	now := time.Now()
+
+	latest := &lexmodelbuildingservice.GetSlotTypeOutput{}
+
+	if exists {
+		latest = versions[lexLatestVersion]
+	}
+
+	if err := checkLexChecksum("slot type", name, exists, latest.Checksum, input.Checksum); err != nil {
+		return nil, err
+	}
+
+	content := awsutil.CopyOf(input).(*lexmodelbuildingservice.PutSlotTypeInput)
+	content.Checksum = nil
+	content.CreateVersion = nil
+
+	slotType := &lexmodelbuildingservice.GetSlotTypeOutput{}
+	awsutil.Copy(slotType, content)
+	slotType.Checksum = aws.String(lexChecksum(content))
+	slotType.CreatedDate = aws.Time(now)
+	slotType.LastUpdatedDate = aws.Time(now)
+	slotType.Version = aws.String(lexLatestVersion)
+
+	if exists {
+		slotType.CreatedDate = latest.CreatedDate
+	} else {
+		versions = make(map[string]*lexmodelbuildingservice.GetSlotTypeOutput)
+		l.slotTypes[name] = versions
+	}
+
+	versions[lexLatestVersion] = slotType
+	result := slotType
+
+	if aws.BoolValue(input.CreateVersion) {
+		var checksums []string
+
+		for i := 1; versions[strconv.Itoa(i)] != nil; i++ {
+			checksums = append(checksums, aws.StringValue(versions[strconv.Itoa(i)].Checksum))
+		}
+
+		version, published := lexNextVersion(checksums, aws.StringValue(slotType.Checksum))
+
+		if !published {
+			v := awsutil.CopyOf(slotType).(*lexmodelbuildingservice.GetSlotTypeOutput)
+			v.Version = aws.String(version)
+			versions[version] = v
+		}
+
+		result = versions[version]
+	}
+
+	output := &lexmodelbuildingservice.PutSlotTypeOutput{}
+	awsutil.Copy(output, result)
+	output.CreateVersion = input.CreateVersion
+
+	return output, nil
+}
+
+func (l *LexModels) GetSlotType(input *lexmodelbuildingservice.GetSlotTypeInput) (*lexmodelbuildingservice.GetSlotTypeOutput, error) {
+	name := aws.StringValue(input.Name)
+	version := aws.StringValue(input.Version)
+	slotType, ok := l.slotTypes[name][version]
+
+	if !ok {
+		return nil, lexModelsError(lexmodelbuildingservice.ErrCodeNotFoundException, "The slot type %s with version %s does not exist.", name, version)
+	}
+
+	return awsutil.CopyOf(slotType).(*lexmodelbuildingservice.GetSlotTypeOutput), nil
+}
+
+func (l *LexModels) DeleteSlotType(input *lexmodelbuildingservice.DeleteSlotTypeInput) (*lexmodelbuildingservice.DeleteSlotTypeOutput, error) {
+	name := aws.StringValue(input.Name)
+
+	if _, ok := l.slotTypes[name]; !ok {
+		return nil, lexModelsError(lexmodelbuildingservice.ErrCodeNotFoundException, "The slot type %s does not exist.", name)
+	}
+
+	for _, intentName := range sortedKeys(l.intents) {
+		for _, intent := range l.intents[intentName] {
+			for _, slot := range intent.Slots {
+				if aws.StringValue(slot.SlotType) == name {
+					return nil, lexModelsError(lexmodelbuildingservice.ErrCodeResourceInUseException, "The slot type %s is referenced by intent %s.", name, intentName)
+				}
+			}
+		}
+	}
+
+	delete(l.slotTypes, name)
+
+	return &lexmodelbuildingservice.DeleteSlotTypeOutput{}, nil
+}
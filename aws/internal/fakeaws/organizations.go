@@ -0,0 +1,471 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+const (
+	organizationID = "o-fakeaws0001"
+	rootID         = "r-fk01"
+)
+
+// Organizations implements the AWS Organizations API operations for an
+// organization whose management account is AccountID.
+type Organizations struct {
+	caller string
+	ids    int
+
+	accounts              map[string]*organizations.Account
+	createAccountStatuses map[string]*organizations.CreateAccountStatus
+	handshakes            map[string]*organizations.Handshake
+	parents               map[string]string
+	tags                  map[string]map[string]string
+}
+
+func newOrganizations() *Organizations {
+	o := &Organizations{
+		caller:                AccountID,
+		accounts:              make(map[string]*organizations.Account),
+		createAccountStatuses: make(map[string]*organizations.CreateAccountStatus),
+		handshakes:            make(map[string]*organizations.Handshake),
+		parents:               make(map[string]string),
+		tags:                  make(map[string]map[string]string),
+	}
+
+	o.addAccount(AccountID, "fakeaws", "fakeaws@example.com", organizations.AccountJoinedMethodCreated)
+
+	return o
+}
+
+func organizationsError(code, format string, a ...interface{}) *Error {
+	return newError(http.StatusBadRequest, code, format, a...)
+}
+
+// newAccountID returns a new account ID distinct from AccountID.
+func (o *Organizations) newAccountID() string {
+	o.ids++
+
+	return fmt.Sprintf("2100%08d", o.ids)
+}
+
+func (o *Organizations) addAccount(id, name, email, joinedMethod string) *organizations.Account {
+	account := &organizations.Account{
+		Arn:             aws.String(fmt.Sprintf("arn:aws:organizations::%s:account/%s/%s", AccountID, organizationID, id)),
+		Email:           aws.String(email),
+		Id:              aws.String(id),
+		JoinedMethod:    aws.String(joinedMethod),
+		JoinedTimestamp: aws.Time(time.Now().UTC().Truncate(time.Second)),
+		Name:            aws.String(name),
+		Status:          aws.String(organizations.AccountStatusActive),
+	}
+
+	o.accounts[id] = account
+	o.parents[id] = rootID
+
+	return account
+}
+
+func (o *Organizations) account(id string) (*organizations.Account, error) {
+	account, ok := o.accounts[id]
+
+	if !ok {
+		return nil, organizationsError(organizations.ErrCodeAccountNotFoundException, "You specified an account that doesn't exist.")
+	}
+
+	return account, nil
+}
+
+// requireManagementAccount returns an error unless the caller is the
+// management account of the organization.
+func (o *Organizations) requireManagementAccount() error {
+	if o.caller != AccountID {
+		return organizationsError(organizations.ErrCodeAccessDeniedException, "You don't have permissions to access this resource.")
+	}
+
+	return nil
+}
+
+func (o *Organizations) DescribeOrganization(input *organizations.DescribeOrganizationInput) (*organizations.DescribeOrganizationOutput, error) {
+	if _, ok := o.accounts[o.caller]; !ok {
+		return nil, organizationsError(organizations.ErrCodeAWSOrganizationsNotInUseException, "Your account is not a member of an organization.")
+	}
+
+	return &organizations.DescribeOrganizationOutput{
+		Organization: &organizations.Organization{
+			Arn:                aws.String(fmt.Sprintf("arn:aws:organizations::%s:organization/%s", AccountID, organizationID)),
+			FeatureSet:         aws.String(organizations.OrganizationFeatureSetAll),
+			Id:                 aws.String(organizationID),
+			MasterAccountArn:   o.accounts[AccountID].Arn,
+			MasterAccountEmail: o.accounts[AccountID].Email,
+			MasterAccountId:    aws.String(AccountID),
+		},
+	}, nil
+}
+
+//
+// Accounts
+//
+
+func (o *Organizations) CreateGovCloudAccount(input *organizations.CreateGovCloudAccountInput) (*organizations.CreateGovCloudAccountOutput, error) {
+	if err := o.requireManagementAccount(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	o.ids++
+
+	status := &organizations.CreateAccountStatus{
+		AccountName:        input.AccountName,
+		CompletedTimestamp: aws.Time(now),
+		Id:                 aws.String(fmt.Sprintf("car-%032x", o.ids)),
+		RequestedTimestamp: aws.Time(now),
+		State:              aws.String(organizations.CreateAccountStateSucceeded),
+	}
+
+	for _, id := range sortedKeys(o.accounts) {
+		if aws.StringValue(o.accounts[id].Email) == aws.StringValue(input.Email) {
+			status.FailureReason = aws.String(organizations.CreateAccountFailureReasonEmailAlreadyExists)
+			status.State = aws.String(organizations.CreateAccountStateFailed)
+		}
+	}
+
+	if aws.StringValue(status.State) == organizations.CreateAccountStateSucceeded {
+		account := o.addAccount(o.newAccountID(), aws.StringValue(input.AccountName), aws.StringValue(input.Email), organizations.AccountJoinedMethodCreated)
+
+		status.AccountId = account.Id
+		status.GovCloudAccountId = aws.String(o.newAccountID())
+
+		if len(input.Tags) > 0 {
+			o.tags[aws.StringValue(account.Id)] = make(map[string]string)
+
+			for _, tag := range input.Tags {
+				o.tags[aws.StringValue(account.Id)][aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+		}
+	}
+
+	o.createAccountStatuses[aws.StringValue(status.Id)] = status
+
+	return &organizations.CreateGovCloudAccountOutput{
+		CreateAccountStatus: awsutil.CopyOf(status).(*organizations.CreateAccountStatus),
+	}, nil
+}
+
+func (o *Organizations) DescribeCreateAccountStatus(input *organizations.DescribeCreateAccountStatusInput) (*organizations.DescribeCreateAccountStatusOutput, error) {
+	status, ok := o.createAccountStatuses[aws.StringValue(input.CreateAccountRequestId)]
+
+	if !ok {
+		return nil, organizationsError(organizations.ErrCodeCreateAccountStatusNotFoundException, "We can't find an create account request with the CreateAccountRequestId that you specified.")
+	}
+
+	return &organizations.DescribeCreateAccountStatusOutput{
+		CreateAccountStatus: awsutil.CopyOf(status).(*organizations.CreateAccountStatus),
+	}, nil
+}
+
+func (o *Organizations) ListCreateAccountStatus(input *organizations.ListCreateAccountStatusInput) (*organizations.ListCreateAccountStatusOutput, error) {
+	output := &organizations.ListCreateAccountStatusOutput{
+		CreateAccountStatuses: []*organizations.CreateAccountStatus{},
+	}
+
+	for _, id := range sortedKeys(o.createAccountStatuses) {
+		status := o.createAccountStatuses[id]
+
+		if len(input.States) > 0 && !contains(aws.StringValueSlice(input.States), aws.StringValue(status.State)) {
+			continue
+		}
+
+		output.CreateAccountStatuses = append(output.CreateAccountStatuses, awsutil.CopyOf(status).(*organizations.CreateAccountStatus))
+	}
+
+	return output, nil
+}
+
+func (o *Organizations) DescribeAccount(input *organizations.DescribeAccountInput) (*organizations.DescribeAccountOutput, error) {
+	account, err := o.account(aws.StringValue(input.AccountId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &organizations.DescribeAccountOutput{
+		Account: awsutil.CopyOf(account).(*organizations.Account),
+	}, nil
+}
+
+func (o *Organizations) RemoveAccountFromOrganization(input *organizations.RemoveAccountFromOrganizationInput) (*organizations.RemoveAccountFromOrganizationOutput, error) {
+	if err := o.requireManagementAccount(); err != nil {
+		return nil, err
+	}
+
+	accountID := aws.StringValue(input.AccountId)
+
+	if _, err := o.account(accountID); err != nil {
+		return nil, err
+	}
+
+	if accountID == AccountID {
+		return nil, organizationsError(organizations.ErrCodeMasterCannotLeaveOrganizationException, "You can't remove a management account from an AWS organization.")
+	}
+
+	o.removeAccount(accountID)
+
+	return &organizations.RemoveAccountFromOrganizationOutput{}, nil
+}
+
+func (o *Organizations) removeAccount(accountID string) {
+	delete(o.accounts, accountID)
+	delete(o.parents, accountID)
+	delete(o.tags, accountID)
+}
+
+func (o *Organizations) ListParents(input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+	parentID, ok := o.parents[aws.StringValue(input.ChildId)]
+
+	if !ok {
+		return nil, organizationsError(organizations.ErrCodeChildNotFoundException, "We can't find an organizational unit (OU) or AWS account with the ChildId that you specified.")
+	}
+
+	return &organizations.ListParentsOutput{
+		Parents: []*organizations.Parent{{
+			Id:   aws.String(parentID),
+			Type: aws.String(organizations.ParentTypeRoot),
+		}},
+	}, nil
+}
+
+// MoveAccount only accepts the root as destination, as organizational units
+// are not implemented.
+func (o *Organizations) MoveAccount(input *organizations.MoveAccountInput) (*organizations.MoveAccountOutput, error) {
+	if err := o.requireManagementAccount(); err != nil {
+		return nil, err
+	}
+
+	accountID := aws.StringValue(input.AccountId)
+
+	if _, err := o.account(accountID); err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(input.SourceParentId) != o.parents[accountID] {
+		return nil, organizationsError(organizations.ErrCodeSourceParentNotFoundException, "We can't find a source root or OU with the ParentId that you specified.")
+	}
+
+	if aws.StringValue(input.DestinationParentId) != rootID {
+		return nil, organizationsError(organizations.ErrCodeDestinationParentNotFoundException, "We can't find the destination container (a root or OU) with the ParentId that you specified.")
+	}
+
+	return nil, organizationsError(organizations.ErrCodeDuplicateAccountException, "That account is already present in the specified destination.")
+}
+
+//
+// Handshakes
+//
+
+func (o *Organizations) handshake(id string) (*organizations.Handshake, error) {
+	handshake, ok := o.handshakes[id]
+
+	if !ok {
+		return nil, organizationsError(organizations.ErrCodeHandshakeNotFoundException, "We can't find a handshake with the HandshakeId that you specified.")
+	}
+
+	return handshake, nil
+}
+
+// handshakeTarget returns the account ID invited by a handshake.
+func handshakeTarget(handshake *organizations.Handshake) string {
+	for _, party := range handshake.Parties {
+		if aws.StringValue(party.Type) == organizations.HandshakePartyTypeAccount {
+			return aws.StringValue(party.Id)
+		}
+	}
+
+	return ""
+}
+
+func (o *Organizations) InviteAccountToOrganization(input *organizations.InviteAccountToOrganizationInput) (*organizations.InviteAccountToOrganizationOutput, error) {
+	if err := o.requireManagementAccount(); err != nil {
+		return nil, err
+	}
+
+	if input.Target == nil || aws.StringValue(input.Target.Type) != organizations.HandshakePartyTypeAccount || !accountIDRegexp.MatchString(aws.StringValue(input.Target.Id)) {
+		return nil, organizationsError(organizations.ErrCodeInvalidInputException, "You provided a value that does not match the required pattern.")
+	}
+
+	accountID := aws.StringValue(input.Target.Id)
+
+	if _, ok := o.accounts[accountID]; ok {
+		return nil, organizationsError(organizations.ErrCodeDuplicateAccountException, "That account is already present in the specified destination.")
+	}
+
+	for _, id := range sortedKeys(o.handshakes) {
+		handshake := o.handshakes[id]
+
+		if handshakeTarget(handshake) == accountID && aws.StringValue(handshake.State) == organizations.HandshakeStateOpen {
+			return nil, organizationsError(organizations.ErrCodeDuplicateHandshakeException, "A handshake with the same action and target already exists.")
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	o.ids++
+	handshakeID := fmt.Sprintf("h-%010x", o.ids)
+
+	handshake := &organizations.Handshake{
+		Action:              aws.String(organizations.ActionTypeInvite),
+		Arn:                 aws.String(fmt.Sprintf("arn:aws:organizations::%s:handshake/%s/invite/%s", AccountID, organizationID, handshakeID)),
+		ExpirationTimestamp: aws.Time(now.Add(15 * 24 * time.Hour)),
+		Id:                  aws.String(handshakeID),
+		Parties: []*organizations.HandshakeParty{
+			{
+				Id:   aws.String(organizationID),
+				Type: aws.String(organizations.HandshakePartyTypeOrganization),
+			},
+			{
+				Id:   aws.String(accountID),
+				Type: aws.String(organizations.HandshakePartyTypeAccount),
+			},
+		},
+		RequestedTimestamp: aws.Time(now),
+		State:              aws.String(organizations.HandshakeStateOpen),
+	}
+
+	o.handshakes[handshakeID] = handshake
+
+	return &organizations.InviteAccountToOrganizationOutput{
+		Handshake: awsutil.CopyOf(handshake).(*organizations.Handshake),
+	}, nil
+}
+
+func (o *Organizations) DescribeHandshake(input *organizations.DescribeHandshakeInput) (*organizations.DescribeHandshakeOutput, error) {
+	handshake, err := o.handshake(aws.StringValue(input.HandshakeId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &organizations.DescribeHandshakeOutput{
+		Handshake: awsutil.CopyOf(handshake).(*organizations.Handshake),
+	}, nil
+}
+
+func (o *Organizations) CancelHandshake(input *organizations.CancelHandshakeInput) (*organizations.CancelHandshakeOutput, error) {
+	if err := o.requireManagementAccount(); err != nil {
+		return nil, err
+	}
+
+	handshake, err := o.handshake(aws.StringValue(input.HandshakeId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(handshake.State) != organizations.HandshakeStateOpen {
+		return nil, organizationsError(organizations.ErrCodeInvalidHandshakeTransitionException, "You can't perform the operation on the handshake in its current state.")
+	}
+
+	handshake.State = aws.String(organizations.HandshakeStateCanceled)
+
+	return &organizations.CancelHandshakeOutput{
+		Handshake: awsutil.CopyOf(handshake).(*organizations.Handshake),
+	}, nil
+}
+
+func (o *Organizations) AcceptHandshake(input *organizations.AcceptHandshakeInput) (*organizations.AcceptHandshakeOutput, error) {
+	handshake, err := o.handshake(aws.StringValue(input.HandshakeId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if handshakeTarget(handshake) != o.caller {
+		return nil, organizationsError(organizations.ErrCodeAccessDeniedException, "You don't have permissions to access this resource.")
+	}
+
+	if aws.StringValue(handshake.State) != organizations.HandshakeStateOpen {
+		return nil, organizationsError(organizations.ErrCodeInvalidHandshakeTransitionException, "You can't perform the operation on the handshake in its current state.")
+	}
+
+	handshake.State = aws.String(organizations.HandshakeStateAccepted)
+	o.addAccount(o.caller, o.caller, fmt.Sprintf("%s@example.com", o.caller), organizations.AccountJoinedMethodInvited)
+
+	return &organizations.AcceptHandshakeOutput{
+		Handshake: awsutil.CopyOf(handshake).(*organizations.Handshake),
+	}, nil
+}
+
+func (o *Organizations) LeaveOrganization(input *organizations.LeaveOrganizationInput) (*organizations.LeaveOrganizationOutput, error) {
+	if o.caller == AccountID {
+		return nil, organizationsError(organizations.ErrCodeMasterCannotLeaveOrganizationException, "You can't remove a management account from an AWS organization.")
+	}
+
+	if _, ok := o.accounts[o.caller]; !ok {
+		return nil, organizationsError(organizations.ErrCodeAWSOrganizationsNotInUseException, "Your account is not a member of an organization.")
+	}
+
+	o.removeAccount(o.caller)
+
+	return &organizations.LeaveOrganizationOutput{}, nil
+}
+
+//
+// Tags
+//
+
+func (o *Organizations) ListTagsForResource(input *organizations.ListTagsForResourceInput) (*organizations.ListTagsForResourceOutput, error) {
+	resourceID := aws.StringValue(input.ResourceId)
+
+	if _, err := o.account(resourceID); err != nil {
+		return nil, organizationsError(organizations.ErrCodeTargetNotFoundException, "We can't find a root, OU, account, or policy with the TargetId that you specified.")
+	}
+
+	output := &organizations.ListTagsForResourceOutput{
+		Tags: []*organizations.Tag{},
+	}
+
+	for _, k := range sortedKeys(o.tags[resourceID]) {
+		output.Tags = append(output.Tags, &organizations.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(o.tags[resourceID][k]),
+		})
+	}
+
+	return output, nil
+}
+
+func (o *Organizations) TagResource(input *organizations.TagResourceInput) (*organizations.TagResourceOutput, error) {
+	resourceID := aws.StringValue(input.ResourceId)
+
+	if _, err := o.account(resourceID); err != nil {
+		return nil, organizationsError(organizations.ErrCodeTargetNotFoundException, "We can't find a root, OU, account, or policy with the TargetId that you specified.")
+	}
+
+	if o.tags[resourceID] == nil {
+		o.tags[resourceID] = make(map[string]string)
+	}
+
+	for _, tag := range input.Tags {
+		o.tags[resourceID][aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return &organizations.TagResourceOutput{}, nil
+}
+
+func (o *Organizations) UntagResource(input *organizations.UntagResourceInput) (*organizations.UntagResourceOutput, error) {
+	resourceID := aws.StringValue(input.ResourceId)
+
+	if _, err := o.account(resourceID); err != nil {
+		return nil, organizationsError(organizations.ErrCodeTargetNotFoundException, "We can't find a root, OU, account, or policy with the TargetId that you specified.")
+	}
+
+	for _, k := range aws.StringValueSlice(input.TagKeys) {
+		delete(o.tags[resourceID], k)
+	}
+
+	return &organizations.UntagResourceOutput{}, nil
+}
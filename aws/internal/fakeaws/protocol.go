@@ -0,0 +1,722 @@
+package fakeaws
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+)
+
+const (
+	protocolEC2      = "ec2"
+	protocolJSON     = "json"
+	protocolQuery    = "query"
+	protocolRestJSON = "rest-json"
+)
+
+const iso8601TimeFormat = "2006-01-02T15:04:05Z"
+
+// service dispatches requests in one wire protocol to the methods of a
+// handler, each named after the API operation it implements and taking and
+// returning the AWS Go SDK input and output types for that operation.
+type service struct {
+	calls    map[string]int
+	handler  reflect.Value
+	protocol string
+	routes   []*restRoute
+}
+
+// restRoute is the HTTP method and URI template of a REST API operation.
+type restRoute struct {
+	method    string
+	operation string
+	segments  []string
+}
+
+func newService(protocol string, handler interface{}) *service {
+	return &service{
+		calls:    make(map[string]int),
+		handler:  reflect.ValueOf(handler),
+		protocol: protocol,
+	}
+}
+
+// newRestJSONService returns a REST-JSON service, routing requests using the
+// HTTP method and URI templates that operations has for each handler method.
+// operations is an AWS Go SDK client whose methods are named <Operation>Request.
+func newRestJSONService(handler interface{}, operations interface{}) *service {
+	svc := newService(protocolRestJSON, handler)
+	client := reflect.ValueOf(operations)
+	handlerType := svc.handler.Type()
+
+	for i := 0; i < handlerType.NumMethod(); i++ {
+		name := handlerType.Method(i).Name
+		requestMethod := client.MethodByName(name + "Request")
+
+		if !requestMethod.IsValid() {
+			continue
+		}
+
+		results := requestMethod.Call([]reflect.Value{reflect.New(requestMethod.Type().In(0).Elem())})
+		operation := results[0].Interface().(*request.Request).Operation
+		path := operation.HTTPPath
+
+		if i := strings.Index(path, "?"); i != -1 {
+			path = path[:i]
+		}
+
+		svc.routes = append(svc.routes, &restRoute{
+			method:    operation.HTTPMethod,
+			operation: name,
+			segments:  strings.Split(strings.Trim(path, "/"), "/"),
+		})
+	}
+
+	return svc
+}
+
+// operationsSession returns a session used only to construct AWS Go SDK
+// clients for their operation metadata. No requests are sent.
+func operationsSession() *session.Session {
+	return session.Must(session.NewSession(&aws.Config{
+		Credentials: credentials.AnonymousCredentials,
+		Region:      aws.String(Region),
+	}))
+}
+
+func (svc *service) serve(w http.ResponseWriter, r *http.Request, requestID string) {
+	switch svc.protocol {
+	case protocolEC2, protocolQuery:
+		svc.serveQuery(w, r, requestID)
+	case protocolJSON:
+		svc.serveJSON(w, r, requestID)
+	case protocolRestJSON:
+		svc.serveRestJSON(w, r, requestID)
+	}
+}
+
+// invoke calls the handler method for operation, with an input value
+// populated by decode.
+func (svc *service) invoke(operation string, decode func(input interface{}) error) (interface{}, error) {
+	method := svc.handler.MethodByName(operation)
+
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().NumOut() != 2 {
+		return nil, newError(http.StatusBadRequest, "InvalidAction", "operation %s is not implemented", operation)
+	}
+
+	svc.calls[operation]++
+
+	input := reflect.New(method.Type().In(0).Elem())
+
+	if err := decode(input.Interface()); err != nil {
+		return nil, newError(http.StatusBadRequest, "SerializationException", "error decoding %s input: %s", operation, err)
+	}
+
+	results := method.Call([]reflect.Value{input})
+
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	return results[0].Interface(), nil
+}
+
+//
+// Query and EC2 Query protocols
+//
+
+func (svc *service) serveQuery(w http.ResponseWriter, r *http.Request, requestID string) {
+	if err := r.ParseForm(); err != nil {
+		svc.writeQueryError(w, requestID, newError(http.StatusBadRequest, "MalformedQueryString", "%s", err))
+		return
+	}
+
+	operation := r.Form.Get("Action")
+
+	output, err := svc.invoke(operation, func(input interface{}) error {
+		return decodeQuery(r.Form, input, svc.protocol == protocolEC2)
+	})
+
+	if err != nil {
+		svc.writeQueryError(w, requestID, toError(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	responseName := xml.Name{Local: operation + "Response"}
+
+	if svc.protocol == protocolEC2 {
+		e.EncodeToken(xml.StartElement{Name: responseName, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: "http://ec2.amazonaws.com/doc/2016-11-15/"}}})
+		encodeXMLElement(e, "requestId", requestID)
+		encodeXMLFields(e, reflect.ValueOf(output))
+	} else {
+		e.EncodeToken(xml.StartElement{Name: responseName})
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: operation + "Result"}})
+		encodeXMLFields(e, reflect.ValueOf(output))
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: operation + "Result"}})
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "ResponseMetadata"}})
+		encodeXMLElement(e, "RequestId", requestID)
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "ResponseMetadata"}})
+	}
+
+	e.EncodeToken(xml.EndElement{Name: responseName})
+	e.Flush()
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func (svc *service) writeQueryError(w http.ResponseWriter, requestID string, err *Error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+
+	if svc.protocol == protocolEC2 {
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Response"}})
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Errors"}})
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Error"}})
+		encodeXMLElement(e, "Code", err.Code)
+		encodeXMLElement(e, "Message", err.Message)
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "Error"}})
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "Errors"}})
+		encodeXMLElement(e, "RequestID", requestID)
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "Response"}})
+	} else {
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "ErrorResponse"}})
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Error"}})
+		encodeXMLElement(e, "Type", "Sender")
+		encodeXMLElement(e, "Code", err.Code)
+		encodeXMLElement(e, "Message", err.Message)
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "Error"}})
+		encodeXMLElement(e, "RequestId", requestID)
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "ErrorResponse"}})
+	}
+
+	e.Flush()
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(err.StatusCode)
+	w.Write(buf.Bytes())
+}
+
+// decodeQuery populates v from query protocol parameters, reversing the
+// serialization of the AWS Go SDK queryutil package.
+func decodeQuery(values url.Values, v interface{}, isEC2 bool) error {
+	d := queryDecoder{isEC2: isEC2, values: values}
+
+	return d.decodeStruct(reflect.ValueOf(v).Elem(), "")
+}
+
+type queryDecoder struct {
+	isEC2  bool
+	values url.Values
+}
+
+// has returns whether any parameter is named prefix or is nested beneath it.
+func (d *queryDecoder) has(prefix string) bool {
+	for k := range d.values {
+		if k == prefix || strings.HasPrefix(k, prefix+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *queryDecoder) decodeStruct(value reflect.Value, prefix string) error {
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.PkgPath != "" || field.Tag.Get("ignore") != "" {
+			continue
+		}
+
+		var name string
+
+		if d.isEC2 {
+			name = field.Tag.Get("queryName")
+		}
+
+		if name == "" {
+			if field.Tag.Get("flattened") != "" && field.Tag.Get("locationNameList") != "" {
+				name = field.Tag.Get("locationNameList")
+			} else if locationName := field.Tag.Get("locationName"); locationName != "" {
+				name = locationName
+			}
+
+			if name != "" && d.isEC2 {
+				name = strings.ToUpper(name[0:1]) + name[1:]
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		if !d.has(name) {
+			continue
+		}
+
+		if err := d.decodeValue(value.Field(i), name, field.Tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *queryDecoder) decodeValue(value reflect.Value, name string, tag reflect.StructTag) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+
+		if elem.Elem().Kind() == reflect.Struct && elem.Elem().Type() != reflect.TypeOf(time.Time{}) {
+			if err := d.decodeStruct(elem.Elem(), name); err != nil {
+				return err
+			}
+		} else if err := decodeScalar(elem.Elem(), d.values.Get(name), tag); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		value.Set(elem)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return decodeScalar(value, d.values.Get(name), tag)
+		}
+
+		if !d.isEC2 && tag.Get("flattened") == "" {
+			if listName := tag.Get("locationNameList"); listName == "" {
+				name += ".member"
+			} else {
+				name += "." + listName
+			}
+		}
+
+		list := reflect.MakeSlice(value.Type(), 0, 0)
+
+		for i := 1; d.has(fmt.Sprintf("%s.%d", name, i)); i++ {
+			item := reflect.New(value.Type().Elem()).Elem()
+
+			if err := d.decodeValue(item, fmt.Sprintf("%s.%d", name, i), ""); err != nil {
+				return err
+			}
+
+			list = reflect.Append(list, item)
+		}
+
+		value.Set(list)
+	case reflect.Map:
+		if !d.isEC2 && tag.Get("flattened") == "" {
+			name += ".entry"
+		}
+
+		keyName, valueName := "key", "value"
+
+		if v := tag.Get("locationNameKey"); v != "" {
+			keyName = v
+		}
+
+		if v := tag.Get("locationNameValue"); v != "" {
+			valueName = v
+		}
+
+		m := reflect.MakeMap(value.Type())
+
+		for i := 1; d.has(fmt.Sprintf("%s.%d", name, i)); i++ {
+			k := reflect.New(value.Type().Key()).Elem()
+
+			if err := decodeScalar(k, d.values.Get(fmt.Sprintf("%s.%d.%s", name, i, keyName)), ""); err != nil {
+				return err
+			}
+
+			v := reflect.New(value.Type().Elem()).Elem()
+
+			if err := d.decodeValue(v, fmt.Sprintf("%s.%d.%s", name, i, valueName), ""); err != nil {
+				return err
+			}
+
+			m.SetMapIndex(k, v)
+		}
+
+		value.Set(m)
+	default:
+		return decodeScalar(value, d.values.Get(name), tag)
+	}
+
+	return nil
+}
+
+func decodeScalar(value reflect.Value, s string, tag reflect.StructTag) error {
+	switch value.Interface().(type) {
+	case string:
+		value.SetString(s)
+	case []byte:
+		b, err := base64.StdEncoding.DecodeString(s)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetBytes(b)
+	case bool:
+		b, err := strconv.ParseBool(s)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(b)
+	case int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(i)
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(f)
+	case time.Time:
+		t, err := time.Parse(time.RFC3339, s)
+
+		if err != nil {
+			return err
+		}
+
+		value.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
+
+func encodeXMLElement(e *xml.Encoder, name, text string) {
+	e.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}})
+	e.EncodeToken(xml.CharData(text))
+	e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+}
+
+// encodeXMLFields encodes the fields of a structure as XML elements named
+// by their AWS Go SDK locationName tags.
+func encodeXMLFields(e *xml.Encoder, value reflect.Value) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+
+		value = value.Elem()
+	}
+
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.PkgPath != "" || field.Tag.Get("location") != "" {
+			continue
+		}
+
+		name := field.Tag.Get("locationName")
+
+		if name == "" {
+			name = field.Name
+		}
+
+		encodeXMLValue(e, value.Field(i), name, field.Tag)
+	}
+}
+
+func encodeXMLValue(e *xml.Encoder, value reflect.Value, name string, tag reflect.StructTag) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+
+		if value.Elem().Kind() == reflect.Struct && value.Elem().Type() != reflect.TypeOf(time.Time{}) {
+			e.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}})
+			encodeXMLFields(e, value)
+			e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+			return
+		}
+
+		encodeXMLValue(e, value.Elem(), name, tag)
+	case reflect.Slice:
+		if value.IsNil() {
+			return
+		}
+
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			encodeXMLElement(e, name, base64.StdEncoding.EncodeToString(value.Bytes()))
+			return
+		}
+
+		if tag.Get("flattened") != "" {
+			for i := 0; i < value.Len(); i++ {
+				encodeXMLValue(e, value.Index(i), name, "")
+			}
+
+			return
+		}
+
+		memberName := tag.Get("locationNameList")
+
+		if memberName == "" {
+			memberName = "member"
+		}
+
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}})
+
+		for i := 0; i < value.Len(); i++ {
+			encodeXMLValue(e, value.Index(i), memberName, "")
+		}
+
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	case reflect.Map:
+		if value.IsNil() {
+			return
+		}
+
+		keys := make([]string, 0, value.Len())
+
+		for _, k := range value.MapKeys() {
+			keys = append(keys, k.String())
+		}
+
+		sort.Strings(keys)
+
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}})
+
+		for _, k := range keys {
+			e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "entry"}})
+			encodeXMLElement(e, "key", k)
+			encodeXMLValue(e, value.MapIndex(reflect.ValueOf(k)), "value", "")
+			e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "entry"}})
+		}
+
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	case reflect.String:
+		encodeXMLElement(e, name, value.String())
+	case reflect.Bool:
+		encodeXMLElement(e, name, strconv.FormatBool(value.Bool()))
+	case reflect.Int64, reflect.Int:
+		encodeXMLElement(e, name, strconv.FormatInt(value.Int(), 10))
+	case reflect.Float64:
+		encodeXMLElement(e, name, strconv.FormatFloat(value.Float(), 'f', -1, 64))
+	case reflect.Struct:
+		if t, ok := value.Interface().(time.Time); ok {
+			encodeXMLElement(e, name, t.UTC().Format(iso8601TimeFormat))
+		}
+	}
+}
+
+//
+// JSON protocol
+//
+
+func (svc *service) serveJSON(w http.ResponseWriter, r *http.Request, requestID string) {
+	target := r.Header.Get("X-Amz-Target")
+	operation := target[strings.LastIndex(target, ".")+1:]
+
+	output, err := svc.invoke(operation, func(input interface{}) error {
+		return jsonutil.UnmarshalJSON(input, r.Body)
+	})
+
+	if err != nil {
+		writeJSONError(w, requestID, toError(err))
+		return
+	}
+
+	writeJSON(w, requestID, http.StatusOK, output)
+}
+
+func writeJSON(w http.ResponseWriter, requestID string, statusCode int, output interface{}) {
+	body := []byte("{}")
+
+	if v := reflect.ValueOf(output); v.IsValid() && !v.IsNil() {
+		b, err := jsonutil.BuildJSON(output)
+
+		if err != nil {
+			writeJSONError(w, requestID, toError(err))
+			return
+		}
+
+		body = b
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.Header().Set("X-Amzn-Requestid", requestID)
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+func writeJSONError(w http.ResponseWriter, requestID string, err *Error) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.Header().Set("X-Amzn-Errortype", err.Code)
+	w.Header().Set("X-Amzn-Requestid", requestID)
+	w.WriteHeader(err.StatusCode)
+	fmt.Fprintf(w, `{"__type":%q,"message":%q,"Message":%q}`, err.Code, err.Message, err.Message)
+}
+
+//
+// REST-JSON protocol
+//
+
+func (svc *service) serveRestJSON(w http.ResponseWriter, r *http.Request, requestID string) {
+	segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+
+	for _, route := range svc.routes {
+		if route.method != r.Method {
+			continue
+		}
+
+		labels, ok := route.match(segments)
+
+		if !ok {
+			continue
+		}
+
+		output, err := svc.invoke(route.operation, func(input interface{}) error {
+			return decodeRestJSON(r, labels, input)
+		})
+
+		if err != nil {
+			writeJSONError(w, requestID, toError(err))
+			return
+		}
+
+		statusCode := http.StatusOK
+
+		if v := reflect.ValueOf(output); v.IsValid() && !v.IsNil() {
+			v = v.Elem()
+
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+
+				if field.Tag.Get("location") == "statusCode" && !v.Field(i).IsNil() {
+					statusCode = int(v.Field(i).Elem().Int())
+				}
+			}
+		}
+
+		writeJSON(w, requestID, statusCode, output)
+		return
+	}
+
+	writeJSONError(w, requestID, newError(http.StatusNotFound, "UnknownOperationException", "no operation for %s %s", r.Method, r.URL.Path))
+}
+
+// match returns the values of the URI template labels if the path segments match.
+func (route *restRoute) match(segments []string) (map[string]string, bool) {
+	labels := make(map[string]string)
+
+	for i, template := range route.segments {
+		if strings.HasPrefix(template, "{") && strings.HasSuffix(template, "+}") {
+			if i >= len(segments) {
+				return nil, false
+			}
+
+			v, err := url.PathUnescape(strings.Join(segments[i:], "/"))
+
+			if err != nil {
+				return nil, false
+			}
+
+			labels[strings.TrimSuffix(strings.TrimPrefix(template, "{"), "+}")] = v
+
+			return labels, true
+		}
+
+		if i >= len(segments) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(template, "{") && strings.HasSuffix(template, "}") {
+			v, err := url.PathUnescape(segments[i])
+
+			if err != nil {
+				return nil, false
+			}
+
+			labels[strings.TrimSuffix(strings.TrimPrefix(template, "{"), "}")] = v
+			continue
+		}
+
+		if v, err := url.PathUnescape(segments[i]); err != nil || v != template {
+			return nil, false
+		}
+	}
+
+	return labels, len(segments) == len(route.segments)
+}
+
+func decodeRestJSON(r *http.Request, labels map[string]string, input interface{}) error {
+	if err := jsonutil.UnmarshalJSON(input, r.Body); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(input).Elem()
+	query := r.URL.Query()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := field.Tag.Get("locationName")
+
+		var s string
+		var ok bool
+
+		switch field.Tag.Get("location") {
+		case "uri":
+			s, ok = labels[name]
+		case "querystring":
+			if _, ok = query[name]; ok {
+				s = query.Get(name)
+			}
+		case "header":
+			if s = r.Header.Get(name); s != "" {
+				ok = true
+			}
+		}
+
+		if !ok || field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+
+		elem := reflect.New(field.Type.Elem())
+
+		if err := decodeScalar(elem.Elem(), s, field.Tag); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		value.Field(i).Set(elem)
+	}
+
+	return nil
+}
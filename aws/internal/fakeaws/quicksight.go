@@ -0,0 +1,677 @@
+package fakeaws
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/quicksight"
+)
+
+const quickSightDefaultNamespace = "default"
+
+// QuickSight implements the Amazon QuickSight API operations for namespaces,
+// groups, users, data sources and IAM policy assignments of AccountID.
+//
+// Namespaces are created and deleted immediately. Groups and users exist
+// only to support group memberships; tests create them with CreateGroup and
+// RegisterUser.
+type QuickSight struct {
+	ids int
+
+	dataSources          map[string]*quicksight.DataSource
+	groupMembers         map[string]map[string]bool
+	groups               map[string]*quicksight.Group
+	iamPolicyAssignments map[string]*quicksight.IAMPolicyAssignment
+	namespaces           map[string]*quicksight.NamespaceInfoV2
+	tags                 map[string]map[string]string
+	users                map[string]*quicksight.User
+}
+
+func newQuickSight() *QuickSight {
+	q := &QuickSight{
+		dataSources:          make(map[string]*quicksight.DataSource),
+		groupMembers:         make(map[string]map[string]bool),
+		groups:               make(map[string]*quicksight.Group),
+		iamPolicyAssignments: make(map[string]*quicksight.IAMPolicyAssignment),
+		namespaces:           make(map[string]*quicksight.NamespaceInfoV2),
+		tags:                 make(map[string]map[string]string),
+		users:                make(map[string]*quicksight.User),
+	}
+
+	q.namespaces[quickSightDefaultNamespace] = q.newNamespace(quickSightDefaultNamespace, quicksight.IdentityStoreQuicksight)
+
+	return q
+}
+
+// quickSightOperations returns the client used to route REST requests.
+func quickSightOperations() *quicksight.QuickSight {
+	return quicksight.New(operationsSession())
+}
+
+func quickSightError(code, format string, a ...interface{}) *Error {
+	statusCode := http.StatusBadRequest
+
+	switch code {
+	case quicksight.ErrCodeAccessDeniedException:
+		statusCode = http.StatusUnauthorized
+	case quicksight.ErrCodeResourceNotFoundException:
+		statusCode = http.StatusNotFound
+	case quicksight.ErrCodeResourceExistsException:
+		statusCode = http.StatusConflict
+	}
+
+	return newError(statusCode, code, format, a...)
+}
+
+func quickSightArn(resource string) string {
+	return fmt.Sprintf("arn:aws:quicksight:%s:%s:%s", Region, AccountID, resource)
+}
+
+// quickSightKey returns the key of a resource within a namespace.
+func quickSightKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func (q *QuickSight) newNamespace(name, identityStore string) *quicksight.NamespaceInfoV2 {
+	return &quicksight.NamespaceInfoV2{
+		Arn:            aws.String(quickSightArn("namespace/" + name)),
+		CapacityRegion: aws.String(Region),
+		CreationStatus: aws.String(quicksight.NamespaceStatusCreated),
+		IdentityStore:  aws.String(identityStore),
+		Name:           aws.String(name),
+	}
+}
+
+func (q *QuickSight) newPrincipalID() string {
+	q.ids++
+
+	return fmt.Sprintf("federated/iam/AIDA%016X", q.ids)
+}
+
+// checkQuickSightAccount returns an error unless accountID is AccountID.
+func checkQuickSightAccount(accountID *string) error {
+	if aws.StringValue(accountID) != AccountID {
+		return quickSightError(quicksight.ErrCodeAccessDeniedException, "You are not authorized to access account %s.", aws.StringValue(accountID))
+	}
+
+	return nil
+}
+
+func (q *QuickSight) namespace(accountID, name *string) (*quicksight.NamespaceInfoV2, error) {
+	if err := checkQuickSightAccount(accountID); err != nil {
+		return nil, err
+	}
+
+	namespace, ok := q.namespaces[aws.StringValue(name)]
+
+	if !ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceNotFoundException, "Namespace %s not found.", aws.StringValue(name))
+	}
+
+	return namespace, nil
+}
+
+func (q *QuickSight) setTags(arn string, tags []*quicksight.Tag) {
+	if q.tags[arn] == nil {
+		q.tags[arn] = make(map[string]string)
+	}
+
+	for _, tag := range tags {
+		q.tags[arn][aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+}
+
+//
+// Namespaces
+//
+
+func (q *QuickSight) CreateNamespace(input *quicksight.CreateNamespaceInput) (*quicksight.CreateNamespaceOutput, error) {
+	if err := checkQuickSightAccount(input.AwsAccountId); err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(input.Namespace)
+
+	if _, ok := q.namespaces[name]; ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceExistsException, "Namespace %s already exists.", name)
+	}
+
+	if aws.StringValue(input.IdentityStore) != quicksight.IdentityStoreQuicksight {
+		return nil, quickSightError(quicksight.ErrCodeInvalidParameterValueException, "IdentityStore must be %s.", quicksight.IdentityStoreQuicksight)
+	}
+
+	namespace := q.newNamespace(name, aws.StringValue(input.IdentityStore))
+	q.namespaces[name] = namespace
+
+	if len(input.Tags) > 0 {
+		q.setTags(aws.StringValue(namespace.Arn), input.Tags)
+	}
+
+	return &quicksight.CreateNamespaceOutput{
+		Arn:            namespace.Arn,
+		CapacityRegion: namespace.CapacityRegion,
+		CreationStatus: namespace.CreationStatus,
+		IdentityStore:  namespace.IdentityStore,
+		Name:           namespace.Name,
+		Status:         aws.Int64(http.StatusAccepted),
+	}, nil
+}
+
+func (q *QuickSight) DescribeNamespace(input *quicksight.DescribeNamespaceInput) (*quicksight.DescribeNamespaceOutput, error) {
+	namespace, err := q.namespace(input.AwsAccountId, input.Namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &quicksight.DescribeNamespaceOutput{
+		Namespace: awsutil.CopyOf(namespace).(*quicksight.NamespaceInfoV2),
+		Status:    aws.Int64(http.StatusOK),
+	}, nil
+}
+
+// DeleteNamespace deletes a namespace with its groups, users and IAM policy assignments.
+func (q *QuickSight) DeleteNamespace(input *quicksight.DeleteNamespaceInput) (*quicksight.DeleteNamespaceOutput, error) {
+	namespace, err := q.namespace(input.AwsAccountId, input.Namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(namespace.Name)
+
+	if name == quickSightDefaultNamespace {
+		return nil, quickSightError(quicksight.ErrCodeInvalidParameterValueException, "The default namespace cannot be deleted.")
+	}
+
+	prefix := quickSightKey(name, "")
+
+	for key := range q.groupMembers {
+		if strings.HasPrefix(key, prefix) {
+			delete(q.groupMembers, key)
+			delete(q.groups, key)
+		}
+	}
+
+	for key := range q.iamPolicyAssignments {
+		if strings.HasPrefix(key, prefix) {
+			delete(q.iamPolicyAssignments, key)
+		}
+	}
+
+	for key := range q.users {
+		if strings.HasPrefix(key, prefix) {
+			delete(q.users, key)
+		}
+	}
+
+	delete(q.namespaces, name)
+	delete(q.tags, aws.StringValue(namespace.Arn))
+
+	return &quicksight.DeleteNamespaceOutput{
+		Status: aws.Int64(http.StatusOK),
+	}, nil
+}
+
+//
+// Groups and users
+//
+
+func (q *QuickSight) CreateGroup(input *quicksight.CreateGroupInput) (*quicksight.CreateGroupOutput, error) {
+	if _, err := q.namespace(input.AwsAccountId, input.Namespace); err != nil {
+		return nil, err
+	}
+
+	namespace := aws.StringValue(input.Namespace)
+	name := aws.StringValue(input.GroupName)
+	key := quickSightKey(namespace, name)
+
+	if _, ok := q.groups[key]; ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceExistsException, "Group %s already exists.", name)
+	}
+
+	group := &quicksight.Group{
+		Arn:         aws.String(quickSightArn(fmt.Sprintf("group/%s/%s", namespace, name))),
+		Description: input.Description,
+		GroupName:   aws.String(name),
+		PrincipalId: aws.String(q.newPrincipalID()),
+	}
+
+	q.groups[key] = group
+	q.groupMembers[key] = make(map[string]bool)
+
+	return &quicksight.CreateGroupOutput{
+		Group:  awsutil.CopyOf(group).(*quicksight.Group),
+		Status: aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) RegisterUser(input *quicksight.RegisterUserInput) (*quicksight.RegisterUserOutput, error) {
+	if _, err := q.namespace(input.AwsAccountId, input.Namespace); err != nil {
+		return nil, err
+	}
+
+	namespace := aws.StringValue(input.Namespace)
+	name := aws.StringValue(input.UserName)
+	key := quickSightKey(namespace, name)
+
+	if _, ok := q.users[key]; ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceExistsException, "User %s already exists.", name)
+	}
+
+	user := &quicksight.User{
+		Active:       aws.Bool(true),
+		Arn:          aws.String(quickSightArn(fmt.Sprintf("user/%s/%s", namespace, name))),
+		Email:        input.Email,
+		IdentityType: input.IdentityType,
+		PrincipalId:  aws.String(q.newPrincipalID()),
+		Role:         input.UserRole,
+		UserName:     aws.String(name),
+	}
+
+	q.users[key] = user
+
+	return &quicksight.RegisterUserOutput{
+		Status: aws.Int64(http.StatusOK),
+		User:   awsutil.CopyOf(user).(*quicksight.User),
+	}, nil
+}
+
+func (q *QuickSight) groupAndUser(accountID, namespace, groupName, userName *string) (string, string, error) {
+	if _, err := q.namespace(accountID, namespace); err != nil {
+		return "", "", err
+	}
+
+	groupKey := quickSightKey(aws.StringValue(namespace), aws.StringValue(groupName))
+
+	if _, ok := q.groups[groupKey]; !ok {
+		return "", "", quickSightError(quicksight.ErrCodeResourceNotFoundException, "Group %s not found.", aws.StringValue(groupName))
+	}
+
+	userKey := quickSightKey(aws.StringValue(namespace), aws.StringValue(userName))
+
+	if _, ok := q.users[userKey]; !ok {
+		return "", "", quickSightError(quicksight.ErrCodeResourceNotFoundException, "User %s not found.", aws.StringValue(userName))
+	}
+
+	return groupKey, userKey, nil
+}
+
+func (q *QuickSight) CreateGroupMembership(input *quicksight.CreateGroupMembershipInput) (*quicksight.CreateGroupMembershipOutput, error) {
+	groupKey, userKey, err := q.groupAndUser(input.AwsAccountId, input.Namespace, input.GroupName, input.MemberName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q.groupMembers[groupKey][aws.StringValue(input.MemberName)] = true
+
+	return &quicksight.CreateGroupMembershipOutput{
+		GroupMember: &quicksight.GroupMember{
+			Arn:        q.users[userKey].Arn,
+			MemberName: input.MemberName,
+		},
+		Status: aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) DeleteGroupMembership(input *quicksight.DeleteGroupMembershipInput) (*quicksight.DeleteGroupMembershipOutput, error) {
+	groupKey, _, err := q.groupAndUser(input.AwsAccountId, input.Namespace, input.GroupName, input.MemberName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	memberName := aws.StringValue(input.MemberName)
+
+	if !q.groupMembers[groupKey][memberName] {
+		return nil, quickSightError(quicksight.ErrCodeResourceNotFoundException, "User %s is not a member of group %s.", memberName, aws.StringValue(input.GroupName))
+	}
+
+	delete(q.groupMembers[groupKey], memberName)
+
+	return &quicksight.DeleteGroupMembershipOutput{
+		Status: aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) ListUserGroups(input *quicksight.ListUserGroupsInput) (*quicksight.ListUserGroupsOutput, error) {
+	if _, err := q.namespace(input.AwsAccountId, input.Namespace); err != nil {
+		return nil, err
+	}
+
+	namespace := aws.StringValue(input.Namespace)
+	userName := aws.StringValue(input.UserName)
+
+	if _, ok := q.users[quickSightKey(namespace, userName)]; !ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceNotFoundException, "User %s not found.", userName)
+	}
+
+	output := &quicksight.ListUserGroupsOutput{
+		GroupList: []*quicksight.Group{},
+		Status:    aws.Int64(http.StatusOK),
+	}
+
+	for _, key := range sortedKeys(q.groups) {
+		if q.groupMembers[key][userName] && strings.HasPrefix(key, quickSightKey(namespace, "")) {
+			output.GroupList = append(output.GroupList, awsutil.CopyOf(q.groups[key]).(*quicksight.Group))
+		}
+	}
+
+	return output, nil
+}
+
+//
+// Data sources
+//
+
+func (q *QuickSight) dataSource(accountID, id *string) (*quicksight.DataSource, error) {
+	if err := checkQuickSightAccount(accountID); err != nil {
+		return nil, err
+	}
+
+	dataSource, ok := q.dataSources[aws.StringValue(id)]
+
+	if !ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceNotFoundException, "Data source %s not found.", aws.StringValue(id))
+	}
+
+	return dataSource, nil
+}
+
+func (q *QuickSight) CreateDataSource(input *quicksight.CreateDataSourceInput) (*quicksight.CreateDataSourceOutput, error) {
+	if err := checkQuickSightAccount(input.AwsAccountId); err != nil {
+		return nil, err
+	}
+
+	id := aws.StringValue(input.DataSourceId)
+
+	if _, ok := q.dataSources[id]; ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceExistsException, "Data source %s already exists.", id)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	dataSource := &quicksight.DataSource{
+		Arn:                     aws.String(quickSightArn("datasource/" + id)),
+		CreatedTime:             aws.Time(now),
+		DataSourceId:            aws.String(id),
+		DataSourceParameters:    input.DataSourceParameters,
+		LastUpdatedTime:         aws.Time(now),
+		Name:                    input.Name,
+		SslProperties:           input.SslProperties,
+		Status:                  aws.String(quicksight.ResourceStatusCreationSuccessful),
+		Type:                    input.Type,
+		VpcConnectionProperties: input.VpcConnectionProperties,
+	}
+
+	q.dataSources[id] = dataSource
+
+	if len(input.Tags) > 0 {
+		q.setTags(aws.StringValue(dataSource.Arn), input.Tags)
+	}
+
+	return &quicksight.CreateDataSourceOutput{
+		Arn:            dataSource.Arn,
+		CreationStatus: dataSource.Status,
+		DataSourceId:   dataSource.DataSourceId,
+		Status:         aws.Int64(http.StatusAccepted),
+	}, nil
+}
+
+func (q *QuickSight) DescribeDataSource(input *quicksight.DescribeDataSourceInput) (*quicksight.DescribeDataSourceOutput, error) {
+	dataSource, err := q.dataSource(input.AwsAccountId, input.DataSourceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &quicksight.DescribeDataSourceOutput{
+		DataSource: awsutil.CopyOf(dataSource).(*quicksight.DataSource),
+		Status:     aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) UpdateDataSource(input *quicksight.UpdateDataSourceInput) (*quicksight.UpdateDataSourceOutput, error) {
+	dataSource, err := q.dataSource(input.AwsAccountId, input.DataSourceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DataSourceParameters != nil {
+		dataSource.DataSourceParameters = input.DataSourceParameters
+	}
+
+	if input.Name != nil {
+		dataSource.Name = input.Name
+	}
+
+	dataSource.LastUpdatedTime = aws.Time(time.Now().UTC().Truncate(time.Second))
+	dataSource.Status = aws.String(quicksight.ResourceStatusUpdateSuccessful)
+
+	return &quicksight.UpdateDataSourceOutput{
+		Arn:          dataSource.Arn,
+		DataSourceId: dataSource.DataSourceId,
+		Status:       aws.Int64(http.StatusAccepted),
+		UpdateStatus: dataSource.Status,
+	}, nil
+}
+
+func (q *QuickSight) DeleteDataSource(input *quicksight.DeleteDataSourceInput) (*quicksight.DeleteDataSourceOutput, error) {
+	dataSource, err := q.dataSource(input.AwsAccountId, input.DataSourceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	delete(q.dataSources, aws.StringValue(dataSource.DataSourceId))
+	delete(q.tags, aws.StringValue(dataSource.Arn))
+
+	return &quicksight.DeleteDataSourceOutput{
+		Arn:          dataSource.Arn,
+		DataSourceId: dataSource.DataSourceId,
+		Status:       aws.Int64(http.StatusOK),
+	}, nil
+}
+
+//
+// IAM policy assignments
+//
+
+func validateAssignmentStatus(status *string) error {
+	if !contains(quicksight.AssignmentStatus_Values(), aws.StringValue(status)) {
+		return quickSightError(quicksight.ErrCodeInvalidParameterValueException, "AssignmentStatus %s is invalid.", aws.StringValue(status))
+	}
+
+	return nil
+}
+
+func (q *QuickSight) iamPolicyAssignment(accountID, namespace, name *string) (*quicksight.IAMPolicyAssignment, error) {
+	if _, err := q.namespace(accountID, namespace); err != nil {
+		return nil, err
+	}
+
+	assignment, ok := q.iamPolicyAssignments[quickSightKey(aws.StringValue(namespace), aws.StringValue(name))]
+
+	if !ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceNotFoundException, "IAM policy assignment %s not found.", aws.StringValue(name))
+	}
+
+	return assignment, nil
+}
+
+func (q *QuickSight) CreateIAMPolicyAssignment(input *quicksight.CreateIAMPolicyAssignmentInput) (*quicksight.CreateIAMPolicyAssignmentOutput, error) {
+	if _, err := q.namespace(input.AwsAccountId, input.Namespace); err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(input.AssignmentName)
+	key := quickSightKey(aws.StringValue(input.Namespace), name)
+
+	if _, ok := q.iamPolicyAssignments[key]; ok {
+		return nil, quickSightError(quicksight.ErrCodeResourceExistsException, "IAM policy assignment %s already exists.", name)
+	}
+
+	if err := validateAssignmentStatus(input.AssignmentStatus); err != nil {
+		return nil, err
+	}
+
+	q.ids++
+
+	assignment := &quicksight.IAMPolicyAssignment{
+		AssignmentId:     aws.String(fmt.Sprintf("00000000-0000-0000-0000-%012x", q.ids)),
+		AssignmentName:   aws.String(name),
+		AssignmentStatus: input.AssignmentStatus,
+		AwsAccountId:     input.AwsAccountId,
+		Identities:       input.Identities,
+		PolicyArn:        input.PolicyArn,
+	}
+
+	q.iamPolicyAssignments[key] = assignment
+
+	return &quicksight.CreateIAMPolicyAssignmentOutput{
+		AssignmentId:     assignment.AssignmentId,
+		AssignmentName:   assignment.AssignmentName,
+		AssignmentStatus: assignment.AssignmentStatus,
+		Identities:       assignment.Identities,
+		PolicyArn:        assignment.PolicyArn,
+		Status:           aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) DescribeIAMPolicyAssignment(input *quicksight.DescribeIAMPolicyAssignmentInput) (*quicksight.DescribeIAMPolicyAssignmentOutput, error) {
+	assignment, err := q.iamPolicyAssignment(input.AwsAccountId, input.Namespace, input.AssignmentName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &quicksight.DescribeIAMPolicyAssignmentOutput{
+		IAMPolicyAssignment: awsutil.CopyOf(assignment).(*quicksight.IAMPolicyAssignment),
+		Status:              aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) UpdateIAMPolicyAssignment(input *quicksight.UpdateIAMPolicyAssignmentInput) (*quicksight.UpdateIAMPolicyAssignmentOutput, error) {
+	assignment, err := q.iamPolicyAssignment(input.AwsAccountId, input.Namespace, input.AssignmentName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if input.AssignmentStatus != nil {
+		if err := validateAssignmentStatus(input.AssignmentStatus); err != nil {
+			return nil, err
+		}
+
+		assignment.AssignmentStatus = input.AssignmentStatus
+	}
+
+	if input.Identities != nil {
+		assignment.Identities = input.Identities
+	}
+
+	if input.PolicyArn != nil {
+		assignment.PolicyArn = input.PolicyArn
+	}
+
+	return &quicksight.UpdateIAMPolicyAssignmentOutput{
+		AssignmentId:     assignment.AssignmentId,
+		AssignmentName:   assignment.AssignmentName,
+		AssignmentStatus: assignment.AssignmentStatus,
+		Identities:       assignment.Identities,
+		PolicyArn:        assignment.PolicyArn,
+		Status:           aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) DeleteIAMPolicyAssignment(input *quicksight.DeleteIAMPolicyAssignmentInput) (*quicksight.DeleteIAMPolicyAssignmentOutput, error) {
+	if _, err := q.iamPolicyAssignment(input.AwsAccountId, input.Namespace, input.AssignmentName); err != nil {
+		return nil, err
+	}
+
+	delete(q.iamPolicyAssignments, quickSightKey(aws.StringValue(input.Namespace), aws.StringValue(input.AssignmentName)))
+
+	return &quicksight.DeleteIAMPolicyAssignmentOutput{
+		AssignmentName: input.AssignmentName,
+		Status:         aws.Int64(http.StatusOK),
+	}, nil
+}
+
+//
+// Tags
+//
+
+// taggable returns an error unless arn is a namespace or data source.
+func (q *QuickSight) taggable(arn string) error {
+	for _, namespace := range q.namespaces {
+		if aws.StringValue(namespace.Arn) == arn {
+			return nil
+		}
+	}
+
+	for _, dataSource := range q.dataSources {
+		if aws.StringValue(dataSource.Arn) == arn {
+			return nil
+		}
+	}
+
+	return quickSightError(quicksight.ErrCodeResourceNotFoundException, "Resource %s not found.", arn)
+}
+
+func (q *QuickSight) TagResource(input *quicksight.TagResourceInput) (*quicksight.TagResourceOutput, error) {
+	arn := aws.StringValue(input.ResourceArn)
+
+	if err := q.taggable(arn); err != nil {
+		return nil, err
+	}
+
+	q.setTags(arn, input.Tags)
+
+	return &quicksight.TagResourceOutput{
+		Status: aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) UntagResource(input *quicksight.UntagResourceInput) (*quicksight.UntagResourceOutput, error) {
+	arn := aws.StringValue(input.ResourceArn)
+
+	if err := q.taggable(arn); err != nil {
+		return nil, err
+	}
+
+	for _, k := range aws.StringValueSlice(input.TagKeys) {
+		delete(q.tags[arn], k)
+	}
+
+	return &quicksight.UntagResourceOutput{
+		Status: aws.Int64(http.StatusOK),
+	}, nil
+}
+
+func (q *QuickSight) ListTagsForResource(input *quicksight.ListTagsForResourceInput) (*quicksight.ListTagsForResourceOutput, error) {
+	arn := aws.StringValue(input.ResourceArn)
+
+	if err := q.taggable(arn); err != nil {
+		return nil, err
+	}
+
+	output := &quicksight.ListTagsForResourceOutput{
+		Status: aws.Int64(http.StatusOK),
+		Tags:   []*quicksight.Tag{},
+	}
+
+	for _, k := range sortedKeys(q.tags[arn]) {
+		output.Tags = append(output.Tags, &quicksight.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(q.tags[arn][k]),
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,191 @@
+// Package fakeaws implements an in-memory stand-in for the AWS service APIs
+// used by the provider, served over HTTP so that provider configurations can
+// point their endpoints at it and run without network access or credentials.
+package fakeaws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// AccountID is the AWS account ID of the caller and of the organization
+	// management account.
+	AccountID = "123456789012"
+
+	// Region is the region the server's resources are created in.
+	Region = "us-west-2"
+
+	// UserName is the IAM user name of the caller.
+	UserName = "fakeaws"
+)
+
+// EndpointServiceNames are the provider endpoints configuration block
+// arguments served by the server.
+var EndpointServiceNames = []string{
+	"ec2",
+	"iam",
+	"lexmodels",
+	"organizations",
+	"quicksight",
+	"sts",
+	"transfer",
+}
+
+// Server is an HTTP server implementing AWS service APIs against in-memory state.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requestID int
+	services  map[string]*service
+
+	EC2           *EC2
+	IAM           *IAM
+	LexModels     *LexModels
+	Organizations *Organizations
+	QuickSight    *QuickSight
+	STS           *STS
+	Transfer      *Transfer
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		EC2:           newEC2(),
+		IAM:           newIAM(),
+		LexModels:     newLexModels(),
+		Organizations: newOrganizations(),
+		QuickSight:    newQuickSight(),
+		STS:           &STS{caller: AccountID},
+		Transfer:      newTransfer(),
+	}
+
+	s.services = map[string]*service{
+		"ec2":           newService(protocolEC2, s.EC2),
+		"iam":           newService(protocolQuery, s.IAM),
+		"lex":           newRestJSONService(s.LexModels, lexModelsOperations()),
+		"organizations": newService(protocolJSON, s.Organizations),
+		"quicksight":    newRestJSONService(s.QuickSight, quickSightOperations()),
+		"sts":           newService(protocolQuery, s.STS),
+		"transfer":      newService(protocolJSON, s.Transfer),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Endpoints returns the provider endpoints configuration block arguments
+// mapped to the server URL.
+func (s *Server) Endpoints() map[string]string {
+	endpoints := make(map[string]string, len(EndpointServiceNames))
+
+	for _, name := range EndpointServiceNames {
+		endpoints[name] = s.URL
+	}
+
+	return endpoints
+}
+
+// Lock blocks other requests from modifying server state, e.g. while
+// a test inspects or alters it directly.
+func (s *Server) Lock() {
+	s.mu.Lock()
+}
+
+// Unlock releases a lock acquired with Lock.
+func (s *Server) Unlock() {
+	s.mu.Unlock()
+}
+
+// Calls returns the number of requests made to an operation, e.g.
+// "AuthorizeSecurityGroupIngress", across all services.
+func (s *Server) Calls(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int
+
+	for _, svc := range s.services {
+		n += svc.calls[operation]
+	}
+
+	return n
+}
+
+var (
+	accountIDRegexp    = regexp.MustCompile(`^[0-9]{12}$`)
+	signingScopeRegexp = regexp.MustCompile(`Credential=([^/]+)/[0-9]+/[^/]+/([^/]+)/aws4_request`)
+)
+
+// callerAccountID returns the account ID of the caller. An access key ID
+// that is itself an account ID identifies that account, which lets tests
+// act as member accounts of the organization; any other access key ID
+// identifies AccountID.
+func callerAccountID(accessKeyID string) string {
+	if accountIDRegexp.MatchString(accessKeyID) {
+		return accessKeyID
+	}
+
+	return AccountID
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requestID++
+	requestID := fmt.Sprintf("00000000-0000-0000-0000-%012d", s.requestID)
+
+	var accessKeyID, signingName string
+
+	if m := signingScopeRegexp.FindStringSubmatch(r.Header.Get("Authorization")); m != nil {
+		accessKeyID = m[1]
+		signingName = strings.ToLower(m[2])
+	}
+
+	s.EC2.caller = callerAccountID(accessKeyID)
+	s.Organizations.caller = callerAccountID(accessKeyID)
+	s.STS.caller = callerAccountID(accessKeyID)
+
+	svc, ok := s.services[signingName]
+
+	if !ok {
+		writeJSONError(w, requestID, newError(http.StatusBadRequest, "UnrecognizedClientException", "unsupported service: %q", signingName))
+		return
+	}
+
+	svc.serve(w, r, requestID)
+}
+
+// Error is an AWS API error response.
+type Error struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func newError(statusCode int, code, format string, a ...interface{}) *Error {
+	return &Error{
+		Code:       code,
+		Message:    fmt.Sprintf(format, a...),
+		StatusCode: statusCode,
+	}
+}
+
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+
+	return newError(http.StatusInternalServerError, "InternalFailure", "%s", err)
+}
@@ -0,0 +1,1591 @@
+package fakeaws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/lexmodelbuildingservice"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/quicksight"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/transfer"
+)
+
+func testSession(t *testing.T, s *Server, accessKeyID string) *session.Session {
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(accessKeyID, "secret", ""),
+		Endpoint:    aws.String(s.URL),
+		MaxRetries:  aws.Int(0),
+		Region:      aws.String(Region),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating session: %s", err)
+	}
+
+	return sess
+}
+
+func testErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	awsErr, ok := err.(awserr.Error)
+
+	if !ok {
+		t.Fatalf("expected %s error, got: %v", code, err)
+	}
+
+	if awsErr.Code() != code {
+		t.Fatalf("expected %s error, got: %s", code, awsErr)
+	}
+}
+
+func TestServer_callerIdentity(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	testCases := []struct {
+		accessKeyID string
+		account     string
+	}{
+		{
+			accessKeyID: "AKIAFAKEAWS",
+			account:     AccountID,
+		},
+		{
+			accessKeyID: "210000000001",
+			account:     "210000000001",
+		},
+	}
+
+	for _, testCase := range testCases {
+		conn := sts.New(testSession(t, s, testCase.accessKeyID))
+
+		output, err := conn.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+
+		if err != nil {
+			t.Fatalf("error getting caller identity: %s", err)
+		}
+
+		if got := aws.StringValue(output.Account); got != testCase.account {
+			t.Errorf("expected account %s for access key %s, got: %s", testCase.account, testCase.accessKeyID, got)
+		}
+	}
+}
+
+func TestServer_unsupportedOperation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := iam.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	_, err := conn.GetUser(&iam.GetUserInput{})
+
+	testErrorCode(t, err, "InvalidAction")
+}
+
+func TestEC2_availabilityZones(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	output, err := conn.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("zone-id"),
+			Values: aws.StringSlice([]string{"usw2-az2"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing availability zones: %s", err)
+	}
+
+	if len(output.AvailabilityZones) != 1 || aws.StringValue(output.AvailabilityZones[0].ZoneName) != "us-west-2b" {
+		t.Fatalf("expected availability zone us-west-2b, got: %v", output.AvailabilityZones)
+	}
+
+	_, err = conn.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		ZoneNames: aws.StringSlice([]string{"us-east-1a"}),
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+}
+
+func TestEC2_regions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	output, err := conn.DescribeRegions(&ec2.DescribeRegionsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("region-name"),
+			Values: aws.StringSlice([]string{Region, "af-south-1"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing regions: %s", err)
+	}
+
+	if len(output.Regions) != 1 || aws.StringValue(output.Regions[0].RegionName) != Region {
+		t.Fatalf("expected region %s, got: %v", Region, output.Regions)
+	}
+
+	output, err = conn.DescribeRegions(&ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("opt-in-status"),
+			Values: aws.StringSlice([]string{"not-opted-in"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing regions: %s", err)
+	}
+
+	if len(output.Regions) != len(optInRegions) {
+		t.Fatalf("expected %d opt-in regions, got: %v", len(optInRegions), output.Regions)
+	}
+
+	_, err = conn.DescribeRegions(&ec2.DescribeRegionsInput{
+		RegionNames: aws.StringSlice([]string{"us-gov-west-1"}),
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+}
+
+func TestEC2_vpc(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	defaultVpcs, err := conn.DescribeVpcs(&ec2.DescribeVpcsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("isDefault"),
+			Values: aws.StringSlice([]string{"true"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing default VPC: %s", err)
+	}
+
+	if len(defaultVpcs.Vpcs) != 1 || aws.StringValue(defaultVpcs.Vpcs[0].VpcId) != s.EC2.DefaultVpcID {
+		t.Fatalf("expected default VPC %s, got: %v", s.EC2.DefaultVpcID, defaultVpcs.Vpcs)
+	}
+
+	created, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	vpcID := aws.StringValue(created.Vpc.VpcId)
+
+	_, err = conn.CreateTags(&ec2.CreateTagsInput{
+		Resources: aws.StringSlice([]string{vpcID}),
+		Tags: []*ec2.Tag{{
+			Key:   aws.String("Name"),
+			Value: aws.String("test"),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error tagging VPC: %s", err)
+	}
+
+	tagged, err := conn.DescribeVpcs(&ec2.DescribeVpcsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("tag:Name"),
+			Values: aws.StringSlice([]string{"te*"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing VPCs by tag: %s", err)
+	}
+
+	if len(tagged.Vpcs) != 1 || aws.StringValue(tagged.Vpcs[0].VpcId) != vpcID {
+		t.Fatalf("expected VPC %s, got: %v", vpcID, tagged.Vpcs)
+	}
+
+	if _, err := conn.DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String(vpcID)}); err != nil {
+		t.Fatalf("error deleting VPC: %s", err)
+	}
+
+	_, err = conn.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: aws.StringSlice([]string{vpcID}),
+	})
+
+	testErrorCode(t, err, "InvalidVpcID.NotFound")
+}
+
+func TestEC2_vpcCidrBlockAssociation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	created, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	vpcID := created.Vpc.VpcId
+
+	if _, err := conn.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{CidrBlock: aws.String("100.64.0.0/16"), VpcId: vpcID}); err != nil {
+		t.Fatalf("error associating VPC CIDR block: %s", err)
+	}
+
+	_, err = conn.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{CidrBlock: aws.String("192.168.0.0/16"), VpcId: vpcID})
+
+	testErrorCode(t, err, "InvalidVpc.Range")
+
+	_, err = conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock:     aws.String("10.2.0.0/16"),
+		Ipv6CidrBlock: aws.String("2600:1f14:ff00:100::/56"),
+	})
+
+	testErrorCode(t, err, "MissingParameter")
+
+	if _, err := conn.ProvisionByoipCidr(&ec2.ProvisionByoipCidrInput{Cidr: aws.String("2600:1f14:ff00::/48")}); err != nil {
+		t.Fatalf("error provisioning BYOIP CIDR: %s", err)
+	}
+
+	pools, err := conn.DescribeIpv6Pools(&ec2.DescribeIpv6PoolsInput{})
+
+	if err != nil {
+		t.Fatalf("error describing IPv6 pools: %s", err)
+	}
+
+	if len(pools.Ipv6Pools) != 1 {
+		t.Fatalf("expected 1 IPv6 pool, got: %v", pools.Ipv6Pools)
+	}
+
+	poolID := pools.Ipv6Pools[0].PoolId
+
+	requested, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock:     aws.String("10.2.0.0/16"),
+		Ipv6CidrBlock: aws.String("2600:1f14:ff00:100::/56"),
+		Ipv6Pool:      poolID,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC from IPv6 pool: %s", err)
+	}
+
+	if got, want := aws.StringValue(requested.Vpc.Ipv6CidrBlockAssociationSet[0].Ipv6CidrBlock), "2600:1f14:ff00:100::/56"; got != want {
+		t.Fatalf("expected IPv6 CIDR block %q, got: %q", want, got)
+	}
+
+	_, err = conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock:     aws.String("10.3.0.0/16"),
+		Ipv6CidrBlock: aws.String("2600:1f14:ff00:100::/56"),
+		Ipv6Pool:      poolID,
+	})
+
+	testErrorCode(t, err, "InvalidVpc.Range")
+
+	allocated, err := conn.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{Ipv6Pool: poolID, VpcId: vpcID})
+
+	if err != nil {
+		t.Fatalf("error associating VPC IPv6 CIDR block from IPv6 pool: %s", err)
+	}
+
+	if got, want := aws.StringValue(allocated.Ipv6CidrBlockAssociation.Ipv6CidrBlock), "2600:1f14:ff00::/56"; got != want {
+		t.Fatalf("expected IPv6 CIDR block %q, got: %q", want, got)
+	}
+
+	_, err = conn.DeprovisionByoipCidr(&ec2.DeprovisionByoipCidrInput{Cidr: aws.String("2600:1f14:ff00::/48")})
+
+	testErrorCode(t, err, "InvalidByoipCidr.InUse")
+}
+
+func TestEC2_internetGatewayDetach(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	vpcID := s.EC2.DefaultVpcID
+	igw := s.EC2.internetGateways[sortedKeys(s.EC2.internetGateways)[0]]
+	eni := s.EC2.networkInterfaces
+
+	created, err := conn.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId: aws.String(sortedKeys(s.EC2.subnets)[0]),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating network interface: %s", err)
+	}
+
+	eni[aws.StringValue(created.NetworkInterface.NetworkInterfaceId)].Association = &ec2.NetworkInterfaceAssociation{
+		PublicIp: aws.String("203.0.113.10"),
+	}
+
+	input := &ec2.DetachInternetGatewayInput{
+		InternetGatewayId: igw.InternetGatewayId,
+		VpcId:             aws.String(vpcID),
+	}
+
+	_, err = conn.DetachInternetGateway(input)
+
+	testErrorCode(t, err, "DependencyViolation")
+
+	eni[aws.StringValue(created.NetworkInterface.NetworkInterfaceId)].Association = nil
+
+	if _, err := conn.DetachInternetGateway(input); err != nil {
+		t.Fatalf("error detaching internet gateway: %s", err)
+	}
+
+	_, err = conn.DetachInternetGateway(input)
+
+	testErrorCode(t, err, "Gateway.NotAttached")
+}
+
+func TestEC2_natGateway(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	subnetID := sortedKeys(s.EC2.subnets)[0]
+
+	address, err := conn.AllocateAddress(&ec2.AllocateAddressInput{
+		Domain: aws.String(ec2.DomainTypeVpc),
+	})
+
+	if err != nil {
+		t.Fatalf("error allocating address: %s", err)
+	}
+
+	created, err := conn.CreateNatGateway(&ec2.CreateNatGatewayInput{
+		AllocationId: address.AllocationId,
+		SubnetId:     aws.String(subnetID),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating NAT gateway: %s", err)
+	}
+
+	ngw := created.NatGateway
+
+	if got, want := aws.StringValue(ngw.State), ec2.NatGatewayStateAvailable; got != want {
+		t.Fatalf("expected state %q, got: %q", want, got)
+	}
+
+	networkInterfaceID := ngw.NatGatewayAddresses[0].NetworkInterfaceId
+
+	_, err = conn.DisassociateAddress(&ec2.DisassociateAddressInput{
+		AssociationId: s.EC2.addresses[aws.StringValue(address.AllocationId)].AssociationId,
+	})
+
+	testErrorCode(t, err, "AuthFailure")
+
+	_, err = conn.ReleaseAddress(&ec2.ReleaseAddressInput{
+		AllocationId: address.AllocationId,
+	})
+
+	testErrorCode(t, err, "InvalidIPAddress.InUse")
+
+	if _, err := conn.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: ngw.NatGatewayId}); err != nil {
+		t.Fatalf("error deleting NAT gateway: %s", err)
+	}
+
+	if _, ok := s.EC2.networkInterfaces[aws.StringValue(networkInterfaceID)]; ok {
+		t.Fatalf("expected network interface %s to be deleted", aws.StringValue(networkInterfaceID))
+	}
+
+	described, err := conn.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{
+		NatGatewayIds: []*string{ngw.NatGatewayId},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing NAT gateway: %s", err)
+	}
+
+	if got, want := aws.StringValue(described.NatGateways[0].State), ec2.NatGatewayStateDeleted; got != want {
+		t.Fatalf("expected state %q, got: %q", want, got)
+	}
+
+	_, err = conn.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: ngw.NatGatewayId})
+
+	testErrorCode(t, err, "NatGatewayNotFound")
+
+	if _, err := conn.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: address.AllocationId}); err != nil {
+		t.Fatalf("error releasing address: %s", err)
+	}
+}
+
+func TestEC2_networkInterfaceAttachment(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	subnetID := sortedKeys(s.EC2.subnets)[0]
+
+	reservation, err := conn.RunInstances(&ec2.RunInstancesInput{
+		MaxCount: aws.Int64(1),
+		MinCount: aws.Int64(1),
+		SubnetId: aws.String(subnetID),
+	})
+
+	if err != nil {
+		t.Fatalf("error running instance: %s", err)
+	}
+
+	instanceID := reservation.Instances[0].InstanceId
+	primaryNetworkInterfaceID := reservation.Instances[0].NetworkInterfaces[0].NetworkInterfaceId
+
+	created, err := conn.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId: aws.String(subnetID),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating network interface: %s", err)
+	}
+
+	networkInterfaceID := created.NetworkInterface.NetworkInterfaceId
+
+	_, err = conn.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(0),
+		InstanceId:         instanceID,
+		NetworkInterfaceId: networkInterfaceID,
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+
+	attached, err := conn.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(1),
+		InstanceId:         instanceID,
+		NetworkInterfaceId: networkInterfaceID,
+	})
+
+	if err != nil {
+		t.Fatalf("error attaching network interface: %s", err)
+	}
+
+	_, err = conn.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: networkInterfaceID})
+
+	testErrorCode(t, err, "InvalidNetworkInterface.InUse")
+
+	described, err := conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("attachment.attachment-id"),
+			Values: []*string{attached.AttachmentId},
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing network interfaces: %s", err)
+	}
+
+	if len(described.NetworkInterfaces) != 1 || aws.StringValue(described.NetworkInterfaces[0].Status) != ec2.NetworkInterfaceStatusInUse {
+		t.Fatalf("expected in-use network interface %s, got: %v", aws.StringValue(networkInterfaceID), described.NetworkInterfaces)
+	}
+
+	if _, err := conn.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{instanceID}}); err != nil {
+		t.Fatalf("error terminating instance: %s", err)
+	}
+
+	if _, ok := s.EC2.networkInterfaces[aws.StringValue(primaryNetworkInterfaceID)]; ok {
+		t.Fatalf("expected primary network interface %s to be deleted", aws.StringValue(primaryNetworkInterfaceID))
+	}
+
+	if eni := s.EC2.networkInterfaces[aws.StringValue(networkInterfaceID)]; eni.Attachment != nil {
+		t.Fatalf("expected network interface %s to be detached, got: %v", aws.StringValue(networkInterfaceID), eni.Attachment)
+	}
+
+	_, err = conn.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(1),
+		InstanceId:         instanceID,
+		NetworkInterfaceId: networkInterfaceID,
+	})
+
+	testErrorCode(t, err, "IncorrectInstanceState")
+}
+
+func TestEC2_networkInterfaceIpv6Addresses(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	vpc, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		AmazonProvidedIpv6CidrBlock: aws.Bool(true),
+		CidrBlock:                   aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	ipv6CidrBlock := strings.Replace(aws.StringValue(vpc.Vpc.Ipv6CidrBlockAssociationSet[0].Ipv6CidrBlock), "/56", "/64", 1)
+
+	subnet, err := conn.CreateSubnet(&ec2.CreateSubnetInput{
+		CidrBlock:     aws.String("10.1.1.0/24"),
+		Ipv6CidrBlock: aws.String(ipv6CidrBlock),
+		VpcId:         vpc.Vpc.VpcId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating subnet: %s", err)
+	}
+
+	_, err = conn.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		Ipv6AddressCount: aws.Int64(1),
+		Ipv6Addresses:    []*ec2.InstanceIpv6Address{{Ipv6Address: aws.String("2001:db8::1")}},
+		SubnetId:         subnet.Subnet.SubnetId,
+	})
+
+	testErrorCode(t, err, "InvalidParameterCombination")
+
+	_, err = conn.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		Ipv6Addresses: []*ec2.InstanceIpv6Address{{Ipv6Address: aws.String("2001:db8::1")}},
+		SubnetId:      subnet.Subnet.SubnetId,
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+
+	created, err := conn.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		InterfaceType:    aws.String(ec2.NetworkInterfaceCreationTypeEfa),
+		Ipv6AddressCount: aws.Int64(2),
+		SubnetId:         subnet.Subnet.SubnetId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating network interface: %s", err)
+	}
+
+	eni := created.NetworkInterface
+
+	if got, want := aws.StringValue(eni.InterfaceType), ec2.NetworkInterfaceTypeEfa; got != want {
+		t.Fatalf("expected interface type %q, got: %q", want, got)
+	}
+
+	if len(eni.Ipv6Addresses) != 2 {
+		t.Fatalf("expected 2 IPv6 addresses, got: %v", eni.Ipv6Addresses)
+	}
+
+	_, err = conn.AssignIpv6Addresses(&ec2.AssignIpv6AddressesInput{
+		Ipv6Addresses:      []*string{eni.Ipv6Addresses[0].Ipv6Address},
+		NetworkInterfaceId: eni.NetworkInterfaceId,
+	})
+
+	testErrorCode(t, err, "InvalidIPAddress.InUse")
+
+	unassigned, err := conn.UnassignIpv6Addresses(&ec2.UnassignIpv6AddressesInput{
+		Ipv6Addresses:      []*string{eni.Ipv6Addresses[0].Ipv6Address},
+		NetworkInterfaceId: eni.NetworkInterfaceId,
+	})
+
+	if err != nil {
+		t.Fatalf("error unassigning IPv6 addresses: %s", err)
+	}
+
+	if len(unassigned.UnassignedIpv6Addresses) != 1 {
+		t.Fatalf("expected 1 unassigned IPv6 address, got: %v", unassigned.UnassignedIpv6Addresses)
+	}
+
+	described, err := conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("ipv6-addresses.ipv6-address"),
+			Values: []*string{eni.Ipv6Addresses[1].Ipv6Address},
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing network interfaces: %s", err)
+	}
+
+	if len(described.NetworkInterfaces) != 1 || len(described.NetworkInterfaces[0].Ipv6Addresses) != 1 {
+		t.Fatalf("expected network interface with 1 IPv6 address, got: %v", described.NetworkInterfaces)
+	}
+}
+
+func TestEC2_clientVpn(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	subnetID := sortedKeys(s.EC2.subnets)[0]
+	subnet := s.EC2.subnets[subnetID]
+
+	created, err := conn.CreateClientVpnEndpoint(&ec2.CreateClientVpnEndpointInput{
+		AuthenticationOptions: []*ec2.ClientVpnAuthenticationRequest{
+			{
+				Type: aws.String(ec2.ClientVpnAuthenticationTypeCertificateAuthentication),
+				MutualAuthentication: &ec2.CertificateAuthenticationRequest{
+					ClientRootCertificateChainArn: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/root"),
+				},
+			},
+		},
+		ClientCidrBlock:      aws.String("10.0.0.0/16"),
+		ConnectionLogOptions: &ec2.ConnectionLogOptions{Enabled: aws.Bool(false)},
+		ServerCertificateArn: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/server"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating Client VPN endpoint: %s", err)
+	}
+
+	endpointID := created.ClientVpnEndpointId
+
+	if got, want := aws.StringValue(created.Status.Code), ec2.ClientVpnEndpointStatusCodePendingAssociate; got != want {
+		t.Fatalf("expected status %q, got: %q", want, got)
+	}
+
+	_, err = conn.CreateClientVpnRoute(&ec2.CreateClientVpnRouteInput{
+		ClientVpnEndpointId:  endpointID,
+		DestinationCidrBlock: aws.String("0.0.0.0/0"),
+		TargetVpcSubnetId:    aws.String(subnetID),
+	})
+
+	testErrorCode(t, err, "InvalidClientVpnActiveAssociationNotFound")
+
+	associated, err := conn.AssociateClientVpnTargetNetwork(&ec2.AssociateClientVpnTargetNetworkInput{
+		ClientVpnEndpointId: endpointID,
+		SubnetId:            aws.String(subnetID),
+	})
+
+	if err != nil {
+		t.Fatalf("error associating target network: %s", err)
+	}
+
+	if got, want := aws.StringValue(s.EC2.clientVpnEndpoints[aws.StringValue(endpointID)].Status.Code), ec2.ClientVpnEndpointStatusCodeAvailable; got != want {
+		t.Fatalf("expected status %q, got: %q", want, got)
+	}
+
+	sameAz, err := conn.CreateSubnet(&ec2.CreateSubnetInput{
+		AvailabilityZone: subnet.AvailabilityZone,
+		CidrBlock:        aws.String("172.31.240.0/24"),
+		VpcId:            subnet.VpcId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating subnet: %s", err)
+	}
+
+	_, err = conn.AssociateClientVpnTargetNetwork(&ec2.AssociateClientVpnTargetNetworkInput{
+		ClientVpnEndpointId: endpointID,
+		SubnetId:            sameAz.Subnet.SubnetId,
+	})
+
+	testErrorCode(t, err, "InvalidClientVpnSubnetId.DuplicateAz")
+
+	routes, err := conn.DescribeClientVpnRoutes(&ec2.DescribeClientVpnRoutesInput{
+		ClientVpnEndpointId: endpointID,
+		Filters: []*ec2.Filter{
+			{Name: aws.String("origin"), Values: aws.StringSlice([]string{"associate"})},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing Client VPN routes: %s", err)
+	}
+
+	if got, want := len(routes.Routes), 1; got != want {
+		t.Fatalf("expected %d routes, got: %d", want, got)
+	}
+
+	if _, err := conn.CreateClientVpnRoute(&ec2.CreateClientVpnRouteInput{
+		ClientVpnEndpointId:  endpointID,
+		DestinationCidrBlock: aws.String("0.0.0.0/0"),
+		TargetVpcSubnetId:    aws.String(subnetID),
+	}); err != nil {
+		t.Fatalf("error creating Client VPN route: %s", err)
+	}
+
+	_, err = conn.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: aws.String(subnetID)})
+
+	testErrorCode(t, err, "DependencyViolation")
+
+	_, err = conn.DeleteClientVpnEndpoint(&ec2.DeleteClientVpnEndpointInput{ClientVpnEndpointId: endpointID})
+
+	testErrorCode(t, err, "InvalidClientVpnEndpoint.InvalidState")
+
+	if _, err := conn.DisassociateClientVpnTargetNetwork(&ec2.DisassociateClientVpnTargetNetworkInput{
+		AssociationId:       associated.AssociationId,
+		ClientVpnEndpointId: endpointID,
+	}); err != nil {
+		t.Fatalf("error disassociating target network: %s", err)
+	}
+
+	if got, want := aws.StringValue(s.EC2.clientVpnEndpoints[aws.StringValue(endpointID)].Status.Code), ec2.ClientVpnEndpointStatusCodePendingAssociate; got != want {
+		t.Fatalf("expected status %q, got: %q", want, got)
+	}
+
+	routes, err = conn.DescribeClientVpnRoutes(&ec2.DescribeClientVpnRoutesInput{
+		ClientVpnEndpointId: endpointID,
+	})
+
+	if err != nil {
+		t.Fatalf("error describing Client VPN routes: %s", err)
+	}
+
+	if got, want := len(routes.Routes), 0; got != want {
+		t.Fatalf("expected %d routes, got: %d", want, got)
+	}
+
+	if _, err := conn.DeleteClientVpnEndpoint(&ec2.DeleteClientVpnEndpointInput{ClientVpnEndpointId: endpointID}); err != nil {
+		t.Fatalf("error deleting Client VPN endpoint: %s", err)
+	}
+
+	_, err = conn.DescribeClientVpnEndpoints(&ec2.DescribeClientVpnEndpointsInput{
+		ClientVpnEndpointIds: []*string{endpointID},
+	})
+
+	testErrorCode(t, err, "InvalidClientVpnEndpointId.NotFound")
+}
+
+func TestEC2_dhcpOptions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	memberConn := ec2.New(testSession(t, s, "210000000001"))
+
+	_, err := conn.CreateDhcpOptions(&ec2.CreateDhcpOptionsInput{
+		DhcpConfigurations: []*ec2.NewDhcpConfiguration{{
+			Key:    aws.String("ntp-servers"),
+			Values: aws.StringSlice([]string{"AmazonProvidedDNS"}),
+		}},
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+
+	created, err := conn.CreateDhcpOptions(&ec2.CreateDhcpOptionsInput{
+		DhcpConfigurations: []*ec2.NewDhcpConfiguration{{
+			Key:    aws.String("domain-name-servers"),
+			Values: aws.StringSlice([]string{"10.0.0.2", "AmazonProvidedDNS"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error creating DHCP options: %s", err)
+	}
+
+	dhcpOptionsID := created.DhcpOptions.DhcpOptionsId
+
+	_, err = memberConn.DescribeDhcpOptions(&ec2.DescribeDhcpOptionsInput{DhcpOptionsIds: []*string{dhcpOptionsID}})
+
+	testErrorCode(t, err, "InvalidDhcpOptionID.NotFound")
+
+	vpc, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	if got, want := aws.StringValue(vpc.Vpc.DhcpOptionsId), "default"; got != want {
+		t.Fatalf("expected DHCP options %q, got: %q", want, got)
+	}
+
+	if _, err := conn.AssociateDhcpOptions(&ec2.AssociateDhcpOptionsInput{DhcpOptionsId: dhcpOptionsID, VpcId: vpc.Vpc.VpcId}); err != nil {
+		t.Fatalf("error associating DHCP options: %s", err)
+	}
+
+	_, err = conn.DeleteDhcpOptions(&ec2.DeleteDhcpOptionsInput{DhcpOptionsId: dhcpOptionsID})
+
+	testErrorCode(t, err, "DependencyViolation")
+
+	if _, err := conn.AssociateDhcpOptions(&ec2.AssociateDhcpOptionsInput{DhcpOptionsId: aws.String("default"), VpcId: vpc.Vpc.VpcId}); err != nil {
+		t.Fatalf("error associating default DHCP options: %s", err)
+	}
+
+	if _, err := conn.DeleteDhcpOptions(&ec2.DeleteDhcpOptionsInput{DhcpOptionsId: dhcpOptionsID}); err != nil {
+		t.Fatalf("error deleting DHCP options: %s", err)
+	}
+}
+
+func TestEC2_flowLog(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	vpc, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	_, err = conn.CreateFlowLogs(&ec2.CreateFlowLogsInput{
+		DeliverLogsPermissionArn: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+		LogDestination:           aws.String("arn:aws:s3:::flow-logs"),
+		LogDestinationType:       aws.String(ec2.LogDestinationTypeS3),
+		ResourceIds:              []*string{vpc.Vpc.VpcId},
+		ResourceType:             aws.String(ec2.FlowLogsResourceTypeVpc),
+		TrafficType:              aws.String(ec2.TrafficTypeAll),
+	})
+
+	testErrorCode(t, err, "InvalidParameter")
+
+	input := &ec2.CreateFlowLogsInput{
+		LogDestination:     aws.String("arn:aws:s3:::flow-logs"),
+		LogDestinationType: aws.String(ec2.LogDestinationTypeS3),
+		LogFormat:          aws.String("${srcaddr} ${dstaddr}"),
+		ResourceIds:        []*string{vpc.Vpc.VpcId, aws.String("vpc-missing")},
+		ResourceType:       aws.String(ec2.FlowLogsResourceTypeVpc),
+		TrafficType:        aws.String(ec2.TrafficTypeAll),
+	}
+
+	created, err := conn.CreateFlowLogs(input)
+
+	if err != nil {
+		t.Fatalf("error creating flow logs: %s", err)
+	}
+
+	if len(created.FlowLogIds) != 1 || len(created.Unsuccessful) != 1 || aws.StringValue(created.Unsuccessful[0].Error.Code) != "InvalidVpcID.NotFound" {
+		t.Fatalf("expected one flow log and one unsuccessful item, got: %v", created)
+	}
+
+	input.ResourceIds = []*string{vpc.Vpc.VpcId}
+
+	duplicate, err := conn.CreateFlowLogs(input)
+
+	if err != nil {
+		t.Fatalf("error creating flow logs: %s", err)
+	}
+
+	if len(duplicate.Unsuccessful) != 1 || aws.StringValue(duplicate.Unsuccessful[0].Error.Code) != "FlowLogAlreadyExists" {
+		t.Fatalf("expected a duplicate flow log to be unsuccessful, got: %v", duplicate)
+	}
+
+	described, err := conn.DescribeFlowLogs(&ec2.DescribeFlowLogsInput{
+		Filter: []*ec2.Filter{{
+			Name:   aws.String("resource-id"),
+			Values: []*string{vpc.Vpc.VpcId},
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing flow logs: %s", err)
+	}
+
+	if len(described.FlowLogs) != 1 || aws.Int64Value(described.FlowLogs[0].MaxAggregationInterval) != 600 {
+		t.Fatalf("expected one flow log with the default aggregation interval, got: %v", described.FlowLogs)
+	}
+
+	if _, err := conn.DeleteVpc(&ec2.DeleteVpcInput{VpcId: vpc.Vpc.VpcId}); err != nil {
+		t.Fatalf("error deleting VPC: %s", err)
+	}
+
+	deleted, err := conn.DeleteFlowLogs(&ec2.DeleteFlowLogsInput{FlowLogIds: created.FlowLogIds})
+
+	if err != nil {
+		t.Fatalf("error deleting flow logs: %s", err)
+	}
+
+	if len(deleted.Unsuccessful) != 1 || aws.StringValue(deleted.Unsuccessful[0].Error.Code) != "InvalidFlowLogId.NotFound" {
+		t.Fatalf("expected the flow log to be deleted with its VPC, got: %v", deleted)
+	}
+}
+
+func TestEC2_managedPrefixList(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	_, err := conn.CreateManagedPrefixList(&ec2.CreateManagedPrefixListInput{
+		AddressFamily:  aws.String("IPv4"),
+		Entries:        []*ec2.AddPrefixListEntry{{Cidr: aws.String("2001:db8::/32")}},
+		MaxEntries:     aws.Int64(1),
+		PrefixListName: aws.String("test"),
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+
+	created, err := conn.CreateManagedPrefixList(&ec2.CreateManagedPrefixListInput{
+		AddressFamily:  aws.String("IPv4"),
+		Entries:        []*ec2.AddPrefixListEntry{{Cidr: aws.String("10.0.0.0/8")}},
+		MaxEntries:     aws.Int64(2),
+		PrefixListName: aws.String("test"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating managed prefix list: %s", err)
+	}
+
+	prefixListID := created.PrefixList.PrefixListId
+
+	if version := aws.Int64Value(created.PrefixList.Version); version != 1 {
+		t.Fatalf("expected version 1, got: %d", version)
+	}
+
+	_, err = conn.ModifyManagedPrefixList(&ec2.ModifyManagedPrefixListInput{
+		AddEntries:     []*ec2.AddPrefixListEntry{{Cidr: aws.String("192.168.0.0/16")}},
+		CurrentVersion: aws.Int64(2),
+		PrefixListId:   prefixListID,
+	})
+
+	testErrorCode(t, err, "PrefixListVersionMismatch")
+
+	_, err = conn.ModifyManagedPrefixList(&ec2.ModifyManagedPrefixListInput{
+		AddEntries: []*ec2.AddPrefixListEntry{
+			{Cidr: aws.String("172.16.0.0/12")},
+			{Cidr: aws.String("192.168.0.0/16")},
+		},
+		CurrentVersion: aws.Int64(1),
+		PrefixListId:   prefixListID,
+	})
+
+	testErrorCode(t, err, "InvalidParameterValue")
+
+	modified, err := conn.ModifyManagedPrefixList(&ec2.ModifyManagedPrefixListInput{
+		AddEntries:     []*ec2.AddPrefixListEntry{{Cidr: aws.String("192.168.0.0/16")}},
+		CurrentVersion: aws.Int64(1),
+		PrefixListId:   prefixListID,
+		RemoveEntries:  []*ec2.RemovePrefixListEntry{{Cidr: aws.String("10.0.0.0/8")}},
+	})
+
+	if err != nil {
+		t.Fatalf("error modifying managed prefix list: %s", err)
+	}
+
+	if version := aws.Int64Value(modified.PrefixList.Version); version != 2 {
+		t.Fatalf("expected version 2, got: %d", version)
+	}
+
+	entries, err := conn.GetManagedPrefixListEntries(&ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId:  prefixListID,
+		TargetVersion: aws.Int64(1),
+	})
+
+	if err != nil {
+		t.Fatalf("error getting managed prefix list entries: %s", err)
+	}
+
+	if len(entries.Entries) != 1 || aws.StringValue(entries.Entries[0].Cidr) != "10.0.0.0/8" {
+		t.Fatalf("expected version 1 entry 10.0.0.0/8, got: %v", entries.Entries)
+	}
+
+	lists, err := conn.DescribeManagedPrefixLists(&ec2.DescribeManagedPrefixListsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("owner-id"),
+			Values: aws.StringSlice([]string{"AWS"}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing managed prefix lists: %s", err)
+	}
+
+	if len(lists.PrefixLists) != 2 {
+		t.Fatalf("expected 2 AWS-managed prefix lists, got: %v", lists.PrefixLists)
+	}
+
+	vpc, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	sg, err := conn.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		Description: aws.String("test"),
+		GroupName:   aws.String("test"),
+		VpcId:       vpc.Vpc.VpcId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating security group: %s", err)
+	}
+
+	_, err = conn.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: sg.GroupId,
+		IpPermissions: []*ec2.IpPermission{{
+			FromPort:      aws.Int64(443),
+			IpProtocol:    aws.String("tcp"),
+			PrefixListIds: []*ec2.PrefixListId{{PrefixListId: prefixListID}},
+			ToPort:        aws.Int64(443),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error authorizing security group ingress: %s", err)
+	}
+
+	_, err = conn.DeleteManagedPrefixList(&ec2.DeleteManagedPrefixListInput{
+		PrefixListId: prefixListID,
+	})
+
+	testErrorCode(t, err, "DependencyViolation")
+
+	_, err = conn.DeleteManagedPrefixList(&ec2.DeleteManagedPrefixListInput{
+		PrefixListId: aws.String("pl-68a54001"),
+	})
+
+	testErrorCode(t, err, "InvalidPrefixListModification")
+}
+
+func TestEC2_vpcEndpoint(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	vpc, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	vpcID := aws.StringValue(vpc.Vpc.VpcId)
+	routeTableID := aws.StringValue(s.EC2.mainRouteTable(vpcID).RouteTableId)
+
+	_, err = conn.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+		ServiceName: aws.String("com.amazonaws.us-west-2.unknown"),
+		VpcId:       aws.String(vpcID),
+	})
+
+	testErrorCode(t, err, "InvalidServiceName")
+
+	created, err := conn.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+		RouteTableIds: aws.StringSlice([]string{routeTableID}),
+		ServiceName:   aws.String("com.amazonaws.us-west-2.s3"),
+		VpcId:         aws.String(vpcID),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC endpoint: %s", err)
+	}
+
+	vpcEndpointID := aws.StringValue(created.VpcEndpoint.VpcEndpointId)
+
+	routeTables, err := conn.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		RouteTableIds: aws.StringSlice([]string{routeTableID}),
+	})
+
+	if err != nil {
+		t.Fatalf("error describing route table: %s", err)
+	}
+
+	if _, ok := findRoute(routeTables.RouteTables[0], "pl-68a54001"); !ok {
+		t.Fatalf("expected route to pl-68a54001, got: %v", routeTables.RouteTables[0].Routes)
+	}
+
+	_, err = conn.DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String(vpcID)})
+
+	testErrorCode(t, err, "DependencyViolation")
+
+	deleted, err := conn.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{vpcEndpointID, "vpce-00000000000000000"}),
+	})
+
+	if err != nil {
+		t.Fatalf("error deleting VPC endpoints: %s", err)
+	}
+
+	if len(deleted.Unsuccessful) != 1 || aws.StringValue(deleted.Unsuccessful[0].Error.Code) != "InvalidVpcEndpointId.NotFound" {
+		t.Fatalf("expected one unsuccessful item, got: %v", deleted.Unsuccessful)
+	}
+
+	if _, ok := findRoute(s.EC2.routeTables[routeTableID], "pl-68a54001"); ok {
+		t.Fatalf("expected route to pl-68a54001 to be removed")
+	}
+
+	if _, err := conn.DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String(vpcID)}); err != nil {
+		t.Fatalf("error deleting VPC: %s", err)
+	}
+}
+
+func TestEC2_vpcPeeringConnection(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	const peerAccountID = "210000000001"
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	peerConn := ec2.New(testSession(t, s, peerAccountID))
+
+	vpc, err := conn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.1.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	peerVpc, err := peerConn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.2.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating peer VPC: %s", err)
+	}
+
+	if got, want := aws.StringValue(peerVpc.Vpc.OwnerId), peerAccountID; got != want {
+		t.Fatalf("expected peer VPC owner %q, got: %q", want, got)
+	}
+
+	failed, err := conn.CreateVpcPeeringConnection(&ec2.CreateVpcPeeringConnectionInput{
+		PeerVpcId: peerVpc.Vpc.VpcId,
+		VpcId:     vpc.Vpc.VpcId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC peering connection: %s", err)
+	}
+
+	if got, want := aws.StringValue(failed.VpcPeeringConnection.Status.Code), ec2.VpcPeeringConnectionStateReasonCodeFailed; got != want {
+		t.Fatalf("expected status %q for the wrong peer owner, got: %q", want, got)
+	}
+
+	created, err := conn.CreateVpcPeeringConnection(&ec2.CreateVpcPeeringConnectionInput{
+		PeerOwnerId: aws.String(peerAccountID),
+		PeerVpcId:   peerVpc.Vpc.VpcId,
+		VpcId:       vpc.Vpc.VpcId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC peering connection: %s", err)
+	}
+
+	pcxID := created.VpcPeeringConnection.VpcPeeringConnectionId
+
+	if got, want := aws.StringValue(created.VpcPeeringConnection.Status.Code), ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance; got != want {
+		t.Fatalf("expected status %q, got: %q", want, got)
+	}
+
+	_, err = conn.AcceptVpcPeeringConnection(&ec2.AcceptVpcPeeringConnectionInput{VpcPeeringConnectionId: pcxID})
+
+	testErrorCode(t, err, "OperationNotPermitted")
+
+	if _, err := peerConn.AcceptVpcPeeringConnection(&ec2.AcceptVpcPeeringConnectionInput{VpcPeeringConnectionId: pcxID}); err != nil {
+		t.Fatalf("error accepting VPC peering connection: %s", err)
+	}
+
+	_, err = conn.ModifyVpcPeeringConnectionOptions(&ec2.ModifyVpcPeeringConnectionOptionsInput{
+		AccepterPeeringConnectionOptions: &ec2.PeeringConnectionOptionsRequest{
+			AllowDnsResolutionFromRemoteVpc: aws.Bool(true),
+		},
+		VpcPeeringConnectionId: pcxID,
+	})
+
+	testErrorCode(t, err, "OperationNotPermitted")
+
+	_, err = peerConn.ModifyVpcPeeringConnectionOptions(&ec2.ModifyVpcPeeringConnectionOptionsInput{
+		AccepterPeeringConnectionOptions: &ec2.PeeringConnectionOptionsRequest{
+			AllowDnsResolutionFromRemoteVpc: aws.Bool(true),
+		},
+		VpcPeeringConnectionId: pcxID,
+	})
+
+	if err != nil {
+		t.Fatalf("error modifying VPC peering connection options: %s", err)
+	}
+
+	described, err := conn.DescribeVpcPeeringConnections(&ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("status-code"),
+			Values: aws.StringSlice([]string{ec2.VpcPeeringConnectionStateReasonCodeActive}),
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing VPC peering connections: %s", err)
+	}
+
+	if len(described.VpcPeeringConnections) != 1 {
+		t.Fatalf("expected one active VPC peering connection, got: %v", described.VpcPeeringConnections)
+	}
+
+	pcx := described.VpcPeeringConnections[0]
+
+	if !aws.BoolValue(pcx.AccepterVpcInfo.PeeringOptions.AllowDnsResolutionFromRemoteVpc) || aws.BoolValue(pcx.RequesterVpcInfo.PeeringOptions.AllowDnsResolutionFromRemoteVpc) {
+		t.Fatalf("expected DNS resolution to be allowed for the accepter only, got: %v", pcx)
+	}
+
+	routeTableID := s.EC2.mainRouteTable(aws.StringValue(vpc.Vpc.VpcId)).RouteTableId
+
+	_, err = conn.CreateRoute(&ec2.CreateRouteInput{
+		DestinationCidrBlock:   aws.String("10.2.0.0/16"),
+		RouteTableId:           routeTableID,
+		VpcPeeringConnectionId: pcxID,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating route: %s", err)
+	}
+
+	if _, err := peerConn.DeleteVpc(&ec2.DeleteVpcInput{VpcId: peerVpc.Vpc.VpcId}); err != nil {
+		t.Fatalf("error deleting peer VPC: %s", err)
+	}
+
+	if got, want := aws.StringValue(s.EC2.vpcPeeringConnections[aws.StringValue(pcxID)].Status.Code), ec2.VpcPeeringConnectionStateReasonCodeDeleted; got != want {
+		t.Fatalf("expected status %q, got: %q", want, got)
+	}
+
+	i, _ := findRoute(s.EC2.routeTables[aws.StringValue(routeTableID)], "10.2.0.0/16")
+
+	if got, want := aws.StringValue(s.EC2.routeTables[aws.StringValue(routeTableID)].Routes[i].State), ec2.RouteStateBlackhole; got != want {
+		t.Fatalf("expected route state %q, got: %q", want, got)
+	}
+}
+
+func TestEC2_transitGateway(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	const memberAccountID = "210000000001"
+
+	conn := ec2.New(testSession(t, s, "AKIAFAKEAWS"))
+	memberConn := ec2.New(testSession(t, s, memberAccountID))
+
+	created, err := conn.CreateTransitGateway(&ec2.CreateTransitGatewayInput{})
+
+	if err != nil {
+		t.Fatalf("error creating transit gateway: %s", err)
+	}
+
+	tgw := created.TransitGateway
+	routeTableID := tgw.Options.AssociationDefaultRouteTableId
+
+	if routeTableID == nil || aws.StringValue(routeTableID) != aws.StringValue(tgw.Options.PropagationDefaultRouteTableId) {
+		t.Fatalf("expected a single default route table, got: %v", tgw.Options)
+	}
+
+	vpc, err := memberConn.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("10.2.0.0/16"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating VPC: %s", err)
+	}
+
+	var subnetIDs []*string
+
+	for _, cidrBlock := range []string{"10.2.1.0/24", "10.2.2.0/24"} {
+		subnet, err := memberConn.CreateSubnet(&ec2.CreateSubnetInput{
+			AvailabilityZone: aws.String("us-west-2a"),
+			CidrBlock:        aws.String(cidrBlock),
+			VpcId:            vpc.Vpc.VpcId,
+		})
+
+		if err != nil {
+			t.Fatalf("error creating subnet: %s", err)
+		}
+
+		subnetIDs = append(subnetIDs, subnet.Subnet.SubnetId)
+	}
+
+	_, err = memberConn.CreateTransitGatewayVpcAttachment(&ec2.CreateTransitGatewayVpcAttachmentInput{
+		SubnetIds:        subnetIDs,
+		TransitGatewayId: tgw.TransitGatewayId,
+		VpcId:            vpc.Vpc.VpcId,
+	})
+
+	testErrorCode(t, err, "DuplicateSubnetsInSameZone")
+
+	attached, err := memberConn.CreateTransitGatewayVpcAttachment(&ec2.CreateTransitGatewayVpcAttachmentInput{
+		SubnetIds:        subnetIDs[:1],
+		TransitGatewayId: tgw.TransitGatewayId,
+		VpcId:            vpc.Vpc.VpcId,
+	})
+
+	if err != nil {
+		t.Fatalf("error creating transit gateway VPC attachment: %s", err)
+	}
+
+	attachmentID := attached.TransitGatewayVpcAttachment.TransitGatewayAttachmentId
+
+	if got, want := aws.StringValue(attached.TransitGatewayVpcAttachment.State), ec2.TransitGatewayAttachmentStatePendingAcceptance; got != want {
+		t.Fatalf("expected state %q, got: %q", want, got)
+	}
+
+	_, err = memberConn.AcceptTransitGatewayVpcAttachment(&ec2.AcceptTransitGatewayVpcAttachmentInput{TransitGatewayAttachmentId: attachmentID})
+
+	testErrorCode(t, err, "InvalidTransitGatewayAttachmentID.NotFound")
+
+	if _, err := conn.AcceptTransitGatewayVpcAttachment(&ec2.AcceptTransitGatewayVpcAttachmentInput{TransitGatewayAttachmentId: attachmentID}); err != nil {
+		t.Fatalf("error accepting transit gateway VPC attachment: %s", err)
+	}
+
+	associations, err := conn.GetTransitGatewayRouteTableAssociations(&ec2.GetTransitGatewayRouteTableAssociationsInput{
+		TransitGatewayRouteTableId: routeTableID,
+	})
+
+	if err != nil {
+		t.Fatalf("error getting transit gateway route table associations: %s", err)
+	}
+
+	if len(associations.Associations) != 1 || aws.StringValue(associations.Associations[0].ResourceId) != aws.StringValue(vpc.Vpc.VpcId) {
+		t.Fatalf("expected the VPC to be associated with the default route table, got: %v", associations.Associations)
+	}
+
+	_, err = memberConn.GetTransitGatewayRouteTableAssociations(&ec2.GetTransitGatewayRouteTableAssociationsInput{
+		TransitGatewayRouteTableId: routeTableID,
+	})
+
+	testErrorCode(t, err, "InvalidRouteTableID.NotFound")
+
+	_, err = conn.AssociateTransitGatewayRouteTable(&ec2.AssociateTransitGatewayRouteTableInput{
+		TransitGatewayAttachmentId: attachmentID,
+		TransitGatewayRouteTableId: routeTableID,
+	})
+
+	testErrorCode(t, err, "Resource.AlreadyAssociated")
+
+	_, err = conn.DeleteTransitGateway(&ec2.DeleteTransitGatewayInput{TransitGatewayId: tgw.TransitGatewayId})
+
+	testErrorCode(t, err, "IncorrectState")
+
+	_, err = memberConn.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnetIDs[0]})
+
+	testErrorCode(t, err, "DependencyViolation")
+
+	if _, err := memberConn.DeleteTransitGatewayVpcAttachment(&ec2.DeleteTransitGatewayVpcAttachmentInput{TransitGatewayAttachmentId: attachmentID}); err != nil {
+		t.Fatalf("error deleting transit gateway VPC attachment: %s", err)
+	}
+
+	if _, err := conn.DeleteTransitGateway(&ec2.DeleteTransitGatewayInput{TransitGatewayId: tgw.TransitGatewayId}); err != nil {
+		t.Fatalf("error deleting transit gateway: %s", err)
+	}
+
+	described, err := memberConn.DescribeTransitGateways(&ec2.DescribeTransitGatewaysInput{
+		TransitGatewayIds: []*string{tgw.TransitGatewayId},
+	})
+
+	if err != nil {
+		t.Fatalf("error describing transit gateways: %s", err)
+	}
+
+	if got, want := aws.StringValue(described.TransitGateways[0].State), ec2.TransitGatewayStateDeleted; got != want {
+		t.Fatalf("expected state %q, got: %q", want, got)
+	}
+}
+
+func TestIAM_role(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := iam.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	_, err := conn.CreateRole(&iam.CreateRoleInput{
+		AssumeRolePolicyDocument: aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+		RoleName:                 aws.String("test"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating role: %s", err)
+	}
+
+	_, err = conn.AttachRolePolicy(&iam.AttachRolePolicyInput{
+		PolicyArn: aws.String("arn:aws:iam::aws:policy/ReadOnlyAccess"),
+		RoleName:  aws.String("test"),
+	})
+
+	if err != nil {
+		t.Fatalf("error attaching role policy: %s", err)
+	}
+
+	_, err = conn.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String("test")})
+
+	testErrorCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	_, err = conn.GetRole(&iam.GetRoleInput{RoleName: aws.String("missing")})
+
+	testErrorCode(t, err, iam.ErrCodeNoSuchEntityException)
+}
+
+func TestLexModels_checksum(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := lexmodelbuildingservice.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	input := &lexmodelbuildingservice.PutSlotTypeInput{
+		CreateVersion: aws.Bool(true),
+		EnumerationValues: []*lexmodelbuildingservice.EnumerationValue{{
+			Value: aws.String("lilies"),
+		}},
+		Name: aws.String("FlowerTypes"),
+	}
+
+	created, err := conn.PutSlotType(input)
+
+	if err != nil {
+		t.Fatalf("error putting slot type: %s", err)
+	}
+
+	if got := aws.StringValue(created.Version); got != "1" {
+		t.Errorf("expected version 1, got: %s", got)
+	}
+
+	if _, err := conn.PutSlotType(input); err == nil {
+		t.Fatal("expected error putting slot type without checksum")
+	} else {
+		testErrorCode(t, err, lexmodelbuildingservice.ErrCodePreconditionFailedException)
+	}
+
+	// Publishing unchanged content returns the existing version.
+	input.Checksum = created.Checksum
+
+	updated, err := conn.PutSlotType(input)
+
+	if err != nil {
+		t.Fatalf("error putting slot type: %s", err)
+	}
+
+	if got := aws.StringValue(updated.Version); got != "1" {
+		t.Errorf("expected version 1, got: %s", got)
+	}
+
+	latest, err := conn.GetSlotType(&lexmodelbuildingservice.GetSlotTypeInput{
+		Name:    aws.String("FlowerTypes"),
+		Version: aws.String(lexLatestVersion),
+	})
+
+	if err != nil {
+		t.Fatalf("error getting slot type: %s", err)
+	}
+
+	if got, want := aws.StringValue(latest.Checksum), aws.StringValue(created.Checksum); got != want {
+		t.Errorf("expected checksum %s, got: %s", want, got)
+	}
+}
+
+func TestOrganizations_invitation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	memberAccountID := "210000000001"
+	management := organizations.New(testSession(t, s, "AKIAFAKEAWS"))
+	member := organizations.New(testSession(t, s, memberAccountID))
+
+	invited, err := management.InviteAccountToOrganization(&organizations.InviteAccountToOrganizationInput{
+		Target: &organizations.HandshakeParty{
+			Id:   aws.String(memberAccountID),
+			Type: aws.String(organizations.HandshakePartyTypeAccount),
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("error inviting account: %s", err)
+	}
+
+	_, err = management.AcceptHandshake(&organizations.AcceptHandshakeInput{
+		HandshakeId: invited.Handshake.Id,
+	})
+
+	testErrorCode(t, err, organizations.ErrCodeAccessDeniedException)
+
+	if _, err := member.AcceptHandshake(&organizations.AcceptHandshakeInput{HandshakeId: invited.Handshake.Id}); err != nil {
+		t.Fatalf("error accepting handshake: %s", err)
+	}
+
+	account, err := management.DescribeAccount(&organizations.DescribeAccountInput{
+		AccountId: aws.String(memberAccountID),
+	})
+
+	if err != nil {
+		t.Fatalf("error describing account: %s", err)
+	}
+
+	if got := aws.StringValue(account.Account.JoinedMethod); got != organizations.AccountJoinedMethodInvited {
+		t.Errorf("expected joined method %s, got: %s", organizations.AccountJoinedMethodInvited, got)
+	}
+
+	if _, err := member.LeaveOrganization(&organizations.LeaveOrganizationInput{}); err != nil {
+		t.Fatalf("error leaving organization: %s", err)
+	}
+
+	_, err = management.DescribeAccount(&organizations.DescribeAccountInput{
+		AccountId: aws.String(memberAccountID),
+	})
+
+	testErrorCode(t, err, organizations.ErrCodeAccountNotFoundException)
+}
+
+func TestQuickSight_namespace(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := quicksight.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	_, err := conn.CreateNamespace(&quicksight.CreateNamespaceInput{
+		AwsAccountId:  aws.String(AccountID),
+		IdentityStore: aws.String(quicksight.IdentityStoreQuicksight),
+		Namespace:     aws.String("test"),
+	})
+
+	if err != nil {
+		t.Fatalf("error creating namespace: %s", err)
+	}
+
+	output, err := conn.DescribeNamespace(&quicksight.DescribeNamespaceInput{
+		AwsAccountId: aws.String(AccountID),
+		Namespace:    aws.String("test"),
+	})
+
+	if err != nil {
+		t.Fatalf("error describing namespace: %s", err)
+	}
+
+	if got := aws.StringValue(output.Namespace.CreationStatus); got != quicksight.NamespaceStatusCreated {
+		t.Errorf("expected status %s, got: %s", quicksight.NamespaceStatusCreated, got)
+	}
+
+	_, err = conn.DescribeNamespace(&quicksight.DescribeNamespaceInput{
+		AwsAccountId: aws.String("111111111111"),
+		Namespace:    aws.String("test"),
+	})
+
+	testErrorCode(t, err, quicksight.ErrCodeAccessDeniedException)
+}
+
+func TestTransfer_server(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	conn := transfer.New(testSession(t, s, "AKIAFAKEAWS"))
+
+	created, err := conn.CreateServer(&transfer.CreateServerInput{})
+
+	if err != nil {
+		t.Fatalf("error creating server: %s", err)
+	}
+
+	if _, err := conn.StopServer(&transfer.StopServerInput{ServerId: created.ServerId}); err != nil {
+		t.Fatalf("error stopping server: %s", err)
+	}
+
+	output, err := conn.DescribeServer(&transfer.DescribeServerInput{ServerId: created.ServerId})
+
+	if err != nil {
+		t.Fatalf("error describing server: %s", err)
+	}
+
+	if got := aws.StringValue(output.Server.State); got != transfer.StateOffline {
+		t.Errorf("expected state %s, got: %s", transfer.StateOffline, got)
+	}
+
+	if _, err := conn.DeleteServer(&transfer.DeleteServerInput{ServerId: created.ServerId}); err != nil {
+		t.Fatalf("error deleting server: %s", err)
+	}
+
+	_, err = conn.DescribeServer(&transfer.DescribeServerInput{ServerId: created.ServerId})
+
+	testErrorCode(t, err, transfer.ErrCodeResourceNotFoundException)
+}
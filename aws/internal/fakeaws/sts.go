@@ -0,0 +1,22 @@
+package fakeaws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// STS implements the AWS Security Token Service API operations used to
+// identify the caller.
+type STS struct {
+	caller string
+}
+
+func (s *STS) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String(s.caller),
+		Arn:     aws.String(fmt.Sprintf("arn:aws:iam::%s:user/%s", s.caller, UserName)),
+		UserId:  aws.String("AIDAFAKEAWS000000000"),
+	}, nil
+}
@@ -0,0 +1,413 @@
+package fakeaws
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/transfer"
+)
+
+// Transfer implements the AWS Transfer Family API operations for servers.
+//
+// Servers start and stop immediately, so ONLINE and OFFLINE are the only
+// states returned.
+type Transfer struct {
+	ids int
+
+	servers map[string]*transferServer
+}
+
+type transferServer struct {
+	server *transfer.DescribedServer
+	tags   map[string]string
+	users  map[string]*transfer.ListedUser
+}
+
+func newTransfer() *Transfer {
+	return &Transfer{
+		servers: make(map[string]*transferServer),
+	}
+}
+
+func transferError(code, format string, a ...interface{}) *Error {
+	return newError(http.StatusBadRequest, code, format, a...)
+}
+
+func (t *Transfer) server(id string) (*transferServer, error) {
+	s, ok := t.servers[id]
+
+	if !ok {
+		return nil, transferError(transfer.ErrCodeResourceNotFoundException, "Unknown server")
+	}
+
+	return s, nil
+}
+
+// serverByArn returns the server with an ARN, for tagging operations.
+func (t *Transfer) serverByArn(arn string) (*transferServer, error) {
+	for _, id := range sortedKeys(t.servers) {
+		if aws.StringValue(t.servers[id].server.Arn) == arn {
+			return t.servers[id], nil
+		}
+	}
+
+	return nil, transferError(transfer.ErrCodeResourceNotFoundException, "Unknown resource %s", arn)
+}
+
+// hostKeyFingerprint returns the SHA256 fingerprint of a host key.
+func hostKeyFingerprint(hostKey string) string {
+	sum := sha256.Sum256([]byte(hostKey))
+
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+func validateTransferIdentityProvider(identityProviderType string, details *transfer.IdentityProviderDetails) error {
+	if identityProviderType != transfer.IdentityProviderTypeApiGateway {
+		return nil
+	}
+
+	if details == nil || details.Url == nil || details.InvocationRole == nil {
+		return transferError(transfer.ErrCodeInvalidRequestException, "Url and InvocationRole are required for API_GATEWAY identity provider")
+	}
+
+	return nil
+}
+
+func (t *Transfer) endpointDetails(endpointType string, details *transfer.EndpointDetails) (*transfer.EndpointDetails, error) {
+	switch endpointType {
+	case transfer.EndpointTypePublic:
+		if details != nil {
+			return nil, transferError(transfer.ErrCodeInvalidRequestException, "EndpointDetails cannot be specified for a PUBLIC endpoint")
+		}
+
+		return nil, nil
+	case transfer.EndpointTypeVpc:
+		if details == nil || details.VpcId == nil {
+			return nil, transferError(transfer.ErrCodeInvalidRequestException, "VpcId is required for a VPC endpoint")
+		}
+	case transfer.EndpointTypeVpcEndpoint:
+		if details == nil || details.VpcEndpointId == nil {
+			return nil, transferError(transfer.ErrCodeInvalidRequestException, "VpcEndpointId is required for a VPC_ENDPOINT endpoint")
+		}
+
+		return details, nil
+	}
+
+	details = awsutil.CopyOf(details).(*transfer.EndpointDetails)
+
+	t.ids++
+	details.VpcEndpointId = aws.String(fmt.Sprintf("vpce-%017x", t.ids))
+
+	return details, nil
+}
+
+func (t *Transfer) CreateServer(input *transfer.CreateServerInput) (*transfer.CreateServerOutput, error) {
+	endpointType := aws.StringValue(input.EndpointType)
+
+	if endpointType == "" {
+		endpointType = transfer.EndpointTypePublic
+	}
+
+	identityProviderType := aws.StringValue(input.IdentityProviderType)
+
+	if identityProviderType == "" {
+		identityProviderType = transfer.IdentityProviderTypeServiceManaged
+	}
+
+	if err := validateTransferIdentityProvider(identityProviderType, input.IdentityProviderDetails); err != nil {
+		return nil, err
+	}
+
+	if input.EndpointDetails != nil && input.EndpointDetails.AddressAllocationIds != nil {
+		return nil, transferError(transfer.ErrCodeInvalidRequestException, "AddressAllocationIds cannot be set in CreateServer")
+	}
+
+	endpointDetails, err := t.endpointDetails(endpointType, input.EndpointDetails)
+
+	if err != nil {
+		return nil, err
+	}
+
+	securityPolicyName := aws.StringValue(input.SecurityPolicyName)
+
+	if securityPolicyName == "" {
+		securityPolicyName = "TransferSecurityPolicy-2018-11"
+	}
+
+	t.ids++
+	serverID := fmt.Sprintf("s-%017x", t.ids)
+
+	s := &transferServer{
+		server: &transfer.DescribedServer{
+			Arn:                     aws.String(fmt.Sprintf("arn:aws:transfer:%s:%s:server/%s", Region, AccountID, serverID)),
+			EndpointDetails:         endpointDetails,
+			EndpointType:            aws.String(endpointType),
+			HostKeyFingerprint:      aws.String(hostKeyFingerprint(aws.StringValue(input.HostKey) + serverID)),
+			IdentityProviderDetails: input.IdentityProviderDetails,
+			IdentityProviderType:    aws.String(identityProviderType),
+			LoggingRole:             input.LoggingRole,
+			Protocols:               aws.StringSlice([]string{transfer.ProtocolSftp}),
+			SecurityPolicyName:      aws.String(securityPolicyName),
+			ServerId:                aws.String(serverID),
+			State:                   aws.String(transfer.StateOnline),
+		},
+		tags:  make(map[string]string),
+		users: make(map[string]*transfer.ListedUser),
+	}
+
+	if input.HostKey != nil {
+		s.server.HostKeyFingerprint = aws.String(hostKeyFingerprint(aws.StringValue(input.HostKey)))
+	}
+
+	for _, tag := range input.Tags {
+		s.tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	t.servers[serverID] = s
+
+	return &transfer.CreateServerOutput{
+		ServerId: aws.String(serverID),
+	}, nil
+}
+
+func (t *Transfer) describeServer(s *transferServer) *transfer.DescribedServer {
+	server := awsutil.CopyOf(s.server).(*transfer.DescribedServer)
+	server.UserCount = aws.Int64(int64(len(s.users)))
+
+	for _, k := range sortedKeys(s.tags) {
+		server.Tags = append(server.Tags, &transfer.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(s.tags[k]),
+		})
+	}
+
+	return server
+}
+
+func (t *Transfer) DescribeServer(input *transfer.DescribeServerInput) (*transfer.DescribeServerOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer.DescribeServerOutput{
+		Server: t.describeServer(s),
+	}, nil
+}
+
+func (t *Transfer) UpdateServer(input *transfer.UpdateServerInput) (*transfer.UpdateServerOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	server := s.server
+
+	if input.IdentityProviderDetails != nil {
+		if err := validateTransferIdentityProvider(aws.StringValue(server.IdentityProviderType), input.IdentityProviderDetails); err != nil {
+			return nil, err
+		}
+	}
+
+	endpointType := aws.StringValue(server.EndpointType)
+
+	if input.EndpointType != nil {
+		endpointType = aws.StringValue(input.EndpointType)
+	}
+
+	endpointDetails := server.EndpointDetails
+
+	if input.EndpointType != nil || input.EndpointDetails != nil {
+		if input.EndpointDetails != nil && input.EndpointDetails.AddressAllocationIds != nil && aws.StringValue(server.State) != transfer.StateOffline {
+			return nil, transferError(transfer.ErrCodeInvalidRequestException, "Server must be OFFLINE to change AddressAllocationIds")
+		}
+
+		endpointDetails, err = t.endpointDetails(endpointType, input.EndpointDetails)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	server.EndpointDetails = endpointDetails
+	server.EndpointType = aws.String(endpointType)
+
+	if input.HostKey != nil {
+		server.HostKeyFingerprint = aws.String(hostKeyFingerprint(aws.StringValue(input.HostKey)))
+	}
+
+	if input.IdentityProviderDetails != nil {
+		server.IdentityProviderDetails = input.IdentityProviderDetails
+	}
+
+	if input.LoggingRole != nil {
+		server.LoggingRole = input.LoggingRole
+
+		if aws.StringValue(input.LoggingRole) == "" {
+			server.LoggingRole = nil
+		}
+	}
+
+	if input.SecurityPolicyName != nil {
+		server.SecurityPolicyName = input.SecurityPolicyName
+	}
+
+	return &transfer.UpdateServerOutput{
+		ServerId: server.ServerId,
+	}, nil
+}
+
+func (t *Transfer) DeleteServer(input *transfer.DeleteServerInput) (*transfer.DeleteServerOutput, error) {
+	serverID := aws.StringValue(input.ServerId)
+
+	if _, err := t.server(serverID); err != nil {
+		return nil, err
+	}
+
+	delete(t.servers, serverID)
+
+	return &transfer.DeleteServerOutput{}, nil
+}
+
+func (t *Transfer) StartServer(input *transfer.StartServerInput) (*transfer.StartServerOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.State = aws.String(transfer.StateOnline)
+
+	return &transfer.StartServerOutput{}, nil
+}
+
+func (t *Transfer) StopServer(input *transfer.StopServerInput) (*transfer.StopServerOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.State = aws.String(transfer.StateOffline)
+
+	return &transfer.StopServerOutput{}, nil
+}
+
+//
+// Users
+//
+
+func (t *Transfer) CreateUser(input *transfer.CreateUserInput) (*transfer.CreateUserOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	userName := aws.StringValue(input.UserName)
+
+	if _, ok := s.users[userName]; ok {
+		return nil, transferError(transfer.ErrCodeResourceExistsException, "User already exists")
+	}
+
+	s.users[userName] = &transfer.ListedUser{
+		Arn:               aws.String(fmt.Sprintf("arn:aws:transfer:%s:%s:user/%s/%s", Region, AccountID, aws.StringValue(input.ServerId), userName)),
+		HomeDirectory:     input.HomeDirectory,
+		HomeDirectoryType: aws.String(transfer.HomeDirectoryTypePath),
+		Role:              input.Role,
+		UserName:          aws.String(userName),
+	}
+
+	return &transfer.CreateUserOutput{
+		ServerId: input.ServerId,
+		UserName: aws.String(userName),
+	}, nil
+}
+
+func (t *Transfer) ListUsers(input *transfer.ListUsersInput) (*transfer.ListUsersOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := &transfer.ListUsersOutput{
+		ServerId: input.ServerId,
+		Users:    []*transfer.ListedUser{},
+	}
+
+	for _, userName := range sortedKeys(s.users) {
+		output.Users = append(output.Users, awsutil.CopyOf(s.users[userName]).(*transfer.ListedUser))
+	}
+
+	return output, nil
+}
+
+func (t *Transfer) DeleteUser(input *transfer.DeleteUserInput) (*transfer.DeleteUserOutput, error) {
+	s, err := t.server(aws.StringValue(input.ServerId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	userName := aws.StringValue(input.UserName)
+
+	if _, ok := s.users[userName]; !ok {
+		return nil, transferError(transfer.ErrCodeResourceNotFoundException, "Unknown user")
+	}
+
+	delete(s.users, userName)
+
+	return &transfer.DeleteUserOutput{}, nil
+}
+
+//
+// Tags
+//
+
+func (t *Transfer) ListTagsForResource(input *transfer.ListTagsForResourceInput) (*transfer.ListTagsForResourceOutput, error) {
+	s, err := t.serverByArn(aws.StringValue(input.Arn))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer.ListTagsForResourceOutput{
+		Arn:  input.Arn,
+		Tags: t.describeServer(s).Tags,
+	}, nil
+}
+
+func (t *Transfer) TagResource(input *transfer.TagResourceInput) (*transfer.TagResourceOutput, error) {
+	s, err := t.serverByArn(aws.StringValue(input.Arn))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range input.Tags {
+		s.tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return &transfer.TagResourceOutput{}, nil
+}
+
+func (t *Transfer) UntagResource(input *transfer.UntagResourceInput) (*transfer.UntagResourceOutput, error) {
+	s, err := t.serverByArn(aws.StringValue(input.Arn))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range aws.StringValueSlice(input.TagKeys) {
+		delete(s.tags, k)
+	}
+
+	return &transfer.UntagResourceOutput{}, nil
+}
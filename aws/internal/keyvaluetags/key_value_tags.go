@@ -34,6 +34,21 @@ type DefaultConfig struct {
 type IgnoreConfig struct {
 	Keys        KeyValueTags
 	KeyPrefixes KeyValueTags
+	KeyPatterns []*IgnorePattern
+}
+
+// IgnorePattern matches tag keys, and optionally tag values, by regular expression.
+type IgnorePattern struct {
+	Key   *regexp.Regexp
+	Value *regexp.Regexp
+}
+
+// RequiredConfig contains tag keys and value patterns required across all
+// resources, with optional replacements for specific resource types.
+type RequiredConfig struct {
+	Keys          KeyValueTags
+	AllowedValues map[string]*regexp.Regexp
+	ResourceTypes map[string]*RequiredConfig
 }
 
 // KeyValueTags is a standard implementation for AWS key-value resource tags.
@@ -85,6 +100,63 @@ func (dc *DefaultConfig) TagsEqual(tags KeyValueTags) bool {
 	return dc.Tags.ContainsAll(tags)
 }
 
+// ForResourceType returns the configuration applying to the given resource
+// type, which is the resource type override when one is present.
+func (rc *RequiredConfig) ForResourceType(resourceType string) *RequiredConfig {
+	if rc == nil {
+		return nil
+	}
+
+	if override, ok := rc.ResourceTypes[resourceType]; ok {
+		return override
+	}
+
+	return rc
+}
+
+// Check returns an error if any required tag keys are missing from the
+// given tags or any tag values do not match their allowed value pattern.
+func (rc *RequiredConfig) Check(resourceType string, tags KeyValueTags) error {
+	config := rc.ForResourceType(resourceType)
+
+	if config == nil {
+		return nil
+	}
+
+	var problems []string
+
+	if missing := tags.Missing(config.Keys).Keys(); len(missing) > 0 {
+		sort.Strings(missing)
+		problems = append(problems, fmt.Sprintf("missing required tags: %s", strings.Join(missing, ", ")))
+	}
+
+	keys := make([]string, 0, len(config.AllowedValues))
+
+	for k := range config.AllowedValues {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := tags.KeyValue(k)
+
+		if v == nil {
+			continue
+		}
+
+		if re := config.AllowedValues[k]; !re.MatchString(*v) {
+			problems = append(problems, fmt.Sprintf("tag %s value (%s) does not match %s", k, *v, re))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s", strings.Join(problems, "; "))
+}
+
 // IgnoreConfig returns any tags not removed by a given configuration.
 func (tags KeyValueTags) IgnoreConfig(config *IgnoreConfig) KeyValueTags {
 	if config == nil {
@@ -93,6 +165,7 @@ func (tags KeyValueTags) IgnoreConfig(config *IgnoreConfig) KeyValueTags {
 
 	result := tags.IgnorePrefixes(config.KeyPrefixes)
 	result = result.Ignore(config.Keys)
+	result = result.IgnorePatterns(config.KeyPatterns)
 
 	return result
 }
@@ -144,6 +217,48 @@ func (tags KeyValueTags) IgnorePrefixes(ignoreTagPrefixes KeyValueTags) KeyValue
 	return result
 }
 
+// IgnorePatterns returns tags not matching any of the given patterns.
+// A pattern without a value regular expression matches on key alone.
+func (tags KeyValueTags) IgnorePatterns(ignorePatterns []*IgnorePattern) KeyValueTags {
+	result := make(KeyValueTags)
+
+	for k, v := range tags {
+		var ignore bool
+
+		for _, ignorePattern := range ignorePatterns {
+			if ignorePattern.Matches(k, v) {
+				ignore = true
+				break
+			}
+		}
+
+		if ignore {
+			continue
+		}
+
+		result[k] = v
+	}
+
+	return result
+}
+
+// Matches returns whether or not the pattern matches the given tag.
+func (ip *IgnorePattern) Matches(key string, td *TagData) bool {
+	if ip == nil || ip.Key == nil || !ip.Key.MatchString(key) {
+		return false
+	}
+
+	if ip.Value == nil {
+		return true
+	}
+
+	if td == nil || td.Value == nil {
+		return false
+	}
+
+	return ip.Value.MatchString(*td.Value)
+}
+
 // IgnoreRds returns non-AWS and non-RDS tag keys.
 func (tags KeyValueTags) IgnoreRds() KeyValueTags {
 	result := make(KeyValueTags)
@@ -347,6 +462,11 @@ func (tags KeyValueTags) Merge(mergeTags KeyValueTags) KeyValueTags {
 	return result
 }
 
+// Missing returns required tag keys not present.
+func (tags KeyValueTags) Missing(requiredTags KeyValueTags) KeyValueTags {
+	return requiredTags.Removed(tags.Only(requiredTags))
+}
+
 // Only returns matching tag keys.
 func (tags KeyValueTags) Only(onlyTags KeyValueTags) KeyValueTags {
 	result := make(KeyValueTags)
@@ -1,6 +1,7 @@
 package keyvaluetags
 
 import (
+	"regexp"
 	"testing"
 )
 
@@ -246,6 +247,23 @@ func TestKeyValueTagsIgnoreConfig(t *testing.T) {
 				"key3": "value3",
 			},
 		},
+		{
+			name: "key patterns some matching",
+			tags: New(map[string]string{
+				"backup-2020-01-01": "value1",
+				"cmdb:ci-1234":      "value2",
+				"key3":              "value3",
+			}),
+			ignoreConfig: &IgnoreConfig{
+				KeyPatterns: []*IgnorePattern{
+					{Key: regexp.MustCompile(`^backup-`)},
+					{Key: regexp.MustCompile(`^cmdb:ci-[0-9]+$`)},
+				},
+			},
+			want: map[string]string{
+				"key3": "value3",
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -402,6 +420,74 @@ func TestKeyValueTagsIgnorePrefixes(t *testing.T) {
 	}
 }
 
+func TestKeyValueTagsIgnorePatterns(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tags           KeyValueTags
+		ignorePatterns []*IgnorePattern
+		want           map[string]string
+	}{
+		{
+			name: "no patterns",
+			tags: New(map[string]string{
+				"key1": "value1",
+			}),
+			ignorePatterns: nil,
+			want: map[string]string{
+				"key1": "value1",
+			},
+		},
+		{
+			name: "key matching",
+			tags: New(map[string]string{
+				"backup-2020-01-01": "value1",
+				"key2":              "value2",
+			}),
+			ignorePatterns: []*IgnorePattern{
+				{Key: regexp.MustCompile(`^backup-[0-9-]+$`)},
+			},
+			want: map[string]string{
+				"key2": "value2",
+			},
+		},
+		{
+			name: "key and value matching",
+			tags: New(map[string]string{
+				"managed-by": "cmdb",
+				"owner":      "team-a",
+			}),
+			ignorePatterns: []*IgnorePattern{
+				{Key: regexp.MustCompile(`^managed-by$`), Value: regexp.MustCompile(`^cmdb$`)},
+			},
+			want: map[string]string{
+				"owner": "team-a",
+			},
+		},
+		{
+			name: "key matching value not matching",
+			tags: New(map[string]string{
+				"managed-by": "terraform",
+				"owner":      "team-a",
+			}),
+			ignorePatterns: []*IgnorePattern{
+				{Key: regexp.MustCompile(`^managed-by$`), Value: regexp.MustCompile(`^cmdb$`)},
+			},
+			want: map[string]string{
+				"managed-by": "terraform",
+				"owner":      "team-a",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := testCase.tags.IgnorePatterns(testCase.ignorePatterns)
+
+			testKeyValueTagsVerifyMap(t, got.Map(), testCase.want)
+		})
+	}
+}
+
 func TestKeyValueTagsIgnoreRds(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -1198,6 +1284,143 @@ func TestKeyValueTagsContainsAll(t *testing.T) {
 	}
 }
 
+func TestKeyValueTagsMissing(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tags     KeyValueTags
+		required KeyValueTags
+		want     []string
+	}{
+		{
+			name:     "empty",
+			tags:     New(map[string]string{}),
+			required: New([]string{}),
+			want:     []string{},
+		},
+		{
+			name: "all_present",
+			tags: New(map[string]string{
+				"key1": "value1",
+				"key2": "value2",
+				"key3": "value3",
+			}),
+			required: New([]string{
+				"key1",
+				"key2",
+			}),
+			want: []string{},
+		},
+		{
+			name: "some_missing",
+			tags: New(map[string]string{
+				"key1": "value1",
+				"key3": "value3",
+			}),
+			required: New([]string{
+				"key1",
+				"key2",
+			}),
+			want: []string{
+				"key2",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := testCase.tags.Missing(testCase.required)
+
+			testKeyValueTagsVerifyKeys(t, got.Keys(), testCase.want)
+		})
+	}
+}
+
+func TestRequiredConfigCheck(t *testing.T) {
+	requiredConfig := &RequiredConfig{
+		Keys: New([]string{
+			"CostCenter",
+			"Owner",
+		}),
+		AllowedValues: map[string]*regexp.Regexp{
+			"CostCenter": regexp.MustCompile(`^[0-9]{4}$`),
+		},
+		ResourceTypes: map[string]*RequiredConfig{
+			"aws_iam_role": {
+				Keys: New([]string{
+					"Owner",
+				}),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		requiredConfig *RequiredConfig
+		resourceType   string
+		tags           KeyValueTags
+		wantErr        bool
+	}{
+		{
+			name:         "no config",
+			resourceType: "aws_vpc",
+			tags:         New(map[string]string{}),
+			wantErr:      false,
+		},
+		{
+			name:           "all present",
+			requiredConfig: requiredConfig,
+			resourceType:   "aws_vpc",
+			tags: New(map[string]string{
+				"CostCenter": "1234",
+				"Owner":      "team-a",
+			}),
+			wantErr: false,
+		},
+		{
+			name:           "missing key",
+			requiredConfig: requiredConfig,
+			resourceType:   "aws_vpc",
+			tags: New(map[string]string{
+				"CostCenter": "1234",
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "invalid value",
+			requiredConfig: requiredConfig,
+			resourceType:   "aws_vpc",
+			tags: New(map[string]string{
+				"CostCenter": "finance",
+				"Owner":      "team-a",
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "resource type override",
+			requiredConfig: requiredConfig,
+			resourceType:   "aws_iam_role",
+			tags: New(map[string]string{
+				"Owner": "team-a",
+			}),
+			wantErr: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.requiredConfig.Check(testCase.resourceType, testCase.tags)
+
+			if err != nil && !testCase.wantErr {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if err == nil && testCase.wantErr {
+				t.Error("expected error, got none")
+			}
+		})
+	}
+}
+
 func TestKeyValueTagsHash(t *testing.T) {
 	testCases := []struct {
 		name string
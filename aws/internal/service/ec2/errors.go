@@ -24,6 +24,35 @@ const (
 	ErrCodeClientVpnRouteNotFound             = "InvalidClientVpnRouteNotFound"
 )
 
+const (
+	ErrCodeDependencyViolation                         = "DependencyViolation"
+	ErrCodeGatewayNotAttached                          = "Gateway.NotAttached"
+	ErrCodeIncorrectState                              = "IncorrectState"
+	ErrCodeInvalidAddressNotFound                      = "InvalidAddress.NotFound"
+	ErrCodeInvalidAllocationIDNotFound                 = "InvalidAllocationID.NotFound"
+	ErrCodeInvalidAssociationNotFound                  = "InvalidAssociation.NotFound"
+	ErrCodeInvalidDhcpOptionIDNotFound                 = "InvalidDhcpOptionID.NotFound"
+	ErrCodeInvalidAttachmentIDNotFound                 = "InvalidAttachmentID.NotFound"
+	ErrCodeInvalidFlowLogIdNotFound                    = "InvalidFlowLogId.NotFound"
+	ErrCodeInvalidInternetGatewayIDNotFound            = "InvalidInternetGatewayID.NotFound"
+	ErrCodeInvalidNetworkAclEntryNotFound              = "InvalidNetworkAclEntry.NotFound"
+	ErrCodeInvalidNetworkAclIDNotFound                 = "InvalidNetworkAclID.NotFound"
+	ErrCodeInvalidNetworkInterfaceIDNotFound           = "InvalidNetworkInterfaceID.NotFound"
+	ErrCodeInvalidPrefixListIDNotFound                 = "InvalidPrefixListID.NotFound"
+	ErrCodeInvalidRouteNotFound                        = "InvalidRoute.NotFound"
+	ErrCodeInvalidRouteTableIDNotFound                 = "InvalidRouteTableID.NotFound"
+	ErrCodeInvalidSubnetIDNotFound                     = "InvalidSubnetID.NotFound"
+	ErrCodeInvalidTransitGatewayAttachmentIDNotFound   = "InvalidTransitGatewayAttachmentID.NotFound"
+	ErrCodeInvalidTransitGatewayIDNotFound             = "InvalidTransitGatewayID.NotFound"
+	ErrCodeInvalidVpcCidrBlockAssociationIDNotFound    = "InvalidVpcCidrBlockAssociationID.NotFound"
+	ErrCodeInvalidVpcEndpointIdNotFound                = "InvalidVpcEndpointId.NotFound"
+	ErrCodeInvalidVpcIDNotFound                        = "InvalidVpcID.NotFound"
+	ErrCodeInvalidVpcPeeringConnectionIDNotFound       = "InvalidVpcPeeringConnectionID.NotFound"
+	ErrCodeNatGatewayNotFound                          = "NatGatewayNotFound"
+	ErrCodePrefixListVersionMismatch                   = "PrefixListVersionMismatch"
+	ErrCodeTransitGatewayRouteTablePropagationNotFound = "TransitGatewayRouteTablePropagation.NotFound"
+)
+
 const (
 	InvalidSecurityGroupIDNotFound = "InvalidSecurityGroupID.NotFound"
 	InvalidGroupNotFound           = "InvalidGroup.NotFound"
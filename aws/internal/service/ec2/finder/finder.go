@@ -1,6 +1,8 @@
 package finder
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
@@ -55,6 +57,120 @@ func ClientVpnRouteByID(conn *ec2.EC2, routeID string) (*ec2.DescribeClientVpnRo
 	return ClientVpnRoute(conn, endpointID, targetSubnetID, destinationCidr)
 }
 
+// ClientVpnNetworkAssociationByID looks up a Client VPN network association by ID. When not found, returns nil and potentially an API error.
+func ClientVpnNetworkAssociationByID(conn *ec2.EC2, associationID, endpointID string) (*ec2.TargetNetwork, error) {
+	input := &ec2.DescribeClientVpnTargetNetworksInput{
+		ClientVpnEndpointId: aws.String(endpointID),
+		AssociationIds:      aws.StringSlice([]string{associationID}),
+	}
+
+	result, err := conn.DescribeClientVpnTargetNetworks(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.ClientVpnTargetNetworks) == 0 || result.ClientVpnTargetNetworks[0] == nil {
+		return nil, nil
+	}
+
+	return result.ClientVpnTargetNetworks[0], nil
+}
+
+// FlowLogByID looks up a flow log by ID. When not found, returns nil and potentially an API error.
+func FlowLogByID(conn *ec2.EC2, id string) (*ec2.FlowLog, error) {
+	input := &ec2.DescribeFlowLogsInput{
+		FlowLogIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeFlowLogs(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.FlowLogs) == 0 || result.FlowLogs[0] == nil {
+		return nil, nil
+	}
+
+	return result.FlowLogs[0], nil
+}
+
+// ManagedPrefixListByID looks up a managed prefix list by ID. When not found, returns nil and potentially an API error.
+func ManagedPrefixListByID(conn *ec2.EC2, id string) (*ec2.ManagedPrefixList, error) {
+	input := &ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeManagedPrefixLists(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.PrefixLists) == 0 || result.PrefixLists[0] == nil {
+		return nil, nil
+	}
+
+	return result.PrefixLists[0], nil
+}
+
+// ManagedPrefixListEntriesByID returns the entries of the current version of a managed prefix list.
+func ManagedPrefixListEntriesByID(conn *ec2.EC2, id string) ([]*ec2.PrefixListEntry, error) {
+	input := &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId: aws.String(id),
+	}
+
+	var entries []*ec2.PrefixListEntry
+
+	err := conn.GetManagedPrefixListEntriesPages(input, func(page *ec2.GetManagedPrefixListEntriesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		entries = append(entries, page.Entries...)
+
+		return !lastPage
+	})
+
+	return entries, err
+}
+
+// NetworkInterfaceByID looks up a network interface by ID. When not found, returns nil and potentially an API error.
+func NetworkInterfaceByID(conn *ec2.EC2, id string) (*ec2.NetworkInterface, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeNetworkInterfaces(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.NetworkInterfaces) == 0 || result.NetworkInterfaces[0] == nil {
+		return nil, nil
+	}
+
+	return result.NetworkInterfaces[0], nil
+}
+
+// NetworkInterfaceByAttachmentID looks up the network interface of an attachment by attachment ID. When not found, returns nil and potentially an API error.
+func NetworkInterfaceByAttachmentID(conn *ec2.EC2, id string) (*ec2.NetworkInterface, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: tfec2.BuildAttributeFilterList(map[string]string{
+			"attachment.attachment-id": id,
+		}),
+	}
+
+	result, err := conn.DescribeNetworkInterfaces(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.NetworkInterfaces) == 0 || result.NetworkInterfaces[0] == nil {
+		return nil, nil
+	}
+
+	return result.NetworkInterfaces[0], nil
+}
+
 // SecurityGroupByID looks up a security group by ID. When not found, returns nil and potentially an API error.
 func SecurityGroupByID(conn *ec2.EC2, id string) (*ec2.SecurityGroup, error) {
 	req := &ec2.DescribeSecurityGroupsInput{
@@ -71,3 +187,520 @@ func SecurityGroupByID(conn *ec2.EC2, id string) (*ec2.SecurityGroup, error) {
 
 	return result.SecurityGroups[0], nil
 }
+
+// SecurityGroupByNameAndVpcID looks up a security group by name and VPC ID. When not found, returns nil and potentially an API error.
+func SecurityGroupByNameAndVpcID(conn *ec2.EC2, name, vpcID string) (*ec2.SecurityGroup, error) {
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: tfec2.BuildAttributeFilterList(map[string]string{
+			"group-name": name,
+			"vpc-id":     vpcID,
+		}),
+	}
+
+	result, err := conn.DescribeSecurityGroups(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.SecurityGroups) == 0 || result.SecurityGroups[0] == nil {
+		return nil, nil
+	}
+
+	return result.SecurityGroups[0], nil
+}
+
+// TransitGatewayByID looks up a transit gateway by ID. When not found, returns nil and potentially an API error.
+func TransitGatewayByID(conn *ec2.EC2, id string) (*ec2.TransitGateway, error) {
+	input := &ec2.DescribeTransitGatewaysInput{
+		TransitGatewayIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeTransitGateways(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.TransitGateways) == 0 || result.TransitGateways[0] == nil {
+		return nil, nil
+	}
+
+	return result.TransitGateways[0], nil
+}
+
+// TransitGatewayRouteTableByID looks up a transit gateway route table by ID. When not found, returns nil and potentially an API error.
+func TransitGatewayRouteTableByID(conn *ec2.EC2, id string) (*ec2.TransitGatewayRouteTable, error) {
+	input := &ec2.DescribeTransitGatewayRouteTablesInput{
+		TransitGatewayRouteTableIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeTransitGatewayRouteTables(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.TransitGatewayRouteTables) == 0 || result.TransitGatewayRouteTables[0] == nil {
+		return nil, nil
+	}
+
+	return result.TransitGatewayRouteTables[0], nil
+}
+
+// TransitGatewayRouteTableAssociation looks up the association of a transit gateway attachment with a route table. When not found, returns nil and potentially an API error.
+func TransitGatewayRouteTableAssociation(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string) (*ec2.TransitGatewayRouteTableAssociation, error) {
+	input := &ec2.GetTransitGatewayRouteTableAssociationsInput{
+		Filters: tfec2.BuildAttributeFilterList(map[string]string{
+			"transit-gateway-attachment-id": transitGatewayAttachmentID,
+		}),
+		TransitGatewayRouteTableId: aws.String(transitGatewayRouteTableID),
+	}
+
+	result, err := conn.GetTransitGatewayRouteTableAssociations(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	for _, association := range result.Associations {
+		if aws.StringValue(association.TransitGatewayAttachmentId) == transitGatewayAttachmentID {
+			return association, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// TransitGatewayRouteTablePropagation looks up the propagation of a transit gateway attachment to a route table. When not found, returns nil and potentially an API error.
+func TransitGatewayRouteTablePropagation(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string) (*ec2.TransitGatewayRouteTablePropagation, error) {
+	input := &ec2.GetTransitGatewayRouteTablePropagationsInput{
+		Filters: tfec2.BuildAttributeFilterList(map[string]string{
+			"transit-gateway-attachment-id": transitGatewayAttachmentID,
+		}),
+		TransitGatewayRouteTableId: aws.String(transitGatewayRouteTableID),
+	}
+
+	result, err := conn.GetTransitGatewayRouteTablePropagations(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	for _, propagation := range result.TransitGatewayRouteTablePropagations {
+		if aws.StringValue(propagation.TransitGatewayAttachmentId) == transitGatewayAttachmentID {
+			return propagation, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// TransitGatewayVpcAttachmentByID looks up a transit gateway VPC attachment by ID. When not found, returns nil and potentially an API error.
+func TransitGatewayVpcAttachmentByID(conn *ec2.EC2, id string) (*ec2.TransitGatewayVpcAttachment, error) {
+	input := &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		TransitGatewayAttachmentIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeTransitGatewayVpcAttachments(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.TransitGatewayVpcAttachments) == 0 || result.TransitGatewayVpcAttachments[0] == nil {
+		return nil, nil
+	}
+
+	return result.TransitGatewayVpcAttachments[0], nil
+}
+
+// VpcDhcpOptionsByID looks up a DHCP options set by ID. When not found, returns nil and potentially an API error.
+func VpcDhcpOptionsByID(conn *ec2.EC2, id string) (*ec2.DhcpOptions, error) {
+	input := &ec2.DescribeDhcpOptionsInput{
+		DhcpOptionsIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeDhcpOptions(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.DhcpOptions) == 0 || result.DhcpOptions[0] == nil {
+		return nil, nil
+	}
+
+	return result.DhcpOptions[0], nil
+}
+
+// VpcCidrBlockAssociationByID returns the IPv4 CIDR block association with
+// the specified ID and the VPC it belongs to.
+func VpcCidrBlockAssociationByID(conn *ec2.EC2, id string) (*ec2.VpcCidrBlockAssociation, *ec2.Vpc, error) {
+	input := &ec2.DescribeVpcsInput{
+		Filters: tfec2.BuildAttributeFilterList(map[string]string{
+			"cidr-block-association.association-id": id,
+		}),
+	}
+
+	result, err := conn.DescribeVpcs(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if result == nil || len(result.Vpcs) == 0 || result.Vpcs[0] == nil {
+		return nil, nil, nil
+	}
+
+	vpc := result.Vpcs[0]
+
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if aws.StringValue(association.AssociationId) == id {
+			return association, vpc, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// VpcByID looks up a VPC by ID. When not found, returns nil and potentially an API error.
+func VpcByID(conn *ec2.EC2, id string) (*ec2.Vpc, error) {
+	input := &ec2.DescribeVpcsInput{
+		VpcIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeVpcs(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.Vpcs) == 0 || result.Vpcs[0] == nil {
+		return nil, nil
+	}
+
+	return result.Vpcs[0], nil
+}
+
+// VpcIpv6CidrBlockAssociationByID looks up a VPC IPv6 CIDR block association by VPC ID and association ID. When not found, returns nil and potentially an API error.
+func VpcIpv6CidrBlockAssociationByID(conn *ec2.EC2, vpcID, associationID string) (*ec2.VpcIpv6CidrBlockAssociation, error) {
+	vpc, err := VpcByID(conn, vpcID)
+	if err != nil || vpc == nil {
+		return nil, err
+	}
+
+	for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(association.AssociationId) == associationID {
+			return association, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// SubnetByID looks up a subnet by ID. When not found, returns nil and potentially an API error.
+func SubnetByID(conn *ec2.EC2, id string) (*ec2.Subnet, error) {
+	input := &ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeSubnets(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.Subnets) == 0 || result.Subnets[0] == nil {
+		return nil, nil
+	}
+
+	return result.Subnets[0], nil
+}
+
+// SubnetIpv6CidrBlockAssociationByID looks up a subnet IPv6 CIDR block association by subnet ID and association ID. When not found, returns nil and potentially an API error.
+func SubnetIpv6CidrBlockAssociationByID(conn *ec2.EC2, subnetID, associationID string) (*ec2.SubnetIpv6CidrBlockAssociation, error) {
+	subnet, err := SubnetByID(conn, subnetID)
+	if err != nil || subnet == nil {
+		return nil, err
+	}
+
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(association.AssociationId) == associationID {
+			return association, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// RouteTableByID looks up a route table by ID. When not found, returns nil and potentially an API error.
+func RouteTableByID(conn *ec2.EC2, id string) (*ec2.RouteTable, error) {
+	input := &ec2.DescribeRouteTablesInput{
+		RouteTableIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeRouteTables(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.RouteTables) == 0 || result.RouteTables[0] == nil {
+		return nil, nil
+	}
+
+	return result.RouteTables[0], nil
+}
+
+// RouteTableAssociationByID looks up a route table association by ID. When not found, returns nil and potentially an API error.
+func RouteTableAssociationByID(conn *ec2.EC2, id string) (*ec2.RouteTableAssociation, error) {
+	input := &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("association.route-table-association-id"),
+				Values: aws.StringSlice([]string{id}),
+			},
+		},
+	}
+
+	result, err := conn.DescribeRouteTables(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	for _, routeTable := range result.RouteTables {
+		for _, association := range routeTable.Associations {
+			if aws.StringValue(association.RouteTableAssociationId) == id {
+				return association, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// MainRouteTableAssociationByVpcID looks up the main route table association of a VPC by VPC ID. When not found, returns nil and potentially an API error.
+func MainRouteTableAssociationByVpcID(conn *ec2.EC2, vpcID string) (*ec2.RouteTableAssociation, error) {
+	input := &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("association.main"),
+				Values: aws.StringSlice([]string{"true"}),
+			},
+			{
+				Name:   aws.String("vpc-id"),
+				Values: aws.StringSlice([]string{vpcID}),
+			},
+		},
+	}
+
+	result, err := conn.DescribeRouteTables(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	for _, routeTable := range result.RouteTables {
+		for _, association := range routeTable.Associations {
+			if aws.BoolValue(association.Main) {
+				return association, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// InternetGatewayByID looks up an internet gateway by ID. When not found, returns nil and potentially an API error.
+func InternetGatewayByID(conn *ec2.EC2, id string) (*ec2.InternetGateway, error) {
+	input := &ec2.DescribeInternetGatewaysInput{
+		InternetGatewayIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeInternetGateways(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.InternetGateways) == 0 || result.InternetGateways[0] == nil {
+		return nil, nil
+	}
+
+	return result.InternetGateways[0], nil
+}
+
+// NatGatewayByID looks up a NAT gateway by ID. When not found, returns nil and potentially an API error.
+func NatGatewayByID(conn *ec2.EC2, id string) (*ec2.NatGateway, error) {
+	input := &ec2.DescribeNatGatewaysInput{
+		NatGatewayIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeNatGateways(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.NatGateways) == 0 || result.NatGateways[0] == nil {
+		return nil, nil
+	}
+
+	return result.NatGateways[0], nil
+}
+
+// EipByID looks up an Elastic IP address by allocation ID, or by public IP for EC2-Classic addresses. When not found, returns nil and potentially an API error.
+func EipByID(conn *ec2.EC2, id string) (*ec2.Address, error) {
+	input := &ec2.DescribeAddressesInput{}
+
+	if strings.Contains(id, "eipalloc") {
+		input.AllocationIds = aws.StringSlice([]string{id})
+	} else {
+		input.PublicIps = aws.StringSlice([]string{id})
+	}
+
+	result, err := conn.DescribeAddresses(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.Addresses) == 0 || result.Addresses[0] == nil {
+		return nil, nil
+	}
+
+	return result.Addresses[0], nil
+}
+
+// Route looks up the route of a route table with a destination CIDR block, IPv6 CIDR block or prefix list ID. When not found, returns nil and potentially an API error.
+func Route(conn *ec2.EC2, routeTableID, destination string) (*ec2.Route, error) {
+	routeTable, err := RouteTableByID(conn, routeTableID)
+	if err != nil {
+		return nil, err
+	}
+
+	if routeTable == nil {
+		return nil, nil
+	}
+
+	for _, route := range routeTable.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) == destination ||
+			aws.StringValue(route.DestinationIpv6CidrBlock) == destination ||
+			aws.StringValue(route.DestinationPrefixListId) == destination {
+			return route, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// VpcEndpointByID looks up a VPC endpoint by ID. When not found, returns nil and potentially an API error.
+func VpcEndpointByID(conn *ec2.EC2, id string) (*ec2.VpcEndpoint, error) {
+	input := &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeVpcEndpoints(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.VpcEndpoints) == 0 || result.VpcEndpoints[0] == nil {
+		return nil, nil
+	}
+
+	return result.VpcEndpoints[0], nil
+}
+
+// VpcPeeringConnectionByID looks up a VPC peering connection by ID. When not found, returns nil and potentially an API error.
+func VpcPeeringConnectionByID(conn *ec2.EC2, id string) (*ec2.VpcPeeringConnection, error) {
+	input := &ec2.DescribeVpcPeeringConnectionsInput{
+		VpcPeeringConnectionIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeVpcPeeringConnections(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.VpcPeeringConnections) == 0 || result.VpcPeeringConnections[0] == nil {
+		return nil, nil
+	}
+
+	return result.VpcPeeringConnections[0], nil
+}
+
+// NetworkAclByID looks up a NetworkAcl by ID. When not found, returns nil and potentially an API error.
+func NetworkAclByID(conn *ec2.EC2, id string) (*ec2.NetworkAcl, error) {
+	input := &ec2.DescribeNetworkAclsInput{
+		NetworkAclIds: aws.StringSlice([]string{id}),
+	}
+
+	result, err := conn.DescribeNetworkAcls(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || len(result.NetworkAcls) == 0 || result.NetworkAcls[0] == nil {
+		return nil, nil
+	}
+
+	return result.NetworkAcls[0], nil
+}
+
+// NetworkAclAssociationByID returns the network ACL association with the
+// specified ID and the network ACL it belongs to.
+func NetworkAclAssociationByID(conn *ec2.EC2, id string) (*ec2.NetworkAclAssociation, *ec2.NetworkAcl, error) {
+	input := &ec2.DescribeNetworkAclsInput{
+		Filters: tfec2.BuildAttributeFilterList(map[string]string{
+			"association.association-id": id,
+		}),
+	}
+
+	result, err := conn.DescribeNetworkAcls(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if result == nil {
+		return nil, nil, nil
+	}
+
+	for _, networkAcl := range result.NetworkAcls {
+		for _, association := range networkAcl.Associations {
+			if aws.StringValue(association.NetworkAclAssociationId) == id {
+				return association, networkAcl, nil
+			}
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// NetworkAclEntry looks up the entry of a network ACL with a rule number and direction. When not found, returns nil and potentially an API error.
+func NetworkAclEntry(conn *ec2.EC2, networkAclID string, ruleNumber int, egress bool) (*ec2.NetworkAclEntry, error) {
+	input := &ec2.DescribeNetworkAclsInput{
+		NetworkAclIds: aws.StringSlice([]string{networkAclID}),
+	}
+
+	result, err := conn.DescribeNetworkAcls(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	for _, networkAcl := range result.NetworkAcls {
+		for _, entry := range networkAcl.Entries {
+			if aws.Int64Value(entry.RuleNumber) == int64(ruleNumber) && aws.BoolValue(entry.Egress) == egress {
+				return entry, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
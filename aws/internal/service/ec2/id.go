@@ -2,7 +2,11 @@ package ec2
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/hashcode"
 )
 
 const clientVpnAuthorizationRuleIDSeparator = ","
@@ -68,3 +72,238 @@ func ClientVpnRouteParseID(id string) (string, string, string, error) {
 		fmt.Errorf("unexpected format for ID (%q), expected endpoint-id"+clientVpnRouteIDSeparator+
 			"target-subnet-id"+clientVpnRouteIDSeparator+"destination-cidr-block", id)
 }
+
+const internetGatewayAttachmentIDSeparator = ":"
+
+func InternetGatewayAttachmentCreateID(internetGatewayID, vpcID string) string {
+	parts := []string{internetGatewayID, vpcID}
+	id := strings.Join(parts, internetGatewayAttachmentIDSeparator)
+	return id
+}
+
+func InternetGatewayAttachmentParseID(id string) (string, string, error) {
+	parts := strings.Split(id, internetGatewayAttachmentIDSeparator)
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "",
+		fmt.Errorf("unexpected format for ID (%q), expected internet-gateway-id"+internetGatewayAttachmentIDSeparator+
+			"vpc-id", id)
+}
+
+// RouteCreateID returns a route resource ID.
+func RouteCreateID(routeTableID, destination string) string {
+	return fmt.Sprintf("r-%s%d", routeTableID, hashcode.String(destination))
+}
+
+const routeImportIDSeparator = "_"
+
+// RouteParseImportID parses a route import ID into a route table ID and a
+// destination CIDR block, IPv6 CIDR block or prefix list ID.
+func RouteParseImportID(id string) (string, string, error) {
+	parts := strings.Split(id, routeImportIDSeparator)
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "",
+		fmt.Errorf("unexpected format for ID (%q), expected route-table-id"+routeImportIDSeparator+
+			"destination", id)
+}
+
+const routeTableAssociationImportIDSeparator = "/"
+
+// RouteTableAssociationParseImportID parses a route table association import
+// ID into a subnet or gateway ID and a route table ID.
+func RouteTableAssociationParseImportID(id string) (string, string, error) {
+	parts := strings.Split(id, routeTableAssociationImportIDSeparator)
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "",
+		fmt.Errorf("unexpected format for ID (%q), expected subnet-id"+routeTableAssociationImportIDSeparator+
+			"route-table-id or gateway-id"+routeTableAssociationImportIDSeparator+"route-table-id", id)
+}
+
+// NetworkAclRuleCreateID returns a network ACL rule resource ID.
+func NetworkAclRuleCreateID(networkAclID string, ruleNumber int, egress bool, protocol string) string {
+	return fmt.Sprintf("nacl-%d", hashcode.String(fmt.Sprintf("%s-%d-%t-%s-", networkAclID, ruleNumber, egress, protocol)))
+}
+
+const networkAclRuleImportIDSeparator = ":"
+
+// NetworkAclRuleParseImportID parses a network ACL rule import ID into a
+// network ACL ID, a rule number, a protocol and the egress flag.
+func NetworkAclRuleParseImportID(id string) (string, int, string, bool, error) {
+	parts := strings.Split(id, networkAclRuleImportIDSeparator)
+	if len(parts) == 4 && parts[0] != "" && parts[1] != "" && parts[2] != "" && parts[3] != "" {
+		ruleNumber, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return "", 0, "", false, fmt.Errorf("unexpected rule number (%q) in ID (%q): %s", parts[1], id, err)
+		}
+
+		egress, err := strconv.ParseBool(parts[3])
+		if err != nil {
+			return "", 0, "", false, fmt.Errorf("unexpected egress flag (%q) in ID (%q): %s", parts[3], id, err)
+		}
+
+		return parts[0], ruleNumber, parts[2], egress, nil
+	}
+
+	return "", 0, "", false,
+		fmt.Errorf("unexpected format for ID (%q), expected network-acl-id"+networkAclRuleImportIDSeparator+
+			"rule-number"+networkAclRuleImportIDSeparator+"protocol"+networkAclRuleImportIDSeparator+"egress", id)
+}
+
+const transitGatewayRouteTableAttachmentIDSeparator = "_"
+
+// TransitGatewayRouteTableAttachmentCreateID returns the ID of a transit
+// gateway route table association or propagation.
+func TransitGatewayRouteTableAttachmentCreateID(transitGatewayRouteTableID, transitGatewayAttachmentID string) string {
+	parts := []string{transitGatewayRouteTableID, transitGatewayAttachmentID}
+	id := strings.Join(parts, transitGatewayRouteTableAttachmentIDSeparator)
+	return id
+}
+
+// TransitGatewayRouteTableAttachmentParseID parses the ID of a transit
+// gateway route table association or propagation into a route table ID and
+// an attachment ID.
+func TransitGatewayRouteTableAttachmentParseID(id string) (string, string, error) {
+	parts := strings.Split(id, transitGatewayRouteTableAttachmentIDSeparator)
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "",
+		fmt.Errorf("unexpected format for ID (%q), expected transit-gateway-route-table-id"+transitGatewayRouteTableAttachmentIDSeparator+
+			"transit-gateway-attachment-id", id)
+}
+
+// VpcTopologyImportIDSuffix is appended to a VPC ID on import to also import
+// the VPC's subnets, route tables and their associations, network ACLs and
+// their associations, security groups, network interfaces and internet
+// gateway.
+const VpcTopologyImportIDSuffix = "/topology"
+
+// VpcParseImportID parses a VPC import ID into a VPC ID and whether the VPC's
+// topology is to be imported with it.
+func VpcParseImportID(id string) (string, bool, error) {
+	vpcID := strings.TrimSuffix(id, VpcTopologyImportIDSuffix)
+	if vpcID != "" && !strings.Contains(vpcID, "/") {
+		return vpcID, vpcID != id, nil
+	}
+
+	return "", false,
+		fmt.Errorf("unexpected format for ID (%q), expected vpc-id or vpc-id"+VpcTopologyImportIDSuffix, id)
+}
+
+const securityGroupRuleImportIDSeparator = "_"
+
+// SecurityGroupRuleImportID is a security group rule identified by its
+// contents rather than by its resource ID.
+type SecurityGroupRuleImportID struct {
+	SecurityGroupID string
+	Type            string
+	Protocol        string
+	FromPort        int
+	ToPort          int
+
+	// Sources are the rule's CIDR blocks, IPv6 CIDR blocks, prefix list IDs,
+	// source security group ID and "self".
+	Sources []string
+}
+
+// SecurityGroupRuleCreateImportID returns a security group rule import ID,
+// e.g. sg-09a093729ef9382a6_ingress_tcp_8000_8000_10.0.3.0/24. The sources are
+// sorted so that the ID does not depend on their order.
+func SecurityGroupRuleCreateImportID(securityGroupID, ruleType, protocol string, fromPort, toPort int, sources []string) string {
+	sorted := append([]string{}, sources...)
+	sort.Strings(sorted)
+
+	parts := []string{securityGroupID, ruleType, protocol, strconv.Itoa(fromPort), strconv.Itoa(toPort)}
+	parts = append(parts, sorted...)
+	id := strings.Join(parts, securityGroupRuleImportIDSeparator)
+	return strings.ToLower(id)
+}
+
+// SecurityGroupRuleParseImportID parses a security group rule import ID. Only
+// the format of the ID is validated; the protocol and sources are validated
+// against AWS when the rule is read. For ICMP and ICMPv6, the from and to ports
+// are the ICMP type and code, so they are not checked for order.
+func SecurityGroupRuleParseImportID(id string) (*SecurityGroupRuleImportID, error) {
+	parts := strings.Split(strings.ToLower(id), securityGroupRuleImportIDSeparator)
+	errFormat := "unexpected format for ID (%q), expected security-group-id" + securityGroupRuleImportIDSeparator +
+		"type" + securityGroupRuleImportIDSeparator + "protocol" + securityGroupRuleImportIDSeparator +
+		"from-port" + securityGroupRuleImportIDSeparator + "to-port" + securityGroupRuleImportIDSeparator +
+		"source[" + securityGroupRuleImportIDSeparator + "source]*: %s"
+
+	if len(parts) < 6 {
+		return nil, fmt.Errorf(errFormat, id, "too few parts")
+	}
+
+	rule := &SecurityGroupRuleImportID{
+		SecurityGroupID: parts[0],
+		Type:            parts[1],
+		Protocol:        parts[2],
+		Sources:         parts[5:],
+	}
+
+	if !strings.HasPrefix(rule.SecurityGroupID, "sg-") {
+		return nil, fmt.Errorf(errFormat, id, "invalid security group ID")
+	}
+
+	if rule.Type != "ingress" && rule.Type != "egress" {
+		return nil, fmt.Errorf(errFormat, id, "expecting 'ingress' or 'egress'")
+	}
+
+	if rule.Protocol == "" {
+		return nil, fmt.Errorf(errFormat, id, "empty protocol")
+	}
+
+	var err error
+
+	if rule.FromPort, err = strconv.Atoi(parts[3]); err != nil {
+		return nil, fmt.Errorf(errFormat, id, "invalid port")
+	}
+
+	if rule.ToPort, err = strconv.Atoi(parts[4]); err != nil {
+		return nil, fmt.Errorf(errFormat, id, "invalid port")
+	}
+
+	switch rule.Protocol {
+	case "icmp", "1", "icmpv6", "58":
+	default:
+		if rule.ToPort < rule.FromPort {
+			return nil, fmt.Errorf(errFormat, id, "invalid port")
+		}
+	}
+
+	for _, source := range rule.Sources {
+		if source != "self" && !strings.Contains(source, "sg-") && !strings.Contains(source, "pl-") && !strings.Contains(source, ":") && !strings.Contains(source, ".") {
+			return nil, fmt.Errorf(errFormat, id, "source must be cidr, ipv6cidr, prefix list, 'self', or a sg ID")
+		}
+	}
+
+	return rule, nil
+}
+
+const networkInterfaceSecurityGroupAttachmentIDSeparator = "_"
+
+func NetworkInterfaceSecurityGroupAttachmentCreateID(securityGroupID, networkInterfaceID string) string {
+	parts := []string{securityGroupID, networkInterfaceID}
+	id := strings.Join(parts, networkInterfaceSecurityGroupAttachmentIDSeparator)
+	return id
+}
+
+func NetworkInterfaceSecurityGroupAttachmentParseID(id string) (string, string, error) {
+	parts := strings.Split(id, networkInterfaceSecurityGroupAttachmentIDSeparator)
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "",
+		fmt.Errorf("unexpected format for ID (%q), expected security-group-id"+networkInterfaceSecurityGroupAttachmentIDSeparator+
+			"network-interface-id", id)
+}
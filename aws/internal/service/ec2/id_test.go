@@ -0,0 +1,165 @@
+package ec2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecurityGroupRuleParseImportID(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		ID          string
+		Expected    *SecurityGroupRuleImportID
+		ExpectError bool
+	}{
+		{
+			Name: "tcp",
+			ID:   "sg-1234567890abcdef0_ingress_tcp_8000_8000_10.0.3.0/24",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "ingress",
+				Protocol:        "tcp",
+				FromPort:        8000,
+				ToPort:          8000,
+				Sources:         []string{"10.0.3.0/24"},
+			},
+		},
+		{
+			Name: "icmp echo request",
+			ID:   "sg-1234567890abcdef0_ingress_icmp_8_0_0.0.0.0/0",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "ingress",
+				Protocol:        "icmp",
+				FromPort:        8,
+				ToPort:          0,
+				Sources:         []string{"0.0.0.0/0"},
+			},
+		},
+		{
+			Name: "icmpv6 all codes",
+			ID:   "sg-1234567890abcdef0_ingress_icmpv6_128_-1_::/0",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "ingress",
+				Protocol:        "icmpv6",
+				FromPort:        128,
+				ToPort:          -1,
+				Sources:         []string{"::/0"},
+			},
+		},
+		{
+			Name:        "tcp ports out of order",
+			ID:          "sg-1234567890abcdef0_ingress_tcp_8000_80_10.0.3.0/24",
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid security group ID",
+			ID:          "1234567890abcdef0_ingress_tcp_80_80_10.0.3.0/24",
+			ExpectError: true,
+		},
+		{
+			Name:        "too few parts",
+			ID:          "sg-1234567890abcdef0_ingress_tcp_80_80",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := SecurityGroupRuleParseImportID(testCase.ID)
+
+			if err == nil && testCase.ExpectError {
+				t.Fatalf("expected error")
+			}
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %#v, expected %#v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupRuleImportIDRoundTrip(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Expected *SecurityGroupRuleImportID
+	}{
+		{
+			Name: "tcp",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "ingress",
+				Protocol:        "tcp",
+				FromPort:        443,
+				ToPort:          443,
+				Sources:         []string{"10.0.0.0/8", "sg-0987654321fedcba0"},
+			},
+		},
+		{
+			Name: "icmp",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "ingress",
+				Protocol:        "icmp",
+				FromPort:        8,
+				ToPort:          0,
+				Sources:         []string{"0.0.0.0/0"},
+			},
+		},
+		{
+			Name: "icmp all codes",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "ingress",
+				Protocol:        "icmp",
+				FromPort:        3,
+				ToPort:          -1,
+				Sources:         []string{"10.0.0.0/8"},
+			},
+		},
+		{
+			Name: "icmpv6",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "egress",
+				Protocol:        "58",
+				FromPort:        128,
+				ToPort:          0,
+				Sources:         []string{"::/0"},
+			},
+		},
+		{
+			Name: "all protocols",
+			Expected: &SecurityGroupRuleImportID{
+				SecurityGroupID: "sg-1234567890abcdef0",
+				Type:            "egress",
+				Protocol:        "all",
+				FromPort:        0,
+				ToPort:          0,
+				Sources:         []string{"0.0.0.0/0", "::/0", "pl-68a54001", "self"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			e := testCase.Expected
+			id := SecurityGroupRuleCreateImportID(e.SecurityGroupID, e.Type, e.Protocol, e.FromPort, e.ToPort, e.Sources)
+
+			got, err := SecurityGroupRuleParseImportID(id)
+
+			if err != nil {
+				t.Fatalf("error parsing ID (%s): %s", id, err)
+			}
+
+			if !reflect.DeepEqual(got, e) {
+				t.Errorf("got %#v, expected %#v", got, e)
+			}
+		})
+	}
+}
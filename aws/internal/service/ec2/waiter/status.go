@@ -5,7 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
 )
@@ -116,12 +116,10 @@ const (
 	ClientVpnNetworkAssociationStatusUnknown = "Unknown"
 )
 
+// ClientVpnNetworkAssociationStatus fetches the Client VPN network association and its Status
 func ClientVpnNetworkAssociationStatus(conn *ec2.EC2, cvnaID string, cvepID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		result, err := conn.DescribeClientVpnTargetNetworks(&ec2.DescribeClientVpnTargetNetworksInput{
-			ClientVpnEndpointId: aws.String(cvepID),
-			AssociationIds:      []*string{aws.String(cvnaID)},
-		})
+		network, err := finder.ClientVpnNetworkAssociationByID(conn, cvnaID, cvepID)
 
 		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeClientVpnAssociationIdNotFound) || tfec2.ErrCodeEquals(err, tfec2.ErrCodeClientVpnEndpointIdNotFound) {
 			return nil, ClientVpnNetworkAssociationStatusNotFound, nil
@@ -130,11 +128,10 @@ func ClientVpnNetworkAssociationStatus(conn *ec2.EC2, cvnaID string, cvepID stri
 			return nil, ClientVpnNetworkAssociationStatusUnknown, err
 		}
 
-		if result == nil || len(result.ClientVpnTargetNetworks) == 0 || result.ClientVpnTargetNetworks[0] == nil {
+		if network == nil {
 			return nil, ClientVpnNetworkAssociationStatusNotFound, nil
 		}
 
-		network := result.ClientVpnTargetNetworks[0]
 		if network.Status == nil || network.Status.Code == nil {
 			return network, ClientVpnNetworkAssociationStatusUnknown, nil
 		}
@@ -204,3 +201,610 @@ func SecurityGroupStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
 		return group, SecurityGroupStatusCreated, nil
 	}
 }
+
+// TransitGatewayState fetches the transit gateway and its State.
+// A deleted transit gateway is reported as not found.
+func TransitGatewayState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		transitGateway, err := finder.TransitGatewayByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidTransitGatewayIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if transitGateway == nil || aws.StringValue(transitGateway.State) == ec2.TransitGatewayStateDeleted {
+			return nil, "", nil
+		}
+
+		return transitGateway, aws.StringValue(transitGateway.State), nil
+	}
+}
+
+// TransitGatewayRouteTableState fetches the transit gateway route table and its State.
+// A deleted route table is reported as not found.
+func TransitGatewayRouteTableState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		routeTable, err := finder.TransitGatewayRouteTableByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidRouteTableIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if routeTable == nil || aws.StringValue(routeTable.State) == ec2.TransitGatewayRouteTableStateDeleted {
+			return nil, "", nil
+		}
+
+		return routeTable, aws.StringValue(routeTable.State), nil
+	}
+}
+
+// TransitGatewayRouteTableAssociationState fetches the transit gateway route table association and its State.
+// A disassociated attachment is reported as not found.
+func TransitGatewayRouteTableAssociationState(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		association, err := finder.TransitGatewayRouteTableAssociation(conn, transitGatewayRouteTableID, transitGatewayAttachmentID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidRouteTableIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if association == nil || aws.StringValue(association.State) == ec2.TransitGatewayAssociationStateDisassociated {
+			return nil, "", nil
+		}
+
+		return association, aws.StringValue(association.State), nil
+	}
+}
+
+// TransitGatewayRouteTablePropagationState fetches the transit gateway route table propagation and its State.
+// A disabled propagation is reported as not found.
+func TransitGatewayRouteTablePropagationState(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		propagation, err := finder.TransitGatewayRouteTablePropagation(conn, transitGatewayRouteTableID, transitGatewayAttachmentID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidRouteTableIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if propagation == nil || aws.StringValue(propagation.State) == ec2.TransitGatewayPropagationStateDisabled {
+			return nil, "", nil
+		}
+
+		return propagation, aws.StringValue(propagation.State), nil
+	}
+}
+
+// TransitGatewayVpcAttachmentState fetches the transit gateway VPC attachment and its State.
+// A deleted attachment is reported as not found.
+func TransitGatewayVpcAttachmentState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		attachment, err := finder.TransitGatewayVpcAttachmentByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidTransitGatewayAttachmentIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if attachment == nil || aws.StringValue(attachment.State) == ec2.TransitGatewayAttachmentStateDeleted {
+			return nil, "", nil
+		}
+
+		return attachment, aws.StringValue(attachment.State), nil
+	}
+}
+
+// VpcCidrBlockAssociationState fetches the VPC IPv4 CIDR block association and its State.
+// A disassociated CIDR block is reported as not found.
+func VpcCidrBlockAssociationState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		association, _, err := finder.VpcCidrBlockAssociationByID(conn, id)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if association == nil || association.CidrBlockState == nil || aws.StringValue(association.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeDisassociated {
+			return nil, "", nil
+		}
+
+		return association, aws.StringValue(association.CidrBlockState.State), nil
+	}
+}
+
+// ManagedPrefixListState fetches the managed prefix list and its State.
+// A deleted prefix list is reported as not found, and a failed operation
+// as an error with the state message.
+func ManagedPrefixListState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		prefixList, err := finder.ManagedPrefixListByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidPrefixListIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if prefixList == nil || aws.StringValue(prefixList.State) == ec2.PrefixListStateDeleteComplete {
+			return nil, "", nil
+		}
+
+		switch state := aws.StringValue(prefixList.State); state {
+		case ec2.PrefixListStateCreateFailed, ec2.PrefixListStateModifyFailed, ec2.PrefixListStateRestoreFailed, ec2.PrefixListStateDeleteFailed:
+			return prefixList, state, fmt.Errorf("%s: %s", state, aws.StringValue(prefixList.StateMessage))
+		}
+
+		return prefixList, aws.StringValue(prefixList.State), nil
+	}
+}
+
+// NetworkInterfaceAttachmentStatus fetches the network interface attachment and its Status.
+// A detached network interface is reported as not found.
+func NetworkInterfaceAttachmentStatus(conn *ec2.EC2, attachmentID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		eni, err := finder.NetworkInterfaceByAttachmentID(conn, attachmentID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if eni == nil || eni.Attachment == nil || aws.StringValue(eni.Attachment.Status) == ec2.AttachmentStatusDetached {
+			return nil, "", nil
+		}
+
+		return eni.Attachment, aws.StringValue(eni.Attachment.Status), nil
+	}
+}
+
+// VpcState fetches the VPC and its State.
+func VpcState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		vpc, err := finder.VpcByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidVpcIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if vpc == nil {
+			return nil, "", nil
+		}
+
+		return vpc, aws.StringValue(vpc.State), nil
+	}
+}
+
+// VpcIpv6CidrBlockAssociationState fetches the VPC IPv6 CIDR block association and its State.
+func VpcIpv6CidrBlockAssociationState(conn *ec2.EC2, vpcID, associationID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		association, err := finder.VpcIpv6CidrBlockAssociationByID(conn, vpcID, associationID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidVpcIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if association == nil || association.Ipv6CidrBlockState == nil {
+			return nil, "", nil
+		}
+
+		return association, aws.StringValue(association.Ipv6CidrBlockState.State), nil
+	}
+}
+
+// SubnetState fetches the subnet and its State.
+func SubnetState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		subnet, err := finder.SubnetByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidSubnetIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if subnet == nil {
+			return nil, "", nil
+		}
+
+		return subnet, aws.StringValue(subnet.State), nil
+	}
+}
+
+// SubnetIpv6CidrBlockAssociationState fetches the subnet IPv6 CIDR block association and its State.
+func SubnetIpv6CidrBlockAssociationState(conn *ec2.EC2, subnetID, associationID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		association, err := finder.SubnetIpv6CidrBlockAssociationByID(conn, subnetID, associationID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidSubnetIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if association == nil || association.Ipv6CidrBlockState == nil {
+			return nil, "", nil
+		}
+
+		return association, aws.StringValue(association.Ipv6CidrBlockState.State), nil
+	}
+}
+
+const (
+	RouteTableStatusReady = "ready"
+)
+
+// RouteTableStatus fetches the route table and its status.
+// Route tables have no state of their own and are ready once described.
+func RouteTableStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		routeTable, err := finder.RouteTableByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidRouteTableIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if routeTable == nil {
+			return nil, "", nil
+		}
+
+		return routeTable, RouteTableStatusReady, nil
+	}
+}
+
+// RouteTableAssociationState fetches the route table association and its State.
+// An association without a state, e.g. the main one of older route tables, is reported as associated.
+func RouteTableAssociationState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		association, err := finder.RouteTableAssociationByID(conn, id)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if association == nil {
+			return nil, "", nil
+		}
+
+		if association.AssociationState == nil {
+			return association, ec2.RouteTableAssociationStateCodeAssociated, nil
+		}
+
+		return association, aws.StringValue(association.AssociationState.State), nil
+	}
+}
+
+// NetworkInterfaceStatus fetches the network interface and its Status.
+func NetworkInterfaceStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		eni, err := finder.NetworkInterfaceByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidNetworkInterfaceIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if eni == nil {
+			return nil, "", nil
+		}
+
+		return eni, aws.StringValue(eni.Status), nil
+	}
+}
+
+const (
+	// The state of an attached internet gateway, which differs from the
+	// attachment states of the EC2 API model
+	InternetGatewayAttachmentStateAvailable = "available"
+)
+
+// InternetGatewayAttachmentState fetches the internet gateway attachment to a VPC and its State.
+// A gateway not attached to the VPC is reported as detached.
+func InternetGatewayAttachmentState(conn *ec2.EC2, internetGatewayID, vpcID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		internetGateway, err := finder.InternetGatewayByID(conn, internetGatewayID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidInternetGatewayIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if internetGateway == nil {
+			return nil, "", nil
+		}
+
+		for _, attachment := range internetGateway.Attachments {
+			if aws.StringValue(attachment.VpcId) == vpcID {
+				return attachment, aws.StringValue(attachment.State), nil
+			}
+		}
+
+		attachment := &ec2.InternetGatewayAttachment{
+			State: aws.String(ec2.AttachmentStatusDetached),
+			VpcId: aws.String(vpcID),
+		}
+
+		return attachment, ec2.AttachmentStatusDetached, nil
+	}
+}
+
+// NatGatewayState fetches the NAT gateway and its State.
+func NatGatewayState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		natGateway, err := finder.NatGatewayByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeNatGatewayNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if natGateway == nil {
+			return nil, "", nil
+		}
+
+		return natGateway, aws.StringValue(natGateway.State), nil
+	}
+}
+
+const (
+	EipStatusAvailable = "available"
+)
+
+// EipStatus fetches the Elastic IP address and its status.
+// Addresses have no state of their own and are available once described.
+func EipStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		address, err := finder.EipByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidAllocationIDNotFound) ||
+			tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidAddressNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if address == nil {
+			return nil, "", nil
+		}
+
+		return address, EipStatusAvailable, nil
+	}
+}
+
+// RouteState fetches the route of a route table with a destination and its State.
+func RouteState(conn *ec2.EC2, routeTableID, destination string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		route, err := finder.Route(conn, routeTableID, destination)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidRouteTableIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if route == nil {
+			return nil, "", nil
+		}
+
+		return route, aws.StringValue(route.State), nil
+	}
+}
+
+const (
+	// The EC2 API returns VPC endpoint states in lower camel case, unlike
+	// the values of the ec2.State enum
+	VpcEndpointStateAvailable         = "available"
+	VpcEndpointStateDeleted           = "deleted"
+	VpcEndpointStateDeleting          = "deleting"
+	VpcEndpointStatePending           = "pending"
+	VpcEndpointStatePendingAcceptance = "pendingAcceptance"
+	VpcEndpointStateRejected          = "rejected"
+)
+
+// VpcEndpointState fetches the VPC endpoint and its State.
+// An endpoint that is not found is reported as deleted.
+func VpcEndpointState(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		deleted := &ec2.VpcEndpoint{
+			State:         aws.String(VpcEndpointStateDeleted),
+			VpcEndpointId: aws.String(id),
+		}
+
+		vpcEndpoint, err := finder.VpcEndpointByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidVpcEndpointIdNotFound) {
+			return deleted, VpcEndpointStateDeleted, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if vpcEndpoint == nil {
+			return deleted, VpcEndpointStateDeleted, nil
+		}
+
+		return vpcEndpoint, aws.StringValue(vpcEndpoint.State), nil
+	}
+}
+
+// VpcPeeringConnectionStatus fetches the VPC peering connection and its Status.
+func VpcPeeringConnectionStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		vpcPeeringConnection, err := finder.VpcPeeringConnectionByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidVpcPeeringConnectionIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if vpcPeeringConnection == nil {
+			return nil, "", nil
+		}
+
+		if vpcPeeringConnection.Status == nil {
+			return vpcPeeringConnection, "", nil
+		}
+
+		return vpcPeeringConnection, aws.StringValue(vpcPeeringConnection.Status.Code), nil
+	}
+}
+
+const (
+	FlowLogStatusActive = "ACTIVE"
+)
+
+// FlowLogStatus fetches the flow log and its Status.
+func FlowLogStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		flowLog, err := finder.FlowLogByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidFlowLogIdNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if flowLog == nil {
+			return nil, "", nil
+		}
+
+		return flowLog, aws.StringValue(flowLog.FlowLogStatus), nil
+	}
+}
+
+const (
+	NetworkAclAssociationStatusAssociated = "associated"
+)
+
+// NetworkAclAssociationStatus fetches the network ACL association and its status.
+// Network ACL associations have no state of their own and are associated once described.
+func NetworkAclAssociationStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		association, _, err := finder.NetworkAclAssociationByID(conn, id)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if association == nil {
+			return nil, "", nil
+		}
+
+		return association, NetworkAclAssociationStatusAssociated, nil
+	}
+}
+
+const (
+	NetworkAclEntryStatusCreated = "created"
+)
+
+// NetworkAclEntryStatus fetches the network ACL entry with a rule number and direction and its status.
+// Network ACL entries have no state of their own and are created once described.
+func NetworkAclEntryStatus(conn *ec2.EC2, networkAclID string, ruleNumber int, egress bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		entry, err := finder.NetworkAclEntry(conn, networkAclID, ruleNumber, egress)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidNetworkAclIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if entry == nil {
+			return nil, "", nil
+		}
+
+		return entry, NetworkAclEntryStatusCreated, nil
+	}
+}
+
+// NetworkInterfaceSecurityGroupAttachmentStatus fetches the security group of a network interface and its attachment status.
+// A security group that is not attached to the network interface is reported as not found.
+func NetworkInterfaceSecurityGroupAttachmentStatus(conn *ec2.EC2, networkInterfaceID, securityGroupID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		networkInterface, err := finder.NetworkInterfaceByID(conn, networkInterfaceID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidNetworkInterfaceIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if networkInterface == nil {
+			return nil, "", nil
+		}
+
+		for _, group := range networkInterface.Groups {
+			if aws.StringValue(group.GroupId) == securityGroupID {
+				return group, ec2.AttachmentStatusAttached, nil
+			}
+		}
+
+		return nil, "", nil
+	}
+}
+
+const (
+	VpcDhcpOptionsStatusCreated = "created"
+)
+
+// VpcDhcpOptionsStatus fetches the DHCP options set and its status.
+// DHCP options sets have no state of their own and are created once described.
+func VpcDhcpOptionsStatus(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		dhcpOptions, err := finder.VpcDhcpOptionsByID(conn, id)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidDhcpOptionIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if dhcpOptions == nil {
+			return nil, "", nil
+		}
+
+		return dhcpOptions, VpcDhcpOptionsStatusCreated, nil
+	}
+}
+
+const (
+	VpcDhcpOptionsAssociationStatusAssociated = "associated"
+
+	// The VPC still uses another DHCP options set
+	VpcDhcpOptionsAssociationStatusDisassociated = "disassociated"
+)
+
+// VpcDhcpOptionsAssociationStatus fetches the VPC and the status of its association with a DHCP options set.
+func VpcDhcpOptionsAssociationStatus(conn *ec2.EC2, vpcID, dhcpOptionsID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		vpc, err := finder.VpcByID(conn, vpcID)
+		if tfec2.ErrCodeEquals(err, tfec2.ErrCodeInvalidVpcIDNotFound) {
+			return nil, "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if vpc == nil {
+			return nil, "", nil
+		}
+
+		if aws.StringValue(vpc.DhcpOptionsId) != dhcpOptionsID {
+			return vpc, VpcDhcpOptionsAssociationStatusDisassociated, nil
+		}
+
+		return vpc, VpcDhcpOptionsAssociationStatusAssociated, nil
+	}
+}
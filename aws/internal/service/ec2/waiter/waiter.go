@@ -1,10 +1,12 @@
 package waiter
 
 import (
+	"errors"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 )
 
 const (
@@ -55,12 +57,12 @@ const (
 	ClientVpnEndpointDeletedTimout = 5 * time.Minute
 )
 
-func ClientVpnEndpointDeleted(conn *ec2.EC2, id string) (*ec2.ClientVpnEndpoint, error) {
+func ClientVpnEndpointDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.ClientVpnEndpoint, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.ClientVpnEndpointStatusCodeDeleting},
 		Target:  []string{},
 		Refresh: ClientVpnEndpointStatus(conn, id),
-		Timeout: ClientVpnEndpointDeletedTimout,
+		Timeout: timeout,
 	}
 
 	outputRaw, err := stateConf.WaitForState()
@@ -78,12 +80,12 @@ const (
 	ClientVpnAuthorizationRuleRevokedTimeout = 1 * time.Minute
 )
 
-func ClientVpnAuthorizationRuleAuthorized(conn *ec2.EC2, authorizationRuleID string) (*ec2.AuthorizationRule, error) {
+func ClientVpnAuthorizationRuleAuthorized(conn *ec2.EC2, authorizationRuleID string, timeout time.Duration) (*ec2.AuthorizationRule, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.ClientVpnAuthorizationRuleStatusCodeAuthorizing},
 		Target:  []string{ec2.ClientVpnAuthorizationRuleStatusCodeActive},
 		Refresh: ClientVpnAuthorizationRuleStatus(conn, authorizationRuleID),
-		Timeout: ClientVpnAuthorizationRuleActiveTimeout,
+		Timeout: timeout,
 	}
 
 	outputRaw, err := stateConf.WaitForState()
@@ -95,12 +97,12 @@ func ClientVpnAuthorizationRuleAuthorized(conn *ec2.EC2, authorizationRuleID str
 	return nil, err
 }
 
-func ClientVpnAuthorizationRuleRevoked(conn *ec2.EC2, authorizationRuleID string) (*ec2.AuthorizationRule, error) {
+func ClientVpnAuthorizationRuleRevoked(conn *ec2.EC2, authorizationRuleID string, timeout time.Duration) (*ec2.AuthorizationRule, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.ClientVpnAuthorizationRuleStatusCodeRevoking},
 		Target:  []string{},
 		Refresh: ClientVpnAuthorizationRuleStatus(conn, authorizationRuleID),
-		Timeout: ClientVpnAuthorizationRuleRevokedTimeout,
+		Timeout: timeout,
 	}
 
 	outputRaw, err := stateConf.WaitForState()
@@ -115,21 +117,25 @@ func ClientVpnAuthorizationRuleRevoked(conn *ec2.EC2, authorizationRuleID string
 const (
 	ClientVpnNetworkAssociationAssociatedTimeout = 10 * time.Minute
 
-	ClientVpnNetworkAssociationAssociatedDelay = 4 * time.Minute
-
 	ClientVpnNetworkAssociationDisassociatedTimeout = 10 * time.Minute
+)
+
+// The association delays and poll interval are variables so that tests
+// against the in-memory AWS stand-in do not wait minutes.
+var (
+	ClientVpnNetworkAssociationAssociatedDelay = 4 * time.Minute
 
 	ClientVpnNetworkAssociationDisassociatedDelay = 4 * time.Minute
 
 	ClientVpnNetworkAssociationStatusPollInterval = 10 * time.Second
 )
 
-func ClientVpnNetworkAssociationAssociated(conn *ec2.EC2, networkAssociationID, clientVpnEndpointID string) (*ec2.TargetNetwork, error) {
+func ClientVpnNetworkAssociationAssociated(conn *ec2.EC2, networkAssociationID, clientVpnEndpointID string, timeout time.Duration) (*ec2.TargetNetwork, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending:      []string{ec2.AssociationStatusCodeAssociating},
 		Target:       []string{ec2.AssociationStatusCodeAssociated},
 		Refresh:      ClientVpnNetworkAssociationStatus(conn, networkAssociationID, clientVpnEndpointID),
-		Timeout:      ClientVpnNetworkAssociationAssociatedTimeout,
+		Timeout:      timeout,
 		Delay:        ClientVpnNetworkAssociationAssociatedDelay,
 		PollInterval: ClientVpnNetworkAssociationStatusPollInterval,
 	}
@@ -143,12 +149,12 @@ func ClientVpnNetworkAssociationAssociated(conn *ec2.EC2, networkAssociationID,
 	return nil, err
 }
 
-func ClientVpnNetworkAssociationDisassociated(conn *ec2.EC2, networkAssociationID, clientVpnEndpointID string) (*ec2.TargetNetwork, error) {
+func ClientVpnNetworkAssociationDisassociated(conn *ec2.EC2, networkAssociationID, clientVpnEndpointID string, timeout time.Duration) (*ec2.TargetNetwork, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending:      []string{ec2.AssociationStatusCodeDisassociating},
 		Target:       []string{},
 		Refresh:      ClientVpnNetworkAssociationStatus(conn, networkAssociationID, clientVpnEndpointID),
-		Timeout:      ClientVpnNetworkAssociationDisassociatedTimeout,
+		Timeout:      timeout,
 		Delay:        ClientVpnNetworkAssociationDisassociatedDelay,
 		PollInterval: ClientVpnNetworkAssociationStatusPollInterval,
 	}
@@ -163,15 +169,34 @@ func ClientVpnNetworkAssociationDisassociated(conn *ec2.EC2, networkAssociationI
 }
 
 const (
+	ClientVpnRouteActiveTimeout = 1 * time.Minute
+
 	ClientVpnRouteDeletedTimeout = 1 * time.Minute
 )
 
-func ClientVpnRouteDeleted(conn *ec2.EC2, routeID string) (*ec2.ClientVpnRoute, error) {
+func ClientVpnRouteActive(conn *ec2.EC2, routeID string, timeout time.Duration) (*ec2.ClientVpnRoute, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.ClientVpnRouteStatusCodeCreating},
+		Target:  []string{ec2.ClientVpnRouteStatusCodeActive},
+		Refresh: ClientVpnRouteStatus(conn, routeID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.ClientVpnRoute); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func ClientVpnRouteDeleted(conn *ec2.EC2, routeID string, timeout time.Duration) (*ec2.ClientVpnRoute, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.ClientVpnRouteStatusCodeActive, ec2.ClientVpnRouteStatusCodeDeleting},
 		Target:  []string{},
 		Refresh: ClientVpnRouteStatus(conn, routeID),
-		Timeout: ClientVpnRouteDeletedTimeout,
+		Timeout: timeout,
 	}
 
 	outputRaw, err := stateConf.WaitForState()
@@ -183,6 +208,115 @@ func ClientVpnRouteDeleted(conn *ec2.EC2, routeID string) (*ec2.ClientVpnRoute,
 	return nil, err
 }
 
+func ManagedPrefixListCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.ManagedPrefixList, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.PrefixListStateCreateInProgress},
+		Target:  []string{ec2.PrefixListStateCreateComplete},
+		Refresh: ManagedPrefixListState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.ManagedPrefixList); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func ManagedPrefixListModified(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.ManagedPrefixList, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.PrefixListStateModifyInProgress},
+		Target:  []string{ec2.PrefixListStateModifyComplete},
+		Refresh: ManagedPrefixListState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.ManagedPrefixList); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func ManagedPrefixListDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.ManagedPrefixList, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.PrefixListStateDeleteInProgress},
+		Target:  []string{},
+		Refresh: ManagedPrefixListState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.ManagedPrefixList); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkInterfaceAttached(conn *ec2.EC2, attachmentID string, timeout time.Duration) (*ec2.NetworkInterfaceAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.AttachmentStatusAttaching},
+		Target:  []string{ec2.AttachmentStatusAttached},
+		Refresh: NetworkInterfaceAttachmentStatus(conn, attachmentID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkInterfaceAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkInterfaceDetached(conn *ec2.EC2, attachmentID string, timeout time.Duration) (*ec2.NetworkInterfaceAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.AttachmentStatusAttaching, ec2.AttachmentStatusAttached, ec2.AttachmentStatusDetaching},
+		Target:  []string{},
+		Refresh: NetworkInterfaceAttachmentStatus(conn, attachmentID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkInterfaceAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// NetworkInterfaceAvailableAfterUse waits for a network interface that was in
+// use by an AWS service, e.g. a Lambda function's Hyperplane ENI, to become
+// available. The status is eventually consistent for up to 3 minutes.
+func NetworkInterfaceAvailableAfterUse(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.NetworkInterface, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:                   []string{ec2.NetworkInterfaceStatusInUse},
+		Target:                    []string{ec2.NetworkInterfaceStatusAvailable},
+		Refresh:                   NetworkInterfaceStatus(conn, id),
+		Timeout:                   timeout,
+		Delay:                     10 * time.Second,
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 18,
+		NotFoundChecks:            1,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkInterface); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
 func SecurityGroupCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.SecurityGroup, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{SecurityGroupStatusNotFound},
@@ -199,3 +333,868 @@ func SecurityGroupCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2
 
 	return nil, err
 }
+
+func TransitGatewayAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGateway, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayStatePending, ec2.TransitGatewayStateModifying},
+		Target:  []string{ec2.TransitGatewayStateAvailable},
+		Refresh: TransitGatewayState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGateway); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGateway, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayStateAvailable, ec2.TransitGatewayStateDeleting, ec2.TransitGatewayStateModifying},
+		Target:  []string{},
+		Refresh: TransitGatewayState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGateway); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+const (
+	TransitGatewayRouteTableCreatedTimeout = 10 * time.Minute
+
+	TransitGatewayRouteTableDeletedTimeout = 10 * time.Minute
+)
+
+func TransitGatewayRouteTableCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGatewayRouteTable, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayRouteTableStatePending},
+		Target:  []string{ec2.TransitGatewayRouteTableStateAvailable},
+		Refresh: TransitGatewayRouteTableState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayRouteTable); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayRouteTableDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGatewayRouteTable, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayRouteTableStateAvailable, ec2.TransitGatewayRouteTableStateDeleting},
+		Target:  []string{},
+		Refresh: TransitGatewayRouteTableState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayRouteTable); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+const (
+	TransitGatewayRouteTableAssociationCreatedTimeout = 5 * time.Minute
+
+	TransitGatewayRouteTableAssociationDeletedTimeout = 5 * time.Minute
+)
+
+func TransitGatewayRouteTableAssociationCreated(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string, timeout time.Duration) (*ec2.TransitGatewayRouteTableAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayAssociationStateAssociating},
+		Target:  []string{ec2.TransitGatewayAssociationStateAssociated},
+		Refresh: TransitGatewayRouteTableAssociationState(conn, transitGatewayRouteTableID, transitGatewayAttachmentID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayRouteTableAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayRouteTableAssociationDeleted(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string, timeout time.Duration) (*ec2.TransitGatewayRouteTableAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayAssociationStateAssociated, ec2.TransitGatewayAssociationStateDisassociating},
+		Target:  []string{},
+		Refresh: TransitGatewayRouteTableAssociationState(conn, transitGatewayRouteTableID, transitGatewayAttachmentID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayRouteTableAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+const (
+	TransitGatewayRouteTablePropagationEnabledTimeout = 5 * time.Minute
+
+	TransitGatewayRouteTablePropagationDisabledTimeout = 5 * time.Minute
+)
+
+func TransitGatewayRouteTablePropagationEnabled(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string, timeout time.Duration) (*ec2.TransitGatewayRouteTablePropagation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayPropagationStateEnabling},
+		Target:  []string{ec2.TransitGatewayPropagationStateEnabled},
+		Refresh: TransitGatewayRouteTablePropagationState(conn, transitGatewayRouteTableID, transitGatewayAttachmentID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayRouteTablePropagation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayRouteTablePropagationDisabled(conn *ec2.EC2, transitGatewayRouteTableID, transitGatewayAttachmentID string, timeout time.Duration) (*ec2.TransitGatewayRouteTablePropagation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayPropagationStateEnabled, ec2.TransitGatewayPropagationStateDisabling},
+		Target:  []string{},
+		Refresh: TransitGatewayRouteTablePropagationState(conn, transitGatewayRouteTableID, transitGatewayAttachmentID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayRouteTablePropagation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// TransitGatewayVpcAttachmentCreated waits for a new transit gateway VPC attachment to become available,
+// or to be pending acceptance by the owner of a shared transit gateway.
+func TransitGatewayVpcAttachmentCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGatewayVpcAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayAttachmentStateInitiating, ec2.TransitGatewayAttachmentStatePending},
+		Target:  []string{ec2.TransitGatewayAttachmentStateAvailable, ec2.TransitGatewayAttachmentStatePendingAcceptance},
+		Refresh: TransitGatewayVpcAttachmentState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayVpcAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayVpcAttachmentAccepted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGatewayVpcAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayAttachmentStatePending, ec2.TransitGatewayAttachmentStatePendingAcceptance},
+		Target:  []string{ec2.TransitGatewayAttachmentStateAvailable},
+		Refresh: TransitGatewayVpcAttachmentState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayVpcAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayVpcAttachmentModified(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGatewayVpcAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.TransitGatewayAttachmentStateModifying},
+		Target:  []string{ec2.TransitGatewayAttachmentStateAvailable},
+		Refresh: TransitGatewayVpcAttachmentState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayVpcAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func TransitGatewayVpcAttachmentDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.TransitGatewayVpcAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			ec2.TransitGatewayAttachmentStateAvailable,
+			ec2.TransitGatewayAttachmentStateDeleting,
+			ec2.TransitGatewayAttachmentStatePendingAcceptance,
+			ec2.TransitGatewayAttachmentStateRejecting,
+		},
+		Target:  []string{},
+		Refresh: TransitGatewayVpcAttachmentState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.TransitGatewayVpcAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcCidrBlockAssociationCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcCidrBlockAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.VpcCidrBlockStateCodeAssociating},
+		Target:  []string{ec2.VpcCidrBlockStateCodeAssociated},
+		Refresh: VpcCidrBlockAssociationState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcCidrBlockAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcCidrBlockAssociationDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcCidrBlockAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.VpcCidrBlockStateCodeAssociated, ec2.VpcCidrBlockStateCodeDisassociating},
+		Target:  []string{},
+		Refresh: VpcCidrBlockAssociationState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcCidrBlockAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.Vpc, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.VpcStatePending},
+		Target:  []string{ec2.VpcStateAvailable},
+		Refresh: VpcState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.Vpc); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcIpv6CidrBlockAssociationCreated(conn *ec2.EC2, vpcID, associationID string, timeout time.Duration) (*ec2.VpcIpv6CidrBlockAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.VpcCidrBlockStateCodeAssociating, ec2.VpcCidrBlockStateCodeDisassociated},
+		Target:  []string{ec2.VpcCidrBlockStateCodeAssociated},
+		Refresh: VpcIpv6CidrBlockAssociationState(conn, vpcID, associationID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcIpv6CidrBlockAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcIpv6CidrBlockAssociationDeleted(conn *ec2.EC2, vpcID, associationID string, timeout time.Duration) (*ec2.VpcIpv6CidrBlockAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:        []string{ec2.VpcCidrBlockStateCodeAssociated, ec2.VpcCidrBlockStateCodeDisassociating},
+		Target:         []string{ec2.VpcCidrBlockStateCodeDisassociated},
+		Refresh:        VpcIpv6CidrBlockAssociationState(conn, vpcID, associationID),
+		Timeout:        timeout,
+		NotFoundChecks: 1,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcIpv6CidrBlockAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func SubnetAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.Subnet, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.SubnetStatePending},
+		Target:  []string{ec2.SubnetStateAvailable},
+		Refresh: SubnetState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.Subnet); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func SubnetIpv6CidrBlockAssociationCreated(conn *ec2.EC2, subnetID, associationID string, timeout time.Duration) (*ec2.SubnetIpv6CidrBlockAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.SubnetCidrBlockStateCodeAssociating, ec2.SubnetCidrBlockStateCodeDisassociated},
+		Target:  []string{ec2.SubnetCidrBlockStateCodeAssociated},
+		Refresh: SubnetIpv6CidrBlockAssociationState(conn, subnetID, associationID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.SubnetIpv6CidrBlockAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func SubnetIpv6CidrBlockAssociationDeleted(conn *ec2.EC2, subnetID, associationID string, timeout time.Duration) (*ec2.SubnetIpv6CidrBlockAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.SubnetCidrBlockStateCodeAssociated, ec2.SubnetCidrBlockStateCodeDisassociating},
+		Target:  []string{ec2.SubnetCidrBlockStateCodeDisassociated},
+		Refresh: SubnetIpv6CidrBlockAssociationState(conn, subnetID, associationID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.SubnetIpv6CidrBlockAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+const (
+	// Number of consecutive times a new route table must not be found before
+	// giving up, to ride out the eventual consistency of DescribeRouteTables
+	RouteTableNotFoundChecks = 40
+)
+
+func RouteTableReady(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.RouteTable, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:        []string{},
+		Target:         []string{RouteTableStatusReady},
+		Refresh:        RouteTableStatus(conn, id),
+		Timeout:        timeout,
+		NotFoundChecks: RouteTableNotFoundChecks,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.RouteTable); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func RouteTableDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.RouteTable, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{RouteTableStatusReady},
+		Target:  []string{},
+		Refresh: RouteTableStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.RouteTable); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func RouteTableAssociationAssociated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.RouteTableAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.RouteTableAssociationStateCodeAssociating},
+		Target:  []string{ec2.RouteTableAssociationStateCodeAssociated},
+		Refresh: RouteTableAssociationState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.RouteTableAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func RouteTableAssociationDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.RouteTableAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.RouteTableAssociationStateCodeDisassociating},
+		Target:  []string{},
+		Refresh: RouteTableAssociationState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.RouteTableAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func InternetGatewayAttached(conn *ec2.EC2, internetGatewayID, vpcID string, timeout time.Duration) (*ec2.InternetGatewayAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.AttachmentStatusDetached, ec2.AttachmentStatusAttaching},
+		Target:  []string{InternetGatewayAttachmentStateAvailable},
+		Refresh: InternetGatewayAttachmentState(conn, internetGatewayID, vpcID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.InternetGatewayAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NatGatewayAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.NatGateway, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.NatGatewayStatePending},
+		Target:  []string{ec2.NatGatewayStateAvailable},
+		Refresh: NatGatewayState(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NatGateway); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NatGatewayDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.NatGateway, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{ec2.NatGatewayStateDeleting},
+		Target:     []string{ec2.NatGatewayStateDeleted},
+		Refresh:    NatGatewayState(conn, id),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NatGateway); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+const (
+	// Number of consecutive times a new Elastic IP address must not be found
+	// before giving up, to ride out the eventual consistency of DescribeAddresses
+	EipNotFoundChecks = 20
+)
+
+func EipAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.Address, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:        []string{},
+		Target:         []string{EipStatusAvailable},
+		Refresh:        EipStatus(conn, id),
+		Timeout:        timeout,
+		NotFoundChecks: EipNotFoundChecks,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.Address); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func EipDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.Address, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{EipStatusAvailable},
+		Target:  []string{},
+		Refresh: EipStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.Address); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func InternetGatewayDetached(conn *ec2.EC2, internetGatewayID, vpcID string, timeout time.Duration) (*ec2.InternetGatewayAttachment, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{InternetGatewayAttachmentStateAvailable, ec2.AttachmentStatusAttached, ec2.AttachmentStatusDetaching},
+		Target:  []string{ec2.AttachmentStatusDetached},
+		Refresh: InternetGatewayAttachmentState(conn, internetGatewayID, vpcID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.InternetGatewayAttachment); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func RouteDeleted(conn *ec2.EC2, routeTableID, destination string, timeout time.Duration) (*ec2.Route, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.RouteStateActive, ec2.RouteStateBlackhole},
+		Target:  []string{},
+		Refresh: RouteState(conn, routeTableID, destination),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.Route); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcEndpointAccepted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcEndpoint, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{VpcEndpointStatePendingAcceptance, VpcEndpointStatePending},
+		Target:     []string{VpcEndpointStateAvailable},
+		Refresh:    VpcEndpointState(conn, id),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcEndpoint); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcEndpointAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcEndpoint, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{VpcEndpointStatePending},
+		Target:     []string{VpcEndpointStateAvailable, VpcEndpointStatePendingAcceptance},
+		Refresh:    VpcEndpointState(conn, id),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcEndpoint); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcEndpointDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcEndpoint, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{VpcEndpointStateAvailable, VpcEndpointStatePending, VpcEndpointStatePendingAcceptance, VpcEndpointStateRejected, VpcEndpointStateDeleting},
+		Target:     []string{VpcEndpointStateDeleted},
+		Refresh:    VpcEndpointState(conn, id),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcEndpoint); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// VpcPeeringConnectionAvailable waits until a VPC peering connection is pending acceptance or active.
+// A connection that failed to be created is reported with the reason from AWS.
+func VpcPeeringConnectionAvailable(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcPeeringConnection, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			ec2.VpcPeeringConnectionStateReasonCodeInitiatingRequest,
+			ec2.VpcPeeringConnectionStateReasonCodeProvisioning,
+		},
+		Target: []string{
+			ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+			ec2.VpcPeeringConnectionStateReasonCodeActive,
+		},
+		Refresh: VpcPeeringConnectionStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcPeeringConnection); ok {
+		if status := output.Status; status != nil && aws.StringValue(status.Code) == ec2.VpcPeeringConnectionStateReasonCodeFailed {
+			return output, errors.New(aws.StringValue(status.Message))
+		}
+
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcPeeringConnectionDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.VpcPeeringConnection, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			ec2.VpcPeeringConnectionStateReasonCodeActive,
+			ec2.VpcPeeringConnectionStateReasonCodeDeleting,
+			ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+		},
+		Target: []string{
+			ec2.VpcPeeringConnectionStateReasonCodeDeleted,
+			ec2.VpcPeeringConnectionStateReasonCodeRejected,
+		},
+		Refresh: VpcPeeringConnectionStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.VpcPeeringConnection); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func FlowLogActive(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.FlowLog, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{},
+		Target:  []string{FlowLogStatusActive},
+		Refresh: FlowLogStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.FlowLog); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func FlowLogDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.FlowLog, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{FlowLogStatusActive},
+		Target:  []string{},
+		Refresh: FlowLogStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.FlowLog); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkAclAssociationAssociated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.NetworkAclAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{},
+		Target:  []string{NetworkAclAssociationStatusAssociated},
+		Refresh: NetworkAclAssociationStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkAclAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkAclAssociationDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.NetworkAclAssociation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{NetworkAclAssociationStatusAssociated},
+		Target:  []string{},
+		Refresh: NetworkAclAssociationStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkAclAssociation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkAclEntryCreated(conn *ec2.EC2, networkAclID string, ruleNumber int, egress bool, timeout time.Duration) (*ec2.NetworkAclEntry, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{},
+		Target:  []string{NetworkAclEntryStatusCreated},
+		Refresh: NetworkAclEntryStatus(conn, networkAclID, ruleNumber, egress),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkAclEntry); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkAclEntryDeleted(conn *ec2.EC2, networkAclID string, ruleNumber int, egress bool, timeout time.Duration) (*ec2.NetworkAclEntry, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{NetworkAclEntryStatusCreated},
+		Target:  []string{},
+		Refresh: NetworkAclEntryStatus(conn, networkAclID, ruleNumber, egress),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.NetworkAclEntry); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkInterfaceSecurityGroupAttached(conn *ec2.EC2, networkInterfaceID, securityGroupID string, timeout time.Duration) (*ec2.GroupIdentifier, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{},
+		Target:  []string{ec2.AttachmentStatusAttached},
+		Refresh: NetworkInterfaceSecurityGroupAttachmentStatus(conn, networkInterfaceID, securityGroupID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.GroupIdentifier); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func NetworkInterfaceSecurityGroupDetached(conn *ec2.EC2, networkInterfaceID, securityGroupID string, timeout time.Duration) (*ec2.GroupIdentifier, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.AttachmentStatusAttached},
+		Target:  []string{},
+		Refresh: NetworkInterfaceSecurityGroupAttachmentStatus(conn, networkInterfaceID, securityGroupID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.GroupIdentifier); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcDhcpOptionsCreated(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.DhcpOptions, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{},
+		Target:  []string{VpcDhcpOptionsStatusCreated},
+		Refresh: VpcDhcpOptionsStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.DhcpOptions); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcDhcpOptionsDeleted(conn *ec2.EC2, id string, timeout time.Duration) (*ec2.DhcpOptions, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{VpcDhcpOptionsStatusCreated},
+		Target:  []string{},
+		Refresh: VpcDhcpOptionsStatus(conn, id),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.DhcpOptions); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+func VpcDhcpOptionsAssociated(conn *ec2.EC2, vpcID, dhcpOptionsID string, timeout time.Duration) (*ec2.Vpc, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{VpcDhcpOptionsAssociationStatusDisassociated},
+		Target:  []string{VpcDhcpOptionsAssociationStatusAssociated},
+		Refresh: VpcDhcpOptionsAssociationStatus(conn, vpcID, dhcpOptionsID),
+		Timeout: timeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*ec2.Vpc); ok {
+		return output, err
+	}
+
+	return nil, err
+}
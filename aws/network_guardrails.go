@@ -0,0 +1,494 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+)
+
+// NetworkGuardrailsConfig contains the forbidden network rule combinations
+// that security group rules and network ACL entries are checked against
+// during plan.
+type NetworkGuardrailsConfig struct {
+	ForbiddenRules []*NetworkGuardrailRule
+
+	// Resources tagged with all of these tags are exempt from the guardrails.
+	AllowedTags keyvaluetags.KeyValueTags
+}
+
+// NetworkGuardrailRule is a single forbidden CIDR, port range and protocol combination.
+type NetworkGuardrailRule struct {
+	CidrBlocks []string
+	FromPort   int64
+	Protocol   int
+	ToPort     int64
+	Type       string
+}
+
+func (r *NetworkGuardrailRule) String() string {
+	return fmt.Sprintf("%s protocol %s, ports %d-%d, %s", r.Type, networkGuardrailProtocolName(r.Protocol), r.FromPort, r.ToPort, strings.Join(r.CidrBlocks, ", "))
+}
+
+func networkGuardrailsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"allowed_tags": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Resources tagged with all of these tags are exempt from the network guardrails.",
+				},
+				"forbidden_rule": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"cidr_blocks": {
+								Type:     schema.TypeSet,
+								Required: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateCIDRNetworkAddress,
+								},
+								Set: schema.HashString,
+							},
+							"from_port": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntBetween(-1, 65535),
+							},
+							"protocol": {
+								Type:     schema.TypeString,
+								Required: true,
+								ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+									if _, ok := networkGuardrailProtocolNumber(v.(string)); !ok {
+										errors = append(errors, fmt.Errorf("%q contains an invalid protocol: %q", k, v.(string)))
+									}
+									return
+								},
+							},
+							"to_port": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntBetween(-1, 65535),
+							},
+							"type": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "ingress",
+								ValidateFunc: validation.StringInSlice([]string{
+									"ingress",
+									"egress",
+								}, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandProviderNetworkGuardrails(l []interface{}) *NetworkGuardrailsConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	config := &NetworkGuardrailsConfig{}
+
+	if v, ok := m["allowed_tags"].(map[string]interface{}); ok && len(v) > 0 {
+		config.AllowedTags = keyvaluetags.New(v)
+	}
+
+	if v, ok := m["forbidden_rule"].([]interface{}); ok {
+		for _, ruleRaw := range v {
+			rule, ok := ruleRaw.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			protocol, _ := networkGuardrailProtocolNumber(rule["protocol"].(string))
+
+			config.ForbiddenRules = append(config.ForbiddenRules, &NetworkGuardrailRule{
+				CidrBlocks: aws.StringValueSlice(expandStringSet(rule["cidr_blocks"].(*schema.Set))),
+				FromPort:   int64(rule["from_port"].(int)),
+				Protocol:   protocol,
+				ToPort:     int64(rule["to_port"].(int)),
+				Type:       rule["type"].(string),
+			})
+		}
+	}
+
+	return config
+}
+
+// networkGuardrailProtocolNumber converts a protocol name or number into its
+// protocol number, with -1 representing all protocols.
+func networkGuardrailProtocolNumber(v string) (int, bool) {
+	protocol := strings.ToLower(v)
+
+	if protocol == "-1" || protocol == "all" {
+		return -1, true
+	}
+
+	if p, err := strconv.Atoi(protocol); err == nil {
+		return p, true
+	}
+
+	p, ok := protocolIntegers()[protocol]
+
+	return p, ok
+}
+
+func networkGuardrailProtocolName(p int) string {
+	if p == -1 {
+		return "all"
+	}
+
+	if v, ok := protocolStrings(protocolIntegers())[p]; ok {
+		return v
+	}
+
+	return strconv.Itoa(p)
+}
+
+// exempt returns whether the given resource tags carry every allow-list tag.
+func (c *NetworkGuardrailsConfig) exempt(tags keyvaluetags.KeyValueTags) bool {
+	if c == nil || len(c.AllowedTags) == 0 {
+		return false
+	}
+
+	return tags.ContainsAll(c.AllowedTags)
+}
+
+// match returns the first forbidden rule matched by the given rule attributes.
+// A port range of nil means all ports.
+func (c *NetworkGuardrailsConfig) match(ruleType string, protocol int, fromPort, toPort *int64, cidrBlock string) *NetworkGuardrailRule {
+	if c == nil || cidrBlock == "" {
+		return nil
+	}
+
+	_, ruleNet, err := net.ParseCIDR(cidrBlock)
+
+	if err != nil {
+		// Unknown or invalid values are caught elsewhere.
+		return nil
+	}
+
+	for _, forbidden := range c.ForbiddenRules {
+		if forbidden.Type != ruleType {
+			continue
+		}
+
+		if forbidden.Protocol != -1 && protocol != -1 && forbidden.Protocol != protocol {
+			continue
+		}
+
+		// All protocols implies all ports.
+		if protocol != -1 && fromPort != nil && toPort != nil {
+			if forbidden.FromPort != -1 && *toPort < forbidden.FromPort {
+				continue
+			}
+
+			if forbidden.ToPort != -1 && *fromPort > forbidden.ToPort {
+				continue
+			}
+		}
+
+		for _, forbiddenCidrBlock := range forbidden.CidrBlocks {
+			_, forbiddenNet, err := net.ParseCIDR(forbiddenCidrBlock)
+
+			if err != nil {
+				continue
+			}
+
+			// The rule is forbidden when it opens at least the forbidden range.
+			forbiddenPrefixLen, _ := forbiddenNet.Mask.Size()
+			rulePrefixLen, _ := ruleNet.Mask.Size()
+
+			if len(ruleNet.IP) == len(forbiddenNet.IP) && rulePrefixLen <= forbiddenPrefixLen && ruleNet.Contains(forbiddenNet.IP) {
+				return forbidden
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkIPPermissions returns an error identifying the first security group
+// permission that matches a forbidden rule.
+func (c *NetworkGuardrailsConfig) checkIPPermissions(resourceName, ruleType string, perms []*ec2.IpPermission) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, perm := range perms {
+		protocol, ok := networkGuardrailProtocolNumber(aws.StringValue(perm.IpProtocol))
+
+		if !ok {
+			continue
+		}
+
+		var cidrBlocks []string
+
+		for _, v := range perm.IpRanges {
+			cidrBlocks = append(cidrBlocks, aws.StringValue(v.CidrIp))
+		}
+
+		for _, v := range perm.Ipv6Ranges {
+			cidrBlocks = append(cidrBlocks, aws.StringValue(v.CidrIpv6))
+		}
+
+		for _, cidrBlock := range cidrBlocks {
+			if forbidden := c.match(ruleType, protocol, perm.FromPort, perm.ToPort, cidrBlock); forbidden != nil {
+				return fmt.Errorf("%s %s rule (protocol %s, ports %d-%d, %s) is forbidden by the provider network_guardrails configuration (%s)",
+					resourceName, ruleType, networkGuardrailProtocolName(protocol), aws.Int64Value(perm.FromPort), aws.Int64Value(perm.ToPort), cidrBlock, forbidden)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkNetworkAclEntries returns an error identifying the first network ACL
+// allow entry that matches a forbidden rule. Deny entries never violate.
+func (c *NetworkGuardrailsConfig) checkNetworkAclEntries(resourceName, entryType string, entries []*ec2.NetworkAclEntry) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if !strings.EqualFold(aws.StringValue(entry.RuleAction), ec2.RuleActionAllow) {
+			continue
+		}
+
+		protocol, ok := networkGuardrailProtocolNumber(aws.StringValue(entry.Protocol))
+
+		if !ok {
+			continue
+		}
+
+		var fromPort, toPort *int64
+
+		if entry.PortRange != nil {
+			fromPort = entry.PortRange.From
+			toPort = entry.PortRange.To
+		}
+
+		for _, cidrBlock := range []string{aws.StringValue(entry.CidrBlock), aws.StringValue(entry.Ipv6CidrBlock)} {
+			if forbidden := c.match(entryType, protocol, fromPort, toPort, cidrBlock); forbidden != nil {
+				return fmt.Errorf("%s %s entry %d (protocol %s, ports %d-%d, %s) is forbidden by the provider network_guardrails configuration (%s)",
+					resourceName, entryType, aws.Int64Value(entry.RuleNumber), networkGuardrailProtocolName(protocol), aws.Int64Value(fromPort), aws.Int64Value(toPort), cidrBlock, forbidden)
+			}
+		}
+	}
+
+	return nil
+}
+
+// networkGuardrailsSecurityGroupCustomizeDiff enforces the provider network
+// guardrails against the inline ingress and egress rules of a security group.
+func networkGuardrailsSecurityGroupCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*AWSClient)
+
+	if !ok || client.NetworkGuardrailsConfig == nil {
+		return nil
+	}
+
+	guardrails := client.NetworkGuardrailsConfig
+
+	if diff.NewValueKnown("tags") && guardrails.exempt(keyvaluetags.New(diff.Get("tags").(map[string]interface{}))) {
+		log.Printf("[DEBUG] Security Group (%s) exempt from network guardrails by tags", diff.Id())
+		return nil
+	}
+
+	// Only the VPC and identifiers are needed to expand the rules.
+	group := &ec2.SecurityGroup{
+		GroupId:   aws.String(diff.Id()),
+		GroupName: aws.String(diff.Get("name").(string)),
+		VpcId:     aws.String(diff.Get("vpc_id").(string)),
+	}
+
+	for _, ruleType := range []string{"ingress", "egress"} {
+		if !diff.NewValueKnown(ruleType) {
+			continue
+		}
+
+		perms, err := expandIPPerms(group, diff.Get(ruleType).(*schema.Set).List())
+
+		if err != nil {
+			return err
+		}
+
+		if err := guardrails.checkIPPermissions("Security Group", ruleType, perms); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkGuardrailsSecurityGroupRuleCustomizeDiff enforces the provider network
+// guardrails against a standalone security group rule. As rules carry no tags,
+// the allow-list tags are checked on the security group when it already exists.
+func networkGuardrailsSecurityGroupRuleCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*AWSClient)
+
+	if !ok || client.NetworkGuardrailsConfig == nil {
+		return nil
+	}
+
+	guardrails := client.NetworkGuardrailsConfig
+	protocol := protocolForValue(diff.Get("protocol").(string))
+	m := map[string]interface{}{
+		"cidr_blocks":      diff.Get("cidr_blocks"),
+		"from_port":        diff.Get("from_port"),
+		"ipv6_cidr_blocks": diff.Get("ipv6_cidr_blocks"),
+		"protocol":         protocol,
+		"to_port":          diff.Get("to_port"),
+	}
+
+	// Ports are ignored for all protocols, matching expandIPPerm.
+	if protocol == "-1" {
+		m["from_port"] = 0
+		m["to_port"] = 0
+	}
+
+	perms, err := expandIPPerms(&ec2.SecurityGroup{}, []interface{}{m})
+
+	if err != nil {
+		return err
+	}
+
+	err = guardrails.checkIPPermissions("Security Group Rule", diff.Get("type").(string), perms)
+
+	if err == nil || len(guardrails.AllowedTags) == 0 || !diff.NewValueKnown("security_group_id") {
+		return err
+	}
+
+	sg, findErr := findResourceSecurityGroup(client.ec2conn, diff.Get("security_group_id").(string))
+
+	if findErr != nil {
+		log.Printf("[WARN] Unable to read Security Group (%s) tags for network guardrails: %s", diff.Get("security_group_id").(string), findErr)
+		return err
+	}
+
+	if !guardrails.exempt(keyvaluetags.Ec2KeyValueTags(sg.Tags)) {
+		return err
+	}
+
+	log.Printf("[DEBUG] Security Group Rule (%s) exempt from network guardrails by Security Group tags", diff.Id())
+
+	return nil
+}
+
+// networkGuardrailsNetworkAclCustomizeDiff enforces the provider network
+// guardrails against the inline ingress and egress entries of a network ACL.
+func networkGuardrailsNetworkAclCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*AWSClient)
+
+	if !ok || client.NetworkGuardrailsConfig == nil {
+		return nil
+	}
+
+	guardrails := client.NetworkGuardrailsConfig
+
+	if diff.NewValueKnown("tags") && guardrails.exempt(keyvaluetags.New(diff.Get("tags").(map[string]interface{}))) {
+		log.Printf("[DEBUG] Network ACL (%s) exempt from network guardrails by tags", diff.Id())
+		return nil
+	}
+
+	for _, entryType := range []string{"ingress", "egress"} {
+		if !diff.NewValueKnown(entryType) {
+			continue
+		}
+
+		entries, err := expandNetworkAclEntries(diff.Get(entryType).(*schema.Set).List(), entryType)
+
+		if err != nil {
+			return err
+		}
+
+		if err := guardrails.checkNetworkAclEntries("Network ACL", entryType, entries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkGuardrailsNetworkAclRuleCustomizeDiff enforces the provider network
+// guardrails against a standalone network ACL entry. As entries carry no tags,
+// the allow-list tags are checked on the network ACL when it already exists.
+func networkGuardrailsNetworkAclRuleCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*AWSClient)
+
+	if !ok || client.NetworkGuardrailsConfig == nil {
+		return nil
+	}
+
+	if !diff.NewValueKnown("protocol") {
+		return nil
+	}
+
+	guardrails := client.NetworkGuardrailsConfig
+	entryType := "ingress"
+
+	if diff.Get("egress").(bool) {
+		entryType = "egress"
+	}
+
+	entries, err := expandNetworkAclEntries([]interface{}{
+		map[string]interface{}{
+			"action":          diff.Get("rule_action"),
+			"cidr_block":      diff.Get("cidr_block"),
+			"from_port":       diff.Get("from_port"),
+			"icmp_code":       diff.Get("icmp_code"),
+			"icmp_type":       diff.Get("icmp_type"),
+			"ipv6_cidr_block": diff.Get("ipv6_cidr_block"),
+			"protocol":        diff.Get("protocol"),
+			"rule_no":         diff.Get("rule_number"),
+			"to_port":         diff.Get("to_port"),
+		},
+	}, entryType)
+
+	if err != nil {
+		return err
+	}
+
+	err = guardrails.checkNetworkAclEntries("Network ACL Rule", entryType, entries)
+
+	if err == nil || len(guardrails.AllowedTags) == 0 || !diff.NewValueKnown("network_acl_id") {
+		return err
+	}
+
+	networkAcl, findErr := finder.NetworkAclByID(client.ec2conn, diff.Get("network_acl_id").(string))
+
+	if findErr != nil || networkAcl == nil {
+		log.Printf("[WARN] Unable to read Network ACL (%s) tags for network guardrails: %v", diff.Get("network_acl_id").(string), findErr)
+		return err
+	}
+
+	if !guardrails.exempt(keyvaluetags.Ec2KeyValueTags(networkAcl.Tags)) {
+		return err
+	}
+
+	log.Printf("[DEBUG] Network ACL Rule (%s) exempt from network guardrails by Network ACL tags", diff.Id())
+
+	return nil
+}
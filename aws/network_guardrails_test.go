@@ -0,0 +1,222 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func testNetworkGuardrailsConfig() *NetworkGuardrailsConfig {
+	return &NetworkGuardrailsConfig{
+		ForbiddenRules: []*NetworkGuardrailRule{
+			{
+				CidrBlocks: []string{"0.0.0.0/0", "::/0"},
+				FromPort:   22,
+				Protocol:   6,
+				ToPort:     22,
+				Type:       "ingress",
+			},
+			{
+				CidrBlocks: []string{"0.0.0.0/0"},
+				FromPort:   3306,
+				Protocol:   -1,
+				ToPort:     5432,
+				Type:       "ingress",
+			},
+		},
+		AllowedTags: keyvaluetags.New(map[string]string{"GuardrailException": "approved"}),
+	}
+}
+
+func TestNetworkGuardrailsConfigCheckIPPermissions(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		RuleType    string
+		Permission  *ec2.IpPermission
+		ExpectError bool
+	}{
+		{
+			Name:     "ssh from world",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(22),
+				ToPort:     aws.Int64(22),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			ExpectError: true,
+		},
+		{
+			Name:     "ssh from world IPv6",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("6"),
+				FromPort:   aws.Int64(22),
+				ToPort:     aws.Int64(22),
+				Ipv6Ranges: []*ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+			},
+			ExpectError: true,
+		},
+		{
+			Name:     "port range covering ssh",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(0),
+				ToPort:     aws.Int64(1024),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			ExpectError: true,
+		},
+		{
+			Name:     "all protocols from world",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			ExpectError: true,
+		},
+		{
+			Name:     "database port any protocol",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("udp"),
+				FromPort:   aws.Int64(5432),
+				ToPort:     aws.Int64(5432),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			ExpectError: true,
+		},
+		{
+			Name:     "ssh from private range",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(22),
+				ToPort:     aws.Int64(22),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}},
+			},
+			ExpectError: false,
+		},
+		{
+			Name:     "https from world",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(443),
+				ToPort:     aws.Int64(443),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			ExpectError: false,
+		},
+		{
+			Name:     "ssh to world egress",
+			RuleType: "egress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(22),
+				ToPort:     aws.Int64(22),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			ExpectError: false,
+		},
+		{
+			Name:     "unknown CIDR block",
+			RuleType: "ingress",
+			Permission: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(22),
+				ToPort:     aws.Int64(22),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("")}},
+			},
+			ExpectError: false,
+		},
+	}
+
+	guardrails := testNetworkGuardrailsConfig()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := guardrails.checkIPPermissions("Security Group", testCase.RuleType, []*ec2.IpPermission{testCase.Permission})
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestNetworkGuardrailsConfigCheckNetworkAclEntries(t *testing.T) {
+	guardrails := testNetworkGuardrailsConfig()
+
+	entries, err := expandNetworkAclEntries([]interface{}{
+		map[string]interface{}{
+			"protocol":   "tcp",
+			"from_port":  22,
+			"to_port":    22,
+			"action":     "deny",
+			"rule_no":    100,
+			"cidr_block": "0.0.0.0/0",
+		},
+		map[string]interface{}{
+			"protocol":   "tcp",
+			"from_port":  443,
+			"to_port":    443,
+			"action":     "allow",
+			"rule_no":    200,
+			"cidr_block": "0.0.0.0/0",
+		},
+	}, "ingress")
+
+	if err != nil {
+		t.Fatalf("error expanding entries: %s", err)
+	}
+
+	if err := guardrails.checkNetworkAclEntries("Network ACL", "ingress", entries); err != nil {
+		t.Fatalf("expected no error, got: %s", err)
+	}
+
+	entries, err = expandNetworkAclEntries([]interface{}{
+		map[string]interface{}{
+			"protocol":        "all",
+			"from_port":       0,
+			"to_port":         0,
+			"action":          "allow",
+			"rule_no":         100,
+			"ipv6_cidr_block": "::/0",
+		},
+	}, "ingress")
+
+	if err != nil {
+		t.Fatalf("error expanding entries: %s", err)
+	}
+
+	if err := guardrails.checkNetworkAclEntries("Network ACL", "ingress", entries); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestNetworkGuardrailsConfigExempt(t *testing.T) {
+	guardrails := testNetworkGuardrailsConfig()
+
+	if !guardrails.exempt(keyvaluetags.New(map[string]string{"GuardrailException": "approved", "Name": "bastion"})) {
+		t.Error("expected tags to be exempt")
+	}
+
+	if guardrails.exempt(keyvaluetags.New(map[string]string{"GuardrailException": "pending"})) {
+		t.Error("expected tags not to be exempt")
+	}
+
+	var nilGuardrails *NetworkGuardrailsConfig
+
+	if nilGuardrails.exempt(keyvaluetags.New(map[string]string{"GuardrailException": "approved"})) {
+		t.Error("expected no exemption without configuration")
+	}
+}
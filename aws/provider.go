@@ -2,11 +2,15 @@ package aws
 
 import (
 	"log"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	homedir "github.com/mitchellh/go-homedir"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -72,6 +76,14 @@ func Provider() terraform.ResourceProvider {
 				Description: descriptions["max_retries"],
 			},
 
+			"security_group_rule_batch_window": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      securityGroupRuleBatchWindow.String(),
+				ValidateFunc: validateDuration,
+				Description:  descriptions["security_group_rule_batch_window"],
+			},
+
 			"allowed_account_ids": {
 				Type:          schema.TypeSet,
 				Elem:          &schema.Schema{Type: schema.TypeString},
@@ -95,17 +107,70 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
+				Deprecated:  "use the key_prefixes argument of the ignore_tags configuration block instead",
 				Description: "Resource tag key prefixes to ignore across all resources.",
 			},
 
 			"ignore_tags": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Set:         schema.HashString,
-				Description: "Resource tag keys to ignore across all resources.",
+				MaxItems:    1,
+				Description: "Configuration block with settings to ignore resource tags across all resources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_patterns": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsValidRegExp,
+							},
+							Set:         schema.HashString,
+							Description: "Regular expressions matching resource tag keys to ignore across all resources.",
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "Resource tag key prefixes to ignore across all resources.",
+						},
+						"key_value_pattern": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsValidRegExp,
+									},
+									"value": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsValidRegExp,
+									},
+								},
+							},
+							Description: "Regular expressions matching resource tag keys and values to ignore across all resources.",
+						},
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "Resource tag keys to ignore across all resources.",
+						},
+					},
+				},
 			},
 
+			"iam_role_policy": iamRolePolicySchema(),
+
+			"network_guardrails": networkGuardrailsSchema(),
+
+			"required_tags": requiredTagsSchema(),
+
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -157,40 +222,87 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"aws_caller_identity":  dataSourceAwsCallerIdentity(),
-			"aws_internet_gateway": dataSourceAwsInternetGateway(),
-			"aws_vpc":              dataSourceAwsVpc(),
+			"aws_availability_zones":      dataSourceAwsAvailabilityZones(),
+			"aws_caller_identity":         dataSourceAwsCallerIdentity(),
+			"aws_ec2_managed_prefix_list": dataSourceAwsEc2ManagedPrefixList(),
+			"aws_internet_gateway":        dataSourceAwsInternetGateway(),
+			"aws_nat_gateway":             dataSourceAwsNatGateway(),
+			"aws_network_acls":            dataSourceAwsNetworkAcls(),
+			"aws_network_interface":       dataSourceAwsNetworkInterface(),
+			"aws_network_interfaces":      dataSourceAwsNetworkInterfaces(),
+			"aws_partition":               dataSourceAwsPartition(),
+			"aws_region":                  dataSourceAwsRegion(),
+			"aws_regions":                 dataSourceAwsRegions(),
+			"aws_route_table":             dataSourceAwsRouteTable(),
+			"aws_security_group":          dataSourceAwsSecurityGroup(),
+			"aws_security_groups":         dataSourceAwsSecurityGroups(),
+			"aws_subnet":                  dataSourceAwsSubnet(),
+			"aws_subnet_ids":              dataSourceAwsSubnetIDs(),
+			"aws_vpc":                     dataSourceAwsVpc(),
+			"aws_vpc_subnet_plan":         dataSourceAwsVpcSubnetPlan(),
+			"aws_vpcs":                    dataSourceAwsVpcs(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"aws_transfer_server":                     resourceAwsTransferServer(),
-			"aws_lex_slot_type":                       resourceAwsLexSlotType(),
-			"aws_lex_intent":                          resourceAwsLexIntent(),
-			"aws_lex_bot":                             resourceAwsLexBot(),
-			"aws_organizations_gov_cloud_account":     resourceAwsOrganizationsGovCloudAccount(),
-			"aws_organizations_invitation":            resourceAwsOrganizationsInvitation(),
-			"aws_organizations_invitation_acceptance": resourceAwsOrganizationsInvitationAcceptance(),
-			"aws_iam_role":                            resourceAwsIamRole(),
-			"aws_iam_role_policy":                     resourceAwsIamRolePolicy(),
-			"aws_iam_role_policy_attachment":          resourceAwsIamRolePolicyAttachment(),
-			"aws_quicksight_data_source":              resourceAwsQuickSightDataSource(),
-			"aws_quicksight_group_membership":         resourceAwsQuickSightGroupMembership(),
-			"aws_quicksight_iam_policy_assignment":    resourceAwsQuickSightIAMPolicyAssignment(),
-			"aws_quicksight_namespace":                resourceAwsQuickSightNamespace(),
-			"aws_internet_gateway_detach":             resourceAwsInternetGatewayDetach(),
-			"aws_internet_gateway_delete":             resourceAwsInternetGatewayDelete(),
-			"aws_default_network_acl":                 resourceAwsDefaultNetworkAcl(),
-			"aws_network_acl":                         resourceAwsNetworkAcl(),
-			"aws_default_route_table":                 resourceAwsDefaultRouteTable(),
-			"aws_route_table":                         resourceAwsRouteTable(),
-			"aws_default_security_group":              resourceAwsDefaultSecurityGroup(),
-			"aws_security_group":                      resourceAwsSecurityGroup(),
-			"aws_security_group_rule":                 resourceAwsSecurityGroupRule(),
-			"aws_subnet":                              resourceAwsSubnet(),
-			"aws_default_subnet":                      resourceAwsDefaultSubnet(),
-			"aws_network_interface":                   resourceAwsNetworkInterface(),
-			"aws_default_vpc":                         resourceAwsDefaultVpc(),
-			"aws_vpc":                                 resourceAwsVpc(),
+			"aws_transfer_server":                             resourceAwsTransferServer(),
+			"aws_lex_slot_type":                               resourceAwsLexSlotType(),
+			"aws_lex_intent":                                  resourceAwsLexIntent(),
+			"aws_lex_bot":                                     resourceAwsLexBot(),
+			"aws_organizations_gov_cloud_account":             resourceAwsOrganizationsGovCloudAccount(),
+			"aws_organizations_invitation":                    resourceAwsOrganizationsInvitation(),
+			"aws_organizations_invitation_acceptance":         resourceAwsOrganizationsInvitationAcceptance(),
+			"aws_iam_role":                                    resourceAwsIamRole(),
+			"aws_iam_role_policy":                             resourceAwsIamRolePolicy(),
+			"aws_iam_role_policy_attachment":                  resourceAwsIamRolePolicyAttachment(),
+			"aws_quicksight_data_source":                      resourceAwsQuickSightDataSource(),
+			"aws_quicksight_group_membership":                 resourceAwsQuickSightGroupMembership(),
+			"aws_quicksight_iam_policy_assignment":            resourceAwsQuickSightIAMPolicyAssignment(),
+			"aws_quicksight_namespace":                        resourceAwsQuickSightNamespace(),
+			"aws_ec2_client_vpn_authorization_rule":           resourceAwsEc2ClientVpnAuthorizationRule(),
+			"aws_ec2_client_vpn_endpoint":                     resourceAwsEc2ClientVpnEndpoint(),
+			"aws_ec2_client_vpn_network_association":          resourceAwsEc2ClientVpnNetworkAssociation(),
+			"aws_ec2_client_vpn_route":                        resourceAwsEc2ClientVpnRoute(),
+			"aws_ec2_managed_prefix_list":                     resourceAwsEc2ManagedPrefixList(),
+			"aws_ec2_transit_gateway":                         resourceAwsEc2TransitGateway(),
+			"aws_ec2_transit_gateway_route_table":             resourceAwsEc2TransitGatewayRouteTable(),
+			"aws_ec2_transit_gateway_route_table_association": resourceAwsEc2TransitGatewayRouteTableAssociation(),
+			"aws_ec2_transit_gateway_route_table_propagation": resourceAwsEc2TransitGatewayRouteTablePropagation(),
+			"aws_ec2_transit_gateway_vpc_attachment":          resourceAwsEc2TransitGatewayVpcAttachment(),
+			"aws_ec2_transit_gateway_vpc_attachment_accepter": resourceAwsEc2TransitGatewayVpcAttachmentAccepter(),
+			"aws_eip":                                  resourceAwsEip(),
+			"aws_flow_log":                             resourceAwsFlowLog(),
+			"aws_internet_gateway":                     resourceAwsInternetGateway(),
+			"aws_internet_gateway_attachment":          resourceAwsInternetGatewayAttachment(),
+			"aws_internet_gateway_detach":              resourceAwsInternetGatewayDetach(),
+			"aws_internet_gateway_delete":              resourceAwsInternetGatewayDelete(),
+			"aws_default_network_acl":                  resourceAwsDefaultNetworkAcl(),
+			"aws_network_acl":                          resourceAwsNetworkAcl(),
+			"aws_network_acl_association":              resourceAwsNetworkAclAssociation(),
+			"aws_network_acl_rule":                     resourceAwsNetworkAclRule(),
+			"aws_default_route_table":                  resourceAwsDefaultRouteTable(),
+			"aws_main_route_table_association":         resourceAwsMainRouteTableAssociation(),
+			"aws_route":                                resourceAwsRoute(),
+			"aws_route_table":                          resourceAwsRouteTable(),
+			"aws_route_table_association":              resourceAwsRouteTableAssociation(),
+			"aws_default_security_group":               resourceAwsDefaultSecurityGroup(),
+			"aws_security_group":                       resourceAwsSecurityGroup(),
+			"aws_security_group_rule":                  resourceAwsSecurityGroupRule(),
+			"aws_subnet":                               resourceAwsSubnet(),
+			"aws_default_subnet":                       resourceAwsDefaultSubnet(),
+			"aws_network_interface":                    resourceAwsNetworkInterface(),
+			"aws_network_interface_attachment":         resourceAwsNetworkInterfaceAttachment(),
+			"aws_network_interface_sg_attachment":      resourceAwsNetworkInterfaceSGAttachment(),
+			"aws_nat_gateway":                          resourceAwsNatGateway(),
+			"aws_default_vpc":                          resourceAwsDefaultVpc(),
+			"aws_vpc":                                  resourceAwsVpc(),
+			"aws_vpc_dhcp_options":                     resourceAwsVpcDhcpOptions(),
+			"aws_vpc_dhcp_options_association":         resourceAwsVpcDhcpOptionsAssociation(),
+			"aws_vpc_ipv4_cidr_block_association":      resourceAwsVpcIpv4CidrBlockAssociation(),
+			"aws_vpc_endpoint":                         resourceAwsVpcEndpoint(),
+			"aws_vpc_endpoint_route_table_association": resourceAwsVpcEndpointRouteTableAssociation(),
+			"aws_vpc_endpoint_subnet_association":      resourceAwsVpcEndpointSubnetAssociation(),
+			"aws_vpc_peering_connection":               resourceAwsVpcPeeringConnection(),
+			"aws_vpc_peering_connection_accepter":      resourceAwsVpcPeeringConnectionAccepter(),
 		},
 	}
 
@@ -234,6 +346,11 @@ func init() {
 			"being executed. If the API request still fails, an error is\n" +
 			"thrown.",
 
+		"security_group_rule_batch_window": "How long a security group rule change waits for changes\n" +
+			"to other rules of the same security group, so that they are sent in one\n" +
+			"API call. Every rule creation and deletion takes at least this long.\n" +
+			"Set to `0s` to send each change on its own without waiting.",
+
 		"endpoint": "Use this to override the default service endpoint URL",
 
 		"insecure": "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted," +
@@ -429,6 +546,12 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		terraformVersion:        terraformVersion,
 	}
 
+	batchWindow, err := time.ParseDuration(d.Get("security_group_rule_batch_window").(string))
+	if err != nil {
+		return nil, err
+	}
+	config.SecurityGroupRuleBatchWindow = batchWindow
+
 	// Set CredsFilename, expanding home directory
 	credsPath, err := homedir.Expand(d.Get("shared_credentials_file").(string))
 	if err != nil {
@@ -473,16 +596,34 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		}
 	}
 
+	if v, ok := d.GetOk("ignore_tags"); ok {
+		config.IgnoreTagsConfig = expandProviderIgnoreTags(v.([]interface{}))
+	}
+
 	if v, ok := d.GetOk("ignore_tag_prefixes"); ok {
-		for _, ignoreTagPrefixRaw := range v.(*schema.Set).List() {
-			config.IgnoreTagPrefixes = append(config.IgnoreTagPrefixes, ignoreTagPrefixRaw.(string))
+		if config.IgnoreTagsConfig == nil {
+			config.IgnoreTagsConfig = &keyvaluetags.IgnoreConfig{}
 		}
+
+		config.IgnoreTagsConfig.KeyPrefixes = config.IgnoreTagsConfig.KeyPrefixes.Merge(keyvaluetags.New(v.(*schema.Set).List()))
 	}
 
-	if v, ok := d.GetOk("ignore_tags"); ok {
-		for _, ignoreTagRaw := range v.(*schema.Set).List() {
-			config.IgnoreTags = append(config.IgnoreTags, ignoreTagRaw.(string))
+	if v, ok := d.GetOk("iam_role_policy"); ok {
+		config.IamRolePolicyConfig = expandProviderIamRolePolicy(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("network_guardrails"); ok {
+		config.NetworkGuardrailsConfig = expandProviderNetworkGuardrails(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("required_tags"); ok {
+		requiredTagsConfig, err := expandProviderRequiredTags(v.([]interface{}))
+
+		if err != nil {
+			return nil, err
 		}
+
+		config.RequiredTagsConfig = requiredTagsConfig
 	}
 
 	if v, ok := d.GetOk("allowed_account_ids"); ok {
@@ -561,3 +702,45 @@ func endpointsSchema() *schema.Schema {
 		},
 	}
 }
+
+func expandProviderIgnoreTags(l []interface{}) *keyvaluetags.IgnoreConfig {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	ignoreConfig := &keyvaluetags.IgnoreConfig{}
+	m := l[0].(map[string]interface{})
+
+	if v, ok := m["keys"].(*schema.Set); ok {
+		ignoreConfig.Keys = keyvaluetags.New(v.List())
+	}
+
+	if v, ok := m["key_prefixes"].(*schema.Set); ok {
+		ignoreConfig.KeyPrefixes = keyvaluetags.New(v.List())
+	}
+
+	if v, ok := m["key_patterns"].(*schema.Set); ok {
+		for _, patternRaw := range v.List() {
+			ignoreConfig.KeyPatterns = append(ignoreConfig.KeyPatterns, &keyvaluetags.IgnorePattern{
+				Key: regexp.MustCompile(patternRaw.(string)),
+			})
+		}
+	}
+
+	if v, ok := m["key_value_pattern"].([]interface{}); ok {
+		for _, patternRaw := range v {
+			patternMap, ok := patternRaw.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			ignoreConfig.KeyPatterns = append(ignoreConfig.KeyPatterns, &keyvaluetags.IgnorePattern{
+				Key:   regexp.MustCompile(patternMap["key"].(string)),
+				Value: regexp.MustCompile(patternMap["value"].(string)),
+			})
+		}
+	}
+
+	return ignoreConfig
+}
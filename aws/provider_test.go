@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
 const rfc3339RegexPattern = `^[0-9]{4}-(0[1-9]|1[012])-(0[1-9]|[12][0-9]|3[01])[Tt]([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9](\.[0-9]+)?([Zz]|([+-]([01][0-9]|2[0-3]):[0-5][0-9]))$`
@@ -55,6 +56,40 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestExpandProviderIgnoreTags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, map[string]interface{}{
+		"ignore_tags": []interface{}{
+			map[string]interface{}{
+				"keys":         []interface{}{"Owner"},
+				"key_patterns": []interface{}{"^backup-"},
+				"key_value_pattern": []interface{}{
+					map[string]interface{}{
+						"key":   "^managed-by$",
+						"value": "^cmdb$",
+					},
+				},
+			},
+		},
+	})
+
+	ignoreConfig := expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{}))
+
+	if ignoreConfig == nil {
+		t.Fatal("expected ignore tags configuration, got none")
+	}
+
+	tags := keyvaluetags.New(map[string]string{
+		"backup-2020-01-01": "daily",
+		"managed-by":        "cmdb",
+		"Name":              "test",
+		"Owner":             "team-a",
+	}).IgnoreConfig(ignoreConfig)
+
+	if got, want := tags.Map(), map[string]string{"Name": "test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected tags %v, got: %v", want, got)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if os.Getenv("AWS_PROFILE") == "" && os.Getenv("AWS_ACCESS_KEY_ID") == "" {
 		t.Fatal("AWS_ACCESS_KEY_ID or AWS_PROFILE must be set for acceptance tests")
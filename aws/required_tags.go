@@ -0,0 +1,147 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func requiredTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"allowed_values": requiredTagsAllowedValuesSchema(),
+				"keys":           requiredTagsKeysSchema(),
+				"resource_type_override": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"allowed_values": requiredTagsAllowedValuesSchema(),
+							"keys":           requiredTagsKeysSchema(),
+							"resource_type": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringMatch(regexp.MustCompile(`^aws_[a-z0-9_]+$`), "must be a resource type, e.g. aws_vpc"),
+							},
+						},
+					},
+					Description: "Required tag configurations replacing the top-level keys and allowed values for specific resource types.",
+				},
+			},
+		},
+	}
+}
+
+func requiredTagsAllowedValuesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Regular expressions, by tag key, that the entire tag value must match.",
+	}
+}
+
+func requiredTagsKeysSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: "Resource tag keys that must be present.",
+	}
+}
+
+func expandProviderRequiredTags(l []interface{}) (*keyvaluetags.RequiredConfig, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	config, err := expandProviderRequiredTagsConfig(m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overrideRaw := range m["resource_type_override"].([]interface{}) {
+		overrideMap, ok := overrideRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		resourceType := overrideMap["resource_type"].(string)
+
+		if _, ok := config.ResourceTypes[resourceType]; ok {
+			return nil, fmt.Errorf("duplicate required_tags resource_type_override for %s", resourceType)
+		}
+
+		override, err := expandProviderRequiredTagsConfig(overrideMap)
+
+		if err != nil {
+			return nil, fmt.Errorf("required_tags resource_type_override (%s): %w", resourceType, err)
+		}
+
+		config.ResourceTypes[resourceType] = override
+	}
+
+	return config, nil
+}
+
+func expandProviderRequiredTagsConfig(m map[string]interface{}) (*keyvaluetags.RequiredConfig, error) {
+	config := &keyvaluetags.RequiredConfig{
+		Keys:          keyvaluetags.New([]interface{}{}),
+		AllowedValues: make(map[string]*regexp.Regexp),
+		ResourceTypes: make(map[string]*keyvaluetags.RequiredConfig),
+	}
+
+	if v, ok := m["keys"].(*schema.Set); ok {
+		config.Keys = keyvaluetags.New(v.List())
+	}
+
+	if v, ok := m["allowed_values"].(map[string]interface{}); ok {
+		for key, patternRaw := range v {
+			re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", patternRaw.(string)))
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid required_tags allowed_values pattern for tag %s: %w", key, err)
+			}
+
+			config.AllowedValues[key] = re
+		}
+	}
+
+	return config, nil
+}
+
+// requiredTagsCustomizeDiff returns a CustomizeDiff function enforcing the
+// provider required tags against the given resource type.
+func requiredTagsCustomizeDiff(resourceType string) schema.CustomizeDiffFunc {
+	return func(diff *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*AWSClient)
+
+		if !ok || client.RequiredTagsConfig == nil {
+			return nil
+		}
+
+		if !diff.NewValueKnown("tags") {
+			return nil
+		}
+
+		tags := keyvaluetags.New(diff.Get("tags").(map[string]interface{}))
+
+		if err := client.RequiredTagsConfig.Check(resourceType, tags); err != nil {
+			return fmt.Errorf("%s tags do not satisfy the provider required_tags configuration: %s", resourceType, err)
+		}
+
+		return nil
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -27,6 +27,15 @@ func resourceAwsDefaultNetworkAcl() *schema.Resource {
 		Read:   resourceAwsNetworkAclRead,
 		Delete: resourceAwsDefaultNetworkAclDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			networkGuardrailsNetworkAclCustomizeDiff,
+			requiredTagsCustomizeDiff("aws_default_network_acl"),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -170,45 +179,7 @@ func resourceAwsDefaultNetworkAclCreate(d *schema.ResourceData, meta interface{}
 		return err1
 	}
 
-	log.Printf("[INFO] Deleting Network Acl: %s", d.Id())
-	input := &ec2.DeleteNetworkAclInput{
-		NetworkAclId: aws.String(d.Id()),
-	}
-	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
-		_, err := conn.DeleteNetworkAcl(input)
-		if err != nil {
-			if isAWSErr(err, "InvalidNetworkAclID.NotFound", "") {
-				return nil
-			}
-			if isAWSErr(err, "DependencyViolation", "") {
-				err = cleanUpDependencyViolations(d, conn)
-				if err != nil {
-					return resource.NonRetryableError(err)
-				}
-				return resource.RetryableError(fmt.Errorf("Dependencies found and cleaned up, retrying"))
-			}
-
-			return resource.NonRetryableError(err)
-
-		}
-		log.Printf("[Info] Deleted network ACL %s successfully", d.Id())
-		return nil
-	})
-	if isResourceTimeoutError(err) {
-		_, err = conn.DeleteNetworkAcl(input)
-		if err != nil && isAWSErr(err, "InvalidNetworkAclID.NotFound", "") {
-			return nil
-		}
-		err = cleanUpDependencyViolations(d, conn)
-		if err != nil {
-			// This seems excessive but is probably the best way to make sure it's actually deleted
-			_, err = conn.DeleteNetworkAcl(input)
-			if err != nil && isAWSErr(err, "InvalidNetworkAclID.NotFound", "") {
-				return nil
-			}
-		}
-	}
-	if err != nil {
+	if err := deleteNetworkAcl(d, conn, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("Error destroying Network ACL (%s): %s", d.Id(), err)
 	}
 
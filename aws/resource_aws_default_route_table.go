@@ -3,10 +3,14 @@ package aws
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
 func resourceAwsDefaultRouteTable() *schema.Resource {
@@ -15,6 +19,12 @@ func resourceAwsDefaultRouteTable() *schema.Resource {
 		Read:   resourceAwsDefaultRouteTableRead,
 		Delete: resourceAwsDefaultRouteTableDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_default_route_table"),
+
 		Schema: map[string]*schema.Schema{
 			"default_route_table_id": {
 				Type:     schema.TypeString,
@@ -54,6 +64,11 @@ func resourceAwsDefaultRouteTable() *schema.Resource {
 							Optional: true,
 						},
 
+						"destination_prefix_list_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
 						"egress_only_gateway_id": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -108,16 +123,14 @@ func resourceAwsDefaultRouteTableCreate(d *schema.ResourceData, meta interface{}
 	d.SetId(d.Get("default_route_table_id").(string))
 
 	conn := meta.(*AWSClient).ec2conn
-	rtRaw, _, err := resourceAwsRouteTableStateRefreshFunc(conn, d.Id())()
+	rt, err := finder.RouteTableByID(conn, d.Id())
 	if err != nil {
 		return nil
 	}
-	if rtRaw == nil {
+	if rt == nil {
 		return nil
 	}
 
-	rt := rtRaw.(*ec2.RouteTable)
-
 	d.Set("vpc_id", rt.VpcId)
 
 	// revoke all default and pre-existing routes on the default route table.
@@ -135,6 +148,10 @@ func resourceAwsDefaultRouteTableCreate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("error deleteing Default Route Table: %s", err)
 	}
 
+	if _, err := waiter.RouteTableDeleted(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Default Route Table (%s) to be deleted: %s", d.Id(), err)
+	}
+
 	return resourceAwsDefaultRouteTableRead(d, meta)
 }
 
@@ -187,7 +204,7 @@ func revokeAllRouteTableRules(defaultRouteTableId string, meta interface{}) erro
 		if r.GatewayId != nil && *r.GatewayId == "local" {
 			continue
 		}
-		if r.DestinationPrefixListId != nil {
+		if r.DestinationPrefixListId != nil && strings.HasPrefix(aws.StringValue(r.GatewayId), "vpce-") {
 			// Skipping because VPC endpoint routes are handled separately
 			// See aws_vpc_endpoint
 			continue
@@ -219,6 +236,19 @@ func revokeAllRouteTableRules(defaultRouteTableId string, meta interface{}) erro
 			}
 		}
 
+		if r.DestinationPrefixListId != nil {
+			log.Printf(
+				"[INFO] Deleting route from %s: %s",
+				defaultRouteTableId, *r.DestinationPrefixListId)
+			_, err := conn.DeleteRoute(&ec2.DeleteRouteInput{
+				RouteTableId:            aws.String(defaultRouteTableId),
+				DestinationPrefixListId: r.DestinationPrefixListId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 	}
 
 	return nil
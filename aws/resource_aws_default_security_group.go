@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
@@ -16,6 +17,10 @@ func resourceAwsDefaultSecurityGroup() *schema.Resource {
 	dsg.Create = resourceAwsDefaultSecurityGroupCreate
 	dsg.Delete = resourceAwsDefaultSecurityGroupDelete
 	dsg.Read = resourceAwsDefaultSecurityGroupRead
+	dsg.CustomizeDiff = customdiff.Sequence(
+		networkGuardrailsSecurityGroupCustomizeDiff,
+		requiredTagsCustomizeDiff("aws_default_security_group"),
+	)
 
 	// description is a computed value for Default Security Groups and cannot be changed
 	dsg.Schema["description"] = &schema.Schema{
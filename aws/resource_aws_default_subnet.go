@@ -6,9 +6,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -18,6 +16,13 @@ func resourceAwsDefaultSubnet() *schema.Resource {
 	dsubnet.Create = resourceAwsDefaultSubnetCreate
 	dsubnet.Read = resourceAwsDefaultSubnetRead
 	dsubnet.Delete = resourceAwsDefaultSubnetDelete
+	dsubnet.CustomizeDiff = requiredTagsCustomizeDiff("aws_default_subnet")
+
+	// The default subnet is deleted on create, and only removed from state on delete
+	dsubnet.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(20 * time.Minute),
+		Update: schema.DefaultTimeout(10 * time.Minute),
+	}
 
 	// availability_zone is a required value for Default Subnets
 	dsubnet.Schema["availability_zone"] = &schema.Schema{
@@ -84,45 +89,13 @@ func resourceAwsDefaultSubnetCreate(d *schema.ResourceData, meta interface{}) er
 		d.SetId(aws.StringValue(resp.Subnets[0].SubnetId))
 		log.Printf("[INFO] Deleting subnet: %s", d.Id())
 
-		if err := deleteLingeringLambdaENIs(conn, "subnet-id", d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		if err := deleteLingeringLambdaENIs(conn, "subnet-id", d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 			return fmt.Errorf("error deleting Lambda ENIs using subnet (%s): %s", d.Id(), err)
 		}
 
-		req2 := &ec2.DeleteSubnetInput{
-			SubnetId: aws.String(d.Id()),
-		}
-
-		wait := resource.StateChangeConf{
-			Pending:    []string{"pending"},
-			Target:     []string{"destroyed"},
-			Timeout:    d.Timeout(schema.TimeoutDelete),
-			MinTimeout: 1 * time.Second,
-			Refresh: func() (interface{}, string, error) {
-				_, err := conn.DeleteSubnet(req2)
-				if err != nil {
-					if apiErr, ok := err.(awserr.Error); ok {
-						if apiErr.Code() == "DependencyViolation" {
-							// There is some pending operation, so just retry
-							// in a bit.
-							return 42, "pending", nil
-						}
-
-						if apiErr.Code() == "InvalidSubnetID.NotFound" {
-							return 42, "destroyed", nil
-						}
-					}
-
-					return 42, "failure", err
-				}
-
-				return 42, "destroyed", nil
-			},
-		}
-
-		if _, err := wait.WaitForState(); err != nil {
+		if err := deleteSubnet(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 			return fmt.Errorf("Error deleting subnet: %s", err)
 		}
-
 	}
 
 	return resourceAwsDefaultSubnetRead(d, meta)
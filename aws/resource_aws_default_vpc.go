@@ -7,8 +7,10 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
 )
 
 func resourceAwsDefaultVpc() *schema.Resource {
@@ -17,6 +19,16 @@ func resourceAwsDefaultVpc() *schema.Resource {
 	dvpc.Create = resourceAwsDefaultVpcCreate
 	dvpc.Delete = resourceAwsDefaultVpcDelete
 	dvpc.Read = resourceAwsDefaultVpcRead
+	dvpc.CustomizeDiff = customdiff.Sequence(
+		resourceAwsVpcCustomizeDiff,
+		requiredTagsCustomizeDiff("aws_default_vpc"),
+	)
+
+	// The default VPC is deleted on create, and only removed from state on delete
+	dvpc.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(10 * time.Minute),
+	}
 
 	// cidr_block is a computed value for Default VPCs
 	dvpc.Schema["cidr_block"] = &schema.Schema{
@@ -33,6 +45,16 @@ func resourceAwsDefaultVpc() *schema.Resource {
 		Type:     schema.TypeBool,
 		Computed: true,
 	}
+	// ipv6_ipam_pool is a computed value for Default VPCs
+	dvpc.Schema["ipv6_ipam_pool"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	// ipv6_cidr_block is a computed value for Default VPCs
+	dvpc.Schema["ipv6_cidr_block"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
 
 	return dvpc
 }
@@ -66,23 +88,23 @@ func resourceAwsDefaultVpcCreate(d *schema.ResourceData, meta interface{}) error
 		}
 		log.Printf("[INFO] Deleting VPC: %s", d.Id())
 
-		err2 := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		err2 := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 			_, err2 := conn.DeleteVpc(deleteVpcOpts)
 			if err2 == nil {
 				return nil
 			}
 
-			if isAWSErr(err2, "InvalidVpcID.NotFound", "") {
+			if isAWSErr(err2, tfec2.ErrCodeInvalidVpcIDNotFound, "") {
 				return nil
 			}
-			if isAWSErr(err2, "DependencyViolation", "") {
+			if isAWSErr(err2, tfec2.ErrCodeDependencyViolation, "") {
 				return resource.RetryableError(err2)
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting VPC: %s", err2))
 		})
 		if isResourceTimeoutError(err2) {
 			_, err2 = conn.DeleteVpc(deleteVpcOpts)
-			if isAWSErr(err2, "InvalidVpcID.NotFound", "") {
+			if isAWSErr(err2, tfec2.ErrCodeInvalidVpcIDNotFound, "") {
 				return nil
 			}
 		}
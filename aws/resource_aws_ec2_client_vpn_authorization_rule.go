@@ -0,0 +1,190 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2ClientVpnAuthorizationRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2ClientVpnAuthorizationRuleCreate,
+		Read:   resourceAwsEc2ClientVpnAuthorizationRuleRead,
+		Delete: resourceAwsEc2ClientVpnAuthorizationRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsEc2ClientVpnAuthorizationRuleImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.ClientVpnAuthorizationRuleActiveTimeout),
+			Delete: schema.DefaultTimeout(waiter.ClientVpnAuthorizationRuleRevokedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"client_vpn_endpoint_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"target_network_cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+			"access_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"access_group_id", "authorize_all_groups"},
+			},
+			"authorize_all_groups": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"access_group_id", "authorize_all_groups"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2ClientVpnAuthorizationRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointID := d.Get("client_vpn_endpoint_id").(string)
+	targetNetworkCidr := d.Get("target_network_cidr").(string)
+
+	input := &ec2.AuthorizeClientVpnIngressInput{
+		ClientVpnEndpointId: aws.String(endpointID),
+		TargetNetworkCidr:   aws.String(targetNetworkCidr),
+	}
+
+	var accessGroupID string
+	if v, ok := d.GetOk("access_group_id"); ok {
+		accessGroupID = v.(string)
+		input.AccessGroupId = aws.String(accessGroupID)
+	}
+
+	if v, ok := d.GetOk("authorize_all_groups"); ok {
+		input.AuthorizeAllGroups = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+
+	id := tfec2.ClientVpnAuthorizationRuleCreateID(endpointID, targetNetworkCidr, accessGroupID)
+
+	log.Printf("[DEBUG] Creating Client VPN authorization rule: %s", input)
+	if _, err := conn.AuthorizeClientVpnIngress(input); err != nil {
+		return fmt.Errorf("error creating Client VPN authorization rule (%s): %s", id, err)
+	}
+
+	d.SetId(id)
+
+	if _, err := waiter.ClientVpnAuthorizationRuleAuthorized(conn, id, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Client VPN authorization rule (%s) to be active: %s", id, err)
+	}
+
+	return resourceAwsEc2ClientVpnAuthorizationRuleRead(d, meta)
+}
+
+func resourceAwsEc2ClientVpnAuthorizationRuleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointID := d.Get("client_vpn_endpoint_id").(string)
+	targetNetworkCidr := d.Get("target_network_cidr").(string)
+	accessGroupID := d.Get("access_group_id").(string)
+
+	result, err := finder.ClientVpnAuthorizationRule(conn, endpointID, targetNetworkCidr, accessGroupID)
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnAuthorizationRuleNotFound, "") || isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		log.Printf("[WARN] Client VPN authorization rule (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Client VPN authorization rule (%s): %s", d.Id(), err)
+	}
+
+	var rule *ec2.AuthorizationRule
+
+	// Without an access group, the filters also match the rules for the same
+	// network and any group.
+	for _, r := range result.AuthorizationRules {
+		if aws.StringValue(r.GroupId) == accessGroupID {
+			rule = r
+			break
+		}
+	}
+
+	if rule == nil {
+		log.Printf("[WARN] Client VPN authorization rule (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("client_vpn_endpoint_id", rule.ClientVpnEndpointId)
+	d.Set("target_network_cidr", rule.DestinationCidr)
+	d.Set("access_group_id", rule.GroupId)
+	d.Set("authorize_all_groups", rule.AccessAll)
+	d.Set("description", rule.Description)
+
+	return nil
+}
+
+func resourceAwsEc2ClientVpnAuthorizationRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.RevokeClientVpnIngressInput{
+		ClientVpnEndpointId: aws.String(d.Get("client_vpn_endpoint_id").(string)),
+		TargetNetworkCidr:   aws.String(d.Get("target_network_cidr").(string)),
+		RevokeAllGroups:     aws.Bool(d.Get("authorize_all_groups").(bool)),
+	}
+
+	if v, ok := d.GetOk("access_group_id"); ok {
+		input.AccessGroupId = aws.String(v.(string))
+	}
+
+	log.Printf("[INFO] Deleting Client VPN authorization rule: %s", input)
+	_, err := conn.RevokeClientVpnIngress(input)
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnAuthorizationRuleNotFound, "") || isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Client VPN authorization rule (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.ClientVpnAuthorizationRuleRevoked(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Client VPN authorization rule (%s) to be revoked: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsEc2ClientVpnAuthorizationRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	endpointID, targetNetworkCidr, accessGroupID, err := tfec2.ClientVpnAuthorizationRuleParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("client_vpn_endpoint_id", endpointID)
+	d.Set("target_network_cidr", targetNetworkCidr)
+	d.Set("access_group_id", accessGroupID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,409 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2ClientVpnEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2ClientVpnEndpointCreate,
+		Read:   resourceAwsEc2ClientVpnEndpointRead,
+		Update: resourceAwsEc2ClientVpnEndpointUpdate,
+		Delete: resourceAwsEc2ClientVpnEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(waiter.ClientVpnEndpointDeletedTimout),
+		},
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_ec2_client_vpn_endpoint"),
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"client_cidr_block": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+			"dns_servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"server_certificate_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"split_tunnel": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"transport_protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  ec2.TransportProtocolUdp,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.TransportProtocolTcp,
+					ec2.TransportProtocolUdp,
+				}, false),
+			},
+			"authentication_options": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 2,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								ec2.ClientVpnAuthenticationTypeCertificateAuthentication,
+								ec2.ClientVpnAuthenticationTypeDirectoryServiceAuthentication,
+								ec2.ClientVpnAuthenticationTypeFederatedAuthentication,
+							}, false),
+						},
+						"active_directory_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"root_certificate_chain_arn": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validateArn,
+						},
+						"saml_provider_arn": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validateArn,
+						},
+					},
+				},
+			},
+			"connection_log_options": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloudwatch_log_group": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"cloudwatch_log_stream": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+			"dns_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2ClientVpnEndpointCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.CreateClientVpnEndpointInput{
+		ClientCidrBlock:      aws.String(d.Get("client_cidr_block").(string)),
+		ServerCertificateArn: aws.String(d.Get("server_certificate_arn").(string)),
+		SplitTunnel:          aws.Bool(d.Get("split_tunnel").(bool)),
+		TransportProtocol:    aws.String(d.Get("transport_protocol").(string)),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("dns_servers"); ok {
+		input.DnsServers = expandStringList(v.([]interface{}))
+	}
+
+	for _, v := range d.Get("authentication_options").([]interface{}) {
+		input.AuthenticationOptions = append(input.AuthenticationOptions, expandEc2ClientVpnAuthenticationRequest(v.(map[string]interface{})))
+	}
+
+	if v, ok := d.GetOk("connection_log_options"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		input.ConnectionLogOptions = expandEc2ClientVpnConnectionLogOptions(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Creating Client VPN endpoint: %s", input)
+	resp, err := conn.CreateClientVpnEndpoint(input)
+	if err != nil {
+		return fmt.Errorf("error creating Client VPN endpoint: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.ClientVpnEndpointId))
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding Client VPN endpoint (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2ClientVpnEndpointRead(d, meta)
+}
+
+func resourceAwsEc2ClientVpnEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	resp, err := conn.DescribeClientVpnEndpoints(&ec2.DescribeClientVpnEndpointsInput{
+		ClientVpnEndpointIds: aws.StringSlice([]string{d.Id()}),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		log.Printf("[WARN] Client VPN endpoint (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Client VPN endpoint (%s): %s", d.Id(), err)
+	}
+
+	if resp == nil || len(resp.ClientVpnEndpoints) == 0 || resp.ClientVpnEndpoints[0] == nil {
+		log.Printf("[WARN] Client VPN endpoint (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	endpoint := resp.ClientVpnEndpoints[0]
+
+	if endpoint.Status != nil && aws.StringValue(endpoint.Status.Code) == ec2.ClientVpnEndpointStatusCodeDeleted {
+		log.Printf("[WARN] Client VPN endpoint (%s) deleted, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("description", endpoint.Description)
+	d.Set("client_cidr_block", endpoint.ClientCidrBlock)
+	d.Set("server_certificate_arn", endpoint.ServerCertificateArn)
+	d.Set("split_tunnel", endpoint.SplitTunnel)
+	d.Set("transport_protocol", endpoint.TransportProtocol)
+	d.Set("dns_name", endpoint.DnsName)
+
+	if endpoint.Status != nil {
+		d.Set("status", endpoint.Status.Code)
+	}
+
+	if err := d.Set("dns_servers", aws.StringValueSlice(endpoint.DnsServers)); err != nil {
+		return fmt.Errorf("error setting dns_servers: %s", err)
+	}
+
+	if err := d.Set("authentication_options", flattenEc2ClientVpnAuthentications(endpoint.AuthenticationOptions)); err != nil {
+		return fmt.Errorf("error setting authentication_options: %s", err)
+	}
+
+	if err := d.Set("connection_log_options", flattenEc2ClientVpnConnectionLogOptions(endpoint.ConnectionLogOptions)); err != nil {
+		return fmt.Errorf("error setting connection_log_options: %s", err)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(endpoint.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	arn := arn.ARN{
+		Partition: meta.(*AWSClient).partition,
+		Service:   "ec2",
+		Region:    meta.(*AWSClient).region,
+		AccountID: meta.(*AWSClient).accountid,
+		Resource:  fmt.Sprintf("client-vpn-endpoint/%s", d.Id()),
+	}.String()
+	d.Set("arn", arn)
+
+	return nil
+}
+
+func resourceAwsEc2ClientVpnEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChanges("description", "dns_servers", "server_certificate_arn", "split_tunnel", "connection_log_options") {
+		input := &ec2.ModifyClientVpnEndpointInput{
+			ClientVpnEndpointId: aws.String(d.Id()),
+		}
+
+		if d.HasChange("description") {
+			input.Description = aws.String(d.Get("description").(string))
+		}
+
+		if d.HasChange("dns_servers") {
+			dnsServers := d.Get("dns_servers").([]interface{})
+			input.DnsServers = &ec2.DnsServersOptionsModifyStructure{
+				CustomDnsServers: expandStringList(dnsServers),
+				Enabled:          aws.Bool(len(dnsServers) > 0),
+			}
+		}
+
+		if d.HasChange("server_certificate_arn") {
+			input.ServerCertificateArn = aws.String(d.Get("server_certificate_arn").(string))
+		}
+
+		if d.HasChange("split_tunnel") {
+			input.SplitTunnel = aws.Bool(d.Get("split_tunnel").(bool))
+		}
+
+		if d.HasChange("connection_log_options") {
+			if v, ok := d.GetOk("connection_log_options"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+				input.ConnectionLogOptions = expandEc2ClientVpnConnectionLogOptions(v.([]interface{})[0].(map[string]interface{}))
+			}
+		}
+
+		log.Printf("[DEBUG] Modifying Client VPN endpoint: %s", input)
+		if _, err := conn.ModifyClientVpnEndpoint(input); err != nil {
+			return fmt.Errorf("error modifying Client VPN endpoint (%s): %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating Client VPN endpoint (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2ClientVpnEndpointRead(d, meta)
+}
+
+func resourceAwsEc2ClientVpnEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting Client VPN endpoint: %s", d.Id())
+	_, err := conn.DeleteClientVpnEndpoint(&ec2.DeleteClientVpnEndpointInput{
+		ClientVpnEndpointId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Client VPN endpoint (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.ClientVpnEndpointDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Client VPN endpoint (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandEc2ClientVpnAuthenticationRequest(data map[string]interface{}) *ec2.ClientVpnAuthenticationRequest {
+	req := &ec2.ClientVpnAuthenticationRequest{
+		Type: aws.String(data["type"].(string)),
+	}
+
+	switch data["type"].(string) {
+	case ec2.ClientVpnAuthenticationTypeCertificateAuthentication:
+		req.MutualAuthentication = &ec2.CertificateAuthenticationRequest{
+			ClientRootCertificateChainArn: aws.String(data["root_certificate_chain_arn"].(string)),
+		}
+	case ec2.ClientVpnAuthenticationTypeDirectoryServiceAuthentication:
+		req.ActiveDirectory = &ec2.DirectoryServiceAuthenticationRequest{
+			DirectoryId: aws.String(data["active_directory_id"].(string)),
+		}
+	case ec2.ClientVpnAuthenticationTypeFederatedAuthentication:
+		req.FederatedAuthentication = &ec2.FederatedAuthenticationRequest{
+			SAMLProviderArn: aws.String(data["saml_provider_arn"].(string)),
+		}
+	}
+
+	return req
+}
+
+func flattenEc2ClientVpnAuthentications(authOptions []*ec2.ClientVpnAuthentication) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(authOptions))
+
+	for _, authOption := range authOptions {
+		r := map[string]interface{}{
+			"type": aws.StringValue(authOption.Type),
+		}
+
+		if authOption.MutualAuthentication != nil {
+			r["root_certificate_chain_arn"] = aws.StringValue(authOption.MutualAuthentication.ClientRootCertificateChain)
+		}
+
+		if authOption.ActiveDirectory != nil {
+			r["active_directory_id"] = aws.StringValue(authOption.ActiveDirectory.DirectoryId)
+		}
+
+		if authOption.FederatedAuthentication != nil {
+			r["saml_provider_arn"] = aws.StringValue(authOption.FederatedAuthentication.SamlProviderArn)
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+func expandEc2ClientVpnConnectionLogOptions(data map[string]interface{}) *ec2.ConnectionLogOptions {
+	options := &ec2.ConnectionLogOptions{
+		Enabled: aws.Bool(data["enabled"].(bool)),
+	}
+
+	if v, ok := data["cloudwatch_log_group"].(string); ok && v != "" {
+		options.CloudwatchLogGroup = aws.String(v)
+	}
+
+	if v, ok := data["cloudwatch_log_stream"].(string); ok && v != "" {
+		options.CloudwatchLogStream = aws.String(v)
+	}
+
+	return options
+}
+
+func flattenEc2ClientVpnConnectionLogOptions(options *ec2.ConnectionLogResponseOptions) []map[string]interface{} {
+	if options == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"cloudwatch_log_group":  aws.StringValue(options.CloudwatchLogGroup),
+			"cloudwatch_log_stream": aws.StringValue(options.CloudwatchLogStream),
+			"enabled":               aws.BoolValue(options.Enabled),
+		},
+	}
+}
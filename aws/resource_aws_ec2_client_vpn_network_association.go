@@ -0,0 +1,199 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2ClientVpnNetworkAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2ClientVpnNetworkAssociationCreate,
+		Read:   resourceAwsEc2ClientVpnNetworkAssociationRead,
+		Update: resourceAwsEc2ClientVpnNetworkAssociationUpdate,
+		Delete: resourceAwsEc2ClientVpnNetworkAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsEc2ClientVpnNetworkAssociationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.ClientVpnNetworkAssociationAssociatedTimeout),
+			Delete: schema.DefaultTimeout(waiter.ClientVpnNetworkAssociationDisassociatedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"client_vpn_endpoint_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_groups": {
+				Type:     schema.TypeSet,
+				MinItems: 1,
+				MaxItems: 5,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2ClientVpnNetworkAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	endpointID := d.Get("client_vpn_endpoint_id").(string)
+
+	input := &ec2.AssociateClientVpnTargetNetworkInput{
+		ClientVpnEndpointId: aws.String(endpointID),
+		SubnetId:            aws.String(d.Get("subnet_id").(string)),
+	}
+
+	log.Printf("[DEBUG] Creating Client VPN network association: %s", input)
+	resp, err := conn.AssociateClientVpnTargetNetwork(input)
+	if err != nil {
+		return fmt.Errorf("error creating Client VPN network association: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.AssociationId))
+
+	network, err := waiter.ClientVpnNetworkAssociationAssociated(conn, d.Id(), endpointID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("error waiting for Client VPN network association (%s) to be associated: %s", d.Id(), err)
+	}
+
+	if v, ok := d.GetOk("security_groups"); ok {
+		if err := applyEc2ClientVpnNetworkAssociationSecurityGroups(conn, endpointID, aws.StringValue(network.VpcId), v.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsEc2ClientVpnNetworkAssociationRead(d, meta)
+}
+
+func resourceAwsEc2ClientVpnNetworkAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	network, err := finder.ClientVpnNetworkAssociationByID(conn, d.Id(), d.Get("client_vpn_endpoint_id").(string))
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnAssociationIdNotFound, "") || isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		log.Printf("[WARN] Client VPN network association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Client VPN network association (%s): %s", d.Id(), err)
+	}
+
+	if network == nil {
+		log.Printf("[WARN] Client VPN network association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if network.Status != nil && aws.StringValue(network.Status.Code) == ec2.AssociationStatusCodeDisassociated {
+		log.Printf("[WARN] Client VPN network association (%s) disassociated, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("client_vpn_endpoint_id", network.ClientVpnEndpointId)
+	d.Set("subnet_id", network.TargetNetworkId)
+	d.Set("vpc_id", network.VpcId)
+
+	if network.Status != nil {
+		d.Set("status", network.Status.Code)
+	}
+
+	if err := d.Set("security_groups", aws.StringValueSlice(network.SecurityGroups)); err != nil {
+		return fmt.Errorf("error setting security_groups: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsEc2ClientVpnNetworkAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("security_groups") {
+		if err := applyEc2ClientVpnNetworkAssociationSecurityGroups(conn, d.Get("client_vpn_endpoint_id").(string), d.Get("vpc_id").(string), d.Get("security_groups").(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsEc2ClientVpnNetworkAssociationRead(d, meta)
+}
+
+func resourceAwsEc2ClientVpnNetworkAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	endpointID := d.Get("client_vpn_endpoint_id").(string)
+
+	log.Printf("[INFO] Deleting Client VPN network association: %s", d.Id())
+	_, err := conn.DisassociateClientVpnTargetNetwork(&ec2.DisassociateClientVpnTargetNetworkInput{
+		ClientVpnEndpointId: aws.String(endpointID),
+		AssociationId:       aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnAssociationIdNotFound, "") || isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Client VPN network association (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.ClientVpnNetworkAssociationDisassociated(conn, d.Id(), endpointID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Client VPN network association (%s) to be disassociated: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsEc2ClientVpnNetworkAssociationImport imports a Client VPN
+// network association given as endpoint-id,association-id.
+func resourceAwsEc2ClientVpnNetworkAssociationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	endpointID, associationID, err := tfec2.ClientVpnNetworkAssociationParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(associationID)
+	d.Set("client_vpn_endpoint_id", endpointID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func applyEc2ClientVpnNetworkAssociationSecurityGroups(conn *ec2.EC2, endpointID, vpcID string, securityGroups *schema.Set) error {
+	input := &ec2.ApplySecurityGroupsToClientVpnTargetNetworkInput{
+		ClientVpnEndpointId: aws.String(endpointID),
+		SecurityGroupIds:    expandStringSet(securityGroups),
+		VpcId:               aws.String(vpcID),
+	}
+
+	log.Printf("[DEBUG] Applying security groups to Client VPN endpoint: %s", input)
+	if _, err := conn.ApplySecurityGroupsToClientVpnTargetNetwork(input); err != nil {
+		return fmt.Errorf("error applying security groups to Client VPN endpoint (%s) target network in VPC (%s): %s", endpointID, vpcID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2ClientVpnRoute() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2ClientVpnRouteCreate,
+		Read:   resourceAwsEc2ClientVpnRouteRead,
+		Delete: resourceAwsEc2ClientVpnRouteDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsEc2ClientVpnRouteImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.ClientVpnRouteActiveTimeout),
+			Delete: schema.DefaultTimeout(waiter.ClientVpnRouteDeletedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"client_vpn_endpoint_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_cidr_block": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+			"target_vpc_subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"origin": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2ClientVpnRouteCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointID := d.Get("client_vpn_endpoint_id").(string)
+	targetSubnetID := d.Get("target_vpc_subnet_id").(string)
+	destinationCidr := d.Get("destination_cidr_block").(string)
+
+	input := &ec2.CreateClientVpnRouteInput{
+		ClientVpnEndpointId:  aws.String(endpointID),
+		DestinationCidrBlock: aws.String(destinationCidr),
+		TargetVpcSubnetId:    aws.String(targetSubnetID),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+
+	id := tfec2.ClientVpnRouteCreateID(endpointID, targetSubnetID, destinationCidr)
+
+	log.Printf("[DEBUG] Creating Client VPN route: %s", input)
+	if _, err := conn.CreateClientVpnRoute(input); err != nil {
+		return fmt.Errorf("error creating Client VPN route (%s): %s", id, err)
+	}
+
+	d.SetId(id)
+
+	if _, err := waiter.ClientVpnRouteActive(conn, id, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Client VPN route (%s) to be active: %s", id, err)
+	}
+
+	return resourceAwsEc2ClientVpnRouteRead(d, meta)
+}
+
+func resourceAwsEc2ClientVpnRouteRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	result, err := finder.ClientVpnRoute(conn,
+		d.Get("client_vpn_endpoint_id").(string),
+		d.Get("target_vpc_subnet_id").(string),
+		d.Get("destination_cidr_block").(string),
+	)
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnRouteNotFound, "") || isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		log.Printf("[WARN] Client VPN route (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Client VPN route (%s): %s", d.Id(), err)
+	}
+
+	if result == nil || len(result.Routes) == 0 || result.Routes[0] == nil {
+		log.Printf("[WARN] Client VPN route (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if len(result.Routes) > 1 {
+		return fmt.Errorf("internal error: found %d results for Client VPN route (%s), need 1", len(result.Routes), d.Id())
+	}
+
+	route := result.Routes[0]
+	d.Set("client_vpn_endpoint_id", route.ClientVpnEndpointId)
+	d.Set("destination_cidr_block", route.DestinationCidr)
+	d.Set("target_vpc_subnet_id", route.TargetSubnet)
+	d.Set("description", route.Description)
+	d.Set("origin", route.Origin)
+	d.Set("type", route.Type)
+
+	return nil
+}
+
+func resourceAwsEc2ClientVpnRouteDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.DeleteClientVpnRouteInput{
+		ClientVpnEndpointId:  aws.String(d.Get("client_vpn_endpoint_id").(string)),
+		DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
+		TargetVpcSubnetId:    aws.String(d.Get("target_vpc_subnet_id").(string)),
+	}
+
+	log.Printf("[INFO] Deleting Client VPN route: %s", input)
+	_, err := conn.DeleteClientVpnRoute(input)
+
+	if isAWSErr(err, tfec2.ErrCodeClientVpnRouteNotFound, "") || isAWSErr(err, tfec2.ErrCodeClientVpnEndpointIdNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Client VPN route (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.ClientVpnRouteDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Client VPN route (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsEc2ClientVpnRouteImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	endpointID, targetSubnetID, destinationCidr, err := tfec2.ClientVpnRouteParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("client_vpn_endpoint_id", endpointID)
+	d.Set("target_vpc_subnet_id", targetSubnetID)
+	d.Set("destination_cidr_block", destinationCidr)
+
+	return []*schema.ResourceData{d}, nil
+}
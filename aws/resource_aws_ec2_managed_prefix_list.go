@@ -0,0 +1,321 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2ManagedPrefixList() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2ManagedPrefixListCreate,
+		Read:   resourceAwsEc2ManagedPrefixListRead,
+		Update: resourceAwsEc2ManagedPrefixListUpdate,
+		Delete: resourceAwsEc2ManagedPrefixListDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsEc2ManagedPrefixListCustomizeDiff,
+			requiredTagsCustomizeDiff("aws_ec2_managed_prefix_list"),
+		),
+
+		Schema: map[string]*schema.Schema{
+			"address_family": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"IPv4", "IPv6"}, false),
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"entry": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsCIDR,
+						},
+						"description": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 255),
+						},
+					},
+				},
+			},
+			"max_entries": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 255),
+					validation.StringDoesNotMatch(regexp.MustCompile(`^com\.amazonaws`), "cannot begin with com.amazonaws"),
+				),
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceAwsEc2ManagedPrefixListCustomizeDiff checks the number of entries
+// against max_entries, and marks the version as changing with the entries.
+func resourceAwsEc2ManagedPrefixListCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if entries, maxEntries := diff.Get("entry").(*schema.Set).Len(), diff.Get("max_entries").(int); maxEntries > 0 && entries > maxEntries {
+		return fmt.Errorf("the number of entries (%d) exceeds max_entries (%d)", entries, maxEntries)
+	}
+
+	if diff.Id() != "" && diff.HasChange("entry") {
+		return diff.SetNewComputed("version")
+	}
+
+	return nil
+}
+
+func resourceAwsEc2ManagedPrefixListCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.CreateManagedPrefixListInput{
+		AddressFamily:  aws.String(d.Get("address_family").(string)),
+		Entries:        expandEc2AddPrefixListEntries(d.Get("entry").(*schema.Set).List()),
+		MaxEntries:     aws.Int64(int64(d.Get("max_entries").(int))),
+		PrefixListName: aws.String(d.Get("name").(string)),
+	}
+
+	log.Printf("[DEBUG] Creating EC2 Managed Prefix List: %s", input)
+	output, err := conn.CreateManagedPrefixList(input)
+
+	if err != nil {
+		return fmt.Errorf("error creating EC2 Managed Prefix List (%s): %s", d.Get("name").(string), err)
+	}
+
+	d.SetId(aws.StringValue(output.PrefixList.PrefixListId))
+
+	if _, err := waiter.ManagedPrefixListCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Managed Prefix List (%s) creation: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 Managed Prefix List (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2ManagedPrefixListRead(d, meta)
+}
+
+func resourceAwsEc2ManagedPrefixListRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	prefixList, err := finder.ManagedPrefixListByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidPrefixListIDNotFound, "") {
+		log.Printf("[WARN] EC2 Managed Prefix List (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Managed Prefix List (%s): %s", d.Id(), err)
+	}
+
+	if prefixList == nil || aws.StringValue(prefixList.State) == ec2.PrefixListStateDeleteComplete {
+		log.Printf("[WARN] EC2 Managed Prefix List (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	entries, err := finder.ManagedPrefixListEntriesByID(conn, d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Managed Prefix List (%s) entries: %s", d.Id(), err)
+	}
+
+	d.Set("address_family", prefixList.AddressFamily)
+	d.Set("arn", prefixList.PrefixListArn)
+
+	if err := d.Set("entry", flattenEc2PrefixListEntries(entries)); err != nil {
+		return fmt.Errorf("error setting entry: %s", err)
+	}
+
+	d.Set("max_entries", prefixList.MaxEntries)
+	d.Set("name", prefixList.PrefixListName)
+	d.Set("owner_id", prefixList.OwnerId)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(prefixList.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("version", prefixList.Version)
+
+	return nil
+}
+
+func resourceAwsEc2ManagedPrefixListUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChanges("entry", "name") {
+		input := &ec2.ModifyManagedPrefixListInput{
+			PrefixListId: aws.String(d.Id()),
+		}
+
+		if d.HasChange("name") {
+			input.PrefixListName = aws.String(d.Get("name").(string))
+		}
+
+		if d.HasChange("entry") {
+			// The entries are modified against the version last read, so
+			// that changes made outside of Terraform since are not
+			// overwritten.
+			o, n := d.GetChange("entry")
+			os, ns := o.(*schema.Set), n.(*schema.Set)
+			ov, _ := d.GetChange("version")
+
+			input.CurrentVersion = aws.Int64(int64(ov.(int)))
+			input.AddEntries = expandEc2AddPrefixListEntries(ns.Difference(os).List())
+
+			// An entry whose description changed is replaced in place by
+			// adding it again, so it is only removed if its CIDR block is
+			// no longer an entry.
+			cidrs := make(map[string]bool)
+
+			for _, v := range ns.List() {
+				cidrs[v.(map[string]interface{})["cidr"].(string)] = true
+			}
+
+			for _, v := range os.Difference(ns).List() {
+				if cidr := v.(map[string]interface{})["cidr"].(string); !cidrs[cidr] {
+					input.RemoveEntries = append(input.RemoveEntries, &ec2.RemovePrefixListEntry{
+						Cidr: aws.String(cidr),
+					})
+				}
+			}
+		}
+
+		log.Printf("[DEBUG] Updating EC2 Managed Prefix List: %s", input)
+		_, err := conn.ModifyManagedPrefixList(input)
+
+		if isAWSErr(err, tfec2.ErrCodePrefixListVersionMismatch, "") {
+			return fmt.Errorf("error updating EC2 Managed Prefix List (%s): the prefix list was modified outside of Terraform, refresh and apply again: %s", d.Id(), err)
+		}
+
+		if err != nil {
+			return fmt.Errorf("error updating EC2 Managed Prefix List (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.ManagedPrefixListModified(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for EC2 Managed Prefix List (%s) update: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Managed Prefix List (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2ManagedPrefixListRead(d, meta)
+}
+
+func resourceAwsEc2ManagedPrefixListDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting EC2 Managed Prefix List: %s", d.Id())
+	_, err := conn.DeleteManagedPrefixList(&ec2.DeleteManagedPrefixListInput{
+		PrefixListId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidPrefixListIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting EC2 Managed Prefix List (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.ManagedPrefixListDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Managed Prefix List (%s) deletion: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandEc2AddPrefixListEntries(tfList []interface{}) []*ec2.AddPrefixListEntry {
+	var apiObjects []*ec2.AddPrefixListEntry
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		apiObject := &ec2.AddPrefixListEntry{
+			Cidr: aws.String(tfMap["cidr"].(string)),
+		}
+
+		if v, ok := tfMap["description"].(string); ok && v != "" {
+			apiObject.Description = aws.String(v)
+		}
+
+		apiObjects = append(apiObjects, apiObject)
+	}
+
+	return apiObjects
+}
+
+func flattenEc2PrefixListEntries(apiObjects []*ec2.PrefixListEntry) []interface{} {
+	tfList := make([]interface{}, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"cidr":        aws.StringValue(apiObject.Cidr),
+			"description": aws.StringValue(apiObject.Description),
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,280 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2TransitGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2TransitGatewayCreate,
+		Read:   resourceAwsEc2TransitGatewayRead,
+		Update: resourceAwsEc2TransitGatewayUpdate,
+		Delete: resourceAwsEc2TransitGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_ec2_transit_gateway"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"amazon_side_asn": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  64512,
+				ValidateFunc: validation.Any(
+					validation.IntBetween(64512, 65534),
+					validation.IntBetween(4200000000, 4294967294),
+				),
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"association_default_route_table_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"auto_accept_shared_attachments": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ec2.AutoAcceptSharedAttachmentsValueDisable,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.AutoAcceptSharedAttachmentsValueDisable,
+					ec2.AutoAcceptSharedAttachmentsValueEnable,
+				}, false),
+			},
+			"default_route_table_association": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ec2.DefaultRouteTableAssociationValueEnable,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.DefaultRouteTableAssociationValueDisable,
+					ec2.DefaultRouteTableAssociationValueEnable,
+				}, false),
+			},
+			"default_route_table_propagation": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ec2.DefaultRouteTablePropagationValueEnable,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.DefaultRouteTablePropagationValueDisable,
+					ec2.DefaultRouteTablePropagationValueEnable,
+				}, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dns_support": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ec2.DnsSupportValueEnable,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.DnsSupportValueDisable,
+					ec2.DnsSupportValueEnable,
+				}, false),
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"propagation_default_route_table_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+			"vpn_ecmp_support": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ec2.VpnEcmpSupportValueEnable,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.VpnEcmpSupportValueDisable,
+					ec2.VpnEcmpSupportValueEnable,
+				}, false),
+			},
+		},
+	}
+}
+
+func resourceAwsEc2TransitGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.CreateTransitGatewayInput{
+		Options: &ec2.TransitGatewayRequestOptions{
+			AmazonSideAsn:                aws.Int64(int64(d.Get("amazon_side_asn").(int))),
+			AutoAcceptSharedAttachments:  aws.String(d.Get("auto_accept_shared_attachments").(string)),
+			DefaultRouteTableAssociation: aws.String(d.Get("default_route_table_association").(string)),
+			DefaultRouteTablePropagation: aws.String(d.Get("default_route_table_propagation").(string)),
+			DnsSupport:                   aws.String(d.Get("dns_support").(string)),
+			VpnEcmpSupport:               aws.String(d.Get("vpn_ecmp_support").(string)),
+		},
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating EC2 Transit Gateway: %s", input)
+	output, err := conn.CreateTransitGateway(input)
+
+	if err != nil {
+		return fmt.Errorf("error creating EC2 Transit Gateway: %s", err)
+	}
+
+	d.SetId(aws.StringValue(output.TransitGateway.TransitGatewayId))
+
+	if _, err := waiter.TransitGatewayAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway (%s) to become available: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 Transit Gateway (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	transitGateway, err := finder.TransitGatewayByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidTransitGatewayIDNotFound, "") {
+		log.Printf("[WARN] EC2 Transit Gateway (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway (%s): %s", d.Id(), err)
+	}
+
+	if transitGateway == nil || aws.StringValue(transitGateway.State) == ec2.TransitGatewayStateDeleted {
+		log.Printf("[WARN] EC2 Transit Gateway (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if transitGateway.Options == nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway (%s): missing options", d.Id())
+	}
+
+	d.Set("amazon_side_asn", transitGateway.Options.AmazonSideAsn)
+	d.Set("arn", transitGateway.TransitGatewayArn)
+	d.Set("association_default_route_table_id", transitGateway.Options.AssociationDefaultRouteTableId)
+	d.Set("auto_accept_shared_attachments", transitGateway.Options.AutoAcceptSharedAttachments)
+	d.Set("default_route_table_association", transitGateway.Options.DefaultRouteTableAssociation)
+	d.Set("default_route_table_propagation", transitGateway.Options.DefaultRouteTablePropagation)
+	d.Set("description", transitGateway.Description)
+	d.Set("dns_support", transitGateway.Options.DnsSupport)
+	d.Set("owner_id", transitGateway.OwnerId)
+	d.Set("propagation_default_route_table_id", transitGateway.Options.PropagationDefaultRouteTableId)
+	d.Set("vpn_ecmp_support", transitGateway.Options.VpnEcmpSupport)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(transitGateway.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsEc2TransitGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChanges("auto_accept_shared_attachments", "default_route_table_association", "default_route_table_propagation", "description", "dns_support", "vpn_ecmp_support") {
+		input := &ec2.ModifyTransitGatewayInput{
+			Description: aws.String(d.Get("description").(string)),
+			Options: &ec2.ModifyTransitGatewayOptions{
+				AutoAcceptSharedAttachments:  aws.String(d.Get("auto_accept_shared_attachments").(string)),
+				DefaultRouteTableAssociation: aws.String(d.Get("default_route_table_association").(string)),
+				DefaultRouteTablePropagation: aws.String(d.Get("default_route_table_propagation").(string)),
+				DnsSupport:                   aws.String(d.Get("dns_support").(string)),
+				VpnEcmpSupport:               aws.String(d.Get("vpn_ecmp_support").(string)),
+			},
+			TransitGatewayId: aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Modifying EC2 Transit Gateway: %s", input)
+		if _, err := conn.ModifyTransitGateway(input); err != nil {
+			return fmt.Errorf("error modifying EC2 Transit Gateway (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.TransitGatewayAvailable(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for EC2 Transit Gateway (%s) update: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Transit Gateway (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.DeleteTransitGatewayInput{
+		TransitGatewayId: aws.String(d.Id()),
+	}
+
+	// Attachments that are still being deleted keep the transit gateway in use.
+	log.Printf("[INFO] Deleting EC2 Transit Gateway: %s", d.Id())
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := conn.DeleteTransitGateway(input)
+
+		if isAWSErr(err, tfec2.ErrCodeIncorrectState, "has non-deleted Transit Gateway Attachments") {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.DeleteTransitGateway(input)
+	}
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidTransitGatewayIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting EC2 Transit Gateway (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.TransitGatewayDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway (%s) deletion: %s", d.Id(), err)
+	}
+
+	return nil
+}
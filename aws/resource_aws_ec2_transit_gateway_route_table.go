@@ -0,0 +1,149 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2TransitGatewayRouteTable() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2TransitGatewayRouteTableCreate,
+		Read:   resourceAwsEc2TransitGatewayRouteTableRead,
+		Update: resourceAwsEc2TransitGatewayRouteTableUpdate,
+		Delete: resourceAwsEc2TransitGatewayRouteTableDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.TransitGatewayRouteTableCreatedTimeout),
+			Delete: schema.DefaultTimeout(waiter.TransitGatewayRouteTableDeletedTimeout),
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_ec2_transit_gateway_route_table"),
+
+		Schema: map[string]*schema.Schema{
+			"default_association_route_table": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"default_propagation_route_table": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+			"transit_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2TransitGatewayRouteTableCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.CreateTransitGatewayRouteTableInput{
+		TransitGatewayId: aws.String(d.Get("transit_gateway_id").(string)),
+	}
+
+	log.Printf("[DEBUG] Creating EC2 Transit Gateway Route Table: %s", input)
+	output, err := conn.CreateTransitGatewayRouteTable(input)
+
+	if err != nil {
+		return fmt.Errorf("error creating EC2 Transit Gateway Route Table: %s", err)
+	}
+
+	d.SetId(aws.StringValue(output.TransitGatewayRouteTable.TransitGatewayRouteTableId))
+
+	if _, err := waiter.TransitGatewayRouteTableCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway Route Table (%s) to become available: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 Transit Gateway Route Table (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayRouteTableRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayRouteTableRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	routeTable, err := finder.TransitGatewayRouteTableByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		log.Printf("[WARN] EC2 Transit Gateway Route Table (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway Route Table (%s): %s", d.Id(), err)
+	}
+
+	if routeTable == nil || aws.StringValue(routeTable.State) == ec2.TransitGatewayRouteTableStateDeleted {
+		log.Printf("[WARN] EC2 Transit Gateway Route Table (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("default_association_route_table", routeTable.DefaultAssociationRouteTable)
+	d.Set("default_propagation_route_table", routeTable.DefaultPropagationRouteTable)
+	d.Set("transit_gateway_id", routeTable.TransitGatewayId)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(routeTable.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsEc2TransitGatewayRouteTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Transit Gateway Route Table (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayRouteTableRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayRouteTableDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting EC2 Transit Gateway Route Table: %s", d.Id())
+	_, err := conn.DeleteTransitGatewayRouteTable(&ec2.DeleteTransitGatewayRouteTableInput{
+		TransitGatewayRouteTableId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting EC2 Transit Gateway Route Table (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.TransitGatewayRouteTableDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway Route Table (%s) deletion: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2TransitGatewayRouteTableAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2TransitGatewayRouteTableAssociationCreate,
+		Read:   resourceAwsEc2TransitGatewayRouteTableAssociationRead,
+		Delete: resourceAwsEc2TransitGatewayRouteTableAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.TransitGatewayRouteTableAssociationCreatedTimeout),
+			Delete: schema.DefaultTimeout(waiter.TransitGatewayRouteTableAssociationDeletedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"transit_gateway_attachment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"transit_gateway_route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2TransitGatewayRouteTableAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID := d.Get("transit_gateway_route_table_id").(string)
+	attachmentID := d.Get("transit_gateway_attachment_id").(string)
+
+	if err := ec2TransitGatewayRouteTableAssociate(conn, routeTableID, attachmentID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.SetId(tfec2.TransitGatewayRouteTableAttachmentCreateID(routeTableID, attachmentID))
+
+	return resourceAwsEc2TransitGatewayRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayRouteTableAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID, attachmentID, err := tfec2.TransitGatewayRouteTableAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	association, err := finder.TransitGatewayRouteTableAssociation(conn, routeTableID, attachmentID)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		log.Printf("[WARN] EC2 Transit Gateway Route Table (%s) not found, removing association (%s) from state", routeTableID, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway Route Table association (%s): %s", d.Id(), err)
+	}
+
+	if association == nil || aws.StringValue(association.State) == ec2.TransitGatewayAssociationStateDisassociated {
+		log.Printf("[WARN] EC2 Transit Gateway Route Table association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("resource_id", association.ResourceId)
+	d.Set("resource_type", association.ResourceType)
+	d.Set("transit_gateway_attachment_id", association.TransitGatewayAttachmentId)
+	d.Set("transit_gateway_route_table_id", routeTableID)
+
+	return nil
+}
+
+func resourceAwsEc2TransitGatewayRouteTableAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID, attachmentID, err := tfec2.TransitGatewayRouteTableAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	return ec2TransitGatewayRouteTableDisassociate(conn, routeTableID, attachmentID, d.Timeout(schema.TimeoutDelete))
+}
+
+func ec2TransitGatewayRouteTableAssociate(conn *ec2.EC2, routeTableID, attachmentID string, timeout time.Duration) error {
+	input := &ec2.AssociateTransitGatewayRouteTableInput{
+		TransitGatewayAttachmentId: aws.String(attachmentID),
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+	}
+
+	log.Printf("[DEBUG] Associating EC2 Transit Gateway Route Table: %s", input)
+	if _, err := conn.AssociateTransitGatewayRouteTable(input); err != nil {
+		return fmt.Errorf("error associating EC2 Transit Gateway Route Table (%s) with attachment (%s): %s", routeTableID, attachmentID, err)
+	}
+
+	if _, err := waiter.TransitGatewayRouteTableAssociationCreated(conn, routeTableID, attachmentID, timeout); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway Route Table (%s) association (%s): %s", routeTableID, attachmentID, err)
+	}
+
+	return nil
+}
+
+func ec2TransitGatewayRouteTableDisassociate(conn *ec2.EC2, routeTableID, attachmentID string, timeout time.Duration) error {
+	input := &ec2.DisassociateTransitGatewayRouteTableInput{
+		TransitGatewayAttachmentId: aws.String(attachmentID),
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+	}
+
+	log.Printf("[DEBUG] Disassociating EC2 Transit Gateway Route Table: %s", input)
+	_, err := conn.DisassociateTransitGatewayRouteTable(input)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") || isAWSErr(err, tfec2.ErrCodeInvalidAssociationNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error disassociating EC2 Transit Gateway Route Table (%s) from attachment (%s): %s", routeTableID, attachmentID, err)
+	}
+
+	if _, err := waiter.TransitGatewayRouteTableAssociationDeleted(conn, routeTableID, attachmentID, timeout); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway Route Table (%s) disassociation (%s): %s", routeTableID, attachmentID, err)
+	}
+
+	return nil
+}
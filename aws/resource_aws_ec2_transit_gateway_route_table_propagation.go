@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2TransitGatewayRouteTablePropagation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2TransitGatewayRouteTablePropagationCreate,
+		Read:   resourceAwsEc2TransitGatewayRouteTablePropagationRead,
+		Delete: resourceAwsEc2TransitGatewayRouteTablePropagationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.TransitGatewayRouteTablePropagationEnabledTimeout),
+			Delete: schema.DefaultTimeout(waiter.TransitGatewayRouteTablePropagationDisabledTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"transit_gateway_attachment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"transit_gateway_route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2TransitGatewayRouteTablePropagationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID := d.Get("transit_gateway_route_table_id").(string)
+	attachmentID := d.Get("transit_gateway_attachment_id").(string)
+
+	if err := ec2TransitGatewayRouteTableEnablePropagation(conn, routeTableID, attachmentID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.SetId(tfec2.TransitGatewayRouteTableAttachmentCreateID(routeTableID, attachmentID))
+
+	return resourceAwsEc2TransitGatewayRouteTablePropagationRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayRouteTablePropagationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID, attachmentID, err := tfec2.TransitGatewayRouteTableAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	propagation, err := finder.TransitGatewayRouteTablePropagation(conn, routeTableID, attachmentID)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		log.Printf("[WARN] EC2 Transit Gateway Route Table (%s) not found, removing propagation (%s) from state", routeTableID, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway Route Table propagation (%s): %s", d.Id(), err)
+	}
+
+	if propagation == nil || aws.StringValue(propagation.State) == ec2.TransitGatewayPropagationStateDisabled {
+		log.Printf("[WARN] EC2 Transit Gateway Route Table propagation (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("resource_id", propagation.ResourceId)
+	d.Set("resource_type", propagation.ResourceType)
+	d.Set("transit_gateway_attachment_id", propagation.TransitGatewayAttachmentId)
+	d.Set("transit_gateway_route_table_id", routeTableID)
+
+	return nil
+}
+
+func resourceAwsEc2TransitGatewayRouteTablePropagationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID, attachmentID, err := tfec2.TransitGatewayRouteTableAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	return ec2TransitGatewayRouteTableDisablePropagation(conn, routeTableID, attachmentID, d.Timeout(schema.TimeoutDelete))
+}
+
+func ec2TransitGatewayRouteTableEnablePropagation(conn *ec2.EC2, routeTableID, attachmentID string, timeout time.Duration) error {
+	input := &ec2.EnableTransitGatewayRouteTablePropagationInput{
+		TransitGatewayAttachmentId: aws.String(attachmentID),
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+	}
+
+	log.Printf("[DEBUG] Enabling EC2 Transit Gateway Route Table propagation: %s", input)
+	if _, err := conn.EnableTransitGatewayRouteTablePropagation(input); err != nil {
+		return fmt.Errorf("error enabling EC2 Transit Gateway Route Table (%s) propagation (%s): %s", routeTableID, attachmentID, err)
+	}
+
+	if _, err := waiter.TransitGatewayRouteTablePropagationEnabled(conn, routeTableID, attachmentID, timeout); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway Route Table (%s) propagation (%s) to be enabled: %s", routeTableID, attachmentID, err)
+	}
+
+	return nil
+}
+
+func ec2TransitGatewayRouteTableDisablePropagation(conn *ec2.EC2, routeTableID, attachmentID string, timeout time.Duration) error {
+	input := &ec2.DisableTransitGatewayRouteTablePropagationInput{
+		TransitGatewayAttachmentId: aws.String(attachmentID),
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+	}
+
+	log.Printf("[DEBUG] Disabling EC2 Transit Gateway Route Table propagation: %s", input)
+	_, err := conn.DisableTransitGatewayRouteTablePropagation(input)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") || isAWSErr(err, tfec2.ErrCodeTransitGatewayRouteTablePropagationNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error disabling EC2 Transit Gateway Route Table (%s) propagation (%s): %s", routeTableID, attachmentID, err)
+	}
+
+	if _, err := waiter.TransitGatewayRouteTablePropagationDisabled(conn, routeTableID, attachmentID, timeout); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway Route Table (%s) propagation (%s) to be disabled: %s", routeTableID, attachmentID, err)
+	}
+
+	return nil
+}
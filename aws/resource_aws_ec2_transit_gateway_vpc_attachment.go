@@ -0,0 +1,373 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEc2TransitGatewayVpcAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2TransitGatewayVpcAttachmentCreate,
+		Read:   resourceAwsEc2TransitGatewayVpcAttachmentRead,
+		Update: resourceAwsEc2TransitGatewayVpcAttachmentUpdate,
+		Delete: resourceAwsEc2TransitGatewayVpcAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_ec2_transit_gateway_vpc_attachment"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dns_support": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ec2.DnsSupportValueEnable,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.DnsSupportValueDisable,
+					ec2.DnsSupportValueEnable,
+				}, false),
+			},
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"tags": tagsSchema(),
+			"transit_gateway_default_route_table_association": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"transit_gateway_default_route_table_propagation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"transit_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vpc_owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	transitGatewayID := d.Get("transit_gateway_id").(string)
+
+	input := &ec2.CreateTransitGatewayVpcAttachmentInput{
+		Options: &ec2.CreateTransitGatewayVpcAttachmentRequestOptions{
+			DnsSupport: aws.String(d.Get("dns_support").(string)),
+		},
+		SubnetIds:        expandStringSet(d.Get("subnet_ids").(*schema.Set)),
+		TransitGatewayId: aws.String(transitGatewayID),
+		VpcId:            aws.String(d.Get("vpc_id").(string)),
+	}
+
+	log.Printf("[DEBUG] Creating EC2 Transit Gateway VPC Attachment: %s", input)
+	output, err := conn.CreateTransitGatewayVpcAttachment(input)
+
+	if err != nil {
+		return fmt.Errorf("error creating EC2 Transit Gateway VPC Attachment: %s", err)
+	}
+
+	d.SetId(aws.StringValue(output.TransitGatewayVpcAttachment.TransitGatewayAttachmentId))
+
+	attachment, err := waiter.TransitGatewayVpcAttachmentCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway VPC Attachment (%s) creation: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 Transit Gateway VPC Attachment (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	// An attachment to a shared transit gateway is only associated with and
+	// propagated to the default route tables once the owner accepts it.
+	if aws.StringValue(attachment.State) == ec2.TransitGatewayAttachmentStateAvailable {
+		if err := ec2TransitGatewayVpcAttachmentUpdateDefaultRouteTables(d, conn, meta.(*AWSClient).accountid, transitGatewayID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayVpcAttachmentRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	attachment, err := finder.TransitGatewayVpcAttachmentByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidTransitGatewayAttachmentIDNotFound, "") {
+		log.Printf("[WARN] EC2 Transit Gateway VPC Attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway VPC Attachment (%s): %s", d.Id(), err)
+	}
+
+	if attachment == nil || ec2TransitGatewayVpcAttachmentGone(attachment) {
+		log.Printf("[WARN] EC2 Transit Gateway VPC Attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	transitGatewayID := aws.StringValue(attachment.TransitGatewayId)
+	association, propagation, err := ec2TransitGatewayVpcAttachmentDefaultRouteTables(conn, meta.(*AWSClient).accountid, transitGatewayID, d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	if attachment.Options != nil {
+		d.Set("dns_support", attachment.Options.DnsSupport)
+	}
+
+	if err := d.Set("subnet_ids", aws.StringValueSlice(attachment.SubnetIds)); err != nil {
+		return fmt.Errorf("error setting subnet_ids: %s", err)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(attachment.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("transit_gateway_default_route_table_association", association)
+	d.Set("transit_gateway_default_route_table_propagation", propagation)
+	d.Set("transit_gateway_id", transitGatewayID)
+	d.Set("vpc_id", attachment.VpcId)
+	d.Set("vpc_owner_id", attachment.VpcOwnerId)
+
+	return nil
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChanges("dns_support", "subnet_ids") {
+		input := &ec2.ModifyTransitGatewayVpcAttachmentInput{
+			TransitGatewayAttachmentId: aws.String(d.Id()),
+		}
+
+		if d.HasChange("dns_support") {
+			input.Options = &ec2.ModifyTransitGatewayVpcAttachmentRequestOptions{
+				DnsSupport: aws.String(d.Get("dns_support").(string)),
+			}
+		}
+
+		if d.HasChange("subnet_ids") {
+			o, n := d.GetChange("subnet_ids")
+			os := o.(*schema.Set)
+			ns := n.(*schema.Set)
+
+			if add := ns.Difference(os); add.Len() > 0 {
+				input.AddSubnetIds = expandStringSet(add)
+			}
+
+			if remove := os.Difference(ns); remove.Len() > 0 {
+				input.RemoveSubnetIds = expandStringSet(remove)
+			}
+		}
+
+		log.Printf("[DEBUG] Modifying EC2 Transit Gateway VPC Attachment: %s", input)
+		if _, err := conn.ModifyTransitGatewayVpcAttachment(input); err != nil {
+			return fmt.Errorf("error modifying EC2 Transit Gateway VPC Attachment (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.TransitGatewayVpcAttachmentModified(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for EC2 Transit Gateway VPC Attachment (%s) update: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChanges("transit_gateway_default_route_table_association", "transit_gateway_default_route_table_propagation") {
+		if err := ec2TransitGatewayVpcAttachmentUpdateDefaultRouteTables(d, conn, meta.(*AWSClient).accountid, d.Get("transit_gateway_id").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Transit Gateway VPC Attachment (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayVpcAttachmentRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting EC2 Transit Gateway VPC Attachment: %s", d.Id())
+	_, err := conn.DeleteTransitGatewayVpcAttachment(&ec2.DeleteTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidTransitGatewayAttachmentIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting EC2 Transit Gateway VPC Attachment (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.TransitGatewayVpcAttachmentDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway VPC Attachment (%s) deletion: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func ec2TransitGatewayVpcAttachmentGone(attachment *ec2.TransitGatewayVpcAttachment) bool {
+	switch aws.StringValue(attachment.State) {
+	case ec2.TransitGatewayAttachmentStateDeleted, ec2.TransitGatewayAttachmentStateDeleting, ec2.TransitGatewayAttachmentStateFailed, ec2.TransitGatewayAttachmentStateRejected:
+		return true
+	}
+
+	return false
+}
+
+// ec2TransitGatewayVpcAttachmentDefaultRouteTables returns whether an
+// attachment is associated with and propagated to its transit gateway's
+// default route tables. Only the transit gateway owner can see its route
+// tables, so other accounts always see both as true.
+func ec2TransitGatewayVpcAttachmentDefaultRouteTables(conn *ec2.EC2, accountID, transitGatewayID, attachmentID string) (bool, bool, error) {
+	transitGateway, err := finder.TransitGatewayByID(conn, transitGatewayID)
+
+	if err != nil {
+		return false, false, fmt.Errorf("error reading EC2 Transit Gateway (%s): %s", transitGatewayID, err)
+	}
+
+	if transitGateway == nil || transitGateway.Options == nil {
+		return false, false, fmt.Errorf("error reading EC2 Transit Gateway (%s): not found", transitGatewayID)
+	}
+
+	if aws.StringValue(transitGateway.OwnerId) != accountID {
+		return true, true, nil
+	}
+
+	association := true
+
+	if routeTableID := aws.StringValue(transitGateway.Options.AssociationDefaultRouteTableId); routeTableID != "" {
+		result, err := finder.TransitGatewayRouteTableAssociation(conn, routeTableID, attachmentID)
+
+		if err != nil {
+			return false, false, fmt.Errorf("error reading EC2 Transit Gateway Route Table (%s) association (%s): %s", routeTableID, attachmentID, err)
+		}
+
+		association = result != nil
+	}
+
+	propagation := true
+
+	if routeTableID := aws.StringValue(transitGateway.Options.PropagationDefaultRouteTableId); routeTableID != "" {
+		result, err := finder.TransitGatewayRouteTablePropagation(conn, routeTableID, attachmentID)
+
+		if err != nil {
+			return false, false, fmt.Errorf("error reading EC2 Transit Gateway Route Table (%s) propagation (%s): %s", routeTableID, attachmentID, err)
+		}
+
+		propagation = result != nil
+	}
+
+	return association, propagation, nil
+}
+
+// ec2TransitGatewayVpcAttachmentUpdateDefaultRouteTables associates the
+// attachment with, or disassociates it from, the transit gateway's default
+// association route table, and likewise enables or disables propagation to
+// its default propagation route table. Only the transit gateway owner can
+// change either.
+func ec2TransitGatewayVpcAttachmentUpdateDefaultRouteTables(d *schema.ResourceData, conn *ec2.EC2, accountID, transitGatewayID string, timeout time.Duration) error {
+	transitGateway, err := finder.TransitGatewayByID(conn, transitGatewayID)
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway (%s): %s", transitGatewayID, err)
+	}
+
+	if transitGateway == nil || transitGateway.Options == nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway (%s): not found", transitGatewayID)
+	}
+
+	if aws.StringValue(transitGateway.OwnerId) != accountID {
+		if !d.Get("transit_gateway_default_route_table_association").(bool) || !d.Get("transit_gateway_default_route_table_propagation").(bool) {
+			return fmt.Errorf("EC2 Transit Gateway (%s) default route table association and propagation can only be disabled by its owner (%s)", transitGatewayID, aws.StringValue(transitGateway.OwnerId))
+		}
+
+		return nil
+	}
+
+	attachmentID := d.Id()
+
+	if routeTableID := aws.StringValue(transitGateway.Options.AssociationDefaultRouteTableId); routeTableID != "" {
+		association, err := finder.TransitGatewayRouteTableAssociation(conn, routeTableID, attachmentID)
+
+		if err != nil {
+			return fmt.Errorf("error reading EC2 Transit Gateway Route Table (%s) association (%s): %s", routeTableID, attachmentID, err)
+		}
+
+		if enabled := d.Get("transit_gateway_default_route_table_association").(bool); enabled && association == nil {
+			if err := ec2TransitGatewayRouteTableAssociate(conn, routeTableID, attachmentID, timeout); err != nil {
+				return err
+			}
+		} else if !enabled && association != nil {
+			if err := ec2TransitGatewayRouteTableDisassociate(conn, routeTableID, attachmentID, timeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	if routeTableID := aws.StringValue(transitGateway.Options.PropagationDefaultRouteTableId); routeTableID != "" {
+		propagation, err := finder.TransitGatewayRouteTablePropagation(conn, routeTableID, attachmentID)
+
+		if err != nil {
+			return fmt.Errorf("error reading EC2 Transit Gateway Route Table (%s) propagation (%s): %s", routeTableID, attachmentID, err)
+		}
+
+		if enabled := d.Get("transit_gateway_default_route_table_propagation").(bool); enabled && propagation == nil {
+			if err := ec2TransitGatewayRouteTableEnablePropagation(conn, routeTableID, attachmentID, timeout); err != nil {
+				return err
+			}
+		} else if !enabled && propagation != nil {
+			if err := ec2TransitGatewayRouteTableDisablePropagation(conn, routeTableID, attachmentID, timeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+// resourceAwsEc2TransitGatewayVpcAttachmentAccepter accepts, on behalf of the
+// transit gateway owner, a VPC attachment created by another account for a
+// transit gateway shared through RAM.
+func resourceAwsEc2TransitGatewayVpcAttachmentAccepter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEc2TransitGatewayVpcAttachmentAccepterCreate,
+		Read:   resourceAwsEc2TransitGatewayVpcAttachmentAccepterRead,
+		Update: resourceAwsEc2TransitGatewayVpcAttachmentAccepterUpdate,
+		Delete: resourceAwsEc2TransitGatewayVpcAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				d.Set("transit_gateway_attachment_id", d.Id())
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_ec2_transit_gateway_vpc_attachment_accepter"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dns_support": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"tags": tagsSchema(),
+			"transit_gateway_attachment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"transit_gateway_default_route_table_association": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"transit_gateway_default_route_table_propagation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"transit_gateway_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentAccepterCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.AcceptTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: aws.String(d.Get("transit_gateway_attachment_id").(string)),
+	}
+
+	log.Printf("[DEBUG] Accepting EC2 Transit Gateway VPC Attachment: %s", input)
+	output, err := conn.AcceptTransitGatewayVpcAttachment(input)
+
+	if err != nil {
+		return fmt.Errorf("error accepting EC2 Transit Gateway VPC Attachment: %s", err)
+	}
+
+	d.SetId(aws.StringValue(output.TransitGatewayVpcAttachment.TransitGatewayAttachmentId))
+	transitGatewayID := aws.StringValue(output.TransitGatewayVpcAttachment.TransitGatewayId)
+
+	if _, err := waiter.TransitGatewayVpcAttachmentAccepted(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Transit Gateway VPC Attachment (%s) to be accepted: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 Transit Gateway VPC Attachment (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	if err := ec2TransitGatewayVpcAttachmentUpdateDefaultRouteTables(d, conn, meta.(*AWSClient).accountid, transitGatewayID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceAwsEc2TransitGatewayVpcAttachmentAccepterRead(d, meta)
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentAccepterRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	attachment, err := finder.TransitGatewayVpcAttachmentByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidTransitGatewayAttachmentIDNotFound, "") {
+		log.Printf("[WARN] EC2 Transit Gateway VPC Attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Transit Gateway VPC Attachment (%s): %s", d.Id(), err)
+	}
+
+	if attachment == nil || ec2TransitGatewayVpcAttachmentGone(attachment) {
+		log.Printf("[WARN] EC2 Transit Gateway VPC Attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	transitGatewayID := aws.StringValue(attachment.TransitGatewayId)
+	association, propagation, err := ec2TransitGatewayVpcAttachmentDefaultRouteTables(conn, meta.(*AWSClient).accountid, transitGatewayID, d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	if attachment.Options != nil {
+		d.Set("dns_support", attachment.Options.DnsSupport)
+	}
+
+	if err := d.Set("subnet_ids", aws.StringValueSlice(attachment.SubnetIds)); err != nil {
+		return fmt.Errorf("error setting subnet_ids: %s", err)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(attachment.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("transit_gateway_attachment_id", d.Id())
+	d.Set("transit_gateway_default_route_table_association", association)
+	d.Set("transit_gateway_default_route_table_propagation", propagation)
+	d.Set("transit_gateway_id", transitGatewayID)
+	d.Set("vpc_id", attachment.VpcId)
+	d.Set("vpc_owner_id", attachment.VpcOwnerId)
+
+	return nil
+}
+
+func resourceAwsEc2TransitGatewayVpcAttachmentAccepterUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChanges("transit_gateway_default_route_table_association", "transit_gateway_default_route_table_propagation") {
+		if err := ec2TransitGatewayVpcAttachmentUpdateDefaultRouteTables(d, conn, meta.(*AWSClient).accountid, d.Get("transit_gateway_id").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Transit Gateway VPC Attachment (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEc2TransitGatewayVpcAttachmentAccepterRead(d, meta)
+}
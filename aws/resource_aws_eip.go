@@ -0,0 +1,502 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsEip() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEipCreate,
+		Read:   resourceAwsEipRead,
+		Update: resourceAwsEipUpdate,
+		Delete: resourceAwsEipDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_eip"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Read:   schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(3 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"instance": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"network_interface"},
+			},
+			"network_interface": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"instance"},
+			},
+			"allocation_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"association_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"public_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"public_dns": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_dns": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"associate_with_private_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"public_ipv4_pool": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"customer_owned_ipv4_pool": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"customer_owned_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsEipCreate(d *schema.ResourceData, meta interface{}) error {
+	ec2conn := meta.(*AWSClient).ec2conn
+
+	// By default, we're not in a VPC
+	domainOpt := ""
+	if v := d.Get("vpc"); v != nil && v.(bool) {
+		domainOpt = ec2.DomainTypeVpc
+	}
+
+	allocOpts := &ec2.AllocateAddressInput{
+		Domain: aws.String(domainOpt),
+	}
+
+	if v, ok := d.GetOk("public_ipv4_pool"); ok {
+		allocOpts.PublicIpv4Pool = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("customer_owned_ipv4_pool"); ok {
+		allocOpts.CustomerOwnedIpv4Pool = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] EIP create configuration: %#v", allocOpts)
+	allocResp, err := ec2conn.AllocateAddress(allocOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating EIP: %s", err)
+	}
+
+	// The domain tells us if we're in a VPC or not
+	d.Set("domain", allocResp.Domain)
+
+	// Assign the eips (unique) allocation id for use later
+	// the EIP api has a conditional unique ID (really), so
+	// if we're in a VPC we need to save the ID as such, otherwise
+	// it defaults to using the public IP
+	log.Printf("[DEBUG] EIP Allocate: %#v", allocResp)
+	if d.Get("domain").(string) == ec2.DomainTypeVpc {
+		d.SetId(aws.StringValue(allocResp.AllocationId))
+	} else {
+		d.SetId(aws.StringValue(allocResp.PublicIp))
+	}
+
+	log.Printf("[INFO] EIP ID: %s (domain: %v)", d.Id(), aws.StringValue(allocResp.Domain))
+
+	if _, err := waiter.EipAvailable(ec2conn, d.Id(), d.Timeout(schema.TimeoutRead)); err != nil {
+		return fmt.Errorf("error waiting for EIP (%s) to become available: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if d.Get("domain").(string) != ec2.DomainTypeVpc {
+			return fmt.Errorf("tags can not be set for an EIP in the standard domain")
+		}
+
+		if err := keyvaluetags.Ec2CreateTags(ec2conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 EIP (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEipUpdate(d, meta)
+}
+
+func resourceAwsEipRead(d *schema.ResourceData, meta interface{}) error {
+	ec2conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	domain := resourceAwsEipDomain(d)
+	id := d.Id()
+
+	req := &ec2.DescribeAddressesInput{}
+
+	if domain == ec2.DomainTypeVpc {
+		req.AllocationIds = []*string{aws.String(id)}
+	} else {
+		req.PublicIps = []*string{aws.String(id)}
+	}
+
+	log.Printf(
+		"[DEBUG] EIP describe configuration: %s (domain: %s)",
+		req, domain)
+
+	var err error
+	var describeAddresses *ec2.DescribeAddressesOutput
+
+	if d.IsNewResource() {
+		err := resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
+			describeAddresses, err = ec2conn.DescribeAddresses(req)
+			if err != nil {
+				if isAWSErr(err, "InvalidAllocationID.NotFound", "") {
+					return resource.RetryableError(err)
+				}
+
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+		if isResourceTimeoutError(err) {
+			describeAddresses, err = ec2conn.DescribeAddresses(req)
+		}
+		if err != nil {
+			return fmt.Errorf("Error retrieving EIP: %s", err)
+		}
+	} else {
+		describeAddresses, err = ec2conn.DescribeAddresses(req)
+		if err != nil {
+			if isAWSErr(err, "InvalidAllocationID.NotFound", "") || isAWSErr(err, "InvalidAddress.NotFound", "") {
+				log.Printf("[WARN] EIP not found, removing from state: %s", req)
+				d.SetId("")
+				return nil
+			}
+			return err
+		}
+	}
+
+	var address *ec2.Address
+
+	// In the case that AWS returns more EIPs than we intend it to, we loop
+	// over the returned addresses to see if it's in the list of results
+	for _, addr := range describeAddresses.Addresses {
+		if (domain == ec2.DomainTypeVpc && aws.StringValue(addr.AllocationId) == id) || aws.StringValue(addr.PublicIp) == id {
+			address = addr
+			break
+		}
+	}
+
+	if address == nil {
+		log.Printf("[WARN] EIP %q not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("association_id", address.AssociationId)
+	if address.InstanceId != nil {
+		d.Set("instance", address.InstanceId)
+	} else {
+		d.Set("instance", "")
+	}
+	if address.NetworkInterfaceId != nil {
+		d.Set("network_interface", address.NetworkInterfaceId)
+	} else {
+		d.Set("network_interface", "")
+	}
+
+	d.Set("private_ip", address.PrivateIpAddress)
+	if v := aws.StringValue(address.PrivateIpAddress); v != "" {
+		d.Set("private_dns", fmt.Sprintf("ip-%s.%s", resourceAwsEc2DashIP(v), resourceAwsEc2RegionalPrivateDnsSuffix(meta.(*AWSClient).region)))
+	} else {
+		d.Set("private_dns", nil)
+	}
+
+	d.Set("public_ip", address.PublicIp)
+	if v := aws.StringValue(address.PublicIp); v != "" {
+		d.Set("public_dns", meta.(*AWSClient).PartitionHostname(fmt.Sprintf("ec2-%s.%s", resourceAwsEc2DashIP(v), resourceAwsEc2RegionalPublicDnsSuffix(meta.(*AWSClient).region))))
+	} else {
+		d.Set("public_dns", nil)
+	}
+
+	d.Set("public_ipv4_pool", address.PublicIpv4Pool)
+	d.Set("customer_owned_ipv4_pool", address.CustomerOwnedIpv4Pool)
+	d.Set("customer_owned_ip", address.CustomerOwnedIp)
+
+	// On import (domain never set, which it must've been if we created),
+	// set the 'vpc' attribute depending on if we're in a VPC.
+	if address.Domain != nil {
+		d.Set("vpc", aws.StringValue(address.Domain) == ec2.DomainTypeVpc)
+	}
+
+	d.Set("domain", address.Domain)
+
+	// Force ID to be an Allocation ID if we're on a VPC
+	// This allows users to import the EIP based on the IP if they are in a VPC
+	if aws.StringValue(address.Domain) == ec2.DomainTypeVpc && net.ParseIP(id) != nil {
+		log.Printf("[DEBUG] Re-assigning EIP ID (%s) to it's Allocation ID (%s)", d.Id(), aws.StringValue(address.AllocationId))
+		d.SetId(aws.StringValue(address.AllocationId))
+	}
+
+	d.Set("allocation_id", address.AllocationId)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(address.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsEipUpdate(d *schema.ResourceData, meta interface{}) error {
+	ec2conn := meta.(*AWSClient).ec2conn
+
+	domain := resourceAwsEipDomain(d)
+
+	// If we are updating an EIP that is not newly created, and we are attached to
+	// an instance or interface, detach first.
+	disassociate := false
+	if !d.IsNewResource() {
+		if d.HasChange("instance") && d.Get("instance").(string) != "" {
+			disassociate = true
+		} else if (d.HasChange("network_interface") || d.HasChange("associate_with_private_ip")) && d.Get("association_id").(string) != "" {
+			disassociate = true
+		}
+	}
+	if disassociate {
+		if err := disassociateEip(d, meta); err != nil {
+			return err
+		}
+	}
+
+	// Associate to instance or interface if specified
+	associate := false
+	v_instance, ok_instance := d.GetOk("instance")
+	v_interface, ok_interface := d.GetOk("network_interface")
+
+	if d.HasChange("instance") && ok_instance {
+		associate = true
+	} else if (d.HasChange("network_interface") || d.HasChange("associate_with_private_ip")) && ok_interface {
+		associate = true
+	}
+	if associate {
+		instanceId := v_instance.(string)
+		networkInterfaceId := v_interface.(string)
+
+		assocOpts := &ec2.AssociateAddressInput{
+			InstanceId: aws.String(instanceId),
+			PublicIp:   aws.String(d.Id()),
+		}
+
+		// more unique ID conditionals
+		if domain == ec2.DomainTypeVpc {
+			var privateIpAddress *string
+			if v := d.Get("associate_with_private_ip").(string); v != "" {
+				privateIpAddress = aws.String(v)
+			}
+			assocOpts = &ec2.AssociateAddressInput{
+				NetworkInterfaceId: aws.String(networkInterfaceId),
+				InstanceId:         aws.String(instanceId),
+				AllocationId:       aws.String(d.Id()),
+				PrivateIpAddress:   privateIpAddress,
+			}
+		}
+
+		log.Printf("[DEBUG] EIP associate configuration: %s (domain: %s)", assocOpts, domain)
+
+		err := resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+			_, err := ec2conn.AssociateAddress(assocOpts)
+			if err != nil {
+				if isAWSErr(err, "InvalidAllocationID.NotFound", "") {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+		if isResourceTimeoutError(err) {
+			_, err = ec2conn.AssociateAddress(assocOpts)
+		}
+		if err != nil {
+			// Prevent saving instance if association failed
+			// e.g. missing internet gateway in VPC
+			d.Set("instance", "")
+			d.Set("network_interface", "")
+			return fmt.Errorf("Failure associating EIP: %s", err)
+		}
+	}
+
+	if d.HasChange("tags") && !d.IsNewResource() {
+		o, n := d.GetChange("tags")
+		if err := keyvaluetags.Ec2UpdateTags(ec2conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EIP (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsEipRead(d, meta)
+}
+
+func resourceAwsEipDelete(d *schema.ResourceData, meta interface{}) error {
+	ec2conn := meta.(*AWSClient).ec2conn
+
+	if err := resourceAwsEipRead(d, meta); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		// This might happen from the read
+		return nil
+	}
+
+	// If we are attached to an instance or interface, detach first.
+	if d.Get("instance").(string) != "" || d.Get("association_id").(string) != "" {
+		if err := disassociateEip(d, meta); err != nil {
+			return err
+		}
+	}
+
+	domain := resourceAwsEipDomain(d)
+
+	var input *ec2.ReleaseAddressInput
+	switch domain {
+	case ec2.DomainTypeVpc:
+		log.Printf("[DEBUG] EIP release (destroy) address allocation: %v", d.Id())
+		input = &ec2.ReleaseAddressInput{
+			AllocationId: aws.String(d.Id()),
+		}
+	case ec2.DomainTypeStandard:
+		log.Printf("[DEBUG] EIP release (destroy) address: %v", d.Id())
+		input = &ec2.ReleaseAddressInput{
+			PublicIp: aws.String(d.Id()),
+		}
+	}
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := ec2conn.ReleaseAddress(input)
+		if isAWSErr(err, "InvalidIPAddress.InUse", "") {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = ec2conn.ReleaseAddress(input)
+	}
+	if isAWSErr(err, "InvalidAllocationID.NotFound", "") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error releasing EIP (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.EipDeleted(ec2conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EIP (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsEipDomain(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("domain"); ok {
+		return v.(string)
+	} else if strings.Contains(d.Id(), "eipalloc") {
+		// We have to do this for backwards compatibility since TF 0.1
+		// didn't have the "domain" computed attribute.
+		return ec2.DomainTypeVpc
+	}
+
+	return ec2.DomainTypeStandard
+}
+
+func resourceAwsEc2DashIP(ip string) string {
+	return strings.Replace(ip, ".", "-", -1)
+}
+
+func resourceAwsEc2RegionalPrivateDnsSuffix(region string) string {
+	if region == "us-east-1" {
+		return "ec2.internal"
+	}
+
+	return fmt.Sprintf("%s.compute.internal", region)
+}
+
+func resourceAwsEc2RegionalPublicDnsSuffix(region string) string {
+	if region == "us-east-1" {
+		return "compute-1"
+	}
+
+	return fmt.Sprintf("%s.compute", region)
+}
+
+func disassociateEip(d *schema.ResourceData, meta interface{}) error {
+	ec2conn := meta.(*AWSClient).ec2conn
+	log.Printf("[DEBUG] Disassociating EIP: %s", d.Id())
+	var err error
+	switch resourceAwsEipDomain(d) {
+	case ec2.DomainTypeVpc:
+		associationID := d.Get("association_id").(string)
+		if associationID == "" {
+			// If assiciationID is empty, it means there's no association.
+			// Hence this disassociation can be skipped.
+			return nil
+		}
+		_, err = ec2conn.DisassociateAddress(&ec2.DisassociateAddressInput{
+			AssociationId: aws.String(associationID),
+		})
+	case ec2.DomainTypeStandard:
+		_, err = ec2conn.DisassociateAddress(&ec2.DisassociateAddressInput{
+			PublicIp: aws.String(d.Get("public_ip").(string)),
+		})
+	}
+
+	// First check if the association ID is not found. If this
+	// is the case, then it was already disassociated somehow,
+	// and that is okay. The most commmon reason for this is that
+	// the instance or ENI it was attached it was destroyed.
+	if isAWSErr(err, "InvalidAssociationID.NotFound", "") {
+		err = nil
+	}
+	return err
+}
@@ -0,0 +1,298 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsFlowLogCreate,
+		Read:   resourceAwsFlowLogRead,
+		Update: resourceAwsFlowLogUpdate,
+		Delete: resourceAwsFlowLogDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_flow_log"),
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"eni_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"eni_id", "subnet_id", "vpc_id"},
+			},
+
+			"iam_role_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"log_destination": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateArn,
+				ConflictsWith: []string{"log_group_name"},
+			},
+
+			"log_destination_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  ec2.LogDestinationTypeCloudWatchLogs,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.LogDestinationTypeCloudWatchLogs,
+					ec2.LogDestinationTypeS3,
+				}, false),
+			},
+
+			"log_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+
+			"log_group_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"log_destination"},
+			},
+
+			"max_aggregation_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      600,
+				ValidateFunc: validation.IntInSlice([]int{60, 600}),
+			},
+
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"eni_id", "subnet_id", "vpc_id"},
+			},
+
+			"tags": tagsSchema(),
+
+			"traffic_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.TrafficTypeAccept,
+					ec2.TrafficTypeAll,
+					ec2.TrafficTypeReject,
+				}, false),
+			},
+
+			"vpc_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"eni_id", "subnet_id", "vpc_id"},
+			},
+		},
+	}
+}
+
+func resourceAwsFlowLogCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	var resourceID, resourceType string
+
+	for _, v := range []struct {
+		key          string
+		resourceType string
+	}{
+		{"vpc_id", ec2.FlowLogsResourceTypeVpc},
+		{"subnet_id", ec2.FlowLogsResourceTypeSubnet},
+		{"eni_id", ec2.FlowLogsResourceTypeNetworkInterface},
+	} {
+		if id := d.Get(v.key).(string); id != "" {
+			resourceID = id
+			resourceType = v.resourceType
+		}
+	}
+
+	input := &ec2.CreateFlowLogsInput{
+		LogDestinationType:     aws.String(d.Get("log_destination_type").(string)),
+		MaxAggregationInterval: aws.Int64(int64(d.Get("max_aggregation_interval").(int))),
+		ResourceIds:            aws.StringSlice([]string{resourceID}),
+		ResourceType:           aws.String(resourceType),
+		TrafficType:            aws.String(d.Get("traffic_type").(string)),
+	}
+
+	if v, ok := d.GetOk("iam_role_arn"); ok {
+		input.DeliverLogsPermissionArn = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("log_destination"); ok {
+		input.LogDestination = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("log_format"); ok {
+		input.LogFormat = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("log_group_name"); ok {
+		input.LogGroupName = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating Flow Log: %s", input)
+	output, err := conn.CreateFlowLogs(input)
+
+	if err != nil {
+		return fmt.Errorf("error creating Flow Log for (%s): %s", resourceID, err)
+	}
+
+	if output != nil && len(output.Unsuccessful) > 0 && output.Unsuccessful[0].Error != nil {
+		return fmt.Errorf("error creating Flow Log for (%s): %s: %s", resourceID, aws.StringValue(output.Unsuccessful[0].Error.Code), aws.StringValue(output.Unsuccessful[0].Error.Message))
+	}
+
+	if output == nil || len(output.FlowLogIds) != 1 {
+		return fmt.Errorf("error creating Flow Log for (%s): empty response", resourceID)
+	}
+
+	d.SetId(aws.StringValue(output.FlowLogIds[0]))
+
+	if _, err := waiter.FlowLogActive(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Flow Log (%s) to become active: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding Flow Log (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsFlowLogRead(d, meta)
+}
+
+func resourceAwsFlowLogRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	fl, err := finder.FlowLogByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidFlowLogIdNotFound, "") {
+		log.Printf("[WARN] Flow Log (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Flow Log (%s): %s", d.Id(), err)
+	}
+
+	if fl == nil {
+		log.Printf("[WARN] Flow Log (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("iam_role_arn", fl.DeliverLogsPermissionArn)
+	d.Set("log_destination", fl.LogDestination)
+	d.Set("log_destination_type", fl.LogDestinationType)
+	d.Set("log_format", fl.LogFormat)
+	d.Set("log_group_name", fl.LogGroupName)
+	d.Set("max_aggregation_interval", fl.MaxAggregationInterval)
+	d.Set("traffic_type", fl.TrafficType)
+
+	resourceID := aws.StringValue(fl.ResourceId)
+
+	switch {
+	case strings.HasPrefix(resourceID, "vpc-"):
+		d.Set("vpc_id", resourceID)
+	case strings.HasPrefix(resourceID, "subnet-"):
+		d.Set("subnet_id", resourceID)
+	case strings.HasPrefix(resourceID, "eni-"):
+		d.Set("eni_id", resourceID)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(fl.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	arn := arn.ARN{
+		Partition: meta.(*AWSClient).partition,
+		Service:   "ec2",
+		Region:    meta.(*AWSClient).region,
+		AccountID: meta.(*AWSClient).accountid,
+		Resource:  fmt.Sprintf("vpc-flow-log/%s", d.Id()),
+	}.String()
+	d.Set("arn", arn)
+
+	return nil
+}
+
+func resourceAwsFlowLogUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating Flow Log (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsFlowLogRead(d, meta)
+}
+
+func resourceAwsFlowLogDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting Flow Log: %s", d.Id())
+	output, err := conn.DeleteFlowLogs(&ec2.DeleteFlowLogsInput{
+		FlowLogIds: aws.StringSlice([]string{d.Id()}),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error deleting Flow Log (%s): %s", d.Id(), err)
+	}
+
+	if output != nil && len(output.Unsuccessful) > 0 && output.Unsuccessful[0].Error != nil {
+		if code := aws.StringValue(output.Unsuccessful[0].Error.Code); code != tfec2.ErrCodeInvalidFlowLogIdNotFound {
+			return fmt.Errorf("error deleting Flow Log (%s): %s: %s", d.Id(), code, aws.StringValue(output.Unsuccessful[0].Error.Message))
+		}
+	}
+
+	if _, err := waiter.FlowLogDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Flow Log (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ func resourceAwsIamRolePolicyAttachment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceAwsIamRolePolicyAttachmentImport,
 		},
+		CustomizeDiff: iamRolePolicyRolePolicyAttachmentCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"role": {
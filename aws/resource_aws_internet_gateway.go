@@ -0,0 +1,291 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsInternetGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsInternetGatewayCreate,
+		Read:   resourceAwsInternetGatewayRead,
+		Update: resourceAwsInternetGatewayUpdate,
+		Delete: resourceAwsInternetGatewayDestroy,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_internet_gateway"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// vpc_id is computed so that the gateway can instead be attached
+			// with an aws_internet_gateway_attachment resource.
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsInternetGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[DEBUG] Creating Internet Gateway")
+	resp, err := conn.CreateInternetGateway(&ec2.CreateInternetGatewayInput{})
+	if err != nil {
+		return fmt.Errorf("Error creating Internet Gateway: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.InternetGateway.InternetGatewayId))
+	log.Printf("[INFO] Internet Gateway ID: %s", d.Id())
+
+	var ig *ec2.InternetGateway
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		ig, err = finder.InternetGatewayByID(conn, d.Id())
+		if isAWSErr(err, tfec2.ErrCodeInvalidInternetGatewayIDNotFound, "") {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if ig == nil {
+			return resource.RetryableError(fmt.Errorf("Internet Gateway (%s) not found", d.Id()))
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		ig, err = finder.InternetGatewayByID(conn, d.Id())
+	}
+	if err != nil {
+		return fmt.Errorf("error refreshing Internet Gateway (%s) state: %s", d.Id(), err)
+	}
+	if ig == nil {
+		return fmt.Errorf("Internet Gateway (%s) eventually consistent read failed", d.Id())
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 Internet Gateway (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	if v, ok := d.GetOk("vpc_id"); ok {
+		if err := internetGatewayAttach(conn, d.Id(), v.(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsInternetGatewayRead(d, meta)
+}
+
+func resourceAwsInternetGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	ig, err := finder.InternetGatewayByID(conn, d.Id())
+	if isAWSErr(err, tfec2.ErrCodeInvalidInternetGatewayIDNotFound, "") {
+		log.Printf("[WARN] Internet Gateway (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Internet Gateway (%s): %s", d.Id(), err)
+	}
+	if ig == nil {
+		log.Printf("[WARN] Internet Gateway (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if len(ig.Attachments) == 0 {
+		d.Set("vpc_id", "")
+	} else {
+		d.Set("vpc_id", ig.Attachments[0].VpcId)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(ig.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	d.Set("owner_id", ig.OwnerId)
+
+	arn := arn.ARN{
+		Partition: meta.(*AWSClient).partition,
+		Service:   "ec2",
+		Region:    meta.(*AWSClient).region,
+		AccountID: aws.StringValue(ig.OwnerId),
+		Resource:  fmt.Sprintf("internet-gateway/%s", d.Id()),
+	}.String()
+
+	d.Set("arn", arn)
+
+	return nil
+}
+
+func resourceAwsInternetGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("vpc_id") {
+		o, n := d.GetChange("vpc_id")
+
+		if v := o.(string); v != "" {
+			if err := internetGatewayDetach(conn, d.Id(), v, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		}
+
+		if v := n.(string); v != "" {
+			if err := internetGatewayAttach(conn, d.Id(), v, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 Internet Gateway (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsInternetGatewayRead(d, meta)
+}
+
+// resourceAwsInternetGatewayDestroy is not named resourceAwsInternetGatewayDelete,
+// which is the aws_internet_gateway_delete resource.
+func resourceAwsInternetGatewayDestroy(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if v := d.Get("vpc_id").(string); v != "" {
+		if err := internetGatewayDetach(conn, d.Id(), v, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Deleting Internet Gateway: %s", d.Id())
+	input := &ec2.DeleteInternetGatewayInput{
+		InternetGatewayId: aws.String(d.Id()),
+	}
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := conn.DeleteInternetGateway(input)
+		if isAWSErr(err, tfec2.ErrCodeDependencyViolation, "") {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.DeleteInternetGateway(input)
+	}
+	if isAWSErr(err, tfec2.ErrCodeInvalidInternetGatewayIDNotFound, "") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error deleting Internet Gateway (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// internetGatewayAttach attaches an Internet Gateway to a VPC and waits for
+// the attachment to become available.
+func internetGatewayAttach(conn *ec2.EC2, gatewayID, vpcID string, timeout time.Duration) error {
+	log.Printf("[INFO] Attaching Internet Gateway (%s) to VPC (%s)", gatewayID, vpcID)
+	input := &ec2.AttachInternetGatewayInput{
+		InternetGatewayId: aws.String(gatewayID),
+		VpcId:             aws.String(vpcID),
+	}
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.AttachInternetGateway(input)
+		if isAWSErr(err, tfec2.ErrCodeInvalidInternetGatewayIDNotFound, "") {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.AttachInternetGateway(input)
+	}
+	if err != nil {
+		return fmt.Errorf("Error attaching Internet Gateway (%s) to VPC (%s): %s", gatewayID, vpcID, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for Internet Gateway (%s) to attach", gatewayID)
+	if _, err := waiter.InternetGatewayAttached(conn, gatewayID, vpcID, timeout); err != nil {
+		return fmt.Errorf("Error waiting for Internet Gateway (%s) to attach to VPC (%s): %s", gatewayID, vpcID, err)
+	}
+
+	return nil
+}
+
+// internetGatewayDetach detaches an Internet Gateway from a VPC. Detaching
+// fails with DependencyViolation while public addresses are still mapped in
+// the VPC, e.g. by NAT gateways or load balancers being deleted, so the
+// detach is retried until they are released.
+func internetGatewayDetach(conn *ec2.EC2, gatewayID, vpcID string, timeout time.Duration) error {
+	log.Printf("[INFO] Detaching Internet Gateway (%s) from VPC (%s)", gatewayID, vpcID)
+	input := &ec2.DetachInternetGatewayInput{
+		InternetGatewayId: aws.String(gatewayID),
+		VpcId:             aws.String(vpcID),
+	}
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.DetachInternetGateway(input)
+		if isAWSErr(err, tfec2.ErrCodeDependencyViolation, "") {
+			log.Printf("[DEBUG] Error detaching Internet Gateway (%s) from VPC (%s), retrying: %s", gatewayID, vpcID, err)
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.DetachInternetGateway(input)
+	}
+	if isAWSErr(err, tfec2.ErrCodeInvalidInternetGatewayIDNotFound, "") || isAWSErr(err, tfec2.ErrCodeGatewayNotAttached, "") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error detaching Internet Gateway (%s) from VPC (%s): %s", gatewayID, vpcID, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for Internet Gateway (%s) to detach", gatewayID)
+	if _, err := waiter.InternetGatewayDetached(conn, gatewayID, vpcID, timeout); err != nil {
+		return fmt.Errorf("Error waiting for Internet Gateway (%s) to detach from VPC (%s): %s", gatewayID, vpcID, err)
+	}
+
+	return nil
+}
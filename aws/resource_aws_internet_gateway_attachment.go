@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsInternetGatewayAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsInternetGatewayAttachmentCreate,
+		Read:   resourceAwsInternetGatewayAttachmentRead,
+		Delete: resourceAwsInternetGatewayAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"internet_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsInternetGatewayAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	igwID := d.Get("internet_gateway_id").(string)
+	vpcID := d.Get("vpc_id").(string)
+
+	if err := internetGatewayAttach(conn, igwID, vpcID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.SetId(tfec2.InternetGatewayAttachmentCreateID(igwID, vpcID))
+
+	return resourceAwsInternetGatewayAttachmentRead(d, meta)
+}
+
+func resourceAwsInternetGatewayAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	igwID, vpcID, err := tfec2.InternetGatewayAttachmentParseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	attachment, state, err := waiter.InternetGatewayAttachmentState(conn, igwID, vpcID)()
+	if err != nil {
+		return fmt.Errorf("error reading Internet Gateway (%s) attachment to VPC (%s): %s", igwID, vpcID, err)
+	}
+	if attachment == nil || state == ec2.AttachmentStatusDetached {
+		log.Printf("[WARN] Internet Gateway (%s) attachment to VPC (%s) not found, removing from state", igwID, vpcID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("internet_gateway_id", igwID)
+	d.Set("vpc_id", vpcID)
+
+	return nil
+}
+
+func resourceAwsInternetGatewayAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	igwID, vpcID, err := tfec2.InternetGatewayAttachmentParseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return internetGatewayDetach(conn, igwID, vpcID, d.Timeout(schema.TimeoutDelete))
+}
@@ -161,7 +161,7 @@ func resourceAwsLexBot() *schema.Resource {
 }
 
 func resourceAwsLexBotCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	params := &lexmodelbuildingservice.PutBotInput{
 		Name:                aws.String(d.Get("name").(string)),
@@ -191,7 +191,7 @@ func resourceAwsLexBotCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAwsLexBotRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	version := d.Get("version").(string)
 	bot, err := getLexBot(d.Id(), version, conn)
@@ -226,7 +226,7 @@ func resourceAwsLexBotRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAwsLexBotUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	name := d.Id()
 
@@ -259,7 +259,7 @@ func resourceAwsLexBotUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAwsLexBotDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	name := d.Id()
 
@@ -307,10 +307,15 @@ func flattenIntents(cs []*lexmodelbuildingservice.Intent) []interface{} {
 	return valuesSlice
 }
 
+// getLexBot returns the Lex bot version, or nil without error when it does not exist.
 func getLexBot(name, version string, conn *lexmodelbuildingservice.LexModelBuildingService) (*lexmodelbuildingservice.GetBotOutput, error) {
 	input := &lexmodelbuildingservice.GetBotInput{
 		Name:           aws.String(name),
 		VersionOrAlias: aws.String(version),
 	}
-	return conn.GetBot(input)
+	output, err := conn.GetBot(input)
+	if isAWSErr(err, lexmodelbuildingservice.ErrCodeNotFoundException, "") {
+		return nil, nil
+	}
+	return output, err
 }
@@ -390,7 +390,7 @@ func resourceAwsLexIntent() *schema.Resource {
 }
 
 func resourceAwsLexIntentCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	params := &lexmodelbuildingservice.PutIntentInput{
 		Name:                  aws.String(d.Get("name").(string)),
@@ -418,7 +418,7 @@ func resourceAwsLexIntentCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAwsLexIntentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	version := d.Get("version").(string)
 	intent, err := getLexIntent(d.Id(), version, conn)
@@ -472,7 +472,7 @@ func resourceAwsLexIntentRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAwsLexIntentUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	name := d.Id()
 
@@ -503,7 +503,7 @@ func resourceAwsLexIntentUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAwsLexIntentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	name := d.Id()
 
@@ -802,10 +802,15 @@ func flattenSlots(cs []*lexmodelbuildingservice.Slot) []interface{} {
 	return valuesSlice
 }
 
+// getLexIntent returns the Lex intent version, or nil without error when it does not exist.
 func getLexIntent(name, version string, conn *lexmodelbuildingservice.LexModelBuildingService) (*lexmodelbuildingservice.GetIntentOutput, error) {
 	input := &lexmodelbuildingservice.GetIntentInput{
 		Name:    aws.String(name),
 		Version: aws.String(version),
 	}
-	return conn.GetIntent(input)
+	output, err := conn.GetIntent(input)
+	if isAWSErr(err, lexmodelbuildingservice.ErrCodeNotFoundException, "") {
+		return nil, nil
+	}
+	return output, err
 }
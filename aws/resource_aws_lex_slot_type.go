@@ -79,7 +79,7 @@ func resourceAwsLexSlotType() *schema.Resource {
 }
 
 func resourceAwsLexSlotTypeCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	params := &lexmodelbuildingservice.PutSlotTypeInput{
 		Name:                   aws.String(d.Get("name").(string)),
@@ -100,7 +100,7 @@ func resourceAwsLexSlotTypeCreate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAwsLexSlotTypeRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	version := d.Get("version").(string)
 	slotType, err := getLexSlotType(d.Id(), version, conn)
@@ -125,7 +125,7 @@ func resourceAwsLexSlotTypeRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAwsLexSlotTypeUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	name := d.Id()
 
@@ -149,7 +149,7 @@ func resourceAwsLexSlotTypeUpdate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAwsLexSlotTypeDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lexmodelbuildingserviceconn
+	conn := meta.(*AWSClient).lexmodelconn
 
 	name := d.Id()
 
@@ -225,10 +225,15 @@ func flattenEnumerationValue(c *lexmodelbuildingservice.EnumerationValue) map[st
 	return column
 }
 
+// getLexSlotType returns the Lex slot type version, or nil without error when it does not exist.
 func getLexSlotType(name, version string, conn *lexmodelbuildingservice.LexModelBuildingService) (*lexmodelbuildingservice.GetSlotTypeOutput, error) {
 	input := &lexmodelbuildingservice.GetSlotTypeInput{
 		Name:    aws.String(name),
 		Version: aws.String(version),
 	}
-	return conn.GetSlotType(input)
+	output, err := conn.GetSlotType(input)
+	if isAWSErr(err, lexmodelbuildingservice.ErrCodeNotFoundException, "") {
+		return nil, nil
+	}
+	return output, err
 }
@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsMainRouteTableAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsMainRouteTableAssociationCreate,
+		Read:   resourceAwsMainRouteTableAssociationRead,
+		Update: resourceAwsMainRouteTableAssociationUpdate,
+		Delete: resourceAwsMainRouteTableAssociationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// We use this field to record the main route table that is automatically
+			// created when the VPC is created. We need this to be able to "destroy"
+			// our main route table association, which we do by returning this route
+			// table to its original place as the Main Route Table for the VPC.
+			"original_route_table_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsMainRouteTableAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	vpcId := d.Get("vpc_id").(string)
+	routeTableId := d.Get("route_table_id").(string)
+
+	log.Printf("[INFO] Creating main route table association: %s => %s", vpcId, routeTableId)
+
+	mainAssociation, err := finder.MainRouteTableAssociationByVpcID(conn, vpcId)
+	if err != nil {
+		return fmt.Errorf("error reading main Route Table Association for VPC (%s): %s", vpcId, err)
+	}
+
+	if mainAssociation == nil {
+		return fmt.Errorf("main Route Table Association for VPC (%s) not found", vpcId)
+	}
+
+	resp, err := conn.ReplaceRouteTableAssociation(&ec2.ReplaceRouteTableAssociationInput{
+		AssociationId: mainAssociation.RouteTableAssociationId,
+		RouteTableId:  aws.String(routeTableId),
+	})
+	if err != nil {
+		return fmt.Errorf("error replacing main Route Table Association for VPC (%s): %s", vpcId, err)
+	}
+
+	d.Set("original_route_table_id", mainAssociation.RouteTableId)
+	d.SetId(aws.StringValue(resp.NewAssociationId))
+	log.Printf("[INFO] New main route table association ID: %s", d.Id())
+
+	if _, err := waiter.RouteTableAssociationAssociated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for main Route Table Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsMainRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsMainRouteTableAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	mainAssociation, err := finder.MainRouteTableAssociationByVpcID(conn, d.Get("vpc_id").(string))
+	if err != nil {
+		return fmt.Errorf("error reading main Route Table Association (%s): %s", d.Id(), err)
+	}
+
+	if mainAssociation == nil || aws.StringValue(mainAssociation.RouteTableAssociationId) != d.Id() {
+		// It seems it doesn't exist anymore, so clear the ID
+		log.Printf("[WARN] main Route Table Association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("route_table_id", mainAssociation.RouteTableId)
+
+	return nil
+}
+
+// Update is almost exactly like Create, except we want to retain the
+// original_route_table_id - this needs to stay recorded as the AWS-created
+// table from VPC creation.
+func resourceAwsMainRouteTableAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	vpcId := d.Get("vpc_id").(string)
+	routeTableId := d.Get("route_table_id").(string)
+
+	log.Printf("[INFO] Updating main route table association: %s => %s", vpcId, routeTableId)
+
+	resp, err := conn.ReplaceRouteTableAssociation(&ec2.ReplaceRouteTableAssociationInput{
+		AssociationId: aws.String(d.Id()),
+		RouteTableId:  aws.String(routeTableId),
+	})
+	if err != nil {
+		return fmt.Errorf("error replacing main Route Table Association (%s): %s", d.Id(), err)
+	}
+
+	d.SetId(aws.StringValue(resp.NewAssociationId))
+	log.Printf("[INFO] New main route table association ID: %s", d.Id())
+
+	if _, err := waiter.RouteTableAssociationAssociated(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error waiting for main Route Table Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsMainRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsMainRouteTableAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	vpcId := d.Get("vpc_id").(string)
+	originalRouteTableId := d.Get("original_route_table_id").(string)
+
+	log.Printf("[INFO] Deleting main route table association by resetting Main Route Table for VPC: %s to its original Route Table: %s",
+		vpcId,
+		originalRouteTableId)
+
+	resp, err := conn.ReplaceRouteTableAssociation(&ec2.ReplaceRouteTableAssociationInput{
+		AssociationId: aws.String(d.Id()),
+		RouteTableId:  aws.String(originalRouteTableId),
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting main Route Table Association (%s) to original Route Table (%s): %s", d.Id(), originalRouteTableId, err)
+	}
+
+	log.Printf("[INFO] Resulting Association ID: %s", aws.StringValue(resp.NewAssociationId))
+
+	if _, err := waiter.RouteTableAssociationAssociated(conn, aws.StringValue(resp.NewAssociationId), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for main Route Table Association (%s) to become associated: %s", aws.StringValue(resp.NewAssociationId), err)
+	}
+
+	return nil
+}
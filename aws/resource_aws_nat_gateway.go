@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsNatGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsNatGatewayCreate,
+		Read:   resourceAwsNatGatewayRead,
+		Update: resourceAwsNatGatewayUpdate,
+		Delete: resourceAwsNatGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_nat_gateway"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Private connectivity is not modelled: the EC2 API version this
+			// provider is built against requires an allocation ID.
+			"allocation_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"network_interface_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"private_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsNatGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	// Create the NAT Gateway
+	createOpts := &ec2.CreateNatGatewayInput{
+		AllocationId: aws.String(d.Get("allocation_id").(string)),
+		SubnetId:     aws.String(d.Get("subnet_id").(string)),
+	}
+
+	log.Printf("[DEBUG] Create NAT Gateway: %s", *createOpts)
+	natResp, err := conn.CreateNatGateway(createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating NAT Gateway: %s", err)
+	}
+
+	// Get the ID and store it
+	ng := natResp.NatGateway
+	d.SetId(aws.StringValue(ng.NatGatewayId))
+	log.Printf("[INFO] NAT Gateway ID: %s", d.Id())
+
+	// Wait for the NAT Gateway to become available
+	log.Printf("[DEBUG] Waiting for NAT Gateway (%s) to become available", d.Id())
+	ng, err = waiter.NatGatewayAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate))
+
+	if ng != nil && aws.StringValue(ng.State) == ec2.NatGatewayStateFailed {
+		err = fmt.Errorf("%s: %s", aws.StringValue(ng.FailureCode), aws.StringValue(ng.FailureMessage))
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error waiting for NAT Gateway (%s) to become available: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 NAT Gateway (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	// Update our attributes and return
+	return resourceAwsNatGatewayRead(d, meta)
+}
+
+func resourceAwsNatGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	// Refresh the NAT Gateway state
+	ngRaw, state, err := waiter.NatGatewayState(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("error reading NAT Gateway (%s): %s", d.Id(), err)
+	}
+
+	status := map[string]bool{
+		ec2.NatGatewayStateDeleted:  true,
+		ec2.NatGatewayStateDeleting: true,
+		ec2.NatGatewayStateFailed:   true,
+	}
+
+	if _, ok := status[state]; ngRaw == nil || ok {
+		log.Printf("[INFO] Removing %s from Terraform state as it is not found or in the deleted state.", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// Set NAT Gateway attributes
+	ng := ngRaw.(*ec2.NatGateway)
+	d.Set("subnet_id", ng.SubnetId)
+
+	// Address
+	if len(ng.NatGatewayAddresses) > 0 {
+		address := ng.NatGatewayAddresses[0]
+		d.Set("allocation_id", address.AllocationId)
+		d.Set("network_interface_id", address.NetworkInterfaceId)
+		d.Set("private_ip", address.PrivateIp)
+		d.Set("public_ip", address.PublicIp)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(ng.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsNatGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 NAT Gateway (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsNatGatewayRead(d, meta)
+}
+
+func resourceAwsNatGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	deleteOpts := &ec2.DeleteNatGatewayInput{
+		NatGatewayId: aws.String(d.Id()),
+	}
+	log.Printf("[INFO] Deleting NAT Gateway: %s", d.Id())
+
+	_, err := conn.DeleteNatGateway(deleteOpts)
+	if err != nil {
+		if isAWSErr(err, tfec2.ErrCodeNatGatewayNotFound, "") {
+			return nil
+		}
+
+		return err
+	}
+
+	_, stateErr := waiter.NatGatewayDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete))
+	if stateErr != nil {
+		return fmt.Errorf("Error waiting for NAT Gateway (%s) to delete: %s", d.Id(), stateErr)
+	}
+
+	return nil
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
 )
 
 func resourceAwsNetworkAcl() *schema.Resource {
@@ -29,6 +31,15 @@ func resourceAwsNetworkAcl() *schema.Resource {
 			State: resourceAwsNetworkAclImportState,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			networkGuardrailsNetworkAclCustomizeDiff,
+			requiredTagsCustomizeDiff("aws_network_acl"),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -211,6 +222,12 @@ func resourceAwsNetworkAclRead(d *schema.ResourceData, meta interface{}) error {
 	var ingressEntries []*ec2.NetworkAclEntry
 	var egressEntries []*ec2.NetworkAclEntry
 
+	// Entries managed inline are tracked by rule number so that entries
+	// added by aws_network_acl_rule resources are not removed from the ACL.
+	// Without inline entries in state, e.g. on import, all entries are read.
+	inlineIngressRuleNumbers := resourceAwsNetworkAclInlineRuleNumbers(d, "ingress")
+	inlineEgressRuleNumbers := resourceAwsNetworkAclInlineRuleNumbers(d, "egress")
+
 	// separate the ingress and egress rules
 	for _, e := range networkAcl.Entries {
 		// Skip the default rules added by AWS. They can be neither
@@ -221,8 +238,14 @@ func resourceAwsNetworkAclRead(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		if *e.Egress {
+			if len(inlineEgressRuleNumbers) > 0 && !inlineEgressRuleNumbers[*e.RuleNumber] {
+				continue
+			}
 			egressEntries = append(egressEntries, e)
 		} else {
+			if len(inlineIngressRuleNumbers) > 0 && !inlineIngressRuleNumbers[*e.RuleNumber] {
+				continue
+			}
 			ingressEntries = append(ingressEntries, e)
 		}
 	}
@@ -446,17 +469,27 @@ func updateNetworkAclEntries(d *schema.ResourceData, entryType string, conn *ec2
 func resourceAwsNetworkAclDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	if err := deleteNetworkAcl(d, conn, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("Error destroying Network ACL (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// deleteNetworkAcl deletes a network ACL, disassociating its subnets
+// while the deletion fails on dependency violations.
+func deleteNetworkAcl(d *schema.ResourceData, conn *ec2.EC2, timeout time.Duration) error {
 	log.Printf("[INFO] Deleting Network Acl: %s", d.Id())
 	input := &ec2.DeleteNetworkAclInput{
 		NetworkAclId: aws.String(d.Id()),
 	}
-	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+	err := resource.Retry(timeout, func() *resource.RetryError {
 		_, err := conn.DeleteNetworkAcl(input)
 		if err != nil {
-			if isAWSErr(err, "InvalidNetworkAclID.NotFound", "") {
+			if isAWSErr(err, tfec2.ErrCodeInvalidNetworkAclIDNotFound, "") {
 				return nil
 			}
-			if isAWSErr(err, "DependencyViolation", "") {
+			if isAWSErr(err, tfec2.ErrCodeDependencyViolation, "") {
 				err = cleanUpDependencyViolations(d, conn)
 				if err != nil {
 					return resource.NonRetryableError(err)
@@ -472,22 +505,20 @@ func resourceAwsNetworkAclDelete(d *schema.ResourceData, meta interface{}) error
 	})
 	if isResourceTimeoutError(err) {
 		_, err = conn.DeleteNetworkAcl(input)
-		if err != nil && isAWSErr(err, "InvalidNetworkAclID.NotFound", "") {
+		if err != nil && isAWSErr(err, tfec2.ErrCodeInvalidNetworkAclIDNotFound, "") {
 			return nil
 		}
 		err = cleanUpDependencyViolations(d, conn)
 		if err != nil {
 			// This seems excessive but is probably the best way to make sure it's actually deleted
 			_, err = conn.DeleteNetworkAcl(input)
-			if err != nil && isAWSErr(err, "InvalidNetworkAclID.NotFound", "") {
+			if err != nil && isAWSErr(err, tfec2.ErrCodeInvalidNetworkAclIDNotFound, "") {
 				return nil
 			}
 		}
 	}
-	if err != nil {
-		return fmt.Errorf("Error destroying Network ACL (%s): %s", d.Id(), err)
-	}
-	return nil
+
+	return err
 }
 
 func cleanUpDependencyViolations(d *schema.ResourceData, conn *ec2.EC2) error {
@@ -545,6 +576,19 @@ func cleanUpDependencyViolations(d *schema.ResourceData, conn *ec2.EC2) error {
 	return nil
 }
 
+// resourceAwsNetworkAclInlineRuleNumbers returns the rule numbers of the
+// inline ingress or egress entries in state.
+func resourceAwsNetworkAclInlineRuleNumbers(d *schema.ResourceData, entryType string) map[int64]bool {
+	ruleNumbers := make(map[int64]bool)
+
+	for _, v := range d.Get(entryType).(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		ruleNumbers[int64(m["rule_no"].(int))] = true
+	}
+
+	return ruleNumbers
+}
+
 func resourceAwsNetworkAclEntryHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
@@ -0,0 +1,184 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsNetworkAclAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsNetworkAclAssociationCreate,
+		Read:   resourceAwsNetworkAclAssociationRead,
+		Update: resourceAwsNetworkAclAssociationUpdate,
+		Delete: resourceAwsNetworkAclAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsNetworkAclAssociationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network_acl_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAwsNetworkAclAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	subnetID := d.Get("subnet_id").(string)
+
+	// Every subnet is always associated with exactly one network ACL, so
+	// associating a subnet means replacing its current association.
+	association, err := findNetworkAclAssociation(subnetID, conn)
+	if err != nil {
+		return fmt.Errorf("error reading Network ACL Association for Subnet (%s): %s", subnetID, err)
+	}
+
+	input := &ec2.ReplaceNetworkAclAssociationInput{
+		AssociationId: association.NetworkAclAssociationId,
+		NetworkAclId:  aws.String(d.Get("network_acl_id").(string)),
+	}
+
+	log.Printf("[INFO] Creating Network ACL Association: %s", input)
+	resp, err := conn.ReplaceNetworkAclAssociation(input)
+	if err != nil {
+		return fmt.Errorf("error creating Network ACL Association for Subnet (%s): %s", subnetID, err)
+	}
+
+	d.SetId(aws.StringValue(resp.NewAssociationId))
+
+	if _, err := waiter.NetworkAclAssociationAssociated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Network ACL Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsNetworkAclAssociationRead(d, meta)
+}
+
+func resourceAwsNetworkAclAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	association, acl, err := finder.NetworkAclAssociationByID(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("error reading Network ACL Association (%s): %s", d.Id(), err)
+	}
+
+	if association == nil {
+		log.Printf("[WARN] Network ACL Association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("network_acl_id", acl.NetworkAclId)
+	d.Set("subnet_id", association.SubnetId)
+
+	return nil
+}
+
+func resourceAwsNetworkAclAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.ReplaceNetworkAclAssociationInput{
+		AssociationId: aws.String(d.Id()),
+		NetworkAclId:  aws.String(d.Get("network_acl_id").(string)),
+	}
+
+	log.Printf("[INFO] Replacing Network ACL Association: %s", input)
+	resp, err := conn.ReplaceNetworkAclAssociation(input)
+
+	if isAWSErr(err, "InvalidAssociationID.NotFound", "") {
+		// Not found, so just create a new one
+		return resourceAwsNetworkAclAssociationCreate(d, meta)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error replacing Network ACL Association (%s): %s", d.Id(), err)
+	}
+
+	d.SetId(aws.StringValue(resp.NewAssociationId))
+
+	if _, err := waiter.NetworkAclAssociationAssociated(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error waiting for Network ACL Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsNetworkAclAssociationRead(d, meta)
+}
+
+// A subnet cannot be left without a network ACL. Deleting the association
+// returns the subnet to the default network ACL of its VPC.
+func resourceAwsNetworkAclAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	association, acl, err := finder.NetworkAclAssociationByID(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("error reading Network ACL Association (%s): %s", d.Id(), err)
+	}
+
+	if association == nil {
+		return nil
+	}
+
+	if aws.BoolValue(acl.IsDefault) {
+		return nil
+	}
+
+	defaultAcl, err := getDefaultNetworkAcl(aws.StringValue(acl.VpcId), conn)
+	if err != nil {
+		return fmt.Errorf("error reading default Network ACL for VPC (%s): %s", aws.StringValue(acl.VpcId), err)
+	}
+
+	input := &ec2.ReplaceNetworkAclAssociationInput{
+		AssociationId: aws.String(d.Id()),
+		NetworkAclId:  defaultAcl.NetworkAclId,
+	}
+
+	log.Printf("[INFO] Deleting Network ACL Association: %s", input)
+	_, err = conn.ReplaceNetworkAclAssociation(input)
+
+	if isAWSErr(err, "InvalidAssociationID.NotFound", "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error replacing Network ACL Association (%s) with default Network ACL (%s): %s", d.Id(), aws.StringValue(defaultAcl.NetworkAclId), err)
+	}
+
+	if _, err := waiter.NetworkAclAssociationDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Network ACL Association (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsNetworkAclAssociationImport imports the network ACL association
+// of a subnet, given as the subnet ID.
+func resourceAwsNetworkAclAssociationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*AWSClient).ec2conn
+
+	association, err := findNetworkAclAssociation(d.Id(), conn)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Network ACL Association for Subnet (%s): %s", d.Id(), err)
+	}
+
+	d.SetId(aws.StringValue(association.NetworkAclAssociationId))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,318 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsNetworkAclRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsNetworkAclRuleCreate,
+		Read:   resourceAwsNetworkAclRuleRead,
+		Delete: resourceAwsNetworkAclRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsNetworkAclRuleImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		CustomizeDiff: networkGuardrailsNetworkAclRuleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"network_acl_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule_number": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 32766),
+			},
+			"egress": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateNetworkAclRuleProtocol,
+			},
+			"rule_action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.RuleActionAllow,
+					ec2.RuleActionDeny,
+				}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+				ExactlyOneOf: []string{"cidr_block", "ipv6_cidr_block"},
+			},
+			"ipv6_cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+				ExactlyOneOf: []string{"cidr_block", "ipv6_cidr_block"},
+			},
+			"from_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"to_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"icmp_type": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(-1, 255),
+			},
+			"icmp_code": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(-1, 255),
+			},
+		},
+	}
+}
+
+func resourceAwsNetworkAclRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	networkAclID := d.Get("network_acl_id").(string)
+	ruleNumber := d.Get("rule_number").(int)
+	egress := d.Get("egress").(bool)
+
+	protocol, err := networkAclRuleProtocolNumber(d.Get("protocol").(string))
+	if err != nil {
+		return err
+	}
+
+	// Creating an entry with a rule number that is already taken fails with
+	// a terse error, and it is easy to collide with a rule defined inline in
+	// aws_network_acl. Look for the conflicting entry first.
+	existing, err := finder.NetworkAclEntry(conn, networkAclID, ruleNumber, egress)
+	if err != nil {
+		return fmt.Errorf("error reading Network ACL (%s): %s", networkAclID, err)
+	}
+
+	if existing != nil {
+		return fmt.Errorf("Network ACL (%s) already has an %s entry with rule number %d", networkAclID, networkAclRuleDirection(egress), ruleNumber)
+	}
+
+	input := &ec2.CreateNetworkAclEntryInput{
+		Egress:       aws.Bool(egress),
+		NetworkAclId: aws.String(networkAclID),
+		PortRange: &ec2.PortRange{
+			From: aws.Int64(int64(d.Get("from_port").(int))),
+			To:   aws.Int64(int64(d.Get("to_port").(int))),
+		},
+		Protocol:   aws.String(strconv.Itoa(protocol)),
+		RuleAction: aws.String(d.Get("rule_action").(string)),
+		RuleNumber: aws.Int64(int64(ruleNumber)),
+	}
+
+	if v, ok := d.GetOk("cidr_block"); ok {
+		input.CidrBlock = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("ipv6_cidr_block"); ok {
+		input.Ipv6CidrBlock = aws.String(v.(string))
+	}
+
+	// Specify additional required fields for ICMP.
+	if protocol == 1 || protocol == 58 {
+		input.IcmpTypeCode = &ec2.IcmpTypeCode{
+			Code: aws.Int64(int64(d.Get("icmp_code").(int))),
+			Type: aws.Int64(int64(d.Get("icmp_type").(int))),
+		}
+	}
+
+	log.Printf("[INFO] Creating Network ACL Entry: %s", input)
+	_, err = conn.CreateNetworkAclEntry(input)
+
+	if isAWSErr(err, "NetworkAclEntryAlreadyExists", "") {
+		return fmt.Errorf("Network ACL (%s) already has an %s entry with rule number %d", networkAclID, networkAclRuleDirection(egress), ruleNumber)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error creating Network ACL (%s) %s entry %d: %s", networkAclID, networkAclRuleDirection(egress), ruleNumber, err)
+	}
+
+	d.SetId(tfec2.NetworkAclRuleCreateID(networkAclID, ruleNumber, egress, d.Get("protocol").(string)))
+
+	if _, err := waiter.NetworkAclEntryCreated(conn, networkAclID, ruleNumber, egress, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Network ACL (%s) %s entry %d to be created: %s", networkAclID, networkAclRuleDirection(egress), ruleNumber, err)
+	}
+
+	return resourceAwsNetworkAclRuleRead(d, meta)
+}
+
+func resourceAwsNetworkAclRuleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	networkAclID := d.Get("network_acl_id").(string)
+	entry, err := finder.NetworkAclEntry(conn, networkAclID, d.Get("rule_number").(int), d.Get("egress").(bool))
+	if isAWSErr(err, tfec2.ErrCodeInvalidNetworkAclIDNotFound, "") {
+		log.Printf("[WARN] Network ACL (%s) not found, removing entry (%s) from state", networkAclID, d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Network ACL (%s) entry (%s): %s", networkAclID, d.Id(), err)
+	}
+
+	if entry == nil {
+		log.Printf("[WARN] Network ACL (%s) entry (%s) not found, removing from state", networkAclID, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("rule_number", entry.RuleNumber)
+	d.Set("egress", entry.Egress)
+	d.Set("rule_action", entry.RuleAction)
+	d.Set("cidr_block", entry.CidrBlock)
+	d.Set("ipv6_cidr_block", entry.Ipv6CidrBlock)
+
+	// The API only returns protocol numbers. Keep a configured protocol name
+	// as long as it refers to the same protocol.
+	protocol := aws.StringValue(entry.Protocol)
+	if p, err := networkAclRuleProtocolNumber(d.Get("protocol").(string)); err != nil || strconv.Itoa(p) != protocol {
+		d.Set("protocol", protocol)
+	}
+
+	if entry.PortRange != nil {
+		d.Set("from_port", entry.PortRange.From)
+		d.Set("to_port", entry.PortRange.To)
+	}
+
+	if entry.IcmpTypeCode != nil {
+		d.Set("icmp_type", entry.IcmpTypeCode.Type)
+		d.Set("icmp_code", entry.IcmpTypeCode.Code)
+	}
+
+	return nil
+}
+
+func resourceAwsNetworkAclRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	networkAclID := d.Get("network_acl_id").(string)
+	ruleNumber := d.Get("rule_number").(int)
+	egress := d.Get("egress").(bool)
+
+	input := &ec2.DeleteNetworkAclEntryInput{
+		Egress:       aws.Bool(egress),
+		NetworkAclId: aws.String(networkAclID),
+		RuleNumber:   aws.Int64(int64(ruleNumber)),
+	}
+
+	log.Printf("[INFO] Deleting Network ACL Entry: %s", input)
+	_, err := conn.DeleteNetworkAclEntry(input)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidNetworkAclIDNotFound, "") || isAWSErr(err, tfec2.ErrCodeInvalidNetworkAclEntryNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Network ACL (%s) entry (%s): %s", networkAclID, d.Id(), err)
+	}
+
+	if _, err := waiter.NetworkAclEntryDeleted(conn, networkAclID, ruleNumber, egress, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Network ACL (%s) entry (%s) to be deleted: %s", networkAclID, d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsNetworkAclRuleImport imports a network ACL entry given as
+// network-acl-id:rule-number:protocol:egress.
+func resourceAwsNetworkAclRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	networkAclID, ruleNumber, protocol, egress, err := tfec2.NetworkAclRuleParseImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := networkAclRuleProtocolNumber(protocol); err != nil {
+		return nil, err
+	}
+
+	d.Set("network_acl_id", networkAclID)
+	d.Set("rule_number", ruleNumber)
+	d.Set("protocol", protocol)
+	d.Set("egress", egress)
+	d.SetId(tfec2.NetworkAclRuleCreateID(networkAclID, ruleNumber, egress, protocol))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// networkAclRuleProtocolNumber returns the protocol number of a protocol
+// given by name or number.
+func networkAclRuleProtocolNumber(protocol string) (int, error) {
+	if p, err := strconv.Atoi(protocol); err == nil {
+		return p, nil
+	}
+
+	p, ok := protocolIntegers()[protocol]
+	if !ok {
+		return 0, fmt.Errorf("invalid protocol %q", protocol)
+	}
+
+	return p, nil
+}
+
+func validateNetworkAclRuleProtocol(v interface{}, k string) (ws []string, errors []error) {
+	p, err := networkAclRuleProtocolNumber(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+		return
+	}
+
+	if p < -1 || p > 255 {
+		errors = append(errors, fmt.Errorf("%q must be a protocol number between -1 and 255, got %d", k, p))
+	}
+
+	return
+}
+
+func networkAclRuleDirection(egress bool) string {
+	if egress {
+		return "egress"
+	}
+
+	return "ingress"
+}
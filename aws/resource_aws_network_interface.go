@@ -5,16 +5,17 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
 func resourceAwsNetworkInterface() *schema.Resource {
@@ -27,6 +28,13 @@ func resourceAwsNetworkInterface() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_network_interface"),
+
 		Schema: map[string]*schema.Schema{
 
 			"subnet_id": {
@@ -65,6 +73,31 @@ func resourceAwsNetworkInterface() *schema.Resource {
 				Computed: true,
 			},
 
+			"ipv6_addresses": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsIPv6Address},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"ipv6_address_count"},
+			},
+
+			"ipv6_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"ipv6_addresses"},
+			},
+
+			"interface_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{ec2.NetworkInterfaceCreationTypeEfa}, false),
+			},
+
 			"security_groups": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -102,6 +135,10 @@ func resourceAwsNetworkInterface() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 				Set: resourceAwsEniAttachmentHash,
@@ -138,6 +175,22 @@ func resourceAwsNetworkInterfaceCreate(d *schema.ResourceData, meta interface{})
 		request.SecondaryPrivateIpAddressCount = aws.Int64(int64(v.(int)))
 	}
 
+	if v := d.Get("ipv6_addresses").(*schema.Set); v.Len() > 0 {
+		for _, address := range v.List() {
+			request.Ipv6Addresses = append(request.Ipv6Addresses, &ec2.InstanceIpv6Address{
+				Ipv6Address: aws.String(address.(string)),
+			})
+		}
+	}
+
+	if v, ok := d.GetOk("ipv6_address_count"); ok {
+		request.Ipv6AddressCount = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("interface_type"); ok {
+		request.InterfaceType = aws.String(v.(string))
+	}
+
 	log.Printf("[DEBUG] Creating network interface")
 	resp, err := conn.CreateNetworkInterface(request)
 	if err != nil {
@@ -189,6 +242,13 @@ func resourceAwsNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.Set("description", eni.Description)
+	d.Set("interface_type", eni.InterfaceType)
+
+	if err := d.Set("ipv6_addresses", flattenNetworkInterfaceIpv6Addresses(eni.Ipv6Addresses)); err != nil {
+		return fmt.Errorf("error setting ipv6_addresses: %s", err)
+	}
+
+	d.Set("ipv6_address_count", len(eni.Ipv6Addresses))
 	d.Set("private_dns_name", eni.PrivateDnsName)
 	d.Set("mac_address", eni.MacAddress)
 	d.Set("private_ip", eni.PrivateIpAddress)
@@ -213,27 +273,7 @@ func resourceAwsNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func networkInterfaceAttachmentRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-
-		describe_network_interfaces_request := &ec2.DescribeNetworkInterfacesInput{
-			NetworkInterfaceIds: []*string{aws.String(id)},
-		}
-		describeResp, err := conn.DescribeNetworkInterfaces(describe_network_interfaces_request)
-
-		if err != nil {
-			log.Printf("[ERROR] Could not find network interface %s. %s", id, err)
-			return nil, "", err
-		}
-
-		eni := describeResp.NetworkInterfaces[0]
-		hasAttachment := strconv.FormatBool(eni.Attachment != nil)
-		log.Printf("[DEBUG] ENI %s has attachment state %s", id, hasAttachment)
-		return eni, hasAttachment, nil
-	}
-}
-
-func resourceAwsNetworkInterfaceDetach(oa *schema.Set, meta interface{}, eniId string) error {
+func resourceAwsNetworkInterfaceDetach(oa *schema.Set, meta interface{}, eniId string, timeout time.Duration) error {
 	// if there was an old attachment, remove it
 	if oa != nil && len(oa.List()) > 0 {
 		old_attachment := oa.List()[0].(map[string]interface{})
@@ -250,13 +290,7 @@ func resourceAwsNetworkInterfaceDetach(oa *schema.Set, meta interface{}, eniId s
 		}
 
 		log.Printf("[DEBUG] Waiting for ENI (%s) to become detached", eniId)
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"true"},
-			Target:  []string{"false"},
-			Refresh: networkInterfaceAttachmentRefreshFunc(conn, eniId),
-			Timeout: 10 * time.Minute,
-		}
-		if _, err := stateConf.WaitForState(); err != nil {
+		if _, err := waiter.NetworkInterfaceDetached(conn, old_attachment["attachment_id"].(string), timeout); err != nil {
 			return fmt.Errorf(
 				"Error waiting for ENI (%s) to become detached: %s", eniId, err)
 		}
@@ -271,7 +305,7 @@ func resourceAwsNetworkInterfaceUpdate(d *schema.ResourceData, meta interface{})
 	if d.HasChange("attachment") {
 		oa, na := d.GetChange("attachment")
 
-		detach_err := resourceAwsNetworkInterfaceDetach(oa.(*schema.Set), meta, d.Id())
+		detach_err := resourceAwsNetworkInterfaceDetach(oa.(*schema.Set), meta, d.Id(), d.Timeout(schema.TimeoutUpdate))
 		if detach_err != nil {
 			return detach_err
 		}
@@ -331,6 +365,60 @@ func resourceAwsNetworkInterfaceUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("ipv6_addresses") && !d.IsNewResource() {
+		o, n := d.GetChange("ipv6_addresses")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if unassign := os.Difference(ns); unassign.Len() != 0 {
+			input := &ec2.UnassignIpv6AddressesInput{
+				NetworkInterfaceId: aws.String(d.Id()),
+				Ipv6Addresses:      expandStringSet(unassign),
+			}
+			_, err := conn.UnassignIpv6Addresses(input)
+			if err != nil {
+				return fmt.Errorf("Failure to unassign IPv6 addresses: %s", err)
+			}
+		}
+
+		if assign := ns.Difference(os); assign.Len() != 0 {
+			input := &ec2.AssignIpv6AddressesInput{
+				NetworkInterfaceId: aws.String(d.Id()),
+				Ipv6Addresses:      expandStringSet(assign),
+			}
+			_, err := conn.AssignIpv6Addresses(input)
+			if err != nil {
+				return fmt.Errorf("Failure to assign IPv6 addresses: %s", err)
+			}
+		}
+	} else if d.HasChange("ipv6_address_count") && !d.IsNewResource() {
+		o, n := d.GetChange("ipv6_address_count")
+		diff := n.(int) - o.(int)
+
+		// Surplus of addresses, assign the diff
+		if diff > 0 {
+			input := &ec2.AssignIpv6AddressesInput{
+				NetworkInterfaceId: aws.String(d.Id()),
+				Ipv6AddressCount:   aws.Int64(int64(diff)),
+			}
+			_, err := conn.AssignIpv6Addresses(input)
+			if err != nil {
+				return fmt.Errorf("Failure to assign IPv6 addresses: %s", err)
+			}
+		}
+
+		if diff < 0 {
+			input := &ec2.UnassignIpv6AddressesInput{
+				NetworkInterfaceId: aws.String(d.Id()),
+				Ipv6Addresses:      expandStringList(d.Get("ipv6_addresses").(*schema.Set).List()[:-diff]),
+			}
+			_, err := conn.UnassignIpv6Addresses(input)
+			if err != nil {
+				return fmt.Errorf("Failure to unassign IPv6 addresses: %s", err)
+			}
+		}
+	}
+
 	// ModifyNetworkInterfaceAttribute needs to be called after creating an ENI
 	// since CreateNetworkInterface doesn't take SourceDeskCheck parameter.
 	if d.HasChange("source_dest_check") || d.IsNewResource() {
@@ -426,7 +514,7 @@ func resourceAwsNetworkInterfaceDelete(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Deleting ENI: %s", d.Id())
 
-	detach_err := resourceAwsNetworkInterfaceDetach(d.Get("attachment").(*schema.Set), meta, d.Id())
+	detach_err := resourceAwsNetworkInterfaceDetach(d.Get("attachment").(*schema.Set), meta, d.Id(), d.Timeout(schema.TimeoutDelete))
 	if detach_err != nil {
 		return detach_err
 	}
@@ -454,7 +542,7 @@ func deleteNetworkInterface(conn *ec2.EC2, eniId string) error {
 		NetworkInterfaceId: aws.String(eniId),
 	})
 
-	if isAWSErr(err, "InvalidNetworkInterfaceID.NotFound", "") {
+	if isAWSErr(err, tfec2.ErrCodeInvalidNetworkInterfaceIDNotFound, "") {
 		return nil
 	}
 
@@ -481,7 +569,7 @@ func detachNetworkInterface(conn *ec2.EC2, eni *ec2.NetworkInterface, timeout ti
 		Force:        aws.Bool(true),
 	})
 
-	if isAWSErr(err, "InvalidAttachmentID.NotFound", "") {
+	if isAWSErr(err, tfec2.ErrCodeInvalidAttachmentIDNotFound, "") {
 		return nil
 	}
 
@@ -489,28 +577,8 @@ func detachNetworkInterface(conn *ec2.EC2, eni *ec2.NetworkInterface, timeout ti
 		return fmt.Errorf("error detaching ENI (%s): %s", eniId, err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{
-			ec2.AttachmentStatusAttaching,
-			ec2.AttachmentStatusAttached,
-			ec2.AttachmentStatusDetaching,
-		},
-		Target: []string{
-			ec2.AttachmentStatusDetached,
-		},
-		Refresh:        networkInterfaceAttachmentStateRefresh(conn, eniId),
-		Timeout:        timeout,
-		Delay:          10 * time.Second,
-		MinTimeout:     5 * time.Second,
-		NotFoundChecks: 1,
-	}
-
 	log.Printf("[DEBUG] Waiting for ENI (%s) to become detached", eniId)
-	_, err = stateConf.WaitForState()
-
-	if isResourceNotFoundError(err) {
-		return nil
-	}
+	_, err = waiter.NetworkInterfaceDetached(conn, aws.StringValue(eni.Attachment.AttachmentId), timeout)
 
 	if err != nil {
 		return fmt.Errorf("error waiting for ENI (%s) to become detached: %s", eniId, err)
@@ -518,64 +586,3 @@ func detachNetworkInterface(conn *ec2.EC2, eni *ec2.NetworkInterface, timeout ti
 
 	return nil
 }
-
-func networkInterfaceAttachmentStateRefresh(conn *ec2.EC2, eniId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		resp, err := conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
-			NetworkInterfaceIds: aws.StringSlice([]string{eniId}),
-		})
-
-		if isAWSErr(err, "InvalidNetworkInterfaceID.NotFound", "") {
-			return nil, ec2.AttachmentStatusDetached, nil
-		}
-
-		if err != nil {
-			return nil, "", fmt.Errorf("error describing ENI (%s): %s", eniId, err)
-		}
-
-		n := len(resp.NetworkInterfaces)
-		switch n {
-		case 0:
-			return nil, ec2.AttachmentStatusDetached, nil
-
-		case 1:
-			attachment := resp.NetworkInterfaces[0].Attachment
-			if attachment == nil {
-				return nil, ec2.AttachmentStatusDetached, nil
-			}
-			return attachment, aws.StringValue(attachment.Status), nil
-
-		default:
-			return nil, "", fmt.Errorf("found %d ENIs for %s, expected 1", n, eniId)
-		}
-	}
-}
-
-func networkInterfaceStateRefresh(conn *ec2.EC2, eniId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		resp, err := conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
-			NetworkInterfaceIds: aws.StringSlice([]string{eniId}),
-		})
-
-		if isAWSErr(err, "InvalidNetworkInterfaceID.NotFound", "") {
-			return nil, "", nil
-		}
-
-		if err != nil {
-			return nil, "", fmt.Errorf("error describing ENI (%s): %s", eniId, err)
-		}
-
-		n := len(resp.NetworkInterfaces)
-		switch n {
-		case 0:
-			return nil, "", nil
-
-		case 1:
-			eni := resp.NetworkInterfaces[0]
-			return eni, aws.StringValue(eni.Status), nil
-
-		default:
-			return nil, "", fmt.Errorf("found %d ENIs for %s, expected 1", n, eniId)
-		}
-	}
-}
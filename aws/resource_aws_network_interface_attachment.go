@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsNetworkInterfaceAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsNetworkInterfaceAttachmentCreate,
+		Read:   resourceAwsNetworkInterfaceAttachmentRead,
+		Delete: resourceAwsNetworkInterfaceAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"attachment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"device_index": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network_interface_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsNetworkInterfaceAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(int64(d.Get("device_index").(int))),
+		InstanceId:         aws.String(d.Get("instance_id").(string)),
+		NetworkInterfaceId: aws.String(d.Get("network_interface_id").(string)),
+	}
+
+	log.Printf("[DEBUG] Attaching EC2 Network Interface: %s", input)
+	output, err := conn.AttachNetworkInterface(input)
+
+	if err != nil {
+		return fmt.Errorf("error attaching EC2 Network Interface (%s) to EC2 Instance (%s): %s", d.Get("network_interface_id").(string), d.Get("instance_id").(string), err)
+	}
+
+	d.SetId(aws.StringValue(output.AttachmentId))
+
+	if _, err := waiter.NetworkInterfaceAttached(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Network Interface Attachment (%s) creation: %s", d.Id(), err)
+	}
+
+	return resourceAwsNetworkInterfaceAttachmentRead(d, meta)
+}
+
+func resourceAwsNetworkInterfaceAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	eni, err := finder.NetworkInterfaceByAttachmentID(conn, d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Network Interface Attachment (%s): %s", d.Id(), err)
+	}
+
+	if eni == nil || eni.Attachment == nil || aws.StringValue(eni.Attachment.Status) == ec2.AttachmentStatusDetached {
+		log.Printf("[WARN] EC2 Network Interface Attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	attachment := flattenAttachment(eni.Attachment)
+
+	d.Set("attachment_id", attachment["attachment_id"])
+	d.Set("device_index", attachment["device_index"])
+	d.Set("instance_id", attachment["instance"])
+	d.Set("network_interface_id", eni.NetworkInterfaceId)
+	d.Set("status", attachment["status"])
+
+	return nil
+}
+
+func resourceAwsNetworkInterfaceAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Detaching EC2 Network Interface Attachment: %s", d.Id())
+	_, err := conn.DetachNetworkInterface(&ec2.DetachNetworkInterfaceInput{
+		AttachmentId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidAttachmentIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error detaching EC2 Network Interface Attachment (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.NetworkInterfaceDetached(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Network Interface Attachment (%s) deletion: %s", d.Id(), err)
+	}
+
+	return nil
+}
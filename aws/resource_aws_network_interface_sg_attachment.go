@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsNetworkInterfaceSGAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsNetworkInterfaceSGAttachmentCreate,
+		Read:   resourceAwsNetworkInterfaceSGAttachmentRead,
+		Delete: resourceAwsNetworkInterfaceSGAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_interface_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsNetworkInterfaceSGAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	sgID := d.Get("security_group_id").(string)
+	eniID := d.Get("network_interface_id").(string)
+
+	// The security groups of a network interface are replaced as a whole, so
+	// concurrent changes to the same network interface are serialized.
+	awsMutexKV.Lock(eniID)
+	defer awsMutexKV.Unlock(eniID)
+
+	eni, err := finder.NetworkInterfaceByID(conn, eniID)
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Network Interface (%s): %s", eniID, err)
+	}
+
+	if eni == nil {
+		return fmt.Errorf("error reading EC2 Network Interface (%s): not found", eniID)
+	}
+
+	groupIDs := flattenGroupIdentifiers(eni.Groups)
+
+	for _, id := range groupIDs {
+		if id == sgID {
+			return fmt.Errorf("EC2 Security Group (%s) is already attached to EC2 Network Interface (%s)", sgID, eniID)
+		}
+	}
+
+	if err := modifyNetworkInterfaceSecurityGroups(conn, eniID, append(groupIDs, sgID)); err != nil {
+		return fmt.Errorf("error attaching EC2 Security Group (%s) to EC2 Network Interface (%s): %s", sgID, eniID, err)
+	}
+
+	d.SetId(tfec2.NetworkInterfaceSecurityGroupAttachmentCreateID(sgID, eniID))
+
+	if _, err := waiter.NetworkInterfaceSecurityGroupAttached(conn, eniID, sgID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Security Group (%s) to attach to EC2 Network Interface (%s): %s", sgID, eniID, err)
+	}
+
+	return resourceAwsNetworkInterfaceSGAttachmentRead(d, meta)
+}
+
+func resourceAwsNetworkInterfaceSGAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	sgID, eniID, err := tfec2.NetworkInterfaceSecurityGroupAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	eni, err := finder.NetworkInterfaceByID(conn, eniID)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidNetworkInterfaceIDNotFound, "") {
+		log.Printf("[WARN] EC2 Network Interface (%s) not found, removing EC2 Security Group (%s) attachment from state", eniID, sgID)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Network Interface (%s): %s", eniID, err)
+	}
+
+	var found bool
+
+	if eni != nil {
+		for _, id := range flattenGroupIdentifiers(eni.Groups) {
+			if id == sgID {
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		log.Printf("[WARN] EC2 Security Group (%s) attachment to EC2 Network Interface (%s) not found, removing from state", sgID, eniID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("network_interface_id", eniID)
+	d.Set("security_group_id", sgID)
+
+	return nil
+}
+
+func resourceAwsNetworkInterfaceSGAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	sgID, eniID, err := tfec2.NetworkInterfaceSecurityGroupAttachmentParseID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	awsMutexKV.Lock(eniID)
+	defer awsMutexKV.Unlock(eniID)
+
+	eni, err := finder.NetworkInterfaceByID(conn, eniID)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidNetworkInterfaceIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 Network Interface (%s): %s", eniID, err)
+	}
+
+	if eni == nil {
+		return nil
+	}
+
+	var groupIDs []string
+	var found bool
+
+	for _, id := range flattenGroupIdentifiers(eni.Groups) {
+		if id == sgID {
+			found = true
+			continue
+		}
+
+		groupIDs = append(groupIDs, id)
+	}
+
+	if !found {
+		return nil
+	}
+
+	// A network interface must have at least one security group, so the
+	// last one is replaced by the default security group of the VPC.
+	if len(groupIDs) == 0 {
+		sg, err := finder.SecurityGroupByNameAndVpcID(conn, "default", aws.StringValue(eni.VpcId))
+
+		if err != nil {
+			return fmt.Errorf("error reading EC2 VPC (%s) default Security Group: %s", aws.StringValue(eni.VpcId), err)
+		}
+
+		if sg == nil {
+			return fmt.Errorf("error detaching EC2 Security Group (%s) from EC2 Network Interface (%s): it is the last security group and the VPC (%s) has no default security group", sgID, eniID, aws.StringValue(eni.VpcId))
+		}
+
+		groupIDs = []string{aws.StringValue(sg.GroupId)}
+
+		// The default security group itself stays attached.
+		if groupIDs[0] == sgID {
+			return nil
+		}
+	}
+
+	if err := modifyNetworkInterfaceSecurityGroups(conn, eniID, groupIDs); err != nil {
+		return fmt.Errorf("error detaching EC2 Security Group (%s) from EC2 Network Interface (%s): %s", sgID, eniID, err)
+	}
+
+	if _, err := waiter.NetworkInterfaceSecurityGroupDetached(conn, eniID, sgID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 Security Group (%s) to detach from EC2 Network Interface (%s): %s", sgID, eniID, err)
+	}
+
+	return nil
+}
+
+func modifyNetworkInterfaceSecurityGroups(conn *ec2.EC2, eniID string, groupIDs []string) error {
+	input := &ec2.ModifyNetworkInterfaceAttributeInput{
+		Groups:             aws.StringSlice(groupIDs),
+		NetworkInterfaceId: aws.String(eniID),
+	}
+
+	log.Printf("[DEBUG] Modifying EC2 Network Interface security groups: %s", input)
+	_, err := conn.ModifyNetworkInterfaceAttribute(input)
+
+	return err
+}
@@ -25,6 +25,8 @@ func resourceAwsOrganizationsGovCloudAccount() *schema.Resource {
 			State: resourceAwsOrganizationsGovCloudAccountImport,
 		},
 
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_organizations_gov_cloud_account"),
+
 		Schema: map[string]*schema.Schema{
 			"arn": {
 				Type:     schema.TypeString,
@@ -35,6 +35,8 @@ func resourceAwsQuickSightNamespace() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_quicksight_namespace"),
+
 		Schema: map[string]*schema.Schema{
 			"aws_account_id": {
 				Type:     schema.TypeString,
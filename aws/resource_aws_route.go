@@ -0,0 +1,420 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+var routeValidDestinations = []string{
+	"destination_cidr_block",
+	"destination_ipv6_cidr_block",
+	"destination_prefix_list_id",
+}
+
+var routeValidTargets = []string{
+	"carrier_gateway_id",
+	"egress_only_gateway_id",
+	"gateway_id",
+	"instance_id",
+	"local_gateway_id",
+	"nat_gateway_id",
+	"network_interface_id",
+	"transit_gateway_id",
+	"vpc_peering_connection_id",
+}
+
+func resourceAwsRoute() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsRouteCreate,
+		Read:   resourceAwsRouteRead,
+		Update: resourceAwsRouteUpdate,
+		Delete: resourceAwsRouteDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsRouteImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			///
+			// Destinations.
+			///
+			"destination_cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+				ExactlyOneOf: routeValidDestinations,
+			},
+			"destination_ipv6_cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+				ExactlyOneOf: routeValidDestinations,
+			},
+			"destination_prefix_list_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: routeValidDestinations,
+			},
+
+			///
+			// Targets.
+			///
+			"carrier_gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"egress_only_gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"instance_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"local_gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"nat_gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"network_interface_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"transit_gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+			"vpc_peering_connection_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: routeValidTargets,
+			},
+
+			///
+			// Computed.
+			///
+			"instance_owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"origin": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID := d.Get("route_table_id").(string)
+	destination := resourceAwsRouteDestination(d)
+
+	input := &ec2.CreateRouteInput{
+		RouteTableId: aws.String(routeTableID),
+	}
+
+	if v, ok := d.GetOk("destination_cidr_block"); ok {
+		input.DestinationCidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_ipv6_cidr_block"); ok {
+		input.DestinationIpv6CidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_prefix_list_id"); ok {
+		input.DestinationPrefixListId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("carrier_gateway_id"); ok {
+		input.CarrierGatewayId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("egress_only_gateway_id"); ok {
+		input.EgressOnlyInternetGatewayId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("gateway_id"); ok {
+		input.GatewayId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("instance_id"); ok {
+		input.InstanceId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("local_gateway_id"); ok {
+		input.LocalGatewayId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("nat_gateway_id"); ok {
+		input.NatGatewayId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("network_interface_id"); ok {
+		input.NetworkInterfaceId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("transit_gateway_id"); ok {
+		input.TransitGatewayId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("vpc_peering_connection_id"); ok {
+		input.VpcPeeringConnectionId = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating Route: %s", input)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		_, err := conn.CreateRoute(input)
+
+		if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+			return resource.RetryableError(err)
+		}
+
+		if isAWSErr(err, "InvalidTransitGatewayID.NotFound", "") {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.CreateRoute(input)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating Route in Route Table (%s) with destination (%s): %s", routeTableID, destination, err)
+	}
+
+	d.SetId(tfec2.RouteCreateID(routeTableID, destination))
+
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		route, err := finder.Route(conn, routeTableID, destination)
+
+		if err != nil && !isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+			return resource.NonRetryableError(err)
+		}
+
+		if route == nil {
+			return resource.RetryableError(fmt.Errorf("Route in Route Table (%s) with destination (%s) not found", routeTableID, destination))
+		}
+
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		var route *ec2.Route
+		route, err = finder.Route(conn, routeTableID, destination)
+		if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") || (err == nil && route == nil) {
+			err = fmt.Errorf("Route in Route Table (%s) with destination (%s) not found", routeTableID, destination)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error waiting for Route in Route Table (%s) with destination (%s) to become available: %s", routeTableID, destination, err)
+	}
+
+	return resourceAwsRouteRead(d, meta)
+}
+
+func resourceAwsRouteRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID := d.Get("route_table_id").(string)
+	destination := resourceAwsRouteDestination(d)
+
+	route, err := finder.Route(conn, routeTableID, destination)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		log.Printf("[WARN] Route Table (%s) not found, removing Route with destination (%s) from state", routeTableID, destination)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Route in Route Table (%s) with destination (%s): %s", routeTableID, destination, err)
+	}
+
+	if route == nil {
+		log.Printf("[WARN] Route in Route Table (%s) with destination (%s) not found, removing from state", routeTableID, destination)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("destination_cidr_block", route.DestinationCidrBlock)
+	d.Set("destination_ipv6_cidr_block", route.DestinationIpv6CidrBlock)
+	d.Set("destination_prefix_list_id", route.DestinationPrefixListId)
+	d.Set("carrier_gateway_id", route.CarrierGatewayId)
+	d.Set("egress_only_gateway_id", route.EgressOnlyInternetGatewayId)
+	d.Set("gateway_id", route.GatewayId)
+	d.Set("instance_id", route.InstanceId)
+	d.Set("instance_owner_id", route.InstanceOwnerId)
+	d.Set("local_gateway_id", route.LocalGatewayId)
+	d.Set("nat_gateway_id", route.NatGatewayId)
+	d.Set("network_interface_id", route.NetworkInterfaceId)
+	d.Set("origin", route.Origin)
+	d.Set("state", route.State)
+	d.Set("transit_gateway_id", route.TransitGatewayId)
+	d.Set("vpc_peering_connection_id", route.VpcPeeringConnectionId)
+
+	return nil
+}
+
+func resourceAwsRouteUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID := d.Get("route_table_id").(string)
+	destination := resourceAwsRouteDestination(d)
+
+	input := &ec2.ReplaceRouteInput{
+		RouteTableId: aws.String(routeTableID),
+	}
+
+	if v, ok := d.GetOk("destination_cidr_block"); ok {
+		input.DestinationCidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_ipv6_cidr_block"); ok {
+		input.DestinationIpv6CidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_prefix_list_id"); ok {
+		input.DestinationPrefixListId = aws.String(v.(string))
+	}
+
+	// Routes to an instance have both instance_id and network_interface_id set,
+	// so a changed network interface takes precedence over the instance.
+	switch {
+	case d.Get("carrier_gateway_id").(string) != "":
+		input.CarrierGatewayId = aws.String(d.Get("carrier_gateway_id").(string))
+	case d.Get("egress_only_gateway_id").(string) != "":
+		input.EgressOnlyInternetGatewayId = aws.String(d.Get("egress_only_gateway_id").(string))
+	case d.Get("gateway_id").(string) != "":
+		input.GatewayId = aws.String(d.Get("gateway_id").(string))
+	case d.Get("local_gateway_id").(string) != "":
+		input.LocalGatewayId = aws.String(d.Get("local_gateway_id").(string))
+	case d.Get("nat_gateway_id").(string) != "":
+		input.NatGatewayId = aws.String(d.Get("nat_gateway_id").(string))
+	case d.Get("transit_gateway_id").(string) != "":
+		input.TransitGatewayId = aws.String(d.Get("transit_gateway_id").(string))
+	case d.Get("vpc_peering_connection_id").(string) != "":
+		input.VpcPeeringConnectionId = aws.String(d.Get("vpc_peering_connection_id").(string))
+	case d.HasChange("network_interface_id") && d.Get("network_interface_id").(string) != "":
+		input.NetworkInterfaceId = aws.String(d.Get("network_interface_id").(string))
+	case d.Get("instance_id").(string) != "":
+		input.InstanceId = aws.String(d.Get("instance_id").(string))
+	case d.Get("network_interface_id").(string) != "":
+		input.NetworkInterfaceId = aws.String(d.Get("network_interface_id").(string))
+	}
+
+	log.Printf("[DEBUG] Updating Route: %s", input)
+	if _, err := conn.ReplaceRoute(input); err != nil {
+		return fmt.Errorf("error updating Route in Route Table (%s) with destination (%s): %s", routeTableID, destination, err)
+	}
+
+	return resourceAwsRouteRead(d, meta)
+}
+
+func resourceAwsRouteDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTableID := d.Get("route_table_id").(string)
+	destination := resourceAwsRouteDestination(d)
+
+	input := &ec2.DeleteRouteInput{
+		RouteTableId: aws.String(routeTableID),
+	}
+
+	if v, ok := d.GetOk("destination_cidr_block"); ok {
+		input.DestinationCidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_ipv6_cidr_block"); ok {
+		input.DestinationIpv6CidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_prefix_list_id"); ok {
+		input.DestinationPrefixListId = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Deleting Route: %s", input)
+	_, err := conn.DeleteRoute(input)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteNotFound, "") || isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting Route in Route Table (%s) with destination (%s): %s", routeTableID, destination, err)
+	}
+
+	if _, err := waiter.RouteDeleted(conn, routeTableID, destination, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Route in Route Table (%s) with destination (%s) to delete: %s", routeTableID, destination, err)
+	}
+
+	return nil
+}
+
+func resourceAwsRouteImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	routeTableID, destination, err := tfec2.RouteParseImportID(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("route_table_id", routeTableID)
+
+	switch {
+	case strings.HasPrefix(destination, "pl-"):
+		d.Set("destination_prefix_list_id", destination)
+	case strings.Contains(destination, ":"):
+		d.Set("destination_ipv6_cidr_block", destination)
+	default:
+		d.Set("destination_cidr_block", destination)
+	}
+
+	d.SetId(tfec2.RouteCreateID(routeTableID, destination))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceAwsRouteDestination returns the configured destination of a route.
+func resourceAwsRouteDestination(d *schema.ResourceData) string {
+	for _, k := range routeValidDestinations {
+		if v := d.Get(k).(string); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
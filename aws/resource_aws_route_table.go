@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -13,6 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
 func resourceAwsRouteTable() *schema.Resource {
@@ -22,9 +26,17 @@ func resourceAwsRouteTable() *schema.Resource {
 		Update: resourceAwsRouteTableUpdate,
 		Delete: resourceAwsRouteTableDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAwsRouteTableImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_route_table"),
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -59,6 +71,11 @@ func resourceAwsRouteTable() *schema.Resource {
 							Optional: true,
 						},
 
+						"destination_prefix_list_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
 						"egress_only_gateway_id": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -129,14 +146,7 @@ func resourceAwsRouteTableCreate(d *schema.ResourceData, meta interface{}) error
 	log.Printf(
 		"[DEBUG] Waiting for route table (%s) to become available",
 		d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:        []string{"pending"},
-		Target:         []string{"ready"},
-		Refresh:        resourceAwsRouteTableStateRefreshFunc(conn, d.Id()),
-		Timeout:        10 * time.Minute,
-		NotFoundChecks: 40,
-	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := waiter.RouteTableReady(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf(
 			"Error waiting for route table (%s) to become available: %s",
 			d.Id(), err)
@@ -154,16 +164,21 @@ func resourceAwsRouteTableCreate(d *schema.ResourceData, meta interface{}) error
 func resourceAwsRouteTableRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
-	rtRaw, _, err := resourceAwsRouteTableStateRefreshFunc(conn, d.Id())()
+	rt, err := finder.RouteTableByID(conn, d.Id())
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		log.Printf("[WARN] Route Table (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("error reading Route Table (%s): %s", d.Id(), err)
 	}
-	if rtRaw == nil {
+	if rt == nil {
+		log.Printf("[WARN] Route Table (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	rt := rtRaw.(*ec2.RouteTable)
 	d.Set("vpc_id", rt.VpcId)
 
 	propagatingVGWs := make([]string, 0, len(rt.PropagatingVgws))
@@ -172,58 +187,9 @@ func resourceAwsRouteTableRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.Set("propagating_vgws", propagatingVGWs)
 
-	// Create an empty schema.Set to hold all routes
-	route := &schema.Set{F: resourceAwsRouteTableHash}
-
-	// Loop through the routes and add them to the set
-	for _, r := range rt.Routes {
-		if r.GatewayId != nil && *r.GatewayId == "local" {
-			continue
-		}
-
-		if r.Origin != nil && *r.Origin == "EnableVgwRoutePropagation" {
-			continue
-		}
-
-		if r.DestinationPrefixListId != nil {
-			// Skipping because VPC endpoint routes are handled separately
-			// See aws_vpc_endpoint
-			continue
-		}
-
-		m := make(map[string]interface{})
-
-		if r.DestinationCidrBlock != nil {
-			m["cidr_block"] = *r.DestinationCidrBlock
-		}
-		if r.DestinationIpv6CidrBlock != nil {
-			m["ipv6_cidr_block"] = *r.DestinationIpv6CidrBlock
-		}
-		if r.EgressOnlyInternetGatewayId != nil {
-			m["egress_only_gateway_id"] = *r.EgressOnlyInternetGatewayId
-		}
-		if r.GatewayId != nil {
-			m["gateway_id"] = *r.GatewayId
-		}
-		if r.NatGatewayId != nil {
-			m["nat_gateway_id"] = *r.NatGatewayId
-		}
-		if r.InstanceId != nil {
-			m["instance_id"] = *r.InstanceId
-		}
-		if r.TransitGatewayId != nil {
-			m["transit_gateway_id"] = *r.TransitGatewayId
-		}
-		if r.VpcPeeringConnectionId != nil {
-			m["vpc_peering_connection_id"] = *r.VpcPeeringConnectionId
-		}
-		if r.NetworkInterfaceId != nil {
-			m["network_interface_id"] = *r.NetworkInterfaceId
-		}
-
-		route.Add(m)
-	}
-	d.Set("route", route)
+	// Routes managed inline are tracked by destination so that routes added
+	// by aws_route resources are not read into the route table.
+	d.Set("route", resourceAwsRouteTableRoutes(rt.Routes, resourceAwsRouteTableInlineDestinations(d)))
 
 	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(rt.Tags).IgnoreAws().Map()); err != nil {
 		return fmt.Errorf("error setting tags: %s", err)
@@ -328,6 +294,14 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 					d.Id(), m["cidr_block"].(string))
 			}
 
+			if s := m["destination_prefix_list_id"].(string); s != "" {
+				deleteOpts.DestinationPrefixListId = aws.String(s)
+
+				log.Printf(
+					"[INFO] Deleting route from %s: %s",
+					d.Id(), m["destination_prefix_list_id"].(string))
+			}
+
 			_, err := conn.DeleteRoute(deleteOpts)
 			if err != nil {
 				return err
@@ -370,6 +344,10 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 				opts.DestinationCidrBlock = aws.String(s)
 			}
 
+			if s := m["destination_prefix_list_id"].(string); s != "" {
+				opts.DestinationPrefixListId = aws.String(s)
+			}
+
 			if s := m["gateway_id"].(string); s != "" {
 				opts.GatewayId = aws.String(s)
 			}
@@ -383,7 +361,7 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 			}
 
 			log.Printf("[INFO] Creating route for %s: %#v", d.Id(), opts)
-			err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+			err := resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
 				_, err := conn.CreateRoute(&opts)
 
 				if isAWSErr(err, "InvalidRouteTableID.NotFound", "") {
@@ -427,14 +405,16 @@ func resourceAwsRouteTableDelete(d *schema.ResourceData, meta interface{}) error
 
 	// First request the routing table since we'll have to disassociate
 	// all the subnets first.
-	rtRaw, _, err := resourceAwsRouteTableStateRefreshFunc(conn, d.Id())()
+	rt, err := finder.RouteTableByID(conn, d.Id())
+	if isAWSErr(err, tfec2.ErrCodeInvalidRouteTableIDNotFound, "") {
+		return nil
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("error reading Route Table (%s): %s", d.Id(), err)
 	}
-	if rtRaw == nil {
+	if rt == nil {
 		return nil
 	}
-	rt := rtRaw.(*ec2.RouteTable)
 
 	// Do all the disassociations
 	for _, a := range rt.Associations {
@@ -462,7 +442,7 @@ func resourceAwsRouteTableDelete(d *schema.ResourceData, meta interface{}) error
 	})
 	if err != nil {
 		ec2err, ok := err.(awserr.Error)
-		if ok && ec2err.Code() == "InvalidRouteTableID.NotFound" {
+		if ok && ec2err.Code() == tfec2.ErrCodeInvalidRouteTableIDNotFound {
 			return nil
 		}
 
@@ -474,13 +454,7 @@ func resourceAwsRouteTableDelete(d *schema.ResourceData, meta interface{}) error
 		"[DEBUG] Waiting for route table (%s) to become destroyed",
 		d.Id())
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"ready"},
-		Target:  []string{},
-		Refresh: resourceAwsRouteTableStateRefreshFunc(conn, d.Id()),
-		Timeout: 5 * time.Minute,
-	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := waiter.RouteTableDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
 		return fmt.Errorf(
 			"Error waiting for route table (%s) to become destroyed: %s",
 			d.Id(), err)
@@ -489,6 +463,108 @@ func resourceAwsRouteTableDelete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+// resourceAwsRouteTableImport reads every route in the table into the inline
+// routes, as there is no configuration to tell them apart from aws_route
+// resources.
+func resourceAwsRouteTableImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*AWSClient).ec2conn
+
+	rt, err := finder.RouteTableByID(conn, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error reading Route Table (%s): %s", d.Id(), err)
+	}
+	if rt == nil {
+		return nil, fmt.Errorf("Route Table (%s) not found", d.Id())
+	}
+
+	d.Set("route", resourceAwsRouteTableRoutes(rt.Routes, nil))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceAwsRouteTableRoutes returns the routes to the given destinations,
+// or every route when destinations is nil.
+func resourceAwsRouteTableRoutes(routes []*ec2.Route, destinations map[string]bool) *schema.Set {
+	// Create an empty schema.Set to hold all routes
+	route := &schema.Set{F: resourceAwsRouteTableHash}
+
+	// Loop through the routes and add them to the set
+	for _, r := range routes {
+		if r.GatewayId != nil && *r.GatewayId == "local" {
+			continue
+		}
+
+		if destinations != nil && !destinations[aws.StringValue(r.DestinationCidrBlock)] && !destinations[aws.StringValue(r.DestinationIpv6CidrBlock)] && !destinations[aws.StringValue(r.DestinationPrefixListId)] {
+			continue
+		}
+
+		if r.Origin != nil && *r.Origin == "EnableVgwRoutePropagation" {
+			continue
+		}
+
+		if r.DestinationPrefixListId != nil && strings.HasPrefix(aws.StringValue(r.GatewayId), "vpce-") {
+			// Skipping because VPC endpoint routes are handled separately
+			// See aws_vpc_endpoint
+			continue
+		}
+
+		m := make(map[string]interface{})
+
+		if r.DestinationCidrBlock != nil {
+			m["cidr_block"] = *r.DestinationCidrBlock
+		}
+		if r.DestinationIpv6CidrBlock != nil {
+			m["ipv6_cidr_block"] = *r.DestinationIpv6CidrBlock
+		}
+		if r.DestinationPrefixListId != nil {
+			m["destination_prefix_list_id"] = *r.DestinationPrefixListId
+		}
+		if r.EgressOnlyInternetGatewayId != nil {
+			m["egress_only_gateway_id"] = *r.EgressOnlyInternetGatewayId
+		}
+		if r.GatewayId != nil {
+			m["gateway_id"] = *r.GatewayId
+		}
+		if r.NatGatewayId != nil {
+			m["nat_gateway_id"] = *r.NatGatewayId
+		}
+		if r.InstanceId != nil {
+			m["instance_id"] = *r.InstanceId
+		}
+		if r.TransitGatewayId != nil {
+			m["transit_gateway_id"] = *r.TransitGatewayId
+		}
+		if r.VpcPeeringConnectionId != nil {
+			m["vpc_peering_connection_id"] = *r.VpcPeeringConnectionId
+		}
+		if r.NetworkInterfaceId != nil {
+			m["network_interface_id"] = *r.NetworkInterfaceId
+		}
+
+		route.Add(m)
+	}
+
+	return route
+}
+
+// resourceAwsRouteTableInlineDestinations returns the destinations of the
+// inline routes in state.
+func resourceAwsRouteTableInlineDestinations(d *schema.ResourceData) map[string]bool {
+	destinations := make(map[string]bool)
+
+	for _, v := range d.Get("route").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+
+		for _, k := range []string{"cidr_block", "ipv6_cidr_block", "destination_prefix_list_id"} {
+			if s := m[k].(string); s != "" {
+				destinations[s] = true
+			}
+		}
+	}
+
+	return destinations
+}
+
 func resourceAwsRouteTableHash(v interface{}) int {
 	var buf bytes.Buffer
 	m, castOk := v.(map[string]interface{})
@@ -504,6 +580,10 @@ func resourceAwsRouteTableHash(v interface{}) int {
 		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
 	}
 
+	if v, ok := m["destination_prefix_list_id"]; ok && v.(string) != "" {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+
 	if v, ok := m["gateway_id"]; ok {
 		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
 	}
@@ -538,30 +618,3 @@ func resourceAwsRouteTableHash(v interface{}) int {
 
 	return hashcode.String(buf.String())
 }
-
-// resourceAwsRouteTableStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
-// a RouteTable.
-func resourceAwsRouteTableStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		resp, err := conn.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
-			RouteTableIds: []*string{aws.String(id)},
-		})
-		if err != nil {
-			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidRouteTableID.NotFound" {
-				resp = nil
-			} else {
-				log.Printf("Error on RouteTableStateRefresh: %s", err)
-				return nil, "", err
-			}
-		}
-
-		if resp == nil {
-			// Sometimes AWS just has consistency issues and doesn't see
-			// our instance yet. Return an empty state.
-			return nil, "", nil
-		}
-
-		rt := resp.RouteTables[0]
-		return rt, "ready", nil
-	}
-}
@@ -0,0 +1,217 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsRouteTableAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsRouteTableAssociationCreate,
+		Read:   resourceAwsRouteTableAssociationRead,
+		Update: resourceAwsRouteTableAssociationUpdate,
+		Delete: resourceAwsRouteTableAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsRouteTableAssociationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet_id", "gateway_id"},
+			},
+			"gateway_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet_id", "gateway_id"},
+			},
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAwsRouteTableAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	associationOpts := &ec2.AssociateRouteTableInput{
+		RouteTableId: aws.String(d.Get("route_table_id").(string)),
+	}
+	if v, ok := d.GetOk("subnet_id"); ok {
+		associationOpts.SubnetId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("gateway_id"); ok {
+		associationOpts.GatewayId = aws.String(v.(string))
+	}
+
+	log.Printf("[INFO] Creating route table association: %s", associationOpts)
+
+	var associationID string
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		resp, err := conn.AssociateRouteTable(associationOpts)
+		if err != nil {
+			if isAWSErr(err, "InvalidRouteTableID.NotFound", "") {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		associationID = aws.StringValue(resp.AssociationId)
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		var resp *ec2.AssociateRouteTableOutput
+		resp, err = conn.AssociateRouteTable(associationOpts)
+		if err == nil {
+			associationID = aws.StringValue(resp.AssociationId)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating route table association: %s", err)
+	}
+
+	// Set the ID and return
+	d.SetId(associationID)
+	log.Printf("[INFO] Association ID: %s", d.Id())
+
+	if _, err := waiter.RouteTableAssociationAssociated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for Route Table Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsRouteTableAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	association, err := finder.RouteTableAssociationByID(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("error reading Route Table Association (%s): %s", d.Id(), err)
+	}
+
+	if association == nil {
+		log.Printf("[WARN] Route Table Association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("route_table_id", association.RouteTableId)
+	d.Set("subnet_id", association.SubnetId)
+	d.Set("gateway_id", association.GatewayId)
+
+	return nil
+}
+
+func resourceAwsRouteTableAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req := &ec2.ReplaceRouteTableAssociationInput{
+		AssociationId: aws.String(d.Id()),
+		RouteTableId:  aws.String(d.Get("route_table_id").(string)),
+	}
+	log.Printf("[INFO] Replacing route table association: %s", req)
+	resp, err := conn.ReplaceRouteTableAssociation(req)
+
+	if err != nil {
+		if isAWSErr(err, "InvalidAssociationID.NotFound", "") {
+			// Not found, so just create a new one
+			return resourceAwsRouteTableAssociationCreate(d, meta)
+		}
+
+		return fmt.Errorf("error replacing Route Table Association (%s): %s", d.Id(), err)
+	}
+
+	// Update the ID
+	d.SetId(aws.StringValue(resp.NewAssociationId))
+	log.Printf("[INFO] Association ID: %s", d.Id())
+
+	if _, err := waiter.RouteTableAssociationAssociated(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error waiting for Route Table Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsRouteTableAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting route table association: %s", d.Id())
+	_, err := conn.DisassociateRouteTable(&ec2.DisassociateRouteTableInput{
+		AssociationId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, "InvalidAssociationID.NotFound", "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error deleting route table association: %s", err)
+	}
+
+	if _, err := waiter.RouteTableAssociationDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for Route Table Association (%s) to become disassociated: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsRouteTableAssociationImport imports an association of a subnet
+// or gateway with a route table, given as subnet-id/route-table-id or
+// gateway-id/route-table-id.
+func resourceAwsRouteTableAssociationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	targetID, routeTableID, err := tfec2.RouteTableAssociationParseImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Importing route table association, target: %s, route table: %s", targetID, routeTableID)
+
+	conn := meta.(*AWSClient).ec2conn
+
+	routeTable, err := finder.RouteTableByID(conn, routeTableID)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading Route Table (%s): %s", routeTableID, err)
+	}
+
+	if routeTable == nil {
+		return nil, fmt.Errorf("Route Table (%s) not found", routeTableID)
+	}
+
+	var associationID string
+
+	for _, association := range routeTable.Associations {
+		if aws.StringValue(association.SubnetId) == targetID || aws.StringValue(association.GatewayId) == targetID {
+			associationID = aws.StringValue(association.RouteTableAssociationId)
+			break
+		}
+	}
+
+	if associationID == "" {
+		return nil, fmt.Errorf("association %s not found", d.Id())
+	}
+
+	d.SetId(associationID)
+	d.Set("route_table_id", routeTableID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -13,12 +13,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/naming"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
 func resourceAwsSecurityGroup() *schema.Resource {
@@ -30,6 +32,10 @@ func resourceAwsSecurityGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceAwsSecurityGroupImportState,
 		},
+		CustomizeDiff: customdiff.Sequence(
+			networkGuardrailsSecurityGroupCustomizeDiff,
+			requiredTagsCustomizeDiff("aws_security_group"),
+		),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -262,7 +268,7 @@ func resourceAwsSecurityGroupCreate(d *schema.ResourceData, meta interface{}) er
 	log.Printf("[INFO] Security Group ID: %s", d.Id())
 
 	// Wait for the security group to truly exist
-	resp, err := waitForSgToExist(conn, d.Id(), d.Timeout(schema.TimeoutCreate))
+	group, err := waiter.SecurityGroupCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for Security Group (%s) to become available: %s",
@@ -277,7 +283,6 @@ func resourceAwsSecurityGroupCreate(d *schema.ResourceData, meta interface{}) er
 
 	// AWS defaults all Security Groups to have an ALLOW ALL egress rule. Here we
 	// revoke that rule, so users don't unknowingly have/use it.
-	group := resp.(*ec2.SecurityGroup)
 	if group.VpcId != nil && *group.VpcId != "" {
 		log.Printf("[DEBUG] Revoking default egress rule for Security Group for %s", d.Id())
 
@@ -342,7 +347,7 @@ func resourceAwsSecurityGroupRead(d *schema.ResourceData, meta interface{}) erro
 	var sgRaw interface{}
 	var err error
 	if d.IsNewResource() {
-		sgRaw, err = waitForSgToExist(conn, d.Id(), d.Timeout(schema.TimeoutRead))
+		sgRaw, err = waiter.SecurityGroupCreated(conn, d.Id(), d.Timeout(schema.TimeoutRead))
 	} else {
 		sgRaw, _, err = SGStateRefreshFunc(conn, d.Id())()
 	}
@@ -405,7 +410,7 @@ func resourceAwsSecurityGroupUpdate(d *schema.ResourceData, meta interface{}) er
 	var sgRaw interface{}
 	var err error
 	if d.IsNewResource() {
-		sgRaw, err = waitForSgToExist(conn, d.Id(), d.Timeout(schema.TimeoutRead))
+		sgRaw, err = waiter.SecurityGroupCreated(conn, d.Id(), d.Timeout(schema.TimeoutRead))
 	} else {
 		sgRaw, _, err = SGStateRefreshFunc(conn, d.Id())()
 	}
@@ -821,18 +826,6 @@ func SGStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
 	}
 }
 
-func waitForSgToExist(conn *ec2.EC2, id string, timeout time.Duration) (interface{}, error) {
-	log.Printf("[DEBUG] Waiting for Security Group (%s) to exist", id)
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{""},
-		Target:  []string{"exists"},
-		Refresh: SGStateRefreshFunc(conn, id),
-		Timeout: timeout,
-	}
-
-	return stateConf.WaitForState()
-}
-
 // matchRules receives the group id, type of rules, and the local / remote maps
 // of rules. We iterate through the local set of rules trying to find a matching
 // remote rule, which may be structured differently because of how AWS
@@ -1426,23 +1419,7 @@ func deleteLingeringLambdaENIs(conn *ec2.EC2, filterName, resourceId string, tim
 		if eni.Attachment != nil && aws.StringValue(eni.Attachment.InstanceOwnerId) == "amazon-aws" {
 			// Hyperplane attached ENI.
 			// Wait for it to be moved into a removable state.
-			stateConf := &resource.StateChangeConf{
-				Pending: []string{
-					ec2.NetworkInterfaceStatusInUse,
-				},
-				Target: []string{
-					ec2.NetworkInterfaceStatusAvailable,
-				},
-				Refresh:    networkInterfaceStateRefresh(conn, eniId),
-				Timeout:    timeout,
-				Delay:      10 * time.Second,
-				MinTimeout: 10 * time.Second,
-				// Handle EC2 ENI eventual consistency. It can take up to 3 minutes.
-				ContinuousTargetOccurence: 18,
-				NotFoundChecks:            1,
-			}
-
-			eniRaw, err := stateConf.WaitForState()
+			eni, err = waiter.NetworkInterfaceAvailableAfterUse(conn, eniId, timeout)
 
 			if isResourceNotFoundError(err) {
 				continue
@@ -1451,8 +1428,6 @@ func deleteLingeringLambdaENIs(conn *ec2.EC2, filterName, resourceId string, tim
 			if err != nil {
 				return fmt.Errorf("error waiting for Lambda V2N ENI (%s) to become available for detachment: %s", eniId, err)
 			}
-
-			eni = eniRaw.(*ec2.NetworkInterface)
 		}
 
 		err = detachNetworkInterface(conn, eni, timeout)
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
 )
 
 func resourceAwsSecurityGroupRule() *schema.Resource {
@@ -26,17 +27,26 @@ func resourceAwsSecurityGroupRule() *schema.Resource {
 		Delete: resourceAwsSecurityGroupRuleDelete,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				importParts, err := validateSecurityGroupRuleImportString(d.Id())
+				rule, err := tfec2.SecurityGroupRuleParseImportID(d.Id())
 				if err != nil {
 					return nil, err
 				}
-				if err := populateSecurityGroupRuleFromImport(d, importParts); err != nil {
-					return nil, err
+				if _, ok := sgProtocolIntegers()[rule.Protocol]; !ok {
+					if _, err := strconv.Atoi(rule.Protocol); err != nil {
+						return nil, fmt.Errorf("unexpected protocol (%q) in ID (%q), must be tcp/udp/icmp/all or a number", rule.Protocol, d.Id())
+					}
 				}
+				populateSecurityGroupRuleFromImport(d, rule)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: networkGuardrailsSecurityGroupRuleCustomizeDiff,
+
 		SchemaVersion: 2,
 		MigrateState:  resourceAwsSecurityGroupRuleMigrateState,
 
@@ -140,18 +150,22 @@ func resourceAwsSecurityGroupRule() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validateSecurityGroupRuleDescription,
 			},
+
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rule identified by its contents, excluding the description, in the format accepted by import.",
+			},
 		},
 	}
 }
 
 func resourceAwsSecurityGroupRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
+	batcher := meta.(*AWSClient).securityGroupRuleBatcher
 	sg_id := d.Get("security_group_id").(string)
 
-	awsMutexKV.Lock(sg_id)
-	defer awsMutexKV.Unlock(sg_id)
-
-	sg, err := findResourceSecurityGroup(conn, sg_id)
+	sg, err := batcher.describe(conn, sg_id)
 	if err != nil {
 		return err
 	}
@@ -172,38 +186,8 @@ func resourceAwsSecurityGroupRuleCreate(d *schema.ResourceData, meta interface{}
 	ruleType := d.Get("type").(string)
 	isVPC := sg.VpcId != nil && *sg.VpcId != ""
 
-	var autherr error
-	switch ruleType {
-	case "ingress":
-		log.Printf("[DEBUG] Authorizing security group %s %s rule: %s",
-			sg_id, "Ingress", perm)
-
-		req := &ec2.AuthorizeSecurityGroupIngressInput{
-			GroupId:       sg.GroupId,
-			IpPermissions: []*ec2.IpPermission{perm},
-		}
-
-		if !isVPC {
-			req.GroupId = nil
-			req.GroupName = sg.GroupName
-		}
-
-		_, autherr = conn.AuthorizeSecurityGroupIngress(req)
-
-	case "egress":
-		log.Printf("[DEBUG] Authorizing security group %s %s rule: %#v",
-			sg_id, "Egress", perm)
-
-		req := &ec2.AuthorizeSecurityGroupEgressInput{
-			GroupId:       sg.GroupId,
-			IpPermissions: []*ec2.IpPermission{perm},
-		}
-
-		_, autherr = conn.AuthorizeSecurityGroupEgress(req)
-
-	default:
-		return fmt.Errorf("Security Group Rule must be type 'ingress' or type 'egress'")
-	}
+	log.Printf("[DEBUG] Authorizing security group %s %s rule: %s", sg_id, ruleType, perm)
+	autherr := batcher.authorize(conn, sg, ruleType, perm)
 
 	if autherr != nil {
 		if awsErr, ok := autherr.(awserr.Error); ok {
@@ -225,8 +209,8 @@ information and instructions for recovery. Error message: %s`, sg_id, awsErr.Mes
 	id := ipPermissionIDHash(sg_id, ruleType, perm)
 	log.Printf("[DEBUG] Computed group rule ID %s", id)
 
-	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
-		sg, err := findResourceSecurityGroup(conn, sg_id)
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		sg, err := batcher.describe(conn, sg_id)
 
 		if err != nil {
 			log.Printf("[DEBUG] Error finding Security Group (%s) for Rule (%s): %s", sg_id, id, err)
@@ -251,7 +235,7 @@ information and instructions for recovery. Error message: %s`, sg_id, awsErr.Mes
 		return nil
 	})
 	if isResourceTimeoutError(err) {
-		sg, err := findResourceSecurityGroup(conn, sg_id)
+		sg, err := batcher.describe(conn, sg_id)
 		if err != nil {
 			return fmt.Errorf("Error finding security group: %s", err)
 		}
@@ -273,13 +257,13 @@ information and instructions for recovery. Error message: %s`, sg_id, awsErr.Mes
 	}
 
 	d.SetId(id)
-	return nil
+	return resourceAwsSecurityGroupRuleRead(d, meta)
 }
 
 func resourceAwsSecurityGroupRuleRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 	sg_id := d.Get("security_group_id").(string)
-	sg, err := findResourceSecurityGroup(conn, sg_id)
+	sg, err := meta.(*AWSClient).securityGroupRuleBatcher.describe(conn, sg_id)
 	if _, notFound := err.(securityGroupNotFound); notFound {
 		// The security group containing this rule no longer exists.
 		d.SetId("")
@@ -331,6 +315,7 @@ func resourceAwsSecurityGroupRuleRead(d *schema.ResourceData, meta interface{})
 	}
 
 	d.Set("description", descriptionFromIPPerm(d, rule))
+	d.Set("fingerprint", securityGroupRuleFingerprint(sg, ruleType, rule, p))
 
 	if strings.Contains(d.Id(), "_") {
 		// import so fix the id
@@ -345,7 +330,7 @@ func resourceAwsSecurityGroupRuleUpdate(d *schema.ResourceData, meta interface{}
 	conn := meta.(*AWSClient).ec2conn
 
 	if d.HasChange("description") {
-		if err := resourceSecurityGroupRuleDescriptionUpdate(conn, d); err != nil {
+		if err := resourceSecurityGroupRuleDescriptionUpdate(conn, meta.(*AWSClient).securityGroupRuleBatcher, d); err != nil {
 			return err
 		}
 	}
@@ -355,12 +340,10 @@ func resourceAwsSecurityGroupRuleUpdate(d *schema.ResourceData, meta interface{}
 
 func resourceAwsSecurityGroupRuleDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
+	batcher := meta.(*AWSClient).securityGroupRuleBatcher
 	sg_id := d.Get("security_group_id").(string)
 
-	awsMutexKV.Lock(sg_id)
-	defer awsMutexKV.Unlock(sg_id)
-
-	sg, err := findResourceSecurityGroup(conn, sg_id)
+	sg, err := batcher.describe(conn, sg_id)
 	if err != nil {
 		return err
 	}
@@ -370,38 +353,12 @@ func resourceAwsSecurityGroupRuleDelete(d *schema.ResourceData, meta interface{}
 		return err
 	}
 	ruleType := d.Get("type").(string)
-	switch ruleType {
-	case "ingress":
-		log.Printf("[DEBUG] Revoking rule (%s) from security group %s:\n%s",
-			"ingress", sg_id, perm)
-		req := &ec2.RevokeSecurityGroupIngressInput{
-			GroupId:       sg.GroupId,
-			IpPermissions: []*ec2.IpPermission{perm},
-		}
 
-		_, err = conn.RevokeSecurityGroupIngress(req)
-
-		if err != nil {
-			return fmt.Errorf(
-				"Error revoking security group %s rules: %s",
-				sg_id, err)
-		}
-	case "egress":
-
-		log.Printf("[DEBUG] Revoking security group %#v %s rule: %#v",
-			sg_id, "egress", perm)
-		req := &ec2.RevokeSecurityGroupEgressInput{
-			GroupId:       sg.GroupId,
-			IpPermissions: []*ec2.IpPermission{perm},
-		}
-
-		_, err = conn.RevokeSecurityGroupEgress(req)
-
-		if err != nil {
-			return fmt.Errorf(
-				"Error revoking security group %s rules: %s",
-				sg_id, err)
-		}
+	log.Printf("[DEBUG] Revoking security group %s %s rule: %s", sg_id, ruleType, perm)
+	if err := batcher.revoke(conn, sg, ruleType, perm); err != nil {
+		return fmt.Errorf(
+			"Error revoking security group %s rules: %s",
+			sg_id, err)
 	}
 
 	return nil
@@ -868,11 +825,12 @@ func validateAwsSecurityGroupRule(d *schema.ResourceData) error {
 	return nil
 }
 
-func resourceSecurityGroupRuleDescriptionUpdate(conn *ec2.EC2, d *schema.ResourceData) error {
+func resourceSecurityGroupRuleDescriptionUpdate(conn *ec2.EC2, batcher *securityGroupRuleBatcher, d *schema.ResourceData) error {
 	sg_id := d.Get("security_group_id").(string)
 
 	awsMutexKV.Lock(sg_id)
 	defer awsMutexKV.Unlock(sg_id)
+	defer batcher.invalidate(sg_id)
 
 	sg, err := findResourceSecurityGroup(conn, sg_id)
 	if err != nil {
@@ -916,88 +874,20 @@ func resourceSecurityGroupRuleDescriptionUpdate(conn *ec2.EC2, d *schema.Resourc
 	return nil
 }
 
-// validateSecurityGroupRuleImportString does minimal validation of import string without going to AWS
-func validateSecurityGroupRuleImportString(importStr string) ([]string, error) {
-	// example: sg-09a093729ef9382a6_ingress_tcp_8000_8000_10.0.3.0/24
-	// example: sg-09a093729ef9382a6_ingress_92_0_65536_10.0.3.0/24_10.0.4.0/24
-	// example: sg-09a093729ef9382a6_egress_tcp_8000_8000_10.0.3.0/24
-	// example: sg-09a093729ef9382a6_egress_tcp_8000_8000_pl-34800000
-	// example: sg-09a093729ef9382a6_ingress_all_0_65536_sg-08123412342323
-	// example: sg-09a093729ef9382a6_ingress_tcp_100_121_10.1.0.0/16_2001:db8::/48_10.2.0.0/16_2002:db8::/48
-
-	log.Printf("[DEBUG] Validating import string %s", importStr)
+func populateSecurityGroupRuleFromImport(d *schema.ResourceData, rule *tfec2.SecurityGroupRuleImportID) {
+	log.Printf("[DEBUG] Populating resource data on import: %#v", rule)
 
-	importParts := strings.Split(strings.ToLower(importStr), "_")
-	errStr := "unexpected format of import string (%q), expected SECURITYGROUPID_TYPE_PROTOCOL_FROMPORT_TOPORT_SOURCE[_SOURCE]*: %s"
-	if len(importParts) < 6 {
-		return nil, fmt.Errorf(errStr, importStr, "too few parts")
-	}
-
-	sgID := importParts[0]
-	ruleType := importParts[1]
-	protocol := importParts[2]
-	fromPort := importParts[3]
-	toPort := importParts[4]
-	sources := importParts[5:]
-
-	if !strings.HasPrefix(sgID, "sg-") {
-		return nil, fmt.Errorf(errStr, importStr, "invalid security group ID")
-	}
-
-	if ruleType != "ingress" && ruleType != "egress" {
-		return nil, fmt.Errorf(errStr, importStr, "expecting 'ingress' or 'egress'")
-	}
-
-	if _, ok := sgProtocolIntegers()[protocol]; !ok {
-		if _, err := strconv.Atoi(protocol); err != nil {
-			return nil, fmt.Errorf(errStr, importStr, "protocol must be tcp/udp/icmp/all or a number")
-		}
-	}
-
-	if p1, err := strconv.Atoi(fromPort); err != nil {
-		return nil, fmt.Errorf(errStr, importStr, "invalid port")
-	} else if p2, err := strconv.Atoi(toPort); err != nil || p2 < p1 {
-		return nil, fmt.Errorf(errStr, importStr, "invalid port")
-	}
-
-	for _, source := range sources {
-		// will be properly validated later
-		if source != "self" && !strings.Contains(source, "sg-") && !strings.Contains(source, "pl-") && !strings.Contains(source, ":") && !strings.Contains(source, ".") {
-			return nil, fmt.Errorf(errStr, importStr, "source must be cidr, ipv6cidr, prefix list, 'self', or a sg ID")
-		}
-	}
-
-	log.Printf("[DEBUG] Validated import string %s", importStr)
-	return importParts, nil
-}
-
-func populateSecurityGroupRuleFromImport(d *schema.ResourceData, importParts []string) error {
-	log.Printf("[DEBUG] Populating resource data on import: %v", importParts)
-
-	sgID := importParts[0]
-	ruleType := importParts[1]
-	protocol := importParts[2]
-	fromPort, _ := strconv.Atoi(importParts[3])
-	toPort, _ := strconv.Atoi(importParts[4])
-	sources := importParts[5:]
-
-	d.Set("security_group_id", sgID)
-
-	if ruleType == "ingress" {
-		d.Set("type", ruleType)
-	} else {
-		d.Set("type", "egress")
-	}
-
-	d.Set("protocol", protocolForValue(protocol))
-	d.Set("from_port", fromPort)
-	d.Set("to_port", toPort)
+	d.Set("security_group_id", rule.SecurityGroupID)
+	d.Set("type", rule.Type)
+	d.Set("protocol", protocolForValue(rule.Protocol))
+	d.Set("from_port", rule.FromPort)
+	d.Set("to_port", rule.ToPort)
 
 	d.Set("self", false)
 	var cidrs []string
 	var prefixList []string
 	var ipv6cidrs []string
-	for _, source := range sources {
+	for _, source := range rule.Sources {
 		if source == "self" {
 			d.Set("self", true)
 		} else if strings.Contains(source, "sg-") {
@@ -1013,6 +903,73 @@ func populateSecurityGroupRuleFromImport(d *schema.ResourceData, importParts []s
 	d.Set("ipv6_cidr_blocks", ipv6cidrs)
 	d.Set("cidr_blocks", cidrs)
 	d.Set("prefix_list_ids", prefixList)
+}
 
-	return nil
+// securityGroupRuleFingerprint returns the import ID of a rule. Unlike the
+// resource ID it is readable and does not change with the description, so it
+// identifies the rule when reporting drift. It is built from the permission
+// read from AWS. AWS merges rules with the same protocol and ports into one
+// permission, so only the sources of the permission that are also in the
+// rule's own permission p are included.
+func securityGroupRuleFingerprint(sg *ec2.SecurityGroup, ruleType string, rule, p *ec2.IpPermission) string {
+	isVPC := aws.StringValue(sg.VpcId) != ""
+	managed := make(map[string]bool)
+
+	for _, v := range p.IpRanges {
+		managed[aws.StringValue(v.CidrIp)] = true
+	}
+	for _, v := range p.Ipv6Ranges {
+		managed[aws.StringValue(v.CidrIpv6)] = true
+	}
+	for _, v := range p.PrefixListIds {
+		managed[aws.StringValue(v.PrefixListId)] = true
+	}
+	for _, v := range p.UserIdGroupPairs {
+		if isVPC {
+			managed[aws.StringValue(v.GroupId)] = true
+		} else {
+			managed[aws.StringValue(v.GroupName)] = true
+		}
+	}
+
+	var sources []string
+
+	for _, v := range rule.IpRanges {
+		if managed[aws.StringValue(v.CidrIp)] {
+			sources = append(sources, aws.StringValue(v.CidrIp))
+		}
+	}
+	for _, v := range rule.Ipv6Ranges {
+		if managed[aws.StringValue(v.CidrIpv6)] {
+			sources = append(sources, aws.StringValue(v.CidrIpv6))
+		}
+	}
+	for _, v := range rule.PrefixListIds {
+		if managed[aws.StringValue(v.PrefixListId)] {
+			sources = append(sources, aws.StringValue(v.PrefixListId))
+		}
+	}
+	for _, v := range rule.UserIdGroupPairs {
+		source, self := aws.StringValue(v.GroupId), aws.StringValue(sg.GroupId)
+		if !isVPC {
+			source, self = aws.StringValue(v.GroupName), aws.StringValue(sg.GroupName)
+		}
+
+		if !managed[source] {
+			continue
+		}
+
+		if source == self {
+			source = "self"
+		}
+
+		sources = append(sources, source)
+	}
+
+	protocol := aws.StringValue(rule.IpProtocol)
+	if protocol == "-1" {
+		protocol = "all"
+	}
+
+	return tfec2.SecurityGroupRuleCreateImportID(aws.StringValue(sg.GroupId), ruleType, protocol, int(aws.Int64Value(rule.FromPort)), int(aws.Int64Value(rule.ToPort)), sources)
 }
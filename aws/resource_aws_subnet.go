@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
 func resourceAwsSubnet() *schema.Resource {
@@ -23,8 +25,11 @@ func resourceAwsSubnet() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_subnet"),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
@@ -126,16 +131,7 @@ func resourceAwsSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 
 	// Wait for the Subnet to become available
 	log.Printf("[DEBUG] Waiting for subnet (%s) to become available", *subnet.SubnetId)
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"pending"},
-		Target:  []string{"available"},
-		Refresh: SubnetStateRefreshFunc(conn, *subnet.SubnetId),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	_, err = stateConf.WaitForState()
-
-	if err != nil {
+	if _, err := waiter.SubnetAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf(
 			"Error waiting for subnet (%s) to become ready: %s",
 			d.Id(), err)
@@ -183,24 +179,24 @@ func resourceAwsSubnetRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
 
-	resp, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
-		SubnetIds: []*string{aws.String(d.Id())},
-	})
+	subnet, err := finder.SubnetByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidSubnetIDNotFound, "") {
+		log.Printf("[WARN] Subnet (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
 
 	if err != nil {
-		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidSubnetID.NotFound" {
-			// Update state to indicate the subnet no longer exists.
-			d.SetId("")
-			return nil
-		}
 		return err
 	}
-	if resp == nil {
+
+	if subnet == nil {
+		log.Printf("[WARN] Subnet (%s) not found, removing from state", d.Id())
+		d.SetId("")
 		return nil
 	}
 
-	subnet := resp.Subnets[0]
-
 	d.Set("vpc_id", subnet.VpcId)
 	d.Set("availability_zone", subnet.AvailabilityZone)
 	d.Set("availability_zone_id", subnet.AvailabilityZoneId)
@@ -282,13 +278,7 @@ func resourceAwsSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
 			log.Printf(
 				"[DEBUG] Waiting for IPv6 CIDR (%s) to become disassociated",
 				d.Id())
-			stateConf := &resource.StateChangeConf{
-				Pending: []string{"disassociating", "associated"},
-				Target:  []string{"disassociated"},
-				Refresh: SubnetIpv6CidrStateRefreshFunc(conn, d.Id(), d.Get("ipv6_cidr_block_association_id").(string)),
-				Timeout: 3 * time.Minute,
-			}
-			if _, err := stateConf.WaitForState(); err != nil {
+			if _, err := waiter.SubnetIpv6CidrBlockAssociationDeleted(conn, d.Id(), v.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
 				return fmt.Errorf(
 					"Error waiting for IPv6 CIDR (%s) to become disassociated: %s",
 					d.Id(), err)
@@ -312,13 +302,7 @@ func resourceAwsSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf(
 			"[DEBUG] Waiting for IPv6 CIDR (%s) to become associated",
 			d.Id())
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"associating", "disassociated"},
-			Target:  []string{"associated"},
-			Refresh: SubnetIpv6CidrStateRefreshFunc(conn, d.Id(), *resp.Ipv6CidrBlockAssociation.AssociationId),
-			Timeout: 3 * time.Minute,
-		}
-		if _, err := stateConf.WaitForState(); err != nil {
+		if _, err := waiter.SubnetIpv6CidrBlockAssociationCreated(conn, d.Id(), aws.StringValue(resp.Ipv6CidrBlockAssociation.AssociationId), d.Timeout(schema.TimeoutUpdate)); err != nil {
 			return fmt.Errorf(
 				"Error waiting for IPv6 CIDR (%s) to become associated: %s",
 				d.Id(), err)
@@ -354,101 +338,40 @@ func resourceAwsSubnetDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error deleting Lambda ENIs using subnet (%s): %s", d.Id(), err)
 	}
 
-	req := &ec2.DeleteSubnetInput{
-		SubnetId: aws.String(d.Id()),
-	}
-
-	wait := resource.StateChangeConf{
-		Pending:    []string{"pending"},
-		Target:     []string{"destroyed"},
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		MinTimeout: 1 * time.Second,
-		Refresh: func() (interface{}, string, error) {
-			_, err := conn.DeleteSubnet(req)
-			if err != nil {
-				if apiErr, ok := err.(awserr.Error); ok {
-					if apiErr.Code() == "DependencyViolation" {
-						// There is some pending operation, so just retry
-						// in a bit.
-						return 42, "pending", nil
-					}
-
-					if apiErr.Code() == "InvalidSubnetID.NotFound" {
-						return 42, "destroyed", nil
-					}
-				}
-
-				return 42, "failure", err
-			}
-
-			return 42, "destroyed", nil
-		},
-	}
-
-	if _, err := wait.WaitForState(); err != nil {
+	if err := deleteSubnet(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
 		return fmt.Errorf("Error deleting subnet: %s", err)
 	}
 
 	return nil
 }
 
-// SubnetStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch a Subnet.
-func SubnetStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		resp, err := conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
-			SubnetIds: []*string{aws.String(id)},
-		})
-		if err != nil {
-			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidSubnetID.NotFound" {
-				resp = nil
-			} else {
-				log.Printf("Error on SubnetStateRefresh: %s", err)
-				return nil, "", err
-			}
-		}
-
-		if resp == nil {
-			// Sometimes AWS just has consistency issues and doesn't see
-			// our instance yet. Return an empty state.
-			return nil, "", nil
-		}
-
-		subnet := resp.Subnets[0]
-		return subnet, *subnet.State, nil
+// deleteSubnet deletes a subnet, retrying while it still has dependencies.
+func deleteSubnet(conn *ec2.EC2, id string, timeout time.Duration) error {
+	input := &ec2.DeleteSubnetInput{
+		SubnetId: aws.String(id),
 	}
-}
 
-func SubnetIpv6CidrStateRefreshFunc(conn *ec2.EC2, id string, associationId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		opts := &ec2.DescribeSubnetsInput{
-			SubnetIds: []*string{aws.String(id)},
-		}
-		resp, err := conn.DescribeSubnets(opts)
-		if err != nil {
-			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidSubnetID.NotFound" {
-				resp = nil
-			} else {
-				log.Printf("Error on SubnetIpv6CidrStateRefreshFunc: %s", err)
-				return nil, "", err
-			}
-		}
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.DeleteSubnet(input)
 
-		if resp == nil {
-			// Sometimes AWS just has consistency issues and doesn't see
-			// our instance yet. Return an empty state.
-			return nil, "", nil
+		if isAWSErr(err, tfec2.ErrCodeDependencyViolation, "") {
+			return resource.RetryableError(err)
 		}
 
-		if resp.Subnets[0].Ipv6CidrBlockAssociationSet == nil {
-			return nil, "", nil
+		if err != nil {
+			return resource.NonRetryableError(err)
 		}
 
-		for _, association := range resp.Subnets[0].Ipv6CidrBlockAssociationSet {
-			if *association.AssociationId == associationId {
-				return association, *association.Ipv6CidrBlockState.State, nil
-			}
-		}
+		return nil
+	})
 
-		return nil, "", nil
+	if isResourceTimeoutError(err) {
+		_, err = conn.DeleteSubnet(input)
 	}
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidSubnetIDNotFound, "") {
+		return nil
+	}
+
+	return err
 }
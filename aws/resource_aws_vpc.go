@@ -9,12 +9,19 @@ import (
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
+// vpcIpv6PoolAmazon is the IPv6 address pool of Amazon-provided IPv6 CIDR blocks.
+const vpcIpv6PoolAmazon = "Amazon"
+
 func resourceAwsVpc() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsVpcCreate,
@@ -24,7 +31,16 @@ func resourceAwsVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceAwsVpcInstanceImport,
 		},
-		CustomizeDiff: resourceAwsVpcCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsVpcCustomizeDiff,
+			requiredTagsCustomizeDiff("aws_vpc"),
+		),
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAwsVpcMigrateState,
@@ -69,9 +85,17 @@ func resourceAwsVpc() *schema.Resource {
 			},
 
 			"assign_generated_ipv6_cidr_block": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"ipv6_ipam_pool"},
+			},
+
+			"ipv6_ipam_pool": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"assign_generated_ipv6_cidr_block"},
 			},
 
 			"main_route_table_id": {
@@ -105,8 +129,10 @@ func resourceAwsVpc() *schema.Resource {
 			},
 
 			"ipv6_cidr_block": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsCIDRNetwork(56, 56),
 			},
 
 			"arn": {
@@ -134,6 +160,16 @@ func resourceAwsVpcCreate(d *schema.ResourceData, meta interface{}) error {
 		AmazonProvidedIpv6CidrBlock: aws.Bool(d.Get("assign_generated_ipv6_cidr_block").(bool)),
 	}
 
+	// An IPv6 CIDR block from a BYOIP address pool is either the requested
+	// one or the next free one in the pool.
+	if v, ok := d.GetOk("ipv6_ipam_pool"); ok {
+		createOpts.Ipv6Pool = aws.String(v.(string))
+
+		if v, ok := d.GetOk("ipv6_cidr_block"); ok {
+			createOpts.Ipv6CidrBlock = aws.String(v.(string))
+		}
+	}
+
 	log.Printf("[DEBUG] VPC create config: %#v", *createOpts)
 	vpcResp, err := conn.CreateVpc(createOpts)
 	if err != nil {
@@ -149,13 +185,7 @@ func resourceAwsVpcCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf(
 		"[DEBUG] Waiting for VPC (%s) to become available",
 		d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"pending"},
-		Target:  []string{"available"},
-		Refresh: VPCStateRefreshFunc(conn, d.Id()),
-		Timeout: 10 * time.Minute,
-	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := waiter.VpcAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf(
 			"Error waiting for VPC (%s) to become available: %s",
 			d.Id(), err)
@@ -163,7 +193,7 @@ func resourceAwsVpcCreate(d *schema.ResourceData, meta interface{}) error {
 
 	if len(vpc.Ipv6CidrBlockAssociationSet) > 0 && vpc.Ipv6CidrBlockAssociationSet[0] != nil {
 		log.Printf("[DEBUG] Waiting for EC2 VPC (%s) IPv6 CIDR to become associated", d.Id())
-		if err := waitForEc2VpcIpv6CidrBlockAssociationCreate(conn, d.Id(), aws.StringValue(vpcResp.Vpc.Ipv6CidrBlockAssociationSet[0].AssociationId)); err != nil {
+		if _, err := waiter.VpcIpv6CidrBlockAssociationCreated(conn, d.Id(), aws.StringValue(vpcResp.Vpc.Ipv6CidrBlockAssociationSet[0].AssociationId), d.Timeout(schema.TimeoutCreate)); err != nil {
 			return fmt.Errorf("error waiting for EC2 VPC (%s) IPv6 CIDR to become associated: %s", d.Id(), err)
 		}
 	}
@@ -233,18 +263,22 @@ func resourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
 
-	// Refresh the VPC state
-	vpcRaw, _, err := VPCStateRefreshFunc(conn, d.Id())()
+	vpc, err := finder.VpcByID(conn, d.Id())
+	if isAWSErr(err, tfec2.ErrCodeInvalidVpcIDNotFound, "") {
+		log.Printf("[WARN] VPC (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	if vpcRaw == nil {
+	if vpc == nil {
+		log.Printf("[WARN] VPC (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
 	// VPC stuff
-	vpc := vpcRaw.(*ec2.Vpc)
 	vpcid := d.Id()
 	d.Set("cidr_block", vpc.CidrBlock)
 	d.Set("dhcp_options_id", vpc.DhcpOptionsId)
@@ -270,10 +304,15 @@ func resourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("assign_generated_ipv6_cidr_block", false)
 	d.Set("ipv6_association_id", "")
 	d.Set("ipv6_cidr_block", "")
+	d.Set("ipv6_ipam_pool", "")
 
 	for _, a := range vpc.Ipv6CidrBlockAssociationSet {
 		if aws.StringValue(a.Ipv6CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated { //we can only ever have 1 IPv6 block associated at once
-			d.Set("assign_generated_ipv6_cidr_block", true)
+			if pool := aws.StringValue(a.Ipv6Pool); pool == vpcIpv6PoolAmazon {
+				d.Set("assign_generated_ipv6_cidr_block", true)
+			} else {
+				d.Set("ipv6_ipam_pool", pool)
+			}
 			d.Set("ipv6_association_id", a.AssociationId)
 			d.Set("ipv6_cidr_block", a.Ipv6CidrBlock)
 		}
@@ -468,7 +507,7 @@ func resourceAwsVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 			}
 
 			log.Printf("[DEBUG] Waiting for EC2 VPC (%s) IPv6 CIDR to become associated", d.Id())
-			if err := waitForEc2VpcIpv6CidrBlockAssociationCreate(conn, d.Id(), aws.StringValue(resp.Ipv6CidrBlockAssociation.AssociationId)); err != nil {
+			if _, err := waiter.VpcIpv6CidrBlockAssociationCreated(conn, d.Id(), aws.StringValue(resp.Ipv6CidrBlockAssociation.AssociationId), d.Timeout(schema.TimeoutUpdate)); err != nil {
 				return fmt.Errorf("error waiting for EC2 VPC (%s) IPv6 CIDR to become associated: %s", d.Id(), err)
 			}
 		} else {
@@ -483,7 +522,7 @@ func resourceAwsVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 			}
 
 			log.Printf("[DEBUG] Waiting for EC2 VPC (%s) IPv6 CIDR to become disassociated", d.Id())
-			if err := waitForEc2VpcIpv6CidrBlockAssociationDelete(conn, d.Id(), associationID); err != nil {
+			if _, err := waiter.VpcIpv6CidrBlockAssociationDeleted(conn, d.Id(), associationID, d.Timeout(schema.TimeoutUpdate)); err != nil {
 				return fmt.Errorf("error waiting for EC2 VPC (%s) IPv6 CIDR to become disassociated: %s", d.Id(), err)
 			}
 		}
@@ -521,23 +560,23 @@ func resourceAwsVpcDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 	log.Printf("[INFO] Deleting VPC: %s", d.Id())
 
-	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := conn.DeleteVpc(deleteVpcOpts)
 		if err == nil {
 			return nil
 		}
 
-		if isAWSErr(err, "InvalidVpcID.NotFound", "") {
+		if isAWSErr(err, tfec2.ErrCodeInvalidVpcIDNotFound, "") {
 			return nil
 		}
-		if isAWSErr(err, "DependencyViolation", "") {
+		if isAWSErr(err, tfec2.ErrCodeDependencyViolation, "") {
 			return resource.RetryableError(err)
 		}
 		return resource.NonRetryableError(fmt.Errorf("Error deleting VPC: %s", err))
 	})
 	if isResourceTimeoutError(err) {
 		_, err = conn.DeleteVpc(deleteVpcOpts)
-		if isAWSErr(err, "InvalidVpcID.NotFound", "") {
+		if isAWSErr(err, tfec2.ErrCodeInvalidVpcIDNotFound, "") {
 			return nil
 		}
 	}
@@ -549,6 +588,18 @@ func resourceAwsVpcDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAwsVpcCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	if diff.HasChange("ipv6_cidr_block") {
+		if _, n := diff.GetChange("ipv6_cidr_block"); n.(string) != "" {
+			if diff.Get("ipv6_ipam_pool").(string) == "" {
+				return fmt.Errorf("ipv6_cidr_block can only be set together with ipv6_ipam_pool")
+			}
+			if diff.Id() != "" {
+				if err := diff.ForceNew("ipv6_cidr_block"); err != nil {
+					return err
+				}
+			}
+		}
+	}
 	if diff.HasChange("assign_generated_ipv6_cidr_block") {
 		if err := diff.SetNewComputed("ipv6_association_id"); err != nil {
 			return fmt.Errorf("error setting ipv6_association_id to computed: %s", err)
@@ -567,65 +618,6 @@ func resourceAwsVpcCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error
 	return nil
 }
 
-// VPCStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
-// a VPC.
-func VPCStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		describeVpcOpts := &ec2.DescribeVpcsInput{
-			VpcIds: []*string{aws.String(id)},
-		}
-		resp, err := conn.DescribeVpcs(describeVpcOpts)
-		if err != nil {
-			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidVpcID.NotFound" {
-				resp = nil
-			} else {
-				log.Printf("Error on VPCStateRefresh: %s", err)
-				return nil, "", err
-			}
-		}
-
-		if resp == nil {
-			// Sometimes AWS just has consistency issues and doesn't see
-			// our instance yet. Return an empty state.
-			return nil, "", nil
-		}
-
-		vpc := resp.Vpcs[0]
-		return vpc, *vpc.State, nil
-	}
-}
-
-func Ipv6CidrStateRefreshFunc(conn *ec2.EC2, id string, associationId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		describeVpcOpts := &ec2.DescribeVpcsInput{
-			VpcIds: []*string{aws.String(id)},
-		}
-		resp, err := conn.DescribeVpcs(describeVpcOpts)
-
-		if isAWSErr(err, "InvalidVpcID.NotFound", "") {
-			return nil, "", nil
-		}
-
-		if err != nil {
-			return nil, "", err
-		}
-
-		if resp == nil || len(resp.Vpcs) == 0 || resp.Vpcs[0] == nil || resp.Vpcs[0].Ipv6CidrBlockAssociationSet == nil {
-			// Sometimes AWS just has consistency issues and doesn't see
-			// our instance yet. Return an empty state.
-			return nil, "", nil
-		}
-
-		for _, association := range resp.Vpcs[0].Ipv6CidrBlockAssociationSet {
-			if aws.StringValue(association.AssociationId) == associationId {
-				return association, aws.StringValue(association.Ipv6CidrBlockState.State), nil
-			}
-		}
-
-		return nil, "", nil
-	}
-}
-
 func resourceAwsVpcSetDefaultNetworkAcl(conn *ec2.EC2, d *schema.ResourceData) error {
 	filter1 := &ec2.Filter{
 		Name:   aws.String("default"),
@@ -732,7 +724,18 @@ func resourceAwsVpcSetMainRouteTable(conn *ec2.EC2, vpcid string) (string, error
 
 func resourceAwsVpcInstanceImport(
 	d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vpcID, topology, err := tfec2.VpcParseImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(vpcID)
 	d.Set("assign_generated_ipv6_cidr_block", false)
+
+	if topology {
+		return resourceAwsVpcImportTopology(d, meta)
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -778,34 +781,3 @@ func vpcDescribe(conn *ec2.EC2, vpcId string) (*ec2.Vpc, error) {
 		return nil, fmt.Errorf("Found %d VPCs for %s, expected 1", n, vpcId)
 	}
 }
-
-func waitForEc2VpcIpv6CidrBlockAssociationCreate(conn *ec2.EC2, vpcID, associationID string) error {
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{
-			ec2.VpcCidrBlockStateCodeAssociating,
-			ec2.VpcCidrBlockStateCodeDisassociated,
-		},
-		Target:  []string{ec2.VpcCidrBlockStateCodeAssociated},
-		Refresh: Ipv6CidrStateRefreshFunc(conn, vpcID, associationID),
-		Timeout: 1 * time.Minute,
-	}
-	_, err := stateConf.WaitForState()
-
-	return err
-}
-
-func waitForEc2VpcIpv6CidrBlockAssociationDelete(conn *ec2.EC2, vpcID, associationID string) error {
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{
-			ec2.VpcCidrBlockStateCodeAssociated,
-			ec2.VpcCidrBlockStateCodeDisassociating,
-		},
-		Target:         []string{ec2.VpcCidrBlockStateCodeDisassociated},
-		Refresh:        Ipv6CidrStateRefreshFunc(conn, vpcID, associationID),
-		Timeout:        1 * time.Minute,
-		NotFoundChecks: 1,
-	}
-	_, err := stateConf.WaitForState()
-
-	return err
-}
@@ -0,0 +1,275 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+// vpcDhcpOptionsKeys are the attributes of which at least one must be set.
+var vpcDhcpOptionsKeys = []string{"domain_name", "domain_name_servers", "netbios_name_servers", "netbios_node_type", "ntp_servers"}
+
+func resourceAwsVpcDhcpOptions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcDhcpOptionsCreate,
+		Read:   resourceAwsVpcDhcpOptionsRead,
+		Update: resourceAwsVpcDhcpOptionsUpdate,
+		Delete: resourceAwsVpcDhcpOptionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_vpc_dhcp_options"),
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: vpcDhcpOptionsKeys,
+			},
+
+			"domain_name_servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 4,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.Any(
+						validation.IsIPv4Address,
+						validation.StringInSlice([]string{"AmazonProvidedDNS"}, false),
+					),
+				},
+				AtLeastOneOf: vpcDhcpOptionsKeys,
+			},
+
+			"netbios_name_servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 4,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv4Address,
+				},
+				AtLeastOneOf: vpcDhcpOptionsKeys,
+			},
+
+			"netbios_node_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"1", "2", "4", "8"}, false),
+				AtLeastOneOf: vpcDhcpOptionsKeys,
+			},
+
+			"ntp_servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 4,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv4Address,
+				},
+				AtLeastOneOf: vpcDhcpOptionsKeys,
+			},
+
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsVpcDhcpOptionsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.CreateDhcpOptionsInput{}
+
+	addConfiguration := func(key string, values []*string) {
+		if len(values) > 0 {
+			input.DhcpConfigurations = append(input.DhcpConfigurations, &ec2.NewDhcpConfiguration{
+				Key:    aws.String(key),
+				Values: values,
+			})
+		}
+	}
+
+	if v, ok := d.GetOk("domain_name"); ok {
+		addConfiguration("domain-name", aws.StringSlice([]string{v.(string)}))
+	}
+
+	addConfiguration("domain-name-servers", expandStringList(d.Get("domain_name_servers").([]interface{})))
+	addConfiguration("netbios-name-servers", expandStringList(d.Get("netbios_name_servers").([]interface{})))
+
+	if v, ok := d.GetOk("netbios_node_type"); ok {
+		addConfiguration("netbios-node-type", aws.StringSlice([]string{v.(string)}))
+	}
+
+	addConfiguration("ntp-servers", expandStringList(d.Get("ntp_servers").([]interface{})))
+
+	log.Printf("[DEBUG] Creating VPC DHCP Options: %s", input)
+	output, err := conn.CreateDhcpOptions(input)
+
+	if err != nil {
+		return fmt.Errorf("error creating VPC DHCP Options: %s", err)
+	}
+
+	d.SetId(aws.StringValue(output.DhcpOptions.DhcpOptionsId))
+
+	if _, err := waiter.VpcDhcpOptionsCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for VPC DHCP Options (%s) to be created: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding VPC DHCP Options (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVpcDhcpOptionsRead(d, meta)
+}
+
+func resourceAwsVpcDhcpOptionsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	options, err := finder.VpcDhcpOptionsByID(conn, d.Id())
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidDhcpOptionIDNotFound, "") {
+		log.Printf("[WARN] VPC DHCP Options (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading VPC DHCP Options (%s): %s", d.Id(), err)
+	}
+
+	if options == nil {
+		log.Printf("[WARN] VPC DHCP Options (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	values := make(map[string][]string)
+
+	for _, c := range options.DhcpConfigurations {
+		for _, v := range c.Values {
+			values[aws.StringValue(c.Key)] = append(values[aws.StringValue(c.Key)], aws.StringValue(v.Value))
+		}
+	}
+
+	d.Set("domain_name", strings.Join(values["domain-name"], " "))
+
+	if err := d.Set("domain_name_servers", values["domain-name-servers"]); err != nil {
+		return fmt.Errorf("error setting domain_name_servers: %s", err)
+	}
+
+	if err := d.Set("netbios_name_servers", values["netbios-name-servers"]); err != nil {
+		return fmt.Errorf("error setting netbios_name_servers: %s", err)
+	}
+
+	d.Set("netbios_node_type", strings.Join(values["netbios-node-type"], " "))
+
+	if err := d.Set("ntp_servers", values["ntp-servers"]); err != nil {
+		return fmt.Errorf("error setting ntp_servers: %s", err)
+	}
+
+	d.Set("owner_id", options.OwnerId)
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(options.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	arn := arn.ARN{
+		Partition: meta.(*AWSClient).partition,
+		Service:   "ec2",
+		Region:    meta.(*AWSClient).region,
+		AccountID: aws.StringValue(options.OwnerId),
+		Resource:  fmt.Sprintf("dhcp-options/%s", d.Id()),
+	}.String()
+	d.Set("arn", arn)
+
+	return nil
+}
+
+func resourceAwsVpcDhcpOptionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating VPC DHCP Options (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVpcDhcpOptionsRead(d, meta)
+}
+
+func resourceAwsVpcDhcpOptionsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	// VPCs still using the DHCP options set revert to the default.
+	vpcs, err := conn.DescribeVpcs(&ec2.DescribeVpcsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"dhcp-options-id": d.Id(),
+		}),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading VPCs using VPC DHCP Options (%s): %s", d.Id(), err)
+	}
+
+	for _, vpc := range vpcs.Vpcs {
+		if err := vpcDhcpOptionsAssociate(conn, vpcDhcpOptionsDefault, aws.StringValue(vpc.VpcId), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Deleting VPC DHCP Options: %s", d.Id())
+	_, err = conn.DeleteDhcpOptions(&ec2.DeleteDhcpOptionsInput{
+		DhcpOptionsId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidDhcpOptionIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting VPC DHCP Options (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.VpcDhcpOptionsDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for VPC DHCP Options (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
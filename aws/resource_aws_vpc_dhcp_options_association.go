@@ -0,0 +1,169 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+// vpcDhcpOptionsDefault is the DHCP options ID of a VPC without a DHCP
+// options set.
+const vpcDhcpOptionsDefault = "default"
+
+func resourceAwsVpcDhcpOptionsAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcDhcpOptionsAssociationCreate,
+		Read:   resourceAwsVpcDhcpOptionsAssociationRead,
+		Update: resourceAwsVpcDhcpOptionsAssociationUpdate,
+		Delete: resourceAwsVpcDhcpOptionsAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsVpcDhcpOptionsAssociationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dhcp_options_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpcDhcpOptionsAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	dhcpOptionsID := d.Get("dhcp_options_id").(string)
+	vpcID := d.Get("vpc_id").(string)
+
+	if err := vpcDhcpOptionsAssociate(conn, dhcpOptionsID, vpcID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s", dhcpOptionsID, vpcID))
+
+	return resourceAwsVpcDhcpOptionsAssociationRead(d, meta)
+}
+
+func resourceAwsVpcDhcpOptionsAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	vpcID := d.Get("vpc_id").(string)
+	vpc, err := vpcDescribe(conn, vpcID)
+
+	if err != nil {
+		return fmt.Errorf("error reading VPC (%s): %s", vpcID, err)
+	}
+
+	// A VPC that reverted to the default DHCP options is no longer
+	// associated.
+	if vpc == nil || aws.StringValue(vpc.DhcpOptionsId) == vpcDhcpOptionsDefault {
+		log.Printf("[WARN] VPC DHCP Options association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("dhcp_options_id", vpc.DhcpOptionsId)
+	d.Set("vpc_id", vpc.VpcId)
+
+	return nil
+}
+
+func resourceAwsVpcDhcpOptionsAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	dhcpOptionsID := d.Get("dhcp_options_id").(string)
+	vpcID := d.Get("vpc_id").(string)
+
+	if err := vpcDhcpOptionsAssociate(conn, dhcpOptionsID, vpcID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s", dhcpOptionsID, vpcID))
+
+	return resourceAwsVpcDhcpOptionsAssociationRead(d, meta)
+}
+
+func resourceAwsVpcDhcpOptionsAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	vpcID := d.Get("vpc_id").(string)
+
+	log.Printf("[INFO] Reverting VPC (%s) to the default DHCP Options", vpcID)
+	_, err := conn.AssociateDhcpOptions(&ec2.AssociateDhcpOptionsInput{
+		DhcpOptionsId: aws.String(vpcDhcpOptionsDefault),
+		VpcId:         aws.String(vpcID),
+	})
+
+	if isAWSErr(err, "InvalidVpcID.NotFound", "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reverting VPC (%s) to the default DHCP Options: %s", vpcID, err)
+	}
+
+	if _, err := waiter.VpcDhcpOptionsAssociated(conn, vpcID, vpcDhcpOptionsDefault, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for VPC (%s) to revert to the default DHCP Options: %s", vpcID, err)
+	}
+
+	return nil
+}
+
+// resourceAwsVpcDhcpOptionsAssociationImport imports the association of a
+// VPC by the VPC's ID.
+func resourceAwsVpcDhcpOptionsAssociationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*AWSClient).ec2conn
+
+	vpc, err := vpcDescribe(conn, d.Id())
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading VPC (%s): %s", d.Id(), err)
+	}
+
+	if vpc == nil {
+		return nil, fmt.Errorf("VPC (%s) not found", d.Id())
+	}
+
+	dhcpOptionsID := aws.StringValue(vpc.DhcpOptionsId)
+
+	d.Set("dhcp_options_id", dhcpOptionsID)
+	d.Set("vpc_id", vpc.VpcId)
+	d.SetId(fmt.Sprintf("%s-%s", dhcpOptionsID, aws.StringValue(vpc.VpcId)))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func vpcDhcpOptionsAssociate(conn *ec2.EC2, dhcpOptionsID, vpcID string, timeout time.Duration) error {
+	input := &ec2.AssociateDhcpOptionsInput{
+		DhcpOptionsId: aws.String(dhcpOptionsID),
+		VpcId:         aws.String(vpcID),
+	}
+
+	log.Printf("[DEBUG] Associating VPC DHCP Options: %s", input)
+	if _, err := conn.AssociateDhcpOptions(input); err != nil {
+		return fmt.Errorf("error associating VPC DHCP Options (%s) with VPC (%s): %s", dhcpOptionsID, vpcID, err)
+	}
+
+	if _, err := waiter.VpcDhcpOptionsAssociated(conn, vpcID, dhcpOptionsID, timeout); err != nil {
+		return fmt.Errorf("error waiting for VPC DHCP Options (%s) to associate with VPC (%s): %s", dhcpOptionsID, vpcID, err)
+	}
+
+	return nil
+}
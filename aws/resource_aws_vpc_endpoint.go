@@ -1,15 +1,19 @@
 package aws
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
 )
 
 const (
@@ -17,52 +21,401 @@ const (
 	Ec2VpcEndpointCreationTimeout = 10 * time.Minute
 )
 
+func resourceAwsVpcEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcEndpointCreate,
+		Read:   resourceAwsVpcEndpointRead,
+		Update: resourceAwsVpcEndpointUpdate,
+		Delete: resourceAwsVpcEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
-func vpcEndpointStateRefresh(conn *ec2.EC2, vpceId string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[DEBUG] Reading VPC Endpoint: %s", vpceId)
-		resp, err := conn.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-			VpcEndpointIds: aws.StringSlice([]string{vpceId}),
-		})
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_vpc_endpoint"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(Ec2VpcEndpointCreationTimeout),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"auto_accept": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"cidr_blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_entry": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dns_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hosted_zone_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"network_interface_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+			},
+			"prefix_list_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_dns_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"requester_managed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"route_table_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"security_group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"service_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"tags": tagsSchema(),
+			"vpc_endpoint_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  ec2.VpcEndpointTypeGateway,
+				ValidateFunc: validation.StringInSlice([]string{
+					ec2.VpcEndpointTypeGateway,
+					ec2.VpcEndpointTypeInterface,
+				}, false),
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpcEndpointCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req := &ec2.CreateVpcEndpointInput{
+		VpcId:             aws.String(d.Get("vpc_id").(string)),
+		VpcEndpointType:   aws.String(d.Get("vpc_endpoint_type").(string)),
+		ServiceName:       aws.String(d.Get("service_name").(string)),
+		PrivateDnsEnabled: aws.Bool(d.Get("private_dns_enabled").(bool)),
+	}
+
+	if v, ok := d.GetOk("policy"); ok {
+		policy, err := structure.NormalizeJsonString(v)
 		if err != nil {
-			if isAWSErr(err, "InvalidVpcEndpointId.NotFound", "") {
-				return "", "deleted", nil
-			}
+			return fmt.Errorf("policy contains an invalid JSON: %s", err)
+		}
+		req.PolicyDocument = aws.String(policy)
+	}
+
+	setVpcEndpointCreateList(d, "route_table_ids", &req.RouteTableIds)
+	setVpcEndpointCreateList(d, "subnet_ids", &req.SubnetIds)
+	setVpcEndpointCreateList(d, "security_group_ids", &req.SecurityGroupIds)
 
-			return nil, "", err
+	log.Printf("[DEBUG] Creating VPC Endpoint: %#v", req)
+	resp, err := conn.CreateVpcEndpoint(req)
+	if err != nil {
+		return fmt.Errorf("Error creating VPC Endpoint: %s", err)
+	}
+
+	vpce := resp.VpcEndpoint
+	d.SetId(aws.StringValue(vpce.VpcEndpointId))
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding tags to VPC Endpoint (%s): %s", d.Id(), err)
 		}
+	}
+
+	if d.Get("auto_accept").(bool) && aws.StringValue(vpce.State) == "pendingAcceptance" {
+		if err := vpcEndpointAccept(conn, d.Id(), aws.StringValue(vpce.ServiceName), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := waiter.VpcEndpointAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceAwsVpcEndpointRead(d, meta)
+}
+
+func resourceAwsVpcEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	vpceRaw, state, err := waiter.VpcEndpointState(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading VPC Endpoint: %s", err)
+	}
+
+	terminalStates := map[string]bool{
+		"deleted":  true,
+		"deleting": true,
+		"failed":   true,
+		"expired":  true,
+		"rejected": true,
+	}
+	if _, ok := terminalStates[state]; ok {
+		log.Printf("[WARN] VPC Endpoint (%s) in state (%s), removing from state", d.Id(), state)
+		d.SetId("")
+		return nil
+	}
+
+	vpce := vpceRaw.(*ec2.VpcEndpoint)
+
+	arn := arn.ARN{
+		Partition: meta.(*AWSClient).partition,
+		Service:   "ec2",
+		Region:    meta.(*AWSClient).region,
+		AccountID: aws.StringValue(vpce.OwnerId),
+		Resource:  fmt.Sprintf("vpc-endpoint/%s", d.Id()),
+	}.String()
+	d.Set("arn", arn)
+
+	serviceName := aws.StringValue(vpce.ServiceName)
+	d.Set("service_name", serviceName)
+	d.Set("state", vpce.State)
+	d.Set("vpc_id", vpce.VpcId)
+
+	respPl, err := conn.DescribePrefixLists(&ec2.DescribePrefixListsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"prefix-list-name": serviceName,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Prefix List (%s): %s", serviceName, err)
+	}
+	if respPl == nil || len(respPl.PrefixLists) == 0 {
+		d.Set("cidr_blocks", []interface{}{})
+		d.Set("prefix_list_id", "")
+	} else if len(respPl.PrefixLists) > 1 {
+		return fmt.Errorf("multiple prefix lists associated with the service name '%s'. Unexpected", serviceName)
+	} else {
+		pl := respPl.PrefixLists[0]
+
+		d.Set("prefix_list_id", pl.PrefixListId)
+		if err := d.Set("cidr_blocks", flattenStringList(pl.Cidrs)); err != nil {
+			return fmt.Errorf("error setting cidr_blocks: %s", err)
+		}
+	}
+
+	if err := d.Set("dns_entry", flattenVpcEndpointDnsEntries(vpce.DnsEntries)); err != nil {
+		return fmt.Errorf("error setting dns_entry: %s", err)
+	}
+	if err := d.Set("network_interface_ids", flattenStringSet(vpce.NetworkInterfaceIds)); err != nil {
+		return fmt.Errorf("error setting network_interface_ids: %s", err)
+	}
+	d.Set("owner_id", vpce.OwnerId)
+	policy, err := structure.NormalizeJsonString(aws.StringValue(vpce.PolicyDocument))
+	if err != nil {
+		return fmt.Errorf("policy contains an invalid JSON: %s", err)
+	}
+	d.Set("policy", policy)
+	d.Set("private_dns_enabled", vpce.PrivateDnsEnabled)
+	d.Set("requester_managed", vpce.RequesterManaged)
+	if err := d.Set("route_table_ids", flattenStringSet(vpce.RouteTableIds)); err != nil {
+		return fmt.Errorf("error setting route_table_ids: %s", err)
+	}
+	if err := d.Set("security_group_ids", flattenVpcEndpointSecurityGroupIds(vpce.Groups)); err != nil {
+		return fmt.Errorf("error setting security_group_ids: %s", err)
+	}
+	if err := d.Set("subnet_ids", flattenStringSet(vpce.SubnetIds)); err != nil {
+		return fmt.Errorf("error setting subnet_ids: %s", err)
+	}
+	// VPC endpoints don't have types in GovCloud, so set type to default if empty
+	if vpceType := aws.StringValue(vpce.VpcEndpointType); vpceType == "" {
+		d.Set("vpc_endpoint_type", ec2.VpcEndpointTypeGateway)
+	} else {
+		d.Set("vpc_endpoint_type", vpceType)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(vpce.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
 
-		n := len(resp.VpcEndpoints)
-		switch n {
-		case 0:
-			return "", "deleted", nil
-
-		case 1:
-			vpce := resp.VpcEndpoints[0]
-			state := aws.StringValue(vpce.State)
-			// No use in retrying if the endpoint is in a failed state.
-			if state == "failed" {
-				return nil, state, errors.New("VPC Endpoint is in a failed state")
+func resourceAwsVpcEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("auto_accept") && d.Get("auto_accept").(bool) && d.Get("state").(string) == "pendingAcceptance" {
+		if err := vpcEndpointAccept(conn, d.Id(), d.Get("service_name").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("policy", "route_table_ids", "subnet_ids", "security_group_ids", "private_dns_enabled") {
+		req := &ec2.ModifyVpcEndpointInput{
+			VpcEndpointId: aws.String(d.Id()),
+		}
+
+		if d.HasChange("policy") {
+			policy, err := structure.NormalizeJsonString(d.Get("policy"))
+			if err != nil {
+				return fmt.Errorf("policy contains an invalid JSON: %s", err)
 			}
-			return vpce, state, nil
 
-		default:
-			return nil, "", fmt.Errorf("Found %d VPC Endpoints for %s, expected 1", n, vpceId)
+			if policy == "" {
+				req.ResetPolicy = aws.Bool(true)
+			} else {
+				req.PolicyDocument = aws.String(policy)
+			}
+		}
+
+		setVpcEndpointUpdateLists(d, "route_table_ids", &req.AddRouteTableIds, &req.RemoveRouteTableIds)
+		setVpcEndpointUpdateLists(d, "subnet_ids", &req.AddSubnetIds, &req.RemoveSubnetIds)
+		setVpcEndpointUpdateLists(d, "security_group_ids", &req.AddSecurityGroupIds, &req.RemoveSecurityGroupIds)
+
+		if d.HasChange("private_dns_enabled") {
+			req.PrivateDnsEnabled = aws.Bool(d.Get("private_dns_enabled").(bool))
+		}
+
+		log.Printf("[DEBUG] Updating VPC Endpoint: %#v", req)
+		if _, err := conn.ModifyVpcEndpoint(req); err != nil {
+			return fmt.Errorf("Error updating VPC Endpoint (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.VpcEndpointAvailable(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating VPC Endpoint (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVpcEndpointRead(d, meta)
+}
+
+func resourceAwsVpcEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{d.Id()}),
+	}
+
+	log.Printf("[DEBUG] Deleting VPC Endpoint: %s", d.Id())
+	output, err := conn.DeleteVpcEndpoints(input)
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidVpcEndpointIdNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting VPC Endpoint (%s): %s", d.Id(), err)
+	}
+
+	for _, item := range output.Unsuccessful {
+		if item.Error == nil || aws.StringValue(item.Error.Code) == tfec2.ErrCodeInvalidVpcEndpointIdNotFound {
+			continue
 		}
+
+		return fmt.Errorf("error deleting VPC Endpoint (%s): %s: %s", d.Id(), aws.StringValue(item.Error.Code), aws.StringValue(item.Error.Message))
 	}
+
+	if _, err := waiter.VpcEndpointDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to delete: %s", d.Id(), err)
+	}
+
+	return nil
 }
 
-func vpcEndpointWaitUntilAvailable(conn *ec2.EC2, vpceId string, timeout time.Duration) error {
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"pending"},
-		Target:     []string{"available", "pendingAcceptance"},
-		Refresh:    vpcEndpointStateRefresh(conn, vpceId),
-		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+func vpcEndpointAccept(conn *ec2.EC2, vpceId, svcName string, timeout time.Duration) error {
+	describeSvcReq := &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: buildEC2AttributeFilterList(map[string]string{
+			"service-name": svcName,
+		}),
+	}
+
+	describeSvcResp, err := conn.DescribeVpcEndpointServiceConfigurations(describeSvcReq)
+	if err != nil {
+		return fmt.Errorf("error reading VPC Endpoint Service (%s): %s", svcName, err)
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", vpceId, err)
+	if describeSvcResp == nil || len(describeSvcResp.ServiceConfigurations) == 0 {
+		return fmt.Errorf("No matching VPC Endpoint Service found for %s", svcName)
+	}
+
+	acceptEpReq := &ec2.AcceptVpcEndpointConnectionsInput{
+		ServiceId:      describeSvcResp.ServiceConfigurations[0].ServiceId,
+		VpcEndpointIds: aws.StringSlice([]string{vpceId}),
+	}
+
+	log.Printf("[DEBUG] Accepting VPC Endpoint connection: %#v", acceptEpReq)
+	if _, err := conn.AcceptVpcEndpointConnections(acceptEpReq); err != nil {
+		return fmt.Errorf("error accepting VPC Endpoint (%s) connection: %s", vpceId, err)
+	}
+
+	if _, err := waiter.VpcEndpointAccepted(conn, vpceId, timeout); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to be accepted: %s", vpceId, err)
 	}
 
 	return nil
@@ -104,3 +457,16 @@ func flattenVpcEndpointSecurityGroupIds(groups []*ec2.SecurityGroupIdentifier) *
 
 	return schema.NewSet(schema.HashString, vSecurityGroupIds)
 }
+
+func flattenVpcEndpointDnsEntries(dnsEntries []*ec2.DnsEntry) []interface{} {
+	vDnsEntries := []interface{}{}
+
+	for _, dnsEntry := range dnsEntries {
+		vDnsEntries = append(vDnsEntries, map[string]interface{}{
+			"dns_name":       aws.StringValue(dnsEntry.DnsName),
+			"hosted_zone_id": aws.StringValue(dnsEntry.HostedZoneId),
+		})
+	}
+
+	return vDnsEntries
+}
@@ -0,0 +1,166 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsVpcEndpointRouteTableAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcEndpointRouteTableAssociationCreate,
+		Read:   resourceAwsVpcEndpointRouteTableAssociationRead,
+		Delete: resourceAwsVpcEndpointRouteTableAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsVpcEndpointRouteTableAssociationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_endpoint_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	if _, err := findResourceVpcEndpoint(conn, endpointId); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating VPC Endpoint/Route Table association: %s => %s", endpointId, rtId)
+	_, err := conn.ModifyVpcEndpoint(&ec2.ModifyVpcEndpointInput{
+		VpcEndpointId:    aws.String(endpointId),
+		AddRouteTableIds: aws.StringSlice([]string{rtId}),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating VPC Endpoint/Route Table association: %s", err)
+	}
+
+	d.SetId(vpcEndpointIdRouteTableIdHash(endpointId, rtId))
+
+	if _, err := waiter.VpcEndpointAvailable(conn, endpointId, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", endpointId, err)
+	}
+
+	return resourceAwsVpcEndpointRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	vpce, err := findResourceVpcEndpoint(conn, endpointId)
+	if isAWSErr(err, "InvalidVpcEndpointId.NotFound", "") {
+		log.Printf("[WARN] VPC Endpoint (%s) not found, removing VPC Endpoint/Route Table association (%s) from state", endpointId, d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading VPC Endpoint (%s): %s", endpointId, err)
+	}
+
+	found := false
+	for _, id := range vpce.RouteTableIds {
+		if aws.StringValue(id) == rtId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		log.Printf("[WARN] VPC Endpoint/Route Table association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	log.Printf("[INFO] Deleting VPC Endpoint/Route Table association: %s => %s", endpointId, rtId)
+	_, err := conn.ModifyVpcEndpoint(&ec2.ModifyVpcEndpointInput{
+		VpcEndpointId:       aws.String(endpointId),
+		RemoveRouteTableIds: aws.StringSlice([]string{rtId}),
+	})
+	if err != nil {
+		if isAWSErr(err, "InvalidVpcEndpointId.NotFound", "") || isAWSErr(err, "InvalidRouteTableId.NotFound", "") || isAWSErr(err, "InvalidParameter", "") {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting VPC Endpoint/Route Table association (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.VpcEndpointAvailable(conn, endpointId, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", endpointId, err)
+	}
+
+	return nil
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Wrong format of resource: %s. Please follow 'vpc-endpoint-id/route-table-id'", d.Id())
+	}
+
+	endpointId := parts[0]
+	rtId := parts[1]
+	log.Printf("[DEBUG] Importing VPC Endpoint (%s) Route Table (%s) association", endpointId, rtId)
+
+	d.SetId(vpcEndpointIdRouteTableIdHash(endpointId, rtId))
+	d.Set("vpc_endpoint_id", endpointId)
+	d.Set("route_table_id", rtId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func findResourceVpcEndpoint(conn *ec2.EC2, id string) (*ec2.VpcEndpoint, error) {
+	resp, err := conn.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{id}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || len(resp.VpcEndpoints) == 0 || resp.VpcEndpoints[0] == nil {
+		return nil, awserr.New("InvalidVpcEndpointId.NotFound", fmt.Sprintf("VPC Endpoint %q not found", id), nil)
+	}
+
+	return resp.VpcEndpoints[0], nil
+}
+
+func vpcEndpointIdRouteTableIdHash(endpointId, rtId string) string {
+	return fmt.Sprintf("a-%s%d", endpointId, hashcode.String(rtId))
+}
@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsVpcEndpointSubnetAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcEndpointSubnetAssociationCreate,
+		Read:   resourceAwsVpcEndpointSubnetAssociationRead,
+		Delete: resourceAwsVpcEndpointSubnetAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsVpcEndpointSubnetAssociationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_endpoint_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpcEndpointSubnetAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	snId := d.Get("subnet_id").(string)
+
+	if _, err := findResourceVpcEndpoint(conn, endpointId); err != nil {
+		return err
+	}
+
+	// Modifications to the subnets of a VPC Endpoint fail while another
+	// modification is in progress, so serialize the associations.
+	mk := "vpc_endpoint_subnet_association_" + endpointId
+	awsMutexKV.Lock(mk)
+	defer awsMutexKV.Unlock(mk)
+
+	log.Printf("[INFO] Creating VPC Endpoint/Subnet association: %s => %s", endpointId, snId)
+	_, err := conn.ModifyVpcEndpoint(&ec2.ModifyVpcEndpointInput{
+		VpcEndpointId: aws.String(endpointId),
+		AddSubnetIds:  aws.StringSlice([]string{snId}),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating VPC Endpoint/Subnet association: %s", err)
+	}
+
+	d.SetId(vpcEndpointSubnetAssociationId(endpointId, snId))
+
+	if _, err := waiter.VpcEndpointAvailable(conn, endpointId, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", endpointId, err)
+	}
+
+	return resourceAwsVpcEndpointSubnetAssociationRead(d, meta)
+}
+
+func resourceAwsVpcEndpointSubnetAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	snId := d.Get("subnet_id").(string)
+
+	vpce, err := findResourceVpcEndpoint(conn, endpointId)
+	if isAWSErr(err, "InvalidVpcEndpointId.NotFound", "") {
+		log.Printf("[WARN] VPC Endpoint (%s) not found, removing VPC Endpoint/Subnet association (%s) from state", endpointId, d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading VPC Endpoint (%s): %s", endpointId, err)
+	}
+
+	found := false
+	for _, id := range vpce.SubnetIds {
+		if aws.StringValue(id) == snId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		log.Printf("[WARN] VPC Endpoint/Subnet association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceAwsVpcEndpointSubnetAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	snId := d.Get("subnet_id").(string)
+
+	mk := "vpc_endpoint_subnet_association_" + endpointId
+	awsMutexKV.Lock(mk)
+	defer awsMutexKV.Unlock(mk)
+
+	log.Printf("[INFO] Deleting VPC Endpoint/Subnet association: %s => %s", endpointId, snId)
+	_, err := conn.ModifyVpcEndpoint(&ec2.ModifyVpcEndpointInput{
+		VpcEndpointId:   aws.String(endpointId),
+		RemoveSubnetIds: aws.StringSlice([]string{snId}),
+	})
+	if err != nil {
+		if isAWSErr(err, "InvalidVpcEndpointId.NotFound", "") || isAWSErr(err, "InvalidSubnetId.NotFound", "") || isAWSErr(err, "InvalidParameter", "") {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting VPC Endpoint/Subnet association (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.VpcEndpointAvailable(conn, endpointId, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", endpointId, err)
+	}
+
+	return nil
+}
+
+func resourceAwsVpcEndpointSubnetAssociationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Wrong format of resource: %s. Please follow 'vpc-endpoint-id/subnet-id'", d.Id())
+	}
+
+	endpointId := parts[0]
+	snId := parts[1]
+	log.Printf("[DEBUG] Importing VPC Endpoint (%s) Subnet (%s) association", endpointId, snId)
+
+	d.SetId(vpcEndpointSubnetAssociationId(endpointId, snId))
+	d.Set("vpc_endpoint_id", endpointId)
+	d.Set("subnet_id", snId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func vpcEndpointSubnetAssociationId(endpointId, snId string) string {
+	return fmt.Sprintf("a-%s%d", endpointId, hashcode.String(snId))
+}
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/finder"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsVpcIpv4CidrBlockAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcIpv4CidrBlockAssociationCreate,
+		Read:   resourceAwsVpcIpv4CidrBlockAssociationRead,
+		Delete: resourceAwsVpcIpv4CidrBlockAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cidr_block": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDRNetwork(16, 28),
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpcIpv4CidrBlockAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	vpcID := d.Get("vpc_id").(string)
+	input := &ec2.AssociateVpcCidrBlockInput{
+		CidrBlock: aws.String(d.Get("cidr_block").(string)),
+		VpcId:     aws.String(vpcID),
+	}
+
+	log.Printf("[DEBUG] Creating EC2 VPC IPv4 CIDR Block Association: %s", input)
+	output, err := conn.AssociateVpcCidrBlock(input)
+
+	if err != nil {
+		return fmt.Errorf("error associating IPv4 CIDR block with EC2 VPC (%s): %s", vpcID, err)
+	}
+
+	d.SetId(aws.StringValue(output.CidrBlockAssociation.AssociationId))
+
+	if _, err := waiter.VpcCidrBlockAssociationCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for EC2 VPC IPv4 CIDR Block Association (%s) to become associated: %s", d.Id(), err)
+	}
+
+	return resourceAwsVpcIpv4CidrBlockAssociationRead(d, meta)
+}
+
+func resourceAwsVpcIpv4CidrBlockAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	association, vpc, err := finder.VpcCidrBlockAssociationByID(conn, d.Id())
+
+	if err != nil {
+		return fmt.Errorf("error reading EC2 VPC IPv4 CIDR Block Association (%s): %s", d.Id(), err)
+	}
+
+	if association == nil || aws.StringValue(association.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeDisassociated {
+		log.Printf("[WARN] EC2 VPC IPv4 CIDR Block Association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cidr_block", association.CidrBlock)
+	d.Set("vpc_id", vpc.VpcId)
+
+	return nil
+}
+
+func resourceAwsVpcIpv4CidrBlockAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting EC2 VPC IPv4 CIDR Block Association: %s", d.Id())
+	_, err := conn.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{
+		AssociationId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidVpcCidrBlockAssociationIDNotFound, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting EC2 VPC IPv4 CIDR Block Association (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.VpcCidrBlockAssociationDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for EC2 VPC IPv4 CIDR Block Association (%s) to become disassociated: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	tfec2 "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+func resourceAwsVpcPeeringConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcPeeringConnectionCreate,
+		Read:   resourceAwsVpcPeeringConnectionRead,
+		Update: resourceAwsVpcPeeringConnectionUpdate,
+		Delete: resourceAwsVpcPeeringConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_vpc_peering_connection"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"peer_owner_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"peer_vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auto_accept": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"accept_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"accepter":  vpcPeeringConnectionOptionsSchema(),
+			"requester": vpcPeeringConnectionOptionsSchema(),
+			"tags":      tagsSchema(),
+		},
+	}
+}
+
+// vpcPeeringConnectionOptionsSchema returns the schema of the options of one
+// side of a VPC peering connection. Options can only be set while the
+// connection is active, and only by the account owning that side's VPC.
+func vpcPeeringConnectionOptionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"allow_remote_vpc_dns_resolution": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsVpcPeeringConnectionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	input := &ec2.CreateVpcPeeringConnectionInput{
+		PeerVpcId: aws.String(d.Get("peer_vpc_id").(string)),
+		VpcId:     aws.String(d.Get("vpc_id").(string)),
+	}
+
+	if v, ok := d.GetOk("peer_owner_id"); ok {
+		input.PeerOwnerId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("peer_region"); ok {
+		if _, ok := d.GetOk("auto_accept"); ok {
+			return fmt.Errorf("peer_region cannot be set whilst auto_accept is true when creating a VPC Peering Connection")
+		}
+
+		input.PeerRegion = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating VPC Peering Connection: %s", input)
+	resp, err := conn.CreateVpcPeeringConnection(input)
+	if err != nil {
+		return fmt.Errorf("error creating VPC Peering Connection: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.VpcPeeringConnection.VpcPeeringConnectionId))
+	log.Printf("[INFO] VPC Peering Connection ID: %s", d.Id())
+
+	if _, err := waiter.VpcPeeringConnectionAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("error waiting for VPC Peering Connection (%s) to become available: %s", d.Id(), err)
+	}
+
+	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
+		if err := keyvaluetags.Ec2CreateTags(conn, d.Id(), v); err != nil {
+			return fmt.Errorf("error adding EC2 VPC Peering Connection (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVpcPeeringConnectionModify(d, meta)
+}
+
+func resourceAwsVpcPeeringConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	pcxRaw, status, err := waiter.VpcPeeringConnectionStatus(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("error reading VPC Peering Connection (%s): %s", d.Id(), err)
+	}
+
+	// The failed, rejected, expired and deleted states remain visible for
+	// a while but cannot return to a usable state.
+	removed := map[string]bool{
+		ec2.VpcPeeringConnectionStateReasonCodeDeleted:  true,
+		ec2.VpcPeeringConnectionStateReasonCodeDeleting: true,
+		ec2.VpcPeeringConnectionStateReasonCodeExpired:  true,
+		ec2.VpcPeeringConnectionStateReasonCodeFailed:   true,
+		ec2.VpcPeeringConnectionStateReasonCodeRejected: true,
+	}
+
+	if pcxRaw == nil || removed[status] {
+		log.Printf("[WARN] VPC Peering Connection (%s) not found or %s, removing from state", d.Id(), status)
+		d.SetId("")
+		return nil
+	}
+
+	pcx := pcxRaw.(*ec2.VpcPeeringConnection)
+
+	d.Set("accept_status", status)
+	d.Set("peer_owner_id", pcx.AccepterVpcInfo.OwnerId)
+	d.Set("peer_region", pcx.AccepterVpcInfo.Region)
+	d.Set("peer_vpc_id", pcx.AccepterVpcInfo.VpcId)
+	d.Set("vpc_id", pcx.RequesterVpcInfo.VpcId)
+
+	if err := d.Set("accepter", flattenVpcPeeringConnectionOptions(pcx.AccepterVpcInfo.PeeringOptions)); err != nil {
+		return fmt.Errorf("error setting accepter: %s", err)
+	}
+
+	if err := d.Set("requester", flattenVpcPeeringConnectionOptions(pcx.RequesterVpcInfo.PeeringOptions)); err != nil {
+		return fmt.Errorf("error setting requester: %s", err)
+	}
+
+	if err := d.Set("tags", keyvaluetags.Ec2KeyValueTags(pcx.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsVpcPeeringConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+
+		if err := keyvaluetags.Ec2UpdateTags(conn, d.Id(), o, n); err != nil {
+			return fmt.Errorf("error updating EC2 VPC Peering Connection (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVpcPeeringConnectionModify(d, meta)
+}
+
+// resourceAwsVpcPeeringConnectionModify accepts a pending VPC peering
+// connection if auto_accept is set and applies changed peering options.
+// It is shared by the requester and accepter resources.
+func resourceAwsVpcPeeringConnectionModify(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	pcxRaw, status, err := waiter.VpcPeeringConnectionStatus(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("error reading VPC Peering Connection (%s): %s", d.Id(), err)
+	}
+
+	if pcxRaw == nil {
+		log.Printf("[WARN] VPC Peering Connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if status == ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance && d.Get("auto_accept").(bool) {
+		log.Printf("[INFO] Accepting VPC Peering Connection: %s", d.Id())
+		_, err := conn.AcceptVpcPeeringConnection(&ec2.AcceptVpcPeeringConnectionInput{
+			VpcPeeringConnectionId: aws.String(d.Id()),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error accepting VPC Peering Connection (%s): %s", d.Id(), err)
+		}
+
+		if _, err := waiter.VpcPeeringConnectionAvailable(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for VPC Peering Connection (%s) to become available: %s", d.Id(), err)
+		}
+
+		_, status, err = waiter.VpcPeeringConnectionStatus(conn, d.Id())()
+		if err != nil {
+			return fmt.Errorf("error reading VPC Peering Connection (%s): %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("accepter") || d.HasChange("requester") {
+		if status != ec2.VpcPeeringConnectionStateReasonCodeActive {
+			return fmt.Errorf("Unable to modify peering options. The VPC Peering Connection (%s) is not active. Please set `auto_accept` attribute to `true`, or activate VPC Peering Connection manually.", d.Id())
+		}
+
+		input := &ec2.ModifyVpcPeeringConnectionOptionsInput{
+			VpcPeeringConnectionId: aws.String(d.Id()),
+		}
+
+		if d.HasChange("accepter") {
+			input.AccepterPeeringConnectionOptions = expandVpcPeeringConnectionOptions(d.Get("accepter").([]interface{}))
+		}
+
+		if d.HasChange("requester") {
+			input.RequesterPeeringConnectionOptions = expandVpcPeeringConnectionOptions(d.Get("requester").([]interface{}))
+		}
+
+		log.Printf("[DEBUG] Modifying VPC Peering Connection options: %s", input)
+		if _, err := conn.ModifyVpcPeeringConnectionOptions(input); err != nil {
+			return fmt.Errorf("error modifying VPC Peering Connection (%s) options: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVpcPeeringConnectionRead(d, meta)
+}
+
+func resourceAwsVpcPeeringConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting VPC Peering Connection: %s", d.Id())
+	_, err := conn.DeleteVpcPeeringConnection(&ec2.DeleteVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: aws.String(d.Id()),
+	})
+
+	if isAWSErr(err, tfec2.ErrCodeInvalidVpcPeeringConnectionIDNotFound, "") {
+		return nil
+	}
+
+	// A peering connection that failed or expired cannot be deleted.
+	if isAWSErr(err, "InvalidStateTransition", "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting VPC Peering Connection (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waiter.VpcPeeringConnectionDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmt.Errorf("error waiting for VPC Peering Connection (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandVpcPeeringConnectionOptions(l []interface{}) *ec2.PeeringConnectionOptionsRequest {
+	options := &ec2.PeeringConnectionOptionsRequest{
+		AllowDnsResolutionFromRemoteVpc: aws.Bool(false),
+	}
+
+	if len(l) == 0 || l[0] == nil {
+		return options
+	}
+
+	m := l[0].(map[string]interface{})
+
+	if v, ok := m["allow_remote_vpc_dns_resolution"].(bool); ok {
+		options.AllowDnsResolutionFromRemoteVpc = aws.Bool(v)
+	}
+
+	return options
+}
+
+func flattenVpcPeeringConnectionOptions(options *ec2.VpcPeeringConnectionOptionsDescription) []interface{} {
+	if options == nil {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"allow_remote_vpc_dns_resolution": aws.BoolValue(options.AllowDnsResolutionFromRemoteVpc),
+	}
+
+	return []interface{}{m}
+}
@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ec2/waiter"
+)
+
+// resourceAwsVpcPeeringConnectionAccepter manages the accepter side of a VPC
+// peering connection, usually through a provider configuration for the peer
+// account or region. It shares its Read and Update with the requester
+// resource, so vpc_id and peer_vpc_id keep the requester's point of view.
+func resourceAwsVpcPeeringConnectionAccepter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcPeeringConnectionAccepterCreate,
+		Read:   resourceAwsVpcPeeringConnectionRead,
+		Update: resourceAwsVpcPeeringConnectionUpdate,
+		Delete: resourceAwsVpcPeeringConnectionAccepterDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				d.Set("vpc_peering_connection_id", d.Id())
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		CustomizeDiff: requiredTagsCustomizeDiff("aws_vpc_peering_connection_accepter"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_peering_connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auto_accept": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"accept_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"accepter":  vpcPeeringConnectionOptionsSchema(),
+			"requester": vpcPeeringConnectionOptionsSchema(),
+			"tags":      tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsVpcPeeringConnectionAccepterCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	id := d.Get("vpc_peering_connection_id").(string)
+
+	// Reading the connection here would overwrite the configured options
+	// before they are applied.
+	pcx, _, err := waiter.VpcPeeringConnectionStatus(conn, id)()
+	if err != nil {
+		return fmt.Errorf("error reading VPC Peering Connection (%s): %s", id, err)
+	}
+
+	if pcx == nil {
+		return fmt.Errorf("VPC Peering Connection (%s) not found", id)
+	}
+
+	d.SetId(id)
+
+	return resourceAwsVpcPeeringConnectionUpdate(d, meta)
+}
+
+func resourceAwsVpcPeeringConnectionAccepterDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] Will not delete VPC Peering Connection (%s). Terraform will remove this resource from the state file, however resources may remain.", d.Id())
+	return nil
+}
@@ -0,0 +1,216 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// securityGroupRuleBatchWindow is the default of how long the first rule
+// change for a security group waits for the changes of other rules in the
+// same group before they are sent together. Every rule change takes at least
+// this long, so a window of zero disables batching.
+const securityGroupRuleBatchWindow = 250 * time.Millisecond
+
+// securityGroupRuleBatcher coalesces the rule authorizations and revocations
+// that aws_security_group_rule resources make concurrently for the same
+// security group into a single API call, and shares the security group
+// descriptions they read. A group with many rules otherwise makes one call
+// per rule, which is slow and quickly throttled.
+type securityGroupRuleBatcher struct {
+	mu        sync.Mutex
+	window    time.Duration
+	batches   map[securityGroupRuleBatchKey]*securityGroupRuleBatch
+	describes map[string]*securityGroupDescribe
+}
+
+// securityGroupRuleBatchKey identifies the rule changes that can be sent in
+// one call: those of one type, ingress or egress, authorized or revoked in
+// the same security group.
+type securityGroupRuleBatchKey struct {
+	groupID  string
+	ruleType string
+	revoke   bool
+}
+
+type securityGroupRuleBatch struct {
+	group *ec2.SecurityGroup
+	perms []*ec2.IpPermission
+
+	// done is closed once the batch has been sent, with err the error of the call.
+	done chan struct{}
+	err  error
+}
+
+type securityGroupDescribe struct {
+	done    chan struct{}
+	expires time.Time
+	group   *ec2.SecurityGroup
+	err     error
+}
+
+func newSecurityGroupRuleBatcher(window time.Duration) *securityGroupRuleBatcher {
+	return &securityGroupRuleBatcher{
+		window:    window,
+		batches:   make(map[securityGroupRuleBatchKey]*securityGroupRuleBatch),
+		describes: make(map[string]*securityGroupDescribe),
+	}
+}
+
+// authorize adds a rule to a security group, sending it together with the
+// other rules of the same type being added to the group.
+func (b *securityGroupRuleBatcher) authorize(conn *ec2.EC2, sg *ec2.SecurityGroup, ruleType string, perm *ec2.IpPermission) error {
+	return b.submit(conn, sg, securityGroupRuleBatchKey{groupID: *sg.GroupId, ruleType: ruleType}, perm)
+}
+
+// revoke removes a rule from a security group, sending it together with the
+// other rules of the same type being removed from the group.
+func (b *securityGroupRuleBatcher) revoke(conn *ec2.EC2, sg *ec2.SecurityGroup, ruleType string, perm *ec2.IpPermission) error {
+	return b.submit(conn, sg, securityGroupRuleBatchKey{groupID: *sg.GroupId, ruleType: ruleType, revoke: true}, perm)
+}
+
+func (b *securityGroupRuleBatcher) submit(conn *ec2.EC2, sg *ec2.SecurityGroup, key securityGroupRuleBatchKey, perm *ec2.IpPermission) error {
+	if b.window <= 0 {
+		awsMutexKV.Lock(key.groupID)
+		defer awsMutexKV.Unlock(key.groupID)
+		defer b.invalidate(key.groupID)
+
+		return updateSecurityGroupRules(conn, sg, key.ruleType, key.revoke, []*ec2.IpPermission{perm})
+	}
+
+	b.mu.Lock()
+	batch, ok := b.batches[key]
+
+	if !ok {
+		batch = &securityGroupRuleBatch{
+			group: sg,
+			done:  make(chan struct{}),
+		}
+		b.batches[key] = batch
+
+		time.AfterFunc(b.window, func() {
+			b.send(conn, key, batch)
+		})
+	}
+
+	batch.perms = append(batch.perms, perm)
+	b.mu.Unlock()
+
+	<-batch.done
+
+	if batch.err == nil || len(batch.perms) == 1 {
+		return batch.err
+	}
+
+	// The call fails as a whole if any of its rules is invalid, so each
+	// rule is sent again on its own to report the error against the rule
+	// that caused it.
+	log.Printf("[DEBUG] Retrying Security Group (%s) %s rule on its own after batch error: %s", key.groupID, key.ruleType, batch.err)
+
+	awsMutexKV.Lock(key.groupID)
+	defer awsMutexKV.Unlock(key.groupID)
+	defer b.invalidate(key.groupID)
+
+	return updateSecurityGroupRules(conn, batch.group, key.ruleType, key.revoke, []*ec2.IpPermission{perm})
+}
+
+func (b *securityGroupRuleBatcher) send(conn *ec2.EC2, key securityGroupRuleBatchKey, batch *securityGroupRuleBatch) {
+	b.mu.Lock()
+	delete(b.batches, key)
+	b.mu.Unlock()
+
+	awsMutexKV.Lock(key.groupID)
+	defer awsMutexKV.Unlock(key.groupID)
+
+	log.Printf("[DEBUG] Sending %d Security Group (%s) %s rule changes", len(batch.perms), key.groupID, key.ruleType)
+	batch.err = updateSecurityGroupRules(conn, batch.group, key.ruleType, key.revoke, batch.perms)
+
+	b.invalidate(key.groupID)
+	close(batch.done)
+}
+
+// describe returns a security group, sharing the result with the other
+// callers describing the same group within the batch window. The returned
+// security group must not be modified.
+func (b *securityGroupRuleBatcher) describe(conn *ec2.EC2, id string) (*ec2.SecurityGroup, error) {
+	b.mu.Lock()
+	d, ok := b.describes[id]
+
+	if ok {
+		select {
+		case <-d.done:
+			ok = time.Now().Before(d.expires)
+		default:
+		}
+	}
+
+	if !ok {
+		d = &securityGroupDescribe{done: make(chan struct{})}
+		b.describes[id] = d
+		b.mu.Unlock()
+
+		d.group, d.err = findResourceSecurityGroup(conn, id)
+		d.expires = time.Now().Add(b.window)
+		close(d.done)
+
+		return d.group, d.err
+	}
+
+	b.mu.Unlock()
+	<-d.done
+
+	return d.group, d.err
+}
+
+// invalidate discards the shared description of a security group after its
+// rules change.
+func (b *securityGroupRuleBatcher) invalidate(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.describes, id)
+}
+
+// updateSecurityGroupRules authorizes or revokes rules of one type in a security group.
+func updateSecurityGroupRules(conn *ec2.EC2, sg *ec2.SecurityGroup, ruleType string, revoke bool, perms []*ec2.IpPermission) error {
+	isVPC := sg.VpcId != nil && *sg.VpcId != ""
+
+	var err error
+
+	switch {
+	case ruleType == "ingress" && !revoke:
+		req := &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: perms,
+		}
+
+		if !isVPC {
+			req.GroupId = nil
+			req.GroupName = sg.GroupName
+		}
+
+		_, err = conn.AuthorizeSecurityGroupIngress(req)
+	case ruleType == "ingress":
+		_, err = conn.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: perms,
+		})
+	case ruleType == "egress" && !revoke:
+		_, err = conn.AuthorizeSecurityGroupEgress(&ec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: perms,
+		})
+	case ruleType == "egress":
+		_, err = conn.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: perms,
+		})
+	default:
+		return fmt.Errorf("Security Group Rule must be type 'ingress' or type 'egress'")
+	}
+
+	return err
+}
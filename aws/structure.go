@@ -249,11 +249,15 @@ func expandPrivateIPAddresses(ips []interface{}) []*ec2.PrivateIpAddressSpecific
 //Flattens network interface attachment into a map[string]interface
 func flattenAttachment(a *ec2.NetworkInterfaceAttachment) map[string]interface{} {
 	att := make(map[string]interface{})
+	if a == nil {
+		return att
+	}
 	if a.InstanceId != nil {
 		att["instance"] = *a.InstanceId
 	}
-	att["device_index"] = *a.DeviceIndex
-	att["attachment_id"] = *a.AttachmentId
+	att["device_index"] = aws.Int64Value(a.DeviceIndex)
+	att["attachment_id"] = aws.StringValue(a.AttachmentId)
+	att["status"] = aws.StringValue(a.Status)
 	return att
 }
 
@@ -267,6 +271,16 @@ func flattenNetworkInterfacesPrivateIPAddresses(dtos []*ec2.NetworkInterfacePriv
 	return ips
 }
 
+//Flattens an array of IPv6 addresses into a []string, where the elements returned are the IP strings e.g. "2001:db8::1"
+func flattenNetworkInterfaceIpv6Addresses(dtos []*ec2.NetworkInterfaceIpv6Address) []string {
+	ips := make([]string, 0, len(dtos))
+	for _, v := range dtos {
+		ip := aws.StringValue(v.Ipv6Address)
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
 //Flattens security group identifiers into a []string, where the elements returned are the GroupIDs
 func flattenGroupIdentifiers(dtos []*ec2.GroupIdentifier) []string {
 	ids := make([]string, 0, len(dtos))
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
@@ -154,3 +155,22 @@ func validateSecurityGroupRuleDescription(v interface{}, k string) (ws []string,
 	}
 	return
 }
+
+// validateDuration ensures that the string value is a non-negative duration,
+// e.g. "250ms"
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must contain a valid duration, got error parsing: %s", k, err))
+		return
+	}
+
+	if d < 0 {
+		errors = append(errors, fmt.Errorf(
+			"%q must not be negative, got %q", k, value))
+	}
+
+	return
+}
@@ -109,3 +109,31 @@ func TestValidateIAMPolicyJsonString(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDuration(t *testing.T) {
+	validValues := []string{
+		"0s",
+		"250ms",
+		"1m30s",
+	}
+
+	for _, v := range validValues {
+		_, errors := validateDuration(v, "duration")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid duration: %q", v, errors)
+		}
+	}
+
+	invalidValues := []string{
+		"",
+		"250",
+		"-1s",
+	}
+
+	for _, v := range invalidValues {
+		_, errors := validateDuration(v, "duration")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid duration", v)
+		}
+	}
+}